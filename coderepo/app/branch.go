@@ -8,6 +8,8 @@ package app
 import (
 	"context"
 
+	"golang.org/x/xerrors"
+
 	"github.com/openmerlin/merlin-server/coderepo/domain"
 	repoprimitive "github.com/openmerlin/merlin-server/coderepo/domain/primitive"
 	"github.com/openmerlin/merlin-server/coderepo/domain/repository"
@@ -50,12 +52,11 @@ type branchAppService struct {
 func (s *branchAppService) Create(ctx context.Context, user primitive.Account, cmd *CmdToCreateBranch) (
 	dto BranchCreateDTO, err error,
 ) {
-	index := cmd.RepoIndex()
-	if err = s.canModify(ctx, user, cmd.RepoType, &index); err != nil {
+	if err = s.canModify(ctx, user, cmd.RepoType, &cmd.BranchIndex, true); err != nil {
 		return
 	}
 
-	branch := cmd.toBranch()
+	branch := cmd.toBranch(user)
 
 	v, err := s.branchClientAdapter.CreateBranch(&branch)
 	if err != nil {
@@ -71,8 +72,7 @@ func (s *branchAppService) Create(ctx context.Context, user primitive.Account, c
 
 // Delete deletes a branch based on the provided command.
 func (s *branchAppService) Delete(ctx context.Context, user primitive.Account, cmd *CmdToDeleteBranch) error {
-	index := cmd.RepoIndex()
-	if err := s.canModify(ctx, user, cmd.RepoType, &index); err != nil {
+	if err := s.canModify(ctx, user, cmd.RepoType, &cmd.BranchIndex, false); err != nil {
 		return err
 	}
 
@@ -92,10 +92,15 @@ func (s *branchAppService) Delete(ctx context.Context, user primitive.Account, c
 	return s.branchAdapter.Delete(ctx, br.Id)
 }
 
+// canModify checks if the user can create or delete the branch, the user who can read the repo
+// is allowed to create a new proposal branch and to modify the ones created by them.
 func (s *branchAppService) canModify(
-	ctx context.Context, user primitive.Account, t repoprimitive.RepoType, index *domain.CodeRepoIndex,
+	ctx context.Context, user primitive.Account, t repoprimitive.RepoType, branch *domain.BranchIndex,
+	creating bool,
 ) error {
-	repo, err := s.resourceAdapter.GetByType(t, index)
+	index := branch.RepoIndex()
+
+	repo, err := s.resourceAdapter.GetByType(t, &index)
 	if err != nil {
 		if commonrepo.IsErrorResourceNotExists(err) {
 			return allerror.NewNotFound(allerror.ErrorCodeRepoNotFound, "no repo", err)
@@ -104,5 +109,41 @@ func (s *branchAppService) canModify(
 		return err
 	}
 
-	return s.permission.CanUpdate(ctx, user, repo)
+	err = s.permission.CanUpdate(ctx, user, repo)
+	if err == nil || !allerror.IsNoPermission(err) || !branch.IsProposalBranch() {
+		return err
+	}
+
+	if err = s.permission.CanRead(ctx, user, repo); err != nil {
+		return err
+	}
+
+	return s.isProposalBranchOwner(ctx, user, branch, creating)
+}
+
+// isProposalBranchOwner checks if the proposal branch was created by the user,
+// the branch which doesn't exist can be created by anyone.
+func (s *branchAppService) isProposalBranchOwner(
+	ctx context.Context, user primitive.Account, index *domain.BranchIndex, creating bool,
+) error {
+	b, err := s.branchAdapter.FindByIndex(ctx, index)
+	if err != nil {
+		if !commonrepo.IsErrorResourceNotExists(err) {
+			return err
+		}
+
+		if creating {
+			return nil
+		}
+
+		return allerror.NewNoPermission("no permission", err)
+	}
+
+	if !b.IsProposalBranchOf(user) {
+		return allerror.NewNoPermission(
+			"no permission", xerrors.Errorf("%s is not the creator of branch", user.Account()),
+		)
+	}
+
+	return nil
 }
@@ -56,12 +56,13 @@ type CmdToCreateBranch struct {
 	BaseBranch repoprimitive.BranchName
 }
 
-func (cmd *CmdToCreateBranch) toBranch() domain.Branch {
+func (cmd *CmdToCreateBranch) toBranch(user primitive.Account) domain.Branch {
 	branch := domain.Branch{
 		BranchIndex: cmd.BranchIndex,
 		BaseBranch:  cmd.BaseBranch,
 		RepoType:    cmd.RepoType,
 		CreatedAt:   utils.Now(),
+		CreatedBy:   user,
 	}
 
 	return branch
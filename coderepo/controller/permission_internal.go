@@ -7,8 +7,10 @@ package controller
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 
 	"github.com/openmerlin/merlin-server/coderepo/domain"
+	"github.com/openmerlin/merlin-server/coderepo/domain/repository"
 	"github.com/openmerlin/merlin-server/coderepo/domain/resourceadapter"
 	commonapp "github.com/openmerlin/merlin-server/common/app"
 	commonctl "github.com/openmerlin/merlin-server/common/controller"
@@ -23,12 +25,14 @@ func AddRouteForCodeRepoPermissionInternalController(
 	r *gin.RouterGroup,
 	s commonapp.ResourcePermissionAppService,
 	a resourceadapter.ResourceAdapter,
+	b repository.BranchRepositoryAdapter,
 	m middleware.UserMiddleWare,
 ) {
 
 	ctl := PermissionInternalController{
-		ps:   s,
-		repo: a,
+		ps:     s,
+		repo:   a,
+		branch: b,
 	}
 
 	r.POST(`/v1/coderepo/permission/update`, m.Write, ctl.Update)
@@ -38,12 +42,14 @@ func AddRouteForCodeRepoPermissionInternalController(
 // PermissionInternalController is a struct that holds the necessary services
 // and adapters for handling permission-related operations.
 type PermissionInternalController struct {
-	ps   commonapp.ResourcePermissionAppService
-	repo resourceadapter.ResourceAdapter
+	ps     commonapp.ResourcePermissionAppService
+	repo   resourceadapter.ResourceAdapter
+	branch repository.BranchRepositoryAdapter
 }
 
 // @Summary  Update
-// @Description  check if can create/update/delete repo's sub-resource not the repo itsself
+// @Description  check if can create/update/delete repo's sub-resource not the repo itsself,
+// @Description  the user who can read the repo can update their own proposal branch
 // @Tags     Permission
 // @Param    body  body  reqToCheckPermission  true  "body of request"
 // @Accept   json
@@ -52,12 +58,17 @@ type PermissionInternalController struct {
 // @Security Internal
 // @Router   /v1/coderepo/permission/update [post]
 func (ctl *PermissionInternalController) Update(ctx *gin.Context) {
-	user, r, err := ctl.parse(ctx)
+	user, r, proposal, err := ctl.parse(ctx)
 	if err != nil {
 		return
 	}
 
-	if err := ctl.ps.CanUpdate(ctx.Request.Context(), user, r); err != nil {
+	err = ctl.ps.CanUpdate(ctx.Request.Context(), user, r)
+	if err != nil && proposal && allerror.IsNoPermission(err) {
+		err = ctl.ps.CanRead(ctx.Request.Context(), user, r)
+	}
+
+	if err != nil {
 		commonctl.SendError(ctx, err)
 	} else {
 		commonctl.SendRespOfPost(ctx, "successfully")
@@ -74,7 +85,7 @@ func (ctl *PermissionInternalController) Update(ctx *gin.Context) {
 // @Security Internal
 // @Router   /v1/coderepo/permission/read [post]
 func (ctl *PermissionInternalController) Read(ctx *gin.Context) {
-	user, r, err := ctl.parse(ctx)
+	user, r, _, err := ctl.parse(ctx)
 	if err != nil {
 		return
 	}
@@ -88,7 +99,7 @@ func (ctl *PermissionInternalController) Read(ctx *gin.Context) {
 }
 
 func (ctl *PermissionInternalController) parse(ctx *gin.Context) (
-	user primitive.Account, resource domain.Resource, err error,
+	user primitive.Account, resource domain.Resource, proposal bool, err error,
 ) {
 	req := reqToCheckPermission{}
 	if err = ctx.BindJSON(&req); err != nil {
@@ -104,6 +115,8 @@ func (ctl *PermissionInternalController) parse(ctx *gin.Context) (
 		return
 	}
 
+	proposal = ctl.isProposalBranch(ctx, &req, user, &index)
+
 	if resource, err = ctl.repo.GetByName(&index); err != nil {
 		if commonrepo.IsErrorResourceNotExists(err) {
 			commonctl.SendError(
@@ -117,3 +130,24 @@ func (ctl *PermissionInternalController) parse(ctx *gin.Context) (
 
 	return
 }
+
+// isProposalBranch checks if the branch to update is a proposal branch created by the user.
+func (ctl *PermissionInternalController) isProposalBranch(
+	ctx *gin.Context, req *reqToCheckPermission, user primitive.Account, index *domain.CodeRepoIndex,
+) bool {
+	b, ok := req.toProposalBranchIndex(index)
+	if !ok {
+		return false
+	}
+
+	branch, err := ctl.branch.FindByIndex(ctx.Request.Context(), &b)
+	if err != nil {
+		if !commonrepo.IsErrorResourceNotExists(err) {
+			logrus.Errorf("failed to find branch %s, err:%s", req.Branch, err.Error())
+		}
+
+		return false
+	}
+
+	return branch.IsProposalBranchOf(user)
+}
@@ -7,13 +7,31 @@ package controller
 
 import (
 	"github.com/openmerlin/merlin-server/coderepo/domain"
+	repoprimitive "github.com/openmerlin/merlin-server/coderepo/domain/primitive"
 	"github.com/openmerlin/merlin-server/common/domain/primitive"
 )
 
 type reqToCheckPermission struct {
-	User  string `json:"user"`
-	Name  string `json:"name"`
-	Owner string `json:"owner"`
+	User   string `json:"user"`
+	Name   string `json:"name"`
+	Owner  string `json:"owner"`
+	Branch string `json:"branch"`
+}
+
+// toProposalBranchIndex returns the index of the branch to update if it is named as a proposal branch.
+func (req *reqToCheckPermission) toProposalBranchIndex(index *domain.CodeRepoIndex) (domain.BranchIndex, bool) {
+	if req.Branch == "" {
+		return domain.BranchIndex{}, false
+	}
+
+	branch, err := repoprimitive.NewBranchName(req.Branch)
+	if err != nil {
+		return domain.BranchIndex{}, false
+	}
+
+	b := domain.BranchIndex{Repo: index.Name, Owner: index.Owner, Branch: branch}
+
+	return b, b.IsProposalBranch()
 }
 
 func (req *reqToCheckPermission) toCmd() (
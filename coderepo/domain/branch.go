@@ -6,6 +6,8 @@ Copyright (c) Huawei Technologies Co., Ltd. 2023. All rights reserved
 package domain
 
 import (
+	"strings"
+
 	coderepoprimitive "github.com/openmerlin/merlin-server/coderepo/domain/primitive"
 	"github.com/openmerlin/merlin-server/common/domain/primitive"
 )
@@ -17,6 +19,7 @@ type Branch struct {
 	Id         primitive.Identity
 	RepoType   coderepoprimitive.RepoType
 	CreatedAt  int64
+	CreatedBy  primitive.Account
 	BaseBranch coderepoprimitive.BranchName
 }
 
@@ -34,3 +37,22 @@ func (index *BranchIndex) RepoIndex() CodeRepoIndex {
 		Name:  index.Repo,
 	}
 }
+
+// proposalBranchPrefix is the prefix of branches created by the users without write permission
+// to propose changes through pull requests.
+const proposalBranchPrefix = "pr-"
+
+// IsProposalBranch checks if the branch is named as a proposal branch.
+func (index *BranchIndex) IsProposalBranch() bool {
+	return index.Branch != nil && strings.HasPrefix(index.Branch.BranchName(), proposalBranchPrefix)
+}
+
+// IsProposalBranchOf checks if the branch is a proposal branch created by the user,
+// the owner is decided by the creator recorded rather than the branch name.
+func (b *Branch) IsProposalBranchOf(user primitive.Account) bool {
+	if user == nil || b.CreatedBy == nil || !b.IsProposalBranch() {
+		return false
+	}
+
+	return b.CreatedBy.Account() == user.Account()
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+
+	coderepoprimitive "github.com/openmerlin/merlin-server/coderepo/domain/primitive"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+)
+
+// TestIsProposalBranchOf tests that a proposal branch belongs only to the account recorded as its creator,
+// whatever the name of branch is.
+func TestIsProposalBranchOf(t *testing.T) {
+	creator := primitive.CreateAccount("Bob.x")
+
+	branch := Branch{
+		BranchIndex: BranchIndex{Branch: coderepoprimitive.CreateBranchName("pr-fix")},
+		CreatedBy:   creator,
+	}
+
+	if !branch.IsProposalBranchOf(creator) {
+		t.Fatal("the proposal branch should belong to its creator")
+	}
+
+	for _, v := range []string{"bob.x", "Bob", "bob-x"} {
+		if branch.IsProposalBranchOf(primitive.CreateAccount(v)) {
+			t.Fatalf("the proposal branch should not belong to %s", v)
+		}
+	}
+
+	branch.Branch = coderepoprimitive.CreateBranchName("fix")
+	if branch.IsProposalBranchOf(creator) {
+		t.Fatal("the branch not named as a proposal branch should not be modified by its creator")
+	}
+
+	legacy := Branch{BranchIndex: BranchIndex{Branch: coderepoprimitive.CreateBranchName("pr-bob__fix")}}
+	if legacy.IsProposalBranchOf(primitive.CreateAccount("bob")) {
+		t.Fatal("the proposal branch without creator should not belong to anyone")
+	}
+}
@@ -22,6 +22,7 @@ type branchDO struct {
 	Branch     string `gorm:"column:branch;index:branch_index,unique,priority:3"`
 	RepoType   string `gorm:"column:repo_type"`
 	CreatedAt  int64  `gorm:"column:created_at"`
+	CreatedBy  string `gorm:"column:created_by"`
 	BaseBranch string `gorm:"column:base_branch"`
 }
 
@@ -32,6 +33,7 @@ func toBranchDO(m *domain.Branch) branchDO {
 		Branch:     m.Branch.BranchName(),
 		RepoType:   m.RepoType.RepoType(),
 		CreatedAt:  m.CreatedAt,
+		CreatedBy:  m.CreatedBy.Account(),
 		BaseBranch: m.BaseBranch.BranchName(),
 	}
 }
@@ -46,11 +48,12 @@ func (do *branchDO) toBranch() domain.Branch {
 		BranchIndex: domain.BranchIndex{
 			Repo:   primitive.CreateMSDName(do.Repo),
 			Owner:  primitive.CreateAccount(do.Owner),
-			Branch: coderepoprimitive.CreateBranchName(do.RepoType),
+			Branch: coderepoprimitive.CreateBranchName(do.Branch),
 		},
 		Id:         primitive.CreateIdentity(do.Id),
 		RepoType:   coderepoprimitive.CreateRepoType(do.RepoType),
 		CreatedAt:  do.CreatedAt,
+		CreatedBy:  primitive.CreateAccount(do.CreatedBy),
 		BaseBranch: coderepoprimitive.CreateBranchName(do.BaseBranch),
 	}
 }
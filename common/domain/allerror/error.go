@@ -47,6 +47,42 @@ const (
 	// ErrorCodeSpaceSecretNotFound space secret
 	ErrorCodeSpaceSecretNotFound = "space_secret_not_found"
 
+	// ErrorCodeSpaceSecretVersionNotFound space secret version
+	ErrorCodeSpaceSecretVersionNotFound = "space_secret_version_not_found"
+
+	// ErrorCodeOrgEnvNotFound org env
+	ErrorCodeOrgEnvNotFound = "org_env_not_found"
+
+	// ErrorCodeOrgEnvExists org env with the same name exists
+	ErrorCodeOrgEnvExists = "org_env_exists"
+
+	// ErrorCodeOrgEnvInUse org env is referenced by spaces
+	ErrorCodeOrgEnvInUse = "org_env_in_use"
+
+	// ErrorCodeSpaceEnvReferenceNotFound reference from space to org env
+	ErrorCodeSpaceEnvReferenceNotFound = "space_env_reference_not_found"
+
+	// ErrorCodeBaseImageNotFound base image of catalog
+	ErrorCodeBaseImageNotFound = "base_image_not_found"
+
+	// ErrorCodeBaseImageExists base image with the same name exists
+	ErrorCodeBaseImageExists = "base_image_exists"
+
+	// ErrorCodeBaseImageInUse base image is used by spaces
+	ErrorCodeBaseImageInUse = "base_image_in_use"
+
+	// ErrorCodeBaseImageDeprecated base image is deprecated and can't be used by new space
+	ErrorCodeBaseImageDeprecated = "base_image_deprecated"
+
+	// ErrorCodeSpaceCustomDomainNotFound space custom domain
+	ErrorCodeSpaceCustomDomainNotFound = "space_custom_domain_not_found"
+
+	// ErrorCodeSpaceCustomDomainExists space custom domain has been registered
+	ErrorCodeSpaceCustomDomainExists = "space_custom_domain_exists"
+
+	// ErrorCodeSpaceCustomDomainUnverified the txt record of custom domain is not matched
+	ErrorCodeSpaceCustomDomainUnverified = "space_custom_domain_unverified"
+
 	// ErrorCodeTokenNotFound is const
 	ErrorCodeTokenNotFound = "token_not_found"
 
@@ -95,6 +131,9 @@ const (
 	// ErrorCodeSpaceAppWakeupFailed sleep space app
 	ErrorCodeSpaceAppWakeupFailed = "space_app_wakeup_failed" // #nosec G101
 
+	// ErrorCodeSpaceAppNotQueued space app is not waiting in the queue of computility quota
+	ErrorCodeSpaceAppNotQueued = "space_app_not_queued"
+
 	// ErrorCodeAccessTokenInvalid This error code is for restful api
 	ErrorCodeAccessTokenInvalid = "access_token_invalid"
 
@@ -191,6 +230,12 @@ const (
 	// ErrorCodeComputilityAccountFindError find computility account error
 	ErrorCodeComputilityAccountFindError = "computility_account_find_error"
 
+	// ErrorCodeComputilityMemberNotFound the user has no quota assigned by the org
+	ErrorCodeComputilityMemberNotFound = "computility_member_not_found"
+
+	// ErrorCodeComputilityOrgNotFound the org has no computility quota
+	ErrorCodeComputilityOrgNotFound = "computility_org_not_found"
+
 	// ErrorBaseCase is const
 	ErrorBaseCase = "internal_error"
 
@@ -379,12 +424,35 @@ const (
 	ErrorCodeIssueNotFound     = "issue_not_found"
 	ErrorCodeIssueClosed       = "issue_closed"
 	ErrorCodeIssueIsOpen       = "issue_is_open"
+	ErrorCodeIssueLocked       = "issue_locked"
+
+	ErrorCodeFailToCreatePullRequest = "failed_to_create_pull_request"
+	ErrorCodeFailToMergePullRequest  = "failed_to_merge_pull_request"
+	ErrorCodePullRequestNotFound     = "pull_request_not_found"
+	ErrorCodePullRequestMerged       = "pull_request_merged"
+	ErrorCodeIssueIsPullRequest      = "issue_is_pull_request"
+
+	ErrorCodeLabelNotFound = "label_not_found"
+	ErrorCodeLabelExists   = "label_exists"
+
+	ErrorCodeIssueTemplateNotFound = "issue_template_not_found"
+	ErrorCodeIssueTemplateExists   = "issue_template_exists"
 
 	ErrorCodeFailToCreateComment = "failed_to_create_comment"
 	ErrorCodeFailToUpdateComment = "failed_to_update_comment"
 	ErrorCodeFailToDeleteComment = "failed_to_delete_comment"
 	ErrorCodeCommentNotFound     = "comment_not_found"
 
+	ErrorCodeFailToUpdateNotification = "failed_to_update_notification"
+
+	ErrorCodeWatchNotFound     = "watch_not_found"
+	ErrorCodeFailToUpdateWatch = "failed_to_update_watch"
+
+	ErrorCodeReportNotFound     = "report_not_found"
+	ErrorCodeReportHandled      = "report_handled"
+	ErrorCodeFailToCreateReport = "failed_to_create_report"
+	ErrorCodeFailToUpdateReport = "failed_to_update_report"
+
 	ErrorCodeDiscussionDisabled = "discussion_is_disabled"
 	ErrorCodeDiscussionEnabled  = "discussion_is_enabled"
 )
@@ -505,6 +573,36 @@ func IsUserDuplicateBind(err error) bool {
 	return false
 }
 
+// IsInsufficientQuota checks if the given error is caused by the exhausted computility quota.
+func IsInsufficientQuota(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var e errorImpl
+	if ok := errors.As(err, &e); ok {
+		code := e.ErrorCode()
+
+		return code == ErrorCodeInsufficientQuota || code == ErrorCodeCompAccountException
+	}
+
+	return false
+}
+
+// IsBranchExist checks if the given error is caused by creating the branch which already exists.
+func IsBranchExist(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var e errorImpl
+	if ok := errors.As(err, &e); ok {
+		return e.ErrorCode() == ErrorCodeBranchExist
+	}
+
+	return false
+}
+
 // noPermissionError
 type noPermissionError struct {
 	errorImpl
@@ -571,3 +669,17 @@ type resourceDisabledError struct {
 func NewResourceDisabled(code string, msg string, err error) resourceDisabledError {
 	return resourceDisabledError{errorImpl: New(code, msg, err)}
 }
+
+// IsResourceAlreadyDisabled checks if the given error is caused by disabling the resource which is disabled.
+func IsResourceAlreadyDisabled(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var e resourceDisabledError
+	if ok := errors.As(err, &e); ok {
+		return e.ErrorCode() == ErrorCodeResourceAlreadyDisabled
+	}
+
+	return false
+}
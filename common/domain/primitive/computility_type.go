@@ -12,6 +12,7 @@ import (
 const (
 	computilityTypeNpu = "npu"
 	computilityTypeCpu = "cpu"
+	computilityTypeGpu = "gpu"
 )
 
 // ComputilityType is an interface that defines computility hardware.
@@ -28,6 +29,7 @@ func NewComputilityType(v string) (ComputilityType, error) {
 	switch v {
 	case computilityTypeNpu:
 	case computilityTypeCpu:
+	case computilityTypeGpu:
 	default:
 		return nil, errors.New("unknown computility type")
 	}
@@ -6,18 +6,27 @@ Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
 package app
 
 import (
+	"context"
+
 	"github.com/sirupsen/logrus"
 	"golang.org/x/xerrors"
 
 	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
 	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
 	"github.com/openmerlin/merlin-server/computility/domain"
 	"github.com/openmerlin/merlin-server/computility/domain/repository"
+	orgrepo "github.com/openmerlin/merlin-server/organization/domain/repository"
 )
 
 // ComputilityAppService is an interface for computility internal application service
 type ComputilityAppService interface {
 	GetAccountDetail(domain.ComputilityAccountIndex) (AccountQuotaDetailDTO, error)
+	ListOrgUsage(context.Context, primitive.Account, *CmdToListUsage) (OrgUsageDTO, error)
+
+	AdjustMemberQuota(context.Context, primitive.Account, *CmdToAdjustQuota) (MemberQuotaDTO, string, error)
+	TransferMemberQuota(context.Context, primitive.Account, *CmdToTransferQuota) ([]MemberQuotaDTO, string, error)
+	ListLedger(context.Context, primitive.Account, *CmdToListLedger) (LedgerDTO, error)
 }
 
 // NewComputilityAppService creates a new instance of ComputilityAppService
@@ -25,11 +34,17 @@ func NewComputilityAppService(
 	orgAdapter repository.ComputilityOrgRepositoryAdapter,
 	detailAdapter repository.ComputilityDetailRepositoryAdapter,
 	accountAdapter repository.ComputilityAccountRepositoryAdapter,
+	usageAdapter repository.ComputilityUsageRepositoryAdapter,
+	ledgerAdapter repository.ComputilityLedgerRepositoryAdapter,
+	member orgrepo.OrgMember,
 ) ComputilityAppService {
 	return &computilityAppService{
 		orgAdapter:     orgAdapter,
 		detailAdapter:  detailAdapter,
 		accountAdapter: accountAdapter,
+		usageAdapter:   usageAdapter,
+		ledgerAdapter:  ledgerAdapter,
+		member:         member,
 	}
 }
 
@@ -37,6 +52,9 @@ type computilityAppService struct {
 	orgAdapter     repository.ComputilityOrgRepositoryAdapter
 	accountAdapter repository.ComputilityAccountRepositoryAdapter
 	detailAdapter  repository.ComputilityDetailRepositoryAdapter
+	usageAdapter   repository.ComputilityUsageRepositoryAdapter
+	ledgerAdapter  repository.ComputilityLedgerRepositoryAdapter
+	member         orgrepo.OrgMember
 }
 
 func (s *computilityAppService) GetAccountDetail(index domain.ComputilityAccountIndex) (
@@ -5,10 +5,13 @@ Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
 package app
 
 import (
+	"fmt"
+
 	"github.com/sirupsen/logrus"
 	"golang.org/x/xerrors"
 
 	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
 	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
 	"github.com/openmerlin/merlin-server/computility/domain"
 	"github.com/openmerlin/merlin-server/computility/domain/message"
@@ -26,6 +29,14 @@ type ComputilityInternalAppService interface {
 	UserQuotaRelease(CmdToUserQuotaUpdate) error
 	UserQuotaConsume(CmdToUserQuotaUpdate) error
 	SpaceCreateSupply(CmdToSupplyRecord) error
+	GetAccountUsage(domain.ComputilityAccountIndex) (AccountUsageDTO, error)
+
+	RenewLease(primitive.Identity) error
+	ExpireLeases(func(primitive.Identity) error) ([]AccountRecordlDTO, error)
+	Reconcile(CmdToReconcile) (ReconcileDTO, error)
+
+	StartUsage(*CmdToStartUsage) error
+	StopUsage(primitive.Identity) error
 }
 
 // NewComputilityInternalAppService creates a new instance of ComputilityInternalAppService
@@ -34,6 +45,8 @@ func NewComputilityInternalAppService(
 	detailAdapter repository.ComputilityDetailRepositoryAdapter,
 	accountAdapter repository.ComputilityAccountRepositoryAdapter,
 	accountRecordAtapter repository.ComputilityAccountRecordRepositoryAdapter,
+	usageAdapter repository.ComputilityUsageRepositoryAdapter,
+	ledgerAdapter repository.ComputilityLedgerRepositoryAdapter,
 	messageAdapter message.ComputilityMessage,
 	privilege orgapp.PrivilegeOrg,
 ) ComputilityInternalAppService {
@@ -42,6 +55,8 @@ func NewComputilityInternalAppService(
 		detailAdapter:        detailAdapter,
 		accountAdapter:       accountAdapter,
 		accountRecordAtapter: accountRecordAtapter,
+		usageAdapter:         usageAdapter,
+		ledgerAdapter:        ledgerAdapter,
 		messageAdapter:       messageAdapter,
 		privilege:            privilege,
 	}
@@ -52,6 +67,8 @@ type computilityInternalAppService struct {
 	accountAdapter       repository.ComputilityAccountRepositoryAdapter
 	detailAdapter        repository.ComputilityDetailRepositoryAdapter
 	accountRecordAtapter repository.ComputilityAccountRecordRepositoryAdapter
+	usageAdapter         repository.ComputilityUsageRepositoryAdapter
+	ledgerAdapter        repository.ComputilityLedgerRepositoryAdapter
 	messageAdapter       message.ComputilityMessage
 	privilege            orgapp.PrivilegeOrg
 }
@@ -132,6 +149,14 @@ func (s *computilityInternalAppService) UserJoin(cmd CmdToUserOrgOperate) error
 		return err
 	}
 
+	appendLedger(s.ledgerAdapter, domain.ComputilityLedgerEntry{
+		Action:      domain.LedgerActionUserJoin,
+		OrgName:     cmd.OrgName,
+		UserName:    cmd.UserName,
+		ComputeType: org.ComputeType,
+		QuotaChange: org.DefaultAssignQuota,
+	})
+
 	return err
 }
 
@@ -166,7 +191,7 @@ func (s *computilityInternalAppService) UserRemove(cmd CmdToUserOrgOperate) (
 		return QuotaRecallDTO{}, err
 	}
 
-	recall, err := s.userRemoveOperate(&cmd.ComputilityIndex)
+	recall, err := s.userRemoveOperate(&cmd.ComputilityIndex, domain.LedgerActionUserRemove)
 
 	return recall, err
 }
@@ -197,7 +222,7 @@ func (s *computilityInternalAppService) OrgDelete(cmd CmdToOrgDelete) (
 		s, err := s.userRemoveOperate(&domain.ComputilityIndex{
 			OrgName:  v.OrgName,
 			UserName: v.UserName,
-		})
+		}, domain.LedgerActionOrgDelete)
 
 		if err != nil {
 			logrus.Errorf("org deleted | computility remove user:%s error: %s", v.UserName.Account(), err)
@@ -213,7 +238,7 @@ func (s *computilityInternalAppService) OrgDelete(cmd CmdToOrgDelete) (
 	return rList, err
 }
 
-func (s *computilityInternalAppService) userRemoveOperate(index *domain.ComputilityIndex) (
+func (s *computilityInternalAppService) userRemoveOperate(index *domain.ComputilityIndex, action string) (
 	QuotaRecallDTO, error,
 ) {
 	if s.privilege == nil {
@@ -277,6 +302,14 @@ func (s *computilityInternalAppService) userRemoveOperate(index *domain.Computil
 		return QuotaRecallDTO{}, err
 	}
 
+	appendLedger(s.ledgerAdapter, domain.ComputilityLedgerEntry{
+		Action:      action,
+		OrgName:     index.OrgName,
+		UserName:    index.UserName,
+		ComputeType: detail.ComputeType,
+		QuotaChange: -assigned,
+	})
+
 	err = s.accountAdapter.CancelAccount(accountIndex)
 
 	return recall, err
@@ -321,11 +354,23 @@ func (s *computilityInternalAppService) UserQuotaRelease(cmd CmdToUserQuotaUpdat
 		return nil
 	}
 
-	err = s.accountAdapter.ReleaseQuota(account, cmd.QuotaCount)
+	// release the quota recorded when it was consumed, the quota weight
+	// of hardware flavor may have changed since then.
+	n := min(record.QuotaCount, account.UsedQuota)
+
+	err = s.accountAdapter.ReleaseQuota(account, n)
 	if err != nil {
 		return err
 	}
 
+	appendLedger(s.ledgerAdapter, domain.ComputilityLedgerEntry{
+		Action:          domain.LedgerActionRelease,
+		UserName:        cmd.Index.UserName,
+		ComputeType:     cmd.Index.ComputeType,
+		SpaceId:         cmd.Index.SpaceId,
+		UsedQuotaChange: -n,
+	})
+
 	err = s.accountRecordAtapter.Delete(record.Id)
 	if err != nil {
 		logrus.Errorf("delete user:%s account record failed, %s", cmd.Index.UserName.Account(), err)
@@ -387,9 +432,9 @@ func (s *computilityInternalAppService) UserQuotaConsume(cmd CmdToUserQuotaUpdat
 		return err
 	}
 
-	balance := account.QuotaCount - account.UsedQuota
-	if balance < 1 {
-		e := xerrors.Errorf("user %s insufficient computing quota balance", user.Account())
+	if balance := account.Balance(); balance < cmd.QuotaCount {
+		e := xerrors.Errorf("user %s insufficient computing quota balance, %d needed but %d left",
+			user.Account(), cmd.QuotaCount, balance)
 
 		logrus.Errorf("consume quota error| %s", e)
 
@@ -403,12 +448,17 @@ func (s *computilityInternalAppService) UserQuotaConsume(cmd CmdToUserQuotaUpdat
 		return err
 	}
 
-	err = s.accountRecordAtapter.Add(&domain.ComputilityAccountRecord{
-		ComputilityAccountRecordIndex: cmd.Index,
-		CreatedAt:                     utils.Now(),
-		QuotaCount:                    cmd.QuotaCount,
-		Version:                       0,
+	appendLedger(s.ledgerAdapter, domain.ComputilityLedgerEntry{
+		Action:          domain.LedgerActionConsume,
+		UserName:        user,
+		ComputeType:     cmd.Index.ComputeType,
+		SpaceId:         cmd.Index.SpaceId,
+		UsedQuotaChange: cmd.QuotaCount,
 	})
+
+	record := domain.NewComputilityAccountRecord(cmd.Index, cmd.QuotaCount, utils.Now())
+
+	err = s.accountRecordAtapter.Add(&record)
 	if err != nil {
 		return err
 	}
@@ -451,7 +501,32 @@ func (s *computilityInternalAppService) SpaceCreateSupply(cmd CmdToSupplyRecord)
 	err = s.accountRecordAtapter.Save(&record)
 	if err != nil {
 		logrus.Errorf("user %s no permission for %s space", cmd.Index.UserName, cmd.Index.ComputeType.ComputilityType())
+	} else {
+		appendLedger(s.ledgerAdapter, domain.ComputilityLedgerEntry{
+			Action:      domain.LedgerActionSupply,
+			UserName:    cmd.Index.UserName,
+			ComputeType: cmd.Index.ComputeType,
+			SpaceId:     cmd.NewSpaceId,
+			Reason:      fmt.Sprintf("moved from space %s", cmd.Index.SpaceId.Identity()),
+		})
 	}
 
 	return nil
 }
+
+// GetAccountUsage returns the balance of the account and the records of space apps which consume its quota.
+func (s *computilityInternalAppService) GetAccountUsage(index domain.ComputilityAccountIndex) (
+	AccountUsageDTO, error,
+) {
+	account, err := s.accountAdapter.FindByAccountIndex(index)
+	if err != nil {
+		return AccountUsageDTO{}, err
+	}
+
+	records, _, err := s.accountRecordAtapter.ListByAccountIndex(index)
+	if err != nil {
+		return AccountUsageDTO{}, err
+	}
+
+	return toAccountUsageDTO(&account, records), nil
+}
@@ -5,8 +5,11 @@ Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
 package app
 
 import (
+	"math"
+
 	"github.com/openmerlin/merlin-server/common/domain/primitive"
 	"github.com/openmerlin/merlin-server/computility/domain"
+	"github.com/openmerlin/merlin-server/computility/domain/repository"
 )
 
 // CmdToUserOrgOperate is a struct used for user join computility.
@@ -93,3 +96,213 @@ func toQuotaRecallDTO(user primitive.Account, a []domain.ComputilityAccountRecor
 		QuotaDebt: debt,
 	}
 }
+
+// AccountUsageDTO is a struct used for the balance of account and the records consuming its quota.
+type AccountUsageDTO struct {
+	Balance int                 `json:"balance"`
+	Records []AccountRecordlDTO `json:"records"`
+}
+
+// toAccountUsageDTO converts a domain.ComputilityAccount object and its records to an AccountUsageDTO.
+func toAccountUsageDTO(a *domain.ComputilityAccount, records []domain.ComputilityAccountRecord) AccountUsageDTO {
+	dto := AccountUsageDTO{
+		Balance: a.Balance(),
+		Records: make([]AccountRecordlDTO, len(records)),
+	}
+
+	for i := range records {
+		dto.Records[i] = toAccountRecordlDTO(&records[i])
+	}
+
+	return dto
+}
+
+// CmdToReconcile is a struct used for reconciling the used quota of accounts.
+type CmdToReconcile struct {
+	DryRun bool
+}
+
+// AccountDriftDTO is a struct used for the drift of account used quota.
+type AccountDriftDTO struct {
+	UserName    string `json:"user_name"`
+	ComputeType string `json:"compute_type"`
+	UsedQuota   int    `json:"used_quota"`
+	LeasedQuota int    `json:"leased_quota"`
+	Drift       int    `json:"drift"`
+	Corrected   bool   `json:"corrected"`
+	Error       string `json:"error,omitempty"`
+}
+
+// toAccountDriftDTO converts a domain.ComputilityAccountDrift object to an AccountDriftDTO.
+func toAccountDriftDTO(d *domain.ComputilityAccountDrift) AccountDriftDTO {
+	return AccountDriftDTO{
+		UserName:    d.UserName.Account(),
+		ComputeType: d.ComputeType.ComputilityType(),
+		UsedQuota:   d.UsedQuota,
+		LeasedQuota: d.LeasedQuota,
+		Drift:       d.Drift(),
+	}
+}
+
+// ReconcileDTO is a struct used for the result of reconciliation.
+type ReconcileDTO struct {
+	DryRun bool              `json:"dry_run"`
+	Drifts []AccountDriftDTO `json:"drifts"`
+}
+
+// CmdToStartUsage is a struct used for starting the usage interval of space.
+type CmdToStartUsage = domain.ComputilityUsage
+
+// CmdToListUsage is a struct used for listing the usage of org.
+type CmdToListUsage struct {
+	domain.UsagePeriod
+
+	OrgName primitive.Account
+}
+
+// DailyUsageDTO is a struct used for the usage of a user on a day.
+type DailyUsageDTO struct {
+	Day         string  `json:"day"`
+	UserName    string  `json:"user_name"`
+	OrgName     string  `json:"org_name"`
+	ComputeType string  `json:"compute_type"`
+	Hours       float64 `json:"hours"`
+	QuotaHours  float64 `json:"quota_hours"`
+}
+
+// OrgUsageDTO is a struct used for the usage report of org.
+type OrgUsageDTO struct {
+	OrgName    string          `json:"org_name"`
+	From       int64           `json:"from"`
+	To         int64           `json:"to"`
+	QuotaHours float64         `json:"quota_hours"`
+	Usages     []DailyUsageDTO `json:"usages"`
+}
+
+// toOrgUsageDTO converts the daily usages to an OrgUsageDTO.
+func toOrgUsageDTO(cmd *CmdToListUsage, daily []domain.ComputilityDailyUsage) OrgUsageDTO {
+	dto := OrgUsageDTO{
+		OrgName: cmd.OrgName.Account(),
+		From:    cmd.From,
+		To:      cmd.To,
+		Usages:  make([]DailyUsageDTO, len(daily)),
+	}
+
+	var total int64
+
+	for i := range daily {
+		d := &daily[i]
+
+		dto.Usages[i] = DailyUsageDTO{
+			Day:         d.Day,
+			UserName:    d.UserName,
+			OrgName:     d.OrgName,
+			ComputeType: d.ComputeType,
+			Hours:       toHours(d.Seconds),
+			QuotaHours:  toHours(d.QuotaSeconds),
+		}
+
+		total += d.QuotaSeconds
+	}
+
+	dto.QuotaHours = toHours(total)
+
+	return dto
+}
+
+func toHours(seconds int64) float64 {
+	return math.Round(float64(seconds)/36) / 100
+}
+
+// CmdToAdjustQuota is a struct used for raising or lowering the quota of org member.
+type CmdToAdjustQuota struct {
+	OrgName  primitive.Account
+	UserName primitive.Account
+	Action   string
+	Quota    int
+	Reason   string
+}
+
+// CmdToTransferQuota is a struct used for moving the quota between org members.
+type CmdToTransferQuota struct {
+	OrgName primitive.Account
+	From    primitive.Account
+	To      primitive.Account
+	Quota   int
+	Reason  string
+}
+
+// CmdToListLedger is a struct used for listing the ledger entries.
+type CmdToListLedger = repository.LedgerListOption
+
+// MemberQuotaDTO is a struct used for the quota assigned by the org to the member.
+type MemberQuotaDTO struct {
+	OrgName     string `json:"org_name"`
+	UserName    string `json:"user_name"`
+	ComputeType string `json:"compute_type"`
+	QuotaCount  int    `json:"quota_count"`
+}
+
+// toMemberQuotaDTO converts a domain.ComputilityDetail object to a MemberQuotaDTO.
+func toMemberQuotaDTO(d *domain.ComputilityDetail) MemberQuotaDTO {
+	return MemberQuotaDTO{
+		OrgName:     d.OrgName.Account(),
+		UserName:    d.UserName.Account(),
+		ComputeType: d.ComputeType.ComputilityType(),
+		QuotaCount:  d.QuotaCount,
+	}
+}
+
+// LedgerEntryDTO is a struct used for the ledger entry.
+type LedgerEntryDTO struct {
+	Id              string `json:"id"`
+	Action          string `json:"action"`
+	OrgName         string `json:"org_name,omitempty"`
+	UserName        string `json:"user_name"`
+	ComputeType     string `json:"compute_type"`
+	SpaceId         string `json:"space_id,omitempty"`
+	QuotaChange     int    `json:"quota_change"`
+	UsedQuotaChange int    `json:"used_quota_change"`
+	Actor           string `json:"actor,omitempty"`
+	Counterpart     string `json:"counterpart,omitempty"`
+	Reason          string `json:"reason,omitempty"`
+	CreatedAt       int64  `json:"created_at"`
+}
+
+// toLedgerEntryDTO converts a domain.ComputilityLedgerEntry object to a LedgerEntryDTO.
+func toLedgerEntryDTO(e *domain.ComputilityLedgerEntry) LedgerEntryDTO {
+	dto := LedgerEntryDTO{
+		Id:              e.Id.Identity(),
+		Action:          e.Action,
+		UserName:        e.UserName.Account(),
+		ComputeType:     e.ComputeType.ComputilityType(),
+		QuotaChange:     e.QuotaChange,
+		UsedQuotaChange: e.UsedQuotaChange,
+		Reason:          e.Reason,
+		CreatedAt:       e.CreatedAt,
+	}
+
+	if e.OrgName != nil {
+		dto.OrgName = e.OrgName.Account()
+	}
+
+	if e.SpaceId != nil {
+		dto.SpaceId = e.SpaceId.Identity()
+	}
+
+	if e.Actor != nil {
+		dto.Actor = e.Actor.Account()
+	}
+
+	if e.Counterpart != nil {
+		dto.Counterpart = e.Counterpart.Account()
+	}
+
+	return dto
+}
+
+// LedgerDTO is a struct used for the ledger entries.
+type LedgerDTO struct {
+	Total   int              `json:"total"`
+	Entries []LedgerEntryDTO `json:"entries"`
+}
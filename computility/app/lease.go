@@ -0,0 +1,229 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	"github.com/openmerlin/merlin-server/computility/domain"
+	"github.com/openmerlin/merlin-server/utils"
+)
+
+// RenewLease renews the lease of quota consumed by the space.
+func (s *computilityInternalAppService) RenewLease(spaceId primitive.Identity) error {
+	if s.privilege == nil {
+		return nil
+	}
+
+	records, err := s.accountRecordAtapter.ListBySpaceId(spaceId)
+	if err != nil {
+		return err
+	}
+
+	now := utils.Now()
+
+	for i := range records {
+		records[i].RenewLease(now)
+
+		if err := s.accountRecordAtapter.Save(&records[i]); err != nil {
+			logrus.Errorf("renew lease of space:%s failed, %s", spaceId.Identity(), err)
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExpireLeases stops the spaces whose lease is expired by the pause function and releases the quota of them.
+// The records which have no lease yet are granted one so that they will expire if nobody renews them.
+func (s *computilityInternalAppService) ExpireLeases(pause func(primitive.Identity) error) (
+	[]AccountRecordlDTO, error,
+) {
+	if s.privilege == nil {
+		return nil, nil
+	}
+
+	now := utils.Now()
+
+	if err := s.accountRecordAtapter.GrantLease(domain.LeaseExpiredAt(now)); err != nil {
+		return nil, err
+	}
+
+	records, err := s.accountRecordAtapter.ListExpired(now)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]AccountRecordlDTO, 0, len(records))
+
+	for i := range records {
+		record := &records[i]
+
+		if err := pause(record.SpaceId); err != nil {
+			logrus.Errorf("lease expired | pause space:%s failed, %s", record.SpaceId.Identity(), err)
+		}
+
+		// the quota may has been released when the space is paused, but the record
+		// is released again in case it is consumed by the user other than the creator.
+		if err := s.releaseExpiredLease(record.ComputilityAccountRecordIndex, now); err != nil {
+			logrus.Errorf("lease expired | release space:%s quota failed, %s", record.SpaceId.Identity(), err)
+
+			continue
+		}
+
+		r = append(r, toAccountRecordlDTO(record))
+	}
+
+	return r, nil
+}
+
+func (s *computilityInternalAppService) releaseExpiredLease(
+	index domain.ComputilityAccountRecordIndex, now int64,
+) error {
+	record, err := s.accountRecordAtapter.FindByRecordIndex(index)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	// the lease has been renewed after it was found expired
+	if !record.IsLeaseExpired(now) {
+		return nil
+	}
+
+	accountIndex := domain.ComputilityAccountIndex{
+		UserName:    index.UserName,
+		ComputeType: index.ComputeType,
+	}
+
+	account, err := s.accountAdapter.FindByAccountIndex(accountIndex)
+	if err != nil {
+		if !commonrepo.IsErrorResourceNotExists(err) {
+			return err
+		}
+
+		return s.accountRecordAtapter.Delete(record.Id)
+	}
+
+	if account.UsedQuota > 0 {
+		n := min(record.QuotaCount, account.UsedQuota)
+
+		if err := s.accountAdapter.ReleaseQuota(account, n); err != nil {
+			return err
+		}
+
+		appendLedger(s.ledgerAdapter, domain.ComputilityLedgerEntry{
+			Action:          domain.LedgerActionLeaseExpire,
+			UserName:        index.UserName,
+			ComputeType:     index.ComputeType,
+			SpaceId:         index.SpaceId,
+			UsedQuotaChange: -n,
+		})
+	}
+
+	if err := s.accountRecordAtapter.Delete(record.Id); err != nil {
+		return err
+	}
+
+	return s.accountAdapter.CancelAccount(accountIndex)
+}
+
+// Reconcile recomputes the used quota of accounts from the records and reports the drift.
+// The used quota is corrected only if it is not a dry run.
+func (s *computilityInternalAppService) Reconcile(cmd CmdToReconcile) (ReconcileDTO, error) {
+	dto := ReconcileDTO{DryRun: cmd.DryRun}
+
+	if s.privilege == nil {
+		return dto, nil
+	}
+
+	leased, err := s.accountRecordAtapter.SumLeasedQuota()
+	if err != nil {
+		return dto, err
+	}
+
+	accounts, err := s.accountAdapter.List()
+	if err != nil {
+		return dto, err
+	}
+
+	leasedOf := make(map[string]int, len(leased))
+	for i := range leased {
+		leasedOf[accountKey(&leased[i].ComputilityAccountIndex)] = leased[i].LeasedQuota
+	}
+
+	for i := range accounts {
+		account := &accounts[i]
+
+		drift := domain.ComputilityAccountDrift{
+			ComputilityAccountIndex: account.ComputilityAccountIndex,
+			UsedQuota:               account.UsedQuota,
+			LeasedQuota:             leasedOf[accountKey(&account.ComputilityAccountIndex)],
+		}
+
+		if drift.Drift() == 0 {
+			continue
+		}
+
+		logrus.Errorf(
+			"computility reconcile | user:%s %s used quota:%d, leased quota:%d",
+			account.UserName.Account(), account.ComputeType.ComputilityType(),
+			drift.UsedQuota, drift.LeasedQuota,
+		)
+
+		item := toAccountDriftDTO(&drift)
+
+		if !cmd.DryRun {
+			if err := s.correctUsedQuota(account, &drift); err != nil {
+				item.Error = err.Error()
+			} else {
+				item.Corrected = true
+			}
+		}
+
+		dto.Drifts = append(dto.Drifts, item)
+	}
+
+	return dto, nil
+}
+
+func (s *computilityInternalAppService) correctUsedQuota(
+	account *domain.ComputilityAccount, drift *domain.ComputilityAccountDrift,
+) error {
+	var err error
+	if n := drift.Drift(); n > 0 {
+		err = s.accountAdapter.ReleaseQuota(*account, n)
+	} else {
+		err = s.accountAdapter.ConsumeQuota(*account, -n)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	appendLedger(s.ledgerAdapter, domain.ComputilityLedgerEntry{
+		Action:          domain.LedgerActionReconcile,
+		UserName:        account.UserName,
+		ComputeType:     account.ComputeType,
+		UsedQuotaChange: -drift.Drift(),
+		Reason: fmt.Sprintf(
+			"used quota %d doesn't match leased quota %d", drift.UsedQuota, drift.LeasedQuota,
+		),
+	})
+
+	return nil
+}
+
+func accountKey(index *domain.ComputilityAccountIndex) string {
+	return index.UserName.Account() + "/" + index.ComputeType.ComputilityType()
+}
@@ -0,0 +1,283 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	"github.com/openmerlin/merlin-server/computility/domain"
+	"github.com/openmerlin/merlin-server/computility/domain/repository"
+	"github.com/openmerlin/merlin-server/utils"
+)
+
+// appendLedger appends the entry to the ledger, the failure is logged
+// because the quota has been changed and can't be rolled back.
+func appendLedger(adapter repository.ComputilityLedgerRepositoryAdapter, entry domain.ComputilityLedgerEntry) {
+	entry.CreatedAt = utils.Now()
+
+	if err := adapter.Add(&entry); err != nil {
+		logrus.Errorf(
+			"computility ledger | append %s of user:%s failed, %s",
+			entry.Action, entry.UserName.Account(), err,
+		)
+	}
+}
+
+// memberQuota is the quota assigned by the org to the member and the account of the member.
+type memberQuota struct {
+	detail  domain.ComputilityDetail
+	account domain.ComputilityAccount
+}
+
+// AdjustMemberQuota raises or lowers the quota assigned by the org to the member,
+// only the admin of org can do it.
+func (s *computilityAppService) AdjustMemberQuota(
+	ctx context.Context, user primitive.Account, cmd *CmdToAdjustQuota,
+) (dto MemberQuotaDTO, action string, err error) {
+	action = fmt.Sprintf(
+		"%s computility quota of %s in %s by %d", cmd.Action, cmd.UserName.Account(), cmd.OrgName.Account(), cmd.Quota,
+	)
+
+	if err = s.checkOrgAdmin(ctx, user, cmd.OrgName); err != nil {
+		return
+	}
+
+	org, err := s.findOrg(cmd.OrgName)
+	if err != nil {
+		return
+	}
+
+	m, err := s.findMember(&org, cmd.UserName)
+	if err != nil {
+		return
+	}
+
+	entry := domain.ComputilityLedgerEntry{
+		Action:      cmd.Action,
+		OrgName:     cmd.OrgName,
+		UserName:    cmd.UserName,
+		ComputeType: org.ComputeType,
+		Actor:       user,
+		Reason:      cmd.Reason,
+	}
+
+	if cmd.Action == domain.LedgerActionRaise {
+		if org.Balance() < cmd.Quota {
+			err = allerror.New(
+				allerror.ErrorCodeInsufficientQuota, "organization insufficient computing quota balance",
+				xerrors.Errorf("organization:%s has only %d quota to assign", org.OrgName.Account(), org.Balance()),
+			)
+
+			return
+		}
+
+		if err = s.changeMemberQuota(&m, cmd.Quota); err != nil {
+			return
+		}
+
+		err = s.orgAdapter.OrgAssignQuota(org, cmd.Quota)
+		entry.QuotaChange = cmd.Quota
+	} else {
+		if err = domain.CheckLower(&m.detail, &m.account, cmd.Quota); err != nil {
+			err = allerror.NewInvalidParam(err.Error(), err)
+
+			return
+		}
+
+		if err = s.changeMemberQuota(&m, -cmd.Quota); err != nil {
+			return
+		}
+
+		err = s.orgAdapter.OrgRecallQuota(org, cmd.Quota)
+		entry.QuotaChange = -cmd.Quota
+	}
+
+	if err != nil {
+		logrus.Errorf("%s | change quota of org failed, %s", action, err)
+
+		return
+	}
+
+	appendLedger(s.ledgerAdapter, entry)
+
+	dto = toMemberQuotaDTO(&m.detail)
+
+	return
+}
+
+// TransferMemberQuota moves the quota between the members of org, only the admin of org can do it.
+func (s *computilityAppService) TransferMemberQuota(
+	ctx context.Context, user primitive.Account, cmd *CmdToTransferQuota,
+) (dtos []MemberQuotaDTO, action string, err error) {
+	action = fmt.Sprintf(
+		"transfer %d computility quota from %s to %s in %s",
+		cmd.Quota, cmd.From.Account(), cmd.To.Account(), cmd.OrgName.Account(),
+	)
+
+	if err = s.checkOrgAdmin(ctx, user, cmd.OrgName); err != nil {
+		return
+	}
+
+	if cmd.From.Account() == cmd.To.Account() {
+		err = allerror.NewInvalidParam("can't transfer quota to the same member", nil)
+
+		return
+	}
+
+	org, err := s.findOrg(cmd.OrgName)
+	if err != nil {
+		return
+	}
+
+	from, err := s.findMember(&org, cmd.From)
+	if err != nil {
+		return
+	}
+
+	to, err := s.findMember(&org, cmd.To)
+	if err != nil {
+		return
+	}
+
+	if err = domain.CheckLower(&from.detail, &from.account, cmd.Quota); err != nil {
+		err = allerror.NewInvalidParam(err.Error(), err)
+
+		return
+	}
+
+	if err = s.changeMemberQuota(&from, -cmd.Quota); err != nil {
+		return
+	}
+
+	appendLedger(s.ledgerAdapter, domain.ComputilityLedgerEntry{
+		Action:      domain.LedgerActionTransferOut,
+		OrgName:     cmd.OrgName,
+		UserName:    cmd.From,
+		ComputeType: org.ComputeType,
+		QuotaChange: -cmd.Quota,
+		Actor:       user,
+		Counterpart: cmd.To,
+		Reason:      cmd.Reason,
+	})
+
+	if err = s.changeMemberQuota(&to, cmd.Quota); err != nil {
+		// the quota has been taken from the member, return it back to the org
+		// so that it is not lost and can be assigned again.
+		logrus.Errorf("%s | raise quota of %s failed, %s", action, cmd.To.Account(), err)
+
+		if e := s.orgAdapter.OrgRecallQuota(org, cmd.Quota); e != nil {
+			logrus.Errorf("%s | recall quota to org failed, %s", action, e)
+		}
+
+		return
+	}
+
+	appendLedger(s.ledgerAdapter, domain.ComputilityLedgerEntry{
+		Action:      domain.LedgerActionTransferIn,
+		OrgName:     cmd.OrgName,
+		UserName:    cmd.To,
+		ComputeType: org.ComputeType,
+		QuotaChange: cmd.Quota,
+		Actor:       user,
+		Counterpart: cmd.From,
+		Reason:      cmd.Reason,
+	})
+
+	dtos = []MemberQuotaDTO{toMemberQuotaDTO(&from.detail), toMemberQuotaDTO(&to.detail)}
+
+	return
+}
+
+// ListLedger lists the ledger entries of the org which only the admin of org can read,
+// or the entries of the user if the org is not specified.
+func (s *computilityAppService) ListLedger(
+	ctx context.Context, user primitive.Account, cmd *CmdToListLedger,
+) (LedgerDTO, error) {
+	if cmd.OrgName != nil {
+		if err := s.checkOrgAdmin(ctx, user, cmd.OrgName); err != nil {
+			return LedgerDTO{}, err
+		}
+	} else {
+		cmd.UserName = user
+	}
+
+	entries, total, err := s.ledgerAdapter.List(cmd)
+	if err != nil {
+		return LedgerDTO{}, err
+	}
+
+	dto := LedgerDTO{
+		Total:   total,
+		Entries: make([]LedgerEntryDTO, len(entries)),
+	}
+
+	for i := range entries {
+		dto.Entries[i] = toLedgerEntryDTO(&entries[i])
+	}
+
+	return dto, nil
+}
+
+func (s *computilityAppService) findOrg(name primitive.Account) (domain.ComputilityOrg, error) {
+	org, err := s.orgAdapter.FindByOrgName(name)
+	if err != nil && commonrepo.IsErrorResourceNotExists(err) {
+		err = allerror.NewNotFound(
+			allerror.ErrorCodeComputilityOrgNotFound, "not found",
+			xerrors.Errorf("org:%s has no computility quota", name.Account()),
+		)
+	}
+
+	return org, err
+}
+
+func (s *computilityAppService) findMember(org *domain.ComputilityOrg, user primitive.Account) (
+	m memberQuota, err error,
+) {
+	m.detail, err = s.detailAdapter.FindByIndex(&domain.ComputilityIndex{
+		OrgName:  org.OrgName,
+		UserName: user,
+	})
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = allerror.NewNotFound(
+				allerror.ErrorCodeComputilityMemberNotFound, "not found",
+				xerrors.Errorf("user:%s has no quota assigned by %s", user.Account(), org.OrgName.Account()),
+			)
+		}
+
+		return
+	}
+
+	m.account, err = s.accountAdapter.FindByAccountIndex(domain.ComputilityAccountIndex{
+		UserName:    user,
+		ComputeType: org.ComputeType,
+	})
+
+	return
+}
+
+// changeMemberQuota changes the quota assigned to the member by delta, the quota of org is not changed.
+func (s *computilityAppService) changeMemberQuota(m *memberQuota, delta int) error {
+	m.detail.QuotaCount += delta
+
+	if err := s.detailAdapter.Save(&m.detail); err != nil {
+		m.detail.QuotaCount -= delta
+
+		return err
+	}
+
+	if delta > 0 {
+		return s.accountAdapter.IncreaseAccountAssignedQuota(m.account, delta)
+	}
+
+	return s.accountAdapter.DecreaseAccountAssignedQuota(m.account, -delta)
+}
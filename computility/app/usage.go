@@ -0,0 +1,111 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package app
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	"github.com/openmerlin/merlin-server/computility/domain"
+	"github.com/openmerlin/merlin-server/utils"
+)
+
+// StartUsage opens the usage interval of the space if it is not open yet.
+// The open interval is closed and a new one is opened if the space consumes the quota differently.
+func (s *computilityInternalAppService) StartUsage(cmd *CmdToStartUsage) error {
+	if cmd.ComputeType == nil || cmd.ComputeType.IsCpu() {
+		return nil
+	}
+
+	now := utils.Now()
+
+	usage, err := s.usageAdapter.FindOpenBySpaceId(cmd.SpaceId)
+	if err == nil {
+		if usage.SameAs(cmd) {
+			return nil
+		}
+
+		usage.Close(now)
+
+		if err := s.usageAdapter.Save(&usage); err != nil {
+			return err
+		}
+	} else if !commonrepo.IsErrorResourceNotExists(err) {
+		return err
+	}
+
+	v := *cmd
+	v.StartedAt = now
+	v.EndedAt = 0
+
+	return s.usageAdapter.Add(&v)
+}
+
+// StopUsage closes the usage interval of the space if it is open.
+func (s *computilityInternalAppService) StopUsage(spaceId primitive.Identity) error {
+	usage, err := s.usageAdapter.FindOpenBySpaceId(spaceId)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	usage.Close(utils.Now())
+
+	return s.usageAdapter.Save(&usage)
+}
+
+// ListOrgUsage lists the daily usage of the org, only the admin of org can do it.
+func (s *computilityAppService) ListOrgUsage(
+	ctx context.Context, user primitive.Account, cmd *CmdToListUsage,
+) (OrgUsageDTO, error) {
+	if err := s.checkOrgAdmin(ctx, user, cmd.OrgName); err != nil {
+		return OrgUsageDTO{}, err
+	}
+
+	usages, err := s.usageAdapter.ListByOrgName(cmd.OrgName, cmd.From, cmd.To)
+	if err != nil {
+		return OrgUsageDTO{}, err
+	}
+
+	daily := domain.AggregateDailyUsage(usages, cmd.From, cmd.To, utils.Now())
+
+	return toOrgUsageDTO(cmd, daily), nil
+}
+
+// checkOrgAdmin checks whether the user is the admin of the org,
+// the user is regarded as the admin of their own account.
+func (s *computilityAppService) checkOrgAdmin(ctx context.Context, user, org primitive.Account) error {
+	if user == nil {
+		return allerror.NewNoPermission("no permission", xerrors.New("anonymous user"))
+	}
+
+	if user.Account() == org.Account() {
+		return nil
+	}
+
+	m, err := s.member.GetByOrgAndUser(ctx, org.Account(), user.Account())
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = allerror.NewNoPermission("no permission",
+				xerrors.Errorf("%s is not a member of %s", user.Account(), org.Account()))
+		}
+
+		return err
+	}
+
+	if m.Role == nil || m.Role.Role() != primitive.NewAdminRole().Role() {
+		return allerror.NewNoPermission("no permission",
+			xerrors.Errorf("%s is not the admin of %s", user.Account(), org.Account()))
+	}
+
+	return nil
+}
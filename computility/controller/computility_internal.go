@@ -26,6 +26,7 @@ func AddRouterForComputilityInternalController(
 	r.POST("/v1/computility/account", m.Write, ctl.ComputilityUserJoin)
 	r.PUT("/v1/computility/account/remove", m.Write, ctl.ComputilityUserRemove)
 	r.POST("/v1/computility/org/delete", m.Write, ctl.ComputilityOrgDelete)
+	r.POST("/v1/computility/reconcile", m.Write, ctl.Reconcile)
 }
 
 // ComputilityInternalController holds the necessary dependencies for handling computility-related operations.
@@ -131,3 +132,27 @@ func (ctl *ComputilityInternalController) ComputilityOrgDelete(ctx *gin.Context)
 		commonctl.SendRespOfPost(ctx, r)
 	}
 }
+
+// @Summary  Reconcile
+// @Description  recompute the used quota of accounts from the records and report the drift
+// @Tags     ComputilityInternal
+// @Param    body  body  reqToReconcile  true  "body"
+// @Accept   json
+// @Security Internal
+// @Success  201   {object} commonctl.ResponseData{data=app.ReconcileDTO,msg=string,code=string}
+// @Router   /v1/computility/reconcile [post]
+func (ctl *ComputilityInternalController) Reconcile(ctx *gin.Context) {
+	req := reqToReconcile{}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	if v, err := ctl.appService.Reconcile(req.toCmd()); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, v)
+	}
+}
@@ -7,8 +7,18 @@ Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
 package controller
 
 import (
+	"errors"
+	"math"
+
+	"github.com/openmerlin/merlin-server/common/controller"
 	"github.com/openmerlin/merlin-server/common/domain/primitive"
 	"github.com/openmerlin/merlin-server/computility/app"
+	"github.com/openmerlin/merlin-server/computility/domain"
+)
+
+const (
+	firstPage             = 1
+	maxLedgerCountPerPage = 100
 )
 
 type reqToUserOrgOperate struct {
@@ -39,3 +49,120 @@ func (req *reqToOrgDelete) toCmd() (cmd app.CmdToOrgDelete, err error) {
 
 	return
 }
+
+type reqToReconcile struct {
+	DryRun bool `json:"dry_run"`
+}
+
+func (req *reqToReconcile) toCmd() app.CmdToReconcile {
+	return app.CmdToReconcile{DryRun: req.DryRun}
+}
+
+type reqToListUsage struct {
+	From string `form:"from"`
+	To   string `form:"to"`
+}
+
+func (req *reqToListUsage) toCmd(org string, now int64) (cmd app.CmdToListUsage, err error) {
+	if cmd.OrgName, err = primitive.NewAccount(org); err != nil {
+		return
+	}
+
+	cmd.UsagePeriod, err = domain.NewUsagePeriod(req.From, req.To, now)
+
+	return
+}
+
+type reqToAdjustQuota struct {
+	UserName string `json:"user_name" required:"true"`
+	Action   string `json:"action"    required:"true"`
+	Quota    int    `json:"quota"     required:"true"`
+	Reason   string `json:"reason"    required:"true"`
+}
+
+func (req *reqToAdjustQuota) toCmd(org string) (cmd app.CmdToAdjustQuota, err error) {
+	if cmd.OrgName, err = primitive.NewAccount(org); err != nil {
+		return
+	}
+
+	if cmd.UserName, err = primitive.NewAccount(req.UserName); err != nil {
+		return
+	}
+
+	if req.Action != domain.LedgerActionRaise && req.Action != domain.LedgerActionLower {
+		err = errors.New("action must be raise or lower")
+
+		return
+	}
+
+	cmd.Action = req.Action
+
+	if cmd.Quota, err = domain.NewQuotaChange(req.Quota); err != nil {
+		return
+	}
+
+	cmd.Reason, err = domain.NewLedgerReason(req.Reason)
+
+	return
+}
+
+type reqToTransferQuota struct {
+	From   string `json:"from"   required:"true"`
+	To     string `json:"to"     required:"true"`
+	Quota  int    `json:"quota"  required:"true"`
+	Reason string `json:"reason" required:"true"`
+}
+
+func (req *reqToTransferQuota) toCmd(org string) (cmd app.CmdToTransferQuota, err error) {
+	if cmd.OrgName, err = primitive.NewAccount(org); err != nil {
+		return
+	}
+
+	if cmd.From, err = primitive.NewAccount(req.From); err != nil {
+		return
+	}
+
+	if cmd.To, err = primitive.NewAccount(req.To); err != nil {
+		return
+	}
+
+	if cmd.Quota, err = domain.NewQuotaChange(req.Quota); err != nil {
+		return
+	}
+
+	cmd.Reason, err = domain.NewLedgerReason(req.Reason)
+
+	return
+}
+
+type reqToListLedger struct {
+	UserName string `form:"user_name"`
+	controller.CommonListRequest
+}
+
+func (req *reqToListLedger) toCmd() (cmd app.CmdToListLedger, err error) {
+	if req.UserName != "" {
+		if cmd.UserName, err = primitive.NewAccount(req.UserName); err != nil {
+			return
+		}
+	}
+
+	if v := req.CountPerPage; v <= 0 || v > maxLedgerCountPerPage {
+		cmd.CountPerPage = maxLedgerCountPerPage
+	} else {
+		cmd.CountPerPage = v
+	}
+
+	if v := req.PageNum; v <= 0 {
+		cmd.PageNum = firstPage
+	} else {
+		if v > (math.MaxInt / cmd.CountPerPage) {
+			err = errors.New("invalid page num")
+
+			return
+		}
+		cmd.PageNum = v
+	}
+
+	return
+}
@@ -6,6 +6,13 @@ Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
 package controller
 
 import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
 	"github.com/gin-gonic/gin"
 
 	commonctl "github.com/openmerlin/merlin-server/common/controller"
@@ -13,6 +20,7 @@ import (
 	"github.com/openmerlin/merlin-server/common/domain/primitive"
 	"github.com/openmerlin/merlin-server/computility/app"
 	"github.com/openmerlin/merlin-server/computility/domain"
+	"github.com/openmerlin/merlin-server/utils"
 )
 
 // AddRouterForComputilityWebController adds routes to
@@ -29,6 +37,12 @@ func AddRouterForComputilityWebController(
 	}
 
 	r.GET("/v1/computility/account/:type", l.Write, m.Read, ctl.GetComputilityAccountDetail)
+	r.GET("/v1/computility/usage/:org", l.Write, m.Read, ctl.ListOrgUsage)
+	r.GET("/v1/computility/usage/:org/csv", l.Write, m.Read, ctl.ExportOrgUsage)
+	r.PUT("/v1/computility/org/:org/quota", m.Write, l.Write, ctl.AdjustMemberQuota)
+	r.POST("/v1/computility/org/:org/quota/transfer", m.Write, l.Write, ctl.TransferMemberQuota)
+	r.GET("/v1/computility/org/:org/ledger", m.Read, ctl.ListOrgLedger)
+	r.GET("/v1/computility/ledger", m.Read, ctl.ListLedger)
 }
 
 // ComputilityWebController is a struct that holds the necessary dependencies for
@@ -69,3 +83,268 @@ func (ctl *ComputilityWebController) GetComputilityAccountDetail(ctx *gin.Contex
 		commonctl.SendRespOfGet(ctx, r)
 	}
 }
+
+// @Summary  ListOrgUsage
+// @Description  list the daily computility usage of org, only the admin of org can do it
+// @Tags     ComputilityWeb
+// @Param    org   path   string  true   "org name" MaxLength(40)
+// @Param    from  query  string  false  "first day of the report, such as 2024-01-01"
+// @Param    to    query  string  false  "last day of the report, such as 2024-01-31"
+// @Accept   json
+// @Success  200  {object} commonctl.ResponseData{data=app.OrgUsageDTO,msg=string,code=string}
+// @Failure  400  {object} commonctl.ResponseData{data=error,msg=string,code=string}
+// @Router   /v1/computility/usage/{org} [get]
+func (ctl *ComputilityWebController) ListOrgUsage(ctx *gin.Context) {
+	user, cmd, ok := ctl.parseListUsage(ctx)
+	if !ok {
+		return
+	}
+
+	if r, err := ctl.appService.ListOrgUsage(ctx.Request.Context(), user, &cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, r)
+	}
+}
+
+// @Summary  ExportOrgUsage
+// @Description  export the daily computility usage of org as csv, only the admin of org can do it
+// @Tags     ComputilityWeb
+// @Param    org   path   string  true   "org name" MaxLength(40)
+// @Param    from  query  string  false  "first day of the report, such as 2024-01-01"
+// @Param    to    query  string  false  "last day of the report, such as 2024-01-31"
+// @Produce  text/csv
+// @Success  200  {file} file
+// @Failure  400  {object} commonctl.ResponseData{data=error,msg=string,code=string}
+// @Router   /v1/computility/usage/{org}/csv [get]
+func (ctl *ComputilityWebController) ExportOrgUsage(ctx *gin.Context) {
+	user, cmd, ok := ctl.parseListUsage(ctx)
+	if !ok {
+		return
+	}
+
+	r, err := ctl.appService.ListOrgUsage(ctx.Request.Context(), user, &cmd)
+	if err != nil {
+		commonctl.SendError(ctx, err)
+
+		return
+	}
+
+	data, err := toUsageCSV(&r)
+	if err != nil {
+		commonctl.SendError(ctx, err)
+
+		return
+	}
+
+	filename := fmt.Sprintf(
+		"%s_computility_usage_%s_%s.csv",
+		r.OrgName, formatUsageDay(r.From), formatUsageDay(r.To-1),
+	)
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+}
+
+// @Summary  AdjustMemberQuota
+// @Description  raise or lower the quota assigned by the org to the member, only the admin of org can do it
+// @Tags     ComputilityWeb
+// @Param    org   path  string            true  "org name" MaxLength(40)
+// @Param    body  body  reqToAdjustQuota  true  "body of adjusting quota"
+// @Accept   json
+// @Security Bearer
+// @Success  202  {object} commonctl.ResponseData{data=app.MemberQuotaDTO,msg=string,code=string}
+// @Failure  400  {object} commonctl.ResponseData{data=error,msg=string,code=string}
+// @Router   /v1/computility/org/{org}/quota [put]
+func (ctl *ComputilityWebController) AdjustMemberQuota(ctx *gin.Context) {
+	req := reqToAdjustQuota{}
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	cmd, err := req.toCmd(ctx.Param("org"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	v, action, err := ctl.appService.AdjustMemberQuota(ctx.Request.Context(), user, &cmd)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, v)
+	}
+}
+
+// @Summary  TransferMemberQuota
+// @Description  move the quota between the members of org, only the admin of org can do it
+// @Tags     ComputilityWeb
+// @Param    org   path  string              true  "org name" MaxLength(40)
+// @Param    body  body  reqToTransferQuota  true  "body of transferring quota"
+// @Accept   json
+// @Security Bearer
+// @Success  201  {object} commonctl.ResponseData{data=[]app.MemberQuotaDTO,msg=string,code=string}
+// @Failure  400  {object} commonctl.ResponseData{data=error,msg=string,code=string}
+// @Router   /v1/computility/org/{org}/quota/transfer [post]
+func (ctl *ComputilityWebController) TransferMemberQuota(ctx *gin.Context) {
+	req := reqToTransferQuota{}
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	cmd, err := req.toCmd(ctx.Param("org"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	v, action, err := ctl.appService.TransferMemberQuota(ctx.Request.Context(), user, &cmd)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, v)
+	}
+}
+
+// @Summary  ListOrgLedger
+// @Description  list the ledger of the computility quota of org, only the admin of org can do it
+// @Tags     ComputilityWeb
+// @Param    org             path   string  true   "org name" MaxLength(40)
+// @Param    user_name       query  string  false  "member of org"
+// @Param    count_per_page  query  int     false  "count per page"
+// @Param    page_num        query  int     false  "page num which starts from 1"
+// @Accept   json
+// @Security Bearer
+// @Success  200  {object} commonctl.ResponseData{data=app.LedgerDTO,msg=string,code=string}
+// @Failure  400  {object} commonctl.ResponseData{data=error,msg=string,code=string}
+// @Router   /v1/computility/org/{org}/ledger [get]
+func (ctl *ComputilityWebController) ListOrgLedger(ctx *gin.Context) {
+	req := reqToListLedger{}
+	if err := ctx.BindQuery(&req); err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	cmd, err := req.toCmd()
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if cmd.OrgName, err = primitive.NewAccount(ctx.Param("org")); err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	if v, err := ctl.appService.ListLedger(ctx.Request.Context(), user, &cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary  ListLedger
+// @Description  list the ledger of the computility quota of the user
+// @Tags     ComputilityWeb
+// @Param    count_per_page  query  int  false  "count per page"
+// @Param    page_num        query  int  false  "page num which starts from 1"
+// @Accept   json
+// @Security Bearer
+// @Success  200  {object} commonctl.ResponseData{data=app.LedgerDTO,msg=string,code=string}
+// @Failure  400  {object} commonctl.ResponseData{data=error,msg=string,code=string}
+// @Router   /v1/computility/ledger [get]
+func (ctl *ComputilityWebController) ListLedger(ctx *gin.Context) {
+	user := ctl.userMiddleWare.GetUserAndExitIfFailed(ctx)
+	if user == nil {
+		return
+	}
+
+	req := reqToListLedger{}
+	if err := ctx.BindQuery(&req); err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	cmd, err := req.toCmd()
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if v, err := ctl.appService.ListLedger(ctx.Request.Context(), user, &cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, v)
+	}
+}
+
+func (ctl *ComputilityWebController) parseListUsage(ctx *gin.Context) (
+	user primitive.Account, cmd app.CmdToListUsage, ok bool,
+) {
+	if user = ctl.userMiddleWare.GetUserAndExitIfFailed(ctx); user == nil {
+		return
+	}
+
+	req := reqToListUsage{}
+	if err := ctx.BindQuery(&req); err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	cmd, err := req.toCmd(ctx.Param("org"), utils.Now())
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	return user, cmd, true
+}
+
+func toUsageCSV(r *app.OrgUsageDTO) ([]byte, error) {
+	buf := bytes.Buffer{}
+	w := csv.NewWriter(&buf)
+
+	records := [][]string{{"day", "org_name", "user_name", "compute_type", "hours", "quota_hours"}}
+	for i := range r.Usages {
+		u := &r.Usages[i]
+
+		records = append(records, []string{
+			u.Day, u.OrgName, u.UserName, u.ComputeType,
+			strconv.FormatFloat(u.Hours, 'f', 2, 64),
+			strconv.FormatFloat(u.QuotaHours, 'f', 2, 64),
+		})
+	}
+
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func formatUsageDay(t int64) string {
+	return time.Unix(t, 0).UTC().Format(time.DateOnly)
+}
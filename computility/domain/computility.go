@@ -83,6 +83,53 @@ type ComputilityAccountRecord struct {
 	Id         primitive.Identity
 	CreatedAt  int64
 	QuotaCount int
+	// ExpiredAt is the time when the lease of quota expires, zero means the record
+	// was created before the lease was introduced and it is granted a lease on the first check.
+	ExpiredAt int64
 
 	Version int
 }
+
+// NewComputilityAccountRecord creates a record which leases the quota to the space.
+func NewComputilityAccountRecord(
+	index ComputilityAccountRecordIndex, quota int, now int64,
+) ComputilityAccountRecord {
+	r := ComputilityAccountRecord{
+		ComputilityAccountRecordIndex: index,
+		CreatedAt:                     now,
+		QuotaCount:                    quota,
+	}
+
+	r.RenewLease(now)
+
+	return r
+}
+
+// RenewLease extends the lease of quota by the ttl from now.
+func (r *ComputilityAccountRecord) RenewLease(now int64) {
+	r.ExpiredAt = LeaseExpiredAt(now)
+}
+
+// LeaseExpiredAt returns the time when the lease granted at now expires.
+func LeaseExpiredAt(now int64) int64 {
+	return now + config.LeaseTTL
+}
+
+// IsLeaseExpired checks if the lease of quota is expired.
+func (r *ComputilityAccountRecord) IsLeaseExpired(now int64) bool {
+	return r.ExpiredAt > 0 && r.ExpiredAt <= now
+}
+
+// ComputilityAccountDrift is the difference between the used quota of account
+// and the quota leased by the records of it.
+type ComputilityAccountDrift struct {
+	ComputilityAccountIndex
+
+	UsedQuota   int
+	LeasedQuota int
+}
+
+// Drift returns how much the used quota exceeds the leased quota.
+func (d *ComputilityAccountDrift) Drift() int {
+	return d.UsedQuota - d.LeasedQuota
+}
@@ -0,0 +1,62 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import "testing"
+
+// TestComputilityAccountRecordLease tests that the lease of quota expires after the ttl unless it is renewed.
+func TestComputilityAccountRecordLease(t *testing.T) {
+	cfg := Config{}
+	cfg.SetDefault()
+	Init(&cfg)
+
+	now := int64(1700000000)
+
+	r := NewComputilityAccountRecord(ComputilityAccountRecordIndex{}, 1, now)
+	if r.IsLeaseExpired(now + leaseTTL - 1) {
+		t.Fatal("lease should not expire before the ttl")
+	}
+
+	if !r.IsLeaseExpired(now + leaseTTL) {
+		t.Fatal("lease should expire after the ttl")
+	}
+
+	r.RenewLease(now + leaseTTL - 1)
+	if r.IsLeaseExpired(now + leaseTTL) {
+		t.Fatal("renewed lease should not expire")
+	}
+
+	legacy := ComputilityAccountRecord{}
+	if legacy.IsLeaseExpired(now) {
+		t.Fatal("record without lease should not expire")
+	}
+}
+
+// TestComputilityAccountDrift tests the drift between the used quota and the leased quota.
+func TestComputilityAccountDrift(t *testing.T) {
+	d := ComputilityAccountDrift{UsedQuota: 3, LeasedQuota: 1}
+	if d.Drift() != 2 {
+		t.Fatalf("unexpected drift: %d", d.Drift())
+	}
+}
+
+// TestCheckLower tests that only the unused quota assigned by the org can be lowered.
+func TestCheckLower(t *testing.T) {
+	detail := ComputilityDetail{QuotaCount: 2}
+	account := ComputilityAccount{QuotaCount: 3, UsedQuota: 2}
+
+	if err := CheckLower(&detail, &account, 1); err != nil {
+		t.Fatalf("unused quota should be lowered, %v", err)
+	}
+
+	if err := CheckLower(&detail, &account, 2); err == nil {
+		t.Fatal("quota being used should not be lowered")
+	}
+
+	account.UsedQuota = 0
+	if err := CheckLower(&detail, &account, 3); err == nil {
+		t.Fatal("quota not assigned by the org should not be lowered")
+	}
+}
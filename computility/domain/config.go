@@ -0,0 +1,27 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+const leaseTTL = 10 * 60
+
+var config Config
+
+// Init initializes the configuration with the given Config struct.
+func Init(cfg *Config) {
+	config = *cfg
+}
+
+// Config is a struct that holds the configuration of computility.
+type Config struct {
+	// LeaseTTL is the lifetime of the quota lease in seconds, the lease must be renewed before it expires.
+	LeaseTTL int64 `json:"lease_ttl"`
+}
+
+// SetDefault sets the default values for the Config struct.
+func (cfg *Config) SetDefault() {
+	if cfg.LeaseTTL <= 0 {
+		cfg.LeaseTTL = leaseTTL
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+)
+
+const (
+	// LedgerActionUserJoin is the action that the default quota is assigned to the member who joins the org.
+	LedgerActionUserJoin = "user_join"
+	// LedgerActionUserRemove is the action that the quota is recalled from the member who leaves the org.
+	LedgerActionUserRemove = "user_remove"
+	// LedgerActionOrgDelete is the action that the quota is recalled from the member when the org is deleted.
+	LedgerActionOrgDelete = "org_delete"
+	// LedgerActionRaise is the action that the org admin raises the quota of member.
+	LedgerActionRaise = "raise"
+	// LedgerActionLower is the action that the org admin lowers the quota of member.
+	LedgerActionLower = "lower"
+	// LedgerActionTransferOut is the action that the quota is moved out of the member by the org admin.
+	LedgerActionTransferOut = "transfer_out"
+	// LedgerActionTransferIn is the action that the quota is moved into the member by the org admin.
+	LedgerActionTransferIn = "transfer_in"
+	// LedgerActionConsume is the action that the space consumes the quota of user.
+	LedgerActionConsume = "consume"
+	// LedgerActionRelease is the action that the space releases the quota of user.
+	LedgerActionRelease = "release"
+	// LedgerActionSupply is the action that the consumed quota is moved to the new space.
+	LedgerActionSupply = "supply"
+	// LedgerActionLeaseExpire is the action that the quota is released because the lease expired.
+	LedgerActionLeaseExpire = "lease_expire"
+	// LedgerActionReconcile is the action that the used quota is corrected by the reconciliation.
+	LedgerActionReconcile = "reconcile"
+
+	maxQuotaChange  = 1000
+	maxReasonLength = 200
+)
+
+// ComputilityLedgerEntry is an append-only record of the change of computility quota.
+// QuotaChange is the change of the quota assigned to the user and
+// UsedQuotaChange is the change of the quota used by the user.
+// The actor is nil if the change is made by the system and
+// the counterpart is the member which the quota is transferred from or to.
+type ComputilityLedgerEntry struct {
+	Id              primitive.Identity
+	Action          string
+	OrgName         primitive.Account
+	UserName        primitive.Account
+	ComputeType     primitive.ComputilityType
+	SpaceId         primitive.Identity
+	QuotaChange     int
+	UsedQuotaChange int
+	Actor           primitive.Account
+	Counterpart     primitive.Account
+	Reason          string
+	CreatedAt       int64
+}
+
+// NewQuotaChange checks the quota which the org admin raises, lowers or transfers.
+func NewQuotaChange(n int) (int, error) {
+	if n <= 0 || n > maxQuotaChange {
+		return 0, fmt.Errorf("quota must be between 1 and %d", maxQuotaChange)
+	}
+
+	return n, nil
+}
+
+// NewLedgerReason checks the reason why the org admin changes the quota.
+func NewLedgerReason(v string) (string, error) {
+	v = strings.TrimSpace(v)
+
+	if v == "" {
+		return "", errors.New("reason can't be empty")
+	}
+
+	if utf8.RuneCountInString(v) > maxReasonLength {
+		return "", fmt.Errorf("reason can't exceed %d characters", maxReasonLength)
+	}
+
+	return v, nil
+}
+
+// Balance returns the quota of org which is not assigned to members.
+func (o *ComputilityOrg) Balance() int {
+	return o.QuotaCount - o.UsedQuota
+}
+
+// Balance returns the quota of account which is not used by spaces.
+func (a *ComputilityAccount) Balance() int {
+	return a.QuotaCount - a.UsedQuota
+}
+
+// CheckLower checks if the quota assigned by the org to the member can be lowered by n,
+// the quota which is being used by spaces can't be taken back.
+func CheckLower(detail *ComputilityDetail, account *ComputilityAccount, n int) error {
+	if detail.QuotaCount < n {
+		return errors.New("quota assigned to the member is not enough")
+	}
+
+	if account.Balance() < n {
+		return errors.New("quota is being used by the spaces of member")
+	}
+
+	return nil
+}
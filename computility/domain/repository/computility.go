@@ -25,6 +25,7 @@ type ComputilityDetailRepositoryAdapter interface {
 	Delete(primitive.Identity) error
 	FindByIndex(*domain.ComputilityIndex) (domain.ComputilityDetail, error)
 	GetMembers(primitive.Account) ([]domain.ComputilityDetail, error)
+	Save(*domain.ComputilityDetail) error
 }
 
 // ComputilityAccountRepositoryAdapter is an interface for interacting with computility account repositories.
@@ -41,6 +42,8 @@ type ComputilityAccountRepositoryAdapter interface {
 	ReleaseQuota(domain.ComputilityAccount, int) error
 
 	CancelAccount(domain.ComputilityAccountIndex) error
+
+	List() ([]domain.ComputilityAccount, error)
 }
 
 // ComputilityAccountRecordRepositoryAdapter is an interface for
@@ -51,4 +54,46 @@ type ComputilityAccountRecordRepositoryAdapter interface {
 	Delete(primitive.Identity) error
 	ListByAccountIndex(domain.ComputilityAccountIndex) ([]domain.ComputilityAccountRecord, int, error)
 	FindByRecordIndex(domain.ComputilityAccountRecordIndex) (domain.ComputilityAccountRecord, error)
+
+	ListBySpaceId(primitive.Identity) ([]domain.ComputilityAccountRecord, error)
+	// ListExpired lists the records whose lease is expired at the time.
+	ListExpired(int64) ([]domain.ComputilityAccountRecord, error)
+	// GrantLease grants the lease which expires at the time to the records which have no lease.
+	GrantLease(int64) error
+	// SumLeasedQuota sums the leased quota of records by account.
+	SumLeasedQuota() ([]domain.ComputilityAccountDrift, error)
+}
+
+// ComputilityUsageRepositoryAdapter is an interface for interacting with computility usage repositories.
+type ComputilityUsageRepositoryAdapter interface {
+	Add(*domain.ComputilityUsage) error
+	Save(*domain.ComputilityUsage) error
+	// FindOpenBySpaceId finds the usage which the space is still consuming.
+	FindOpenBySpaceId(primitive.Identity) (domain.ComputilityUsage, error)
+	// ListByOrgName lists the usages of the org which overlap with [from, to).
+	ListByOrgName(org primitive.Account, from, to int64) ([]domain.ComputilityUsage, error)
+}
+
+// LedgerListOption is the option of listing the ledger entries, the empty field is not used as filter.
+type LedgerListOption struct {
+	OrgName      primitive.Account
+	UserName     primitive.Account
+	PageNum      int
+	CountPerPage int
+}
+
+// Pagination returns a boolean indicating whether pagination is enabled and the offset for pagination.
+func (opt *LedgerListOption) Pagination() (bool, int) {
+	if opt.PageNum > 0 && opt.CountPerPage > 0 {
+		return true, (opt.PageNum - 1) * opt.CountPerPage
+	}
+
+	return false, 0
+}
+
+// ComputilityLedgerRepositoryAdapter is an interface for interacting with the append-only computility ledger.
+type ComputilityLedgerRepositoryAdapter interface {
+	Add(*domain.ComputilityLedgerEntry) error
+	// List lists the entries from the newest one and returns the total.
+	List(*LedgerListOption) ([]domain.ComputilityLedgerEntry, int, error)
 }
@@ -0,0 +1,168 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+)
+
+const (
+	usageDayLayout = "2006-01-02"
+	secondsOfDay   = 24 * 60 * 60
+
+	usageDefaultDays = 30
+	usageMaxDays     = 366
+)
+
+// UsagePeriod is the range of UTC days [From, To) which the usage report covers.
+type UsagePeriod struct {
+	From int64
+	To   int64
+}
+
+// NewUsagePeriod parses the first and the last day of the report, both of which are included.
+// The report covers the last 30 days up to today if they are empty.
+func NewUsagePeriod(from, to string, now int64) (UsagePeriod, error) {
+	p := UsagePeriod{To: now - now%secondsOfDay + secondsOfDay}
+
+	if to != "" {
+		t, err := time.Parse(usageDayLayout, to)
+		if err != nil {
+			return UsagePeriod{}, errors.New("invalid end day")
+		}
+
+		p.To = t.Unix() + secondsOfDay
+	}
+
+	p.From = p.To - usageDefaultDays*secondsOfDay
+
+	if from != "" {
+		t, err := time.Parse(usageDayLayout, from)
+		if err != nil {
+			return UsagePeriod{}, errors.New("invalid start day")
+		}
+
+		p.From = t.Unix()
+	}
+
+	if p.From >= p.To {
+		return UsagePeriod{}, errors.New("start day must not be after end day")
+	}
+
+	if p.To-p.From > usageMaxDays*secondsOfDay {
+		return UsagePeriod{}, errors.New("period can't exceed 366 days")
+	}
+
+	return p, nil
+}
+
+// ComputilityUsage is an interval during which the space consumes the computility quota,
+// the user is the creator of space and the org is the owner of it.
+type ComputilityUsage struct {
+	Id          primitive.Identity
+	SpaceId     primitive.Identity
+	UserName    primitive.Account
+	OrgName     primitive.Account
+	ComputeType primitive.ComputilityType
+	QuotaCount  int
+	StartedAt   int64
+	// EndedAt is zero if the space is still consuming the quota.
+	EndedAt int64
+
+	Version int
+}
+
+// IsOpen checks if the space is still consuming the quota.
+func (u *ComputilityUsage) IsOpen() bool {
+	return u.EndedAt == 0
+}
+
+// Close ends the interval at the time.
+func (u *ComputilityUsage) Close(now int64) {
+	if now < u.StartedAt {
+		now = u.StartedAt
+	}
+
+	u.EndedAt = now
+}
+
+// SameAs checks if the usage consumes the same quota of the same account as the other one.
+func (u *ComputilityUsage) SameAs(other *ComputilityUsage) bool {
+	return u.UserName.Account() == other.UserName.Account() &&
+		u.OrgName.Account() == other.OrgName.Account() &&
+		u.ComputeType.ComputilityType() == other.ComputeType.ComputilityType() &&
+		u.QuotaCount == other.QuotaCount
+}
+
+// ComputilityDailyUsage is the usage aggregated by user, org, compute type and UTC day.
+type ComputilityDailyUsage struct {
+	Day          string
+	UserName     string
+	OrgName      string
+	ComputeType  string
+	Seconds      int64
+	QuotaSeconds int64
+}
+
+func (d *ComputilityDailyUsage) key() string {
+	return d.Day + "/" + d.OrgName + "/" + d.UserName + "/" + d.ComputeType
+}
+
+// AggregateDailyUsage splits the usages by UTC day and sums them in [from, to),
+// the interval which is still open is counted up to now.
+func AggregateDailyUsage(usages []ComputilityUsage, from, to, now int64) []ComputilityDailyUsage {
+	m := map[string]*ComputilityDailyUsage{}
+
+	for i := range usages {
+		u := &usages[i]
+
+		end := u.EndedAt
+		if u.IsOpen() {
+			end = now
+		}
+
+		start := max(u.StartedAt, from)
+		end = min(end, to)
+
+		for start < end {
+			dayEnd := start - start%secondsOfDay + secondsOfDay
+			seconds := min(end, dayEnd) - start
+
+			item := ComputilityDailyUsage{
+				Day:         time.Unix(start, 0).UTC().Format(usageDayLayout),
+				UserName:    u.UserName.Account(),
+				OrgName:     u.OrgName.Account(),
+				ComputeType: u.ComputeType.ComputilityType(),
+			}
+
+			k := item.key()
+			if v, ok := m[k]; ok {
+				v.Seconds += seconds
+				v.QuotaSeconds += seconds * int64(u.QuotaCount)
+			} else {
+				item.Seconds = seconds
+				item.QuotaSeconds = seconds * int64(u.QuotaCount)
+				m[k] = &item
+			}
+
+			start = dayEnd
+		}
+	}
+
+	r := make([]ComputilityDailyUsage, 0, len(m))
+	for _, v := range m {
+		r = append(r, *v)
+	}
+
+	sort.Slice(r, func(i, j int) bool {
+		return r[i].key() < r[j].key()
+	})
+
+	return r
+}
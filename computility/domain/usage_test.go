@@ -0,0 +1,62 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+)
+
+// TestAggregateDailyUsage tests that the usages are split at midnight and clipped by the range.
+func TestAggregateDailyUsage(t *testing.T) {
+	// 2024-01-01T00:00:00Z
+	day := int64(1704067200)
+
+	u := ComputilityUsage{
+		UserName:    primitive.CreateAccount("alice"),
+		OrgName:     primitive.CreateAccount("org"),
+		ComputeType: primitive.CreateComputilityType("npu"),
+		QuotaCount:  2,
+		StartedAt:   day + secondsOfDay - 3600,
+	}
+
+	// the open interval is counted up to now and split at midnight
+	r := AggregateDailyUsage([]ComputilityUsage{u}, day, day+2*secondsOfDay, day+secondsOfDay+1800)
+	if len(r) != 2 {
+		t.Fatalf("expect 2 days, got %d", len(r))
+	}
+
+	if r[0].Day != "2024-01-01" || r[0].Seconds != 3600 || r[0].QuotaSeconds != 7200 {
+		t.Fatalf("unexpected usage of the first day: %+v", r[0])
+	}
+
+	if r[1].Day != "2024-01-02" || r[1].Seconds != 1800 {
+		t.Fatalf("unexpected usage of the second day: %+v", r[1])
+	}
+
+	// the interval is clipped by the range
+	u.Close(day + secondsOfDay + 1800)
+	r = AggregateDailyUsage([]ComputilityUsage{u, u}, day+secondsOfDay, day+2*secondsOfDay, 0)
+	if len(r) != 1 || r[0].Seconds != 3600 {
+		t.Fatalf("unexpected clipped usage: %+v", r)
+	}
+}
+
+// TestNewUsagePeriod tests the validation of the start day, the end day and the length of usage period.
+func TestNewUsagePeriod(t *testing.T) {
+	p, err := NewUsagePeriod("2024-01-01", "2024-01-01", 0)
+	if err != nil || p.From != 1704067200 || p.To != p.From+secondsOfDay {
+		t.Fatalf("unexpected period: %+v, %v", p, err)
+	}
+
+	if _, err := NewUsagePeriod("2024-01-02", "2024-01-01", 0); err == nil {
+		t.Fatal("start day after end day should be rejected")
+	}
+
+	if _, err := NewUsagePeriod("2022-01-01", "2024-01-01", 0); err == nil {
+		t.Fatal("period longer than a year should be rejected")
+	}
+}
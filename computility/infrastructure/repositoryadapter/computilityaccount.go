@@ -207,3 +207,19 @@ func (adapter *computilityAccountAdapter) updateQuotaCount(do computilityAccount
 
 	return nil
 }
+
+// List lists all the computility accounts.
+func (adapter *computilityAccountAdapter) List() ([]domain.ComputilityAccount, error) {
+	var result []computilityAccountDO
+
+	if err := adapter.db().Find(&result).Error; err != nil {
+		return nil, err
+	}
+
+	r := make([]domain.ComputilityAccount, len(result))
+	for i := range result {
+		r[i] = result[i].toComputilityAccount()
+	}
+
+	return r, nil
+}
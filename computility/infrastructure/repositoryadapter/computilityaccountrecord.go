@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
 	"github.com/openmerlin/merlin-server/common/domain/primitive"
@@ -101,3 +102,76 @@ func (adapter *computilityAccountRecordAdapter) Save(record *domain.ComputilityA
 
 	return nil
 }
+
+// ListBySpaceId lists all the records of the space.
+func (adapter *computilityAccountRecordAdapter) ListBySpaceId(spaceId primitive.Identity) (
+	[]domain.ComputilityAccountRecord, error,
+) {
+	var result []computilityAccountRecordDO
+
+	err := adapter.db().Where(equalQuery(fieldSpaceId), spaceId.Integer()).Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toComputilityAccountRecords(result), nil
+}
+
+// ListExpired lists the records whose lease is expired at the time.
+func (adapter *computilityAccountRecordAdapter) ListExpired(now int64) (
+	[]domain.ComputilityAccountRecord, error,
+) {
+	var result []computilityAccountRecordDO
+
+	sql := fmt.Sprintf(`%s > 0 and %s <= ?`, fieldExpiredAt, fieldExpiredAt)
+
+	if err := adapter.db().Where(sql, now).Find(&result).Error; err != nil {
+		return nil, err
+	}
+
+	return toComputilityAccountRecords(result), nil
+}
+
+// GrantLease grants the lease which expires at the time to the records which have no lease.
+func (adapter *computilityAccountRecordAdapter) GrantLease(expiredAt int64) error {
+	return adapter.db().Where(
+		equalQuery(fieldExpiredAt), 0,
+	).Updates(map[string]interface{}{
+		fieldExpiredAt: expiredAt,
+		filedVersion:   gorm.Expr(filedVersion + " + 1"),
+	}).Error
+}
+
+// SumLeasedQuota sums the leased quota of records by account.
+func (adapter *computilityAccountRecordAdapter) SumLeasedQuota() ([]domain.ComputilityAccountDrift, error) {
+	var result []leasedQuotaDO
+
+	err := adapter.db().Select(
+		fmt.Sprintf("%s, %s, sum(%s) as %s", filedUserName, filedComputeType, fieldQuotaCount, fieldQuotaCount),
+	).Group(filedUserName).Group(filedComputeType).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]domain.ComputilityAccountDrift, len(result))
+	for i := range result {
+		r[i] = domain.ComputilityAccountDrift{
+			ComputilityAccountIndex: domain.ComputilityAccountIndex{
+				UserName:    primitive.CreateAccount(result[i].UserName),
+				ComputeType: primitive.CreateComputilityType(result[i].ComputeType),
+			},
+			LeasedQuota: result[i].QuotaCount,
+		}
+	}
+
+	return r, nil
+}
+
+func toComputilityAccountRecords(dos []computilityAccountRecordDO) []domain.ComputilityAccountRecord {
+	r := make([]domain.ComputilityAccountRecord, len(dos))
+	for i := range dos {
+		r[i] = dos[i].toComputilityAccountRecord()
+	}
+
+	return r
+}
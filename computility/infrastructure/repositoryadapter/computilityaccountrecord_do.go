@@ -9,6 +9,11 @@ import (
 	"github.com/openmerlin/merlin-server/computility/domain"
 )
 
+const (
+	fieldSpaceId   = "space_id"
+	fieldExpiredAt = "expired_at"
+)
+
 var (
 	computilityAccountRecordTableName = ""
 )
@@ -24,10 +29,18 @@ type computilityAccountRecordDO struct {
 	CreatedAt   int64  `gorm:"column:created_at"`
 	QuotaCount  int    `gorm:"column:quota_count"`
 	ComputeType string `gorm:"column:compute_type"`
+	ExpiredAt   int64  `gorm:"column:expired_at;not null;default:0;index"`
 
 	Version int `gorm:"column:version"`
 }
 
+// leasedQuotaDO is the leased quota of records summed by account.
+type leasedQuotaDO struct {
+	UserName    string `gorm:"column:user_name"`
+	ComputeType string `gorm:"column:compute_type"`
+	QuotaCount  int    `gorm:"column:quota_count"`
+}
+
 func toComputilityAccountRecordDO(d *domain.ComputilityAccountRecord) computilityAccountRecordDO {
 	return computilityAccountRecordDO{
 		Id:          d.Id.Integer(),
@@ -36,6 +49,7 @@ func toComputilityAccountRecordDO(d *domain.ComputilityAccountRecord) computilit
 		QuotaCount:  d.QuotaCount,
 		CreatedAt:   d.CreatedAt,
 		ComputeType: d.ComputeType.ComputilityType(),
+		ExpiredAt:   d.ExpiredAt,
 		Version:     d.Version,
 	}
 }
@@ -50,6 +64,7 @@ func (do *computilityAccountRecordDO) toComputilityAccountRecord() domain.Comput
 		},
 		CreatedAt:  do.CreatedAt,
 		QuotaCount: do.QuotaCount,
+		ExpiredAt:  do.ExpiredAt,
 		Version:    do.Version,
 	}
 }
@@ -5,9 +5,12 @@ Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
 package repositoryadapter
 
 import (
+	"errors"
+
 	"gorm.io/gorm/clause"
 
 	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/common/domain/repository"
 	"github.com/openmerlin/merlin-server/computility/domain"
 )
 
@@ -64,3 +67,27 @@ func (adapter *computilityDetailAdapter) GetMembers(orgName primitive.Account) (
 
 	return r, nil
 }
+
+// Save saves the computility detail record in the repository.
+func (adapter *computilityDetailAdapter) Save(d *domain.ComputilityDetail) error {
+	do := toComputilityDetailDO(d)
+	do.Version += 1
+
+	v := adapter.db().Model(
+		&computilityDetailDO{Id: d.Id.Integer()},
+	).Where(
+		equalQuery(filedVersion), d.Version,
+	).Select(`*`).Omit(fieldCreatedAt).Updates(&do)
+
+	if v.Error != nil {
+		return v.Error
+	}
+
+	if v.RowsAffected == 0 {
+		return repository.NewErrorConcurrentUpdating(
+			errors.New("concurrent updating"),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package repositoryadapter
+
+import (
+	"gorm.io/gorm/clause"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/computility/domain"
+	"github.com/openmerlin/merlin-server/computility/domain/repository"
+)
+
+// computilityLedgerAdapter only appends and reads the entries, they are never updated or deleted.
+type computilityLedgerAdapter struct {
+	daoImpl
+}
+
+// Add appends an entry to the ledger.
+func (adapter *computilityLedgerAdapter) Add(d *domain.ComputilityLedgerEntry) error {
+	d.Id = primitive.CreateIdentity(primitive.GetId())
+
+	do := toComputilityLedgerDO(d)
+
+	return adapter.db().Clauses(clause.Returning{}).Create(&do).Error
+}
+
+// List lists the entries from the newest one and returns the total.
+func (adapter *computilityLedgerAdapter) List(opt *repository.LedgerListOption) (
+	[]domain.ComputilityLedgerEntry, int, error,
+) {
+	query := adapter.db()
+
+	if opt.OrgName != nil {
+		query = query.Where(equalQuery(filedOrgName), opt.OrgName.Account())
+	}
+
+	if opt.UserName != nil {
+		query = query.Where(equalQuery(filedUserName), opt.UserName.Account())
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Order(fieldCreatedAt + " desc").Order(filedId + " desc")
+
+	if b, offset := opt.Pagination(); b {
+		query = query.Limit(opt.CountPerPage).Offset(offset)
+	}
+
+	var result []computilityLedgerDO
+	if err := query.Find(&result).Error; err != nil {
+		return nil, 0, err
+	}
+
+	r := make([]domain.ComputilityLedgerEntry, len(result))
+	for i := range result {
+		r[i] = result[i].toComputilityLedgerEntry()
+	}
+
+	return r, int(total), nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package repositoryadapter
+
+import (
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/computility/domain"
+)
+
+var (
+	computilityLedgerTableName = ""
+)
+
+func (do *computilityLedgerDO) TableName() string {
+	return computilityLedgerTableName
+}
+
+type computilityLedgerDO struct {
+	Id              int64  `gorm:"primaryKey"`
+	Action          string `gorm:"column:action"`
+	OrgName         string `gorm:"column:org_name;index"`
+	UserName        string `gorm:"column:user_name;index"`
+	ComputeType     string `gorm:"column:compute_type"`
+	SpaceId         int64  `gorm:"column:space_id"`
+	QuotaChange     int    `gorm:"column:quota_change"`
+	UsedQuotaChange int    `gorm:"column:used_quota_change"`
+	Actor           string `gorm:"column:actor"`
+	Counterpart     string `gorm:"column:counterpart"`
+	Reason          string `gorm:"column:reason"`
+	CreatedAt       int64  `gorm:"column:created_at"`
+}
+
+func toComputilityLedgerDO(d *domain.ComputilityLedgerEntry) computilityLedgerDO {
+	do := computilityLedgerDO{
+		Id:              d.Id.Integer(),
+		Action:          d.Action,
+		UserName:        d.UserName.Account(),
+		ComputeType:     d.ComputeType.ComputilityType(),
+		QuotaChange:     d.QuotaChange,
+		UsedQuotaChange: d.UsedQuotaChange,
+		Reason:          d.Reason,
+		CreatedAt:       d.CreatedAt,
+	}
+
+	if d.OrgName != nil {
+		do.OrgName = d.OrgName.Account()
+	}
+
+	if d.SpaceId != nil {
+		do.SpaceId = d.SpaceId.Integer()
+	}
+
+	if d.Actor != nil {
+		do.Actor = d.Actor.Account()
+	}
+
+	if d.Counterpart != nil {
+		do.Counterpart = d.Counterpart.Account()
+	}
+
+	return do
+}
+
+func (do *computilityLedgerDO) toComputilityLedgerEntry() domain.ComputilityLedgerEntry {
+	v := domain.ComputilityLedgerEntry{
+		Id:              primitive.CreateIdentity(do.Id),
+		Action:          do.Action,
+		UserName:        primitive.CreateAccount(do.UserName),
+		ComputeType:     primitive.CreateComputilityType(do.ComputeType),
+		QuotaChange:     do.QuotaChange,
+		UsedQuotaChange: do.UsedQuotaChange,
+		Reason:          do.Reason,
+		CreatedAt:       do.CreatedAt,
+	}
+
+	if do.OrgName != "" {
+		v.OrgName = primitive.CreateAccount(do.OrgName)
+	}
+
+	if do.SpaceId != 0 {
+		v.SpaceId = primitive.CreateIdentity(do.SpaceId)
+	}
+
+	if do.Actor != "" {
+		v.Actor = primitive.CreateAccount(do.Actor)
+	}
+
+	if do.Counterpart != "" {
+		v.Counterpart = primitive.CreateAccount(do.Counterpart)
+	}
+
+	return v
+}
@@ -0,0 +1,101 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package repositoryadapter
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	"github.com/openmerlin/merlin-server/computility/domain"
+)
+
+type computilityUsageAdapter struct {
+	daoImpl
+}
+
+// Add adds a new computility usage to the database and returns an error if any occurs.
+func (adapter *computilityUsageAdapter) Add(d *domain.ComputilityUsage) error {
+	d.Id = primitive.CreateIdentity(primitive.GetId())
+
+	do := toComputilityUsageDO(d)
+
+	return adapter.db().Clauses(clause.Returning{}).Create(&do).Error
+}
+
+// Save saves the usage in the repository.
+func (adapter *computilityUsageAdapter) Save(d *domain.ComputilityUsage) error {
+	do := toComputilityUsageDO(d)
+	do.Version += 1
+
+	v := adapter.db().Model(
+		&computilityUsageDO{Id: d.Id.Integer()},
+	).Where(
+		equalQuery(filedVersion), d.Version,
+	).Select(`*`).Updates(&do)
+
+	if v.Error != nil {
+		return v.Error
+	}
+
+	if v.RowsAffected == 0 {
+		return commonrepo.NewErrorConcurrentUpdating(
+			errors.New("concurrent updating"),
+		)
+	}
+
+	return nil
+}
+
+// FindOpenBySpaceId finds the usage which the space is still consuming.
+func (adapter *computilityUsageAdapter) FindOpenBySpaceId(spaceId primitive.Identity) (
+	domain.ComputilityUsage, error,
+) {
+	var do computilityUsageDO
+
+	err := adapter.db().Where(
+		equalQuery(fieldSpaceId), spaceId.Integer(),
+	).Where(
+		equalQuery(fieldEndedAt), 0,
+	).Order(fieldStartedAt + " desc").First(&do).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ComputilityUsage{}, commonrepo.NewErrorResourceNotExists(errors.New("not found"))
+	}
+
+	if err != nil {
+		return domain.ComputilityUsage{}, err
+	}
+
+	return do.toComputilityUsage(), nil
+}
+
+// ListByOrgName lists the usages of the org which overlap with [from, to).
+func (adapter *computilityUsageAdapter) ListByOrgName(org primitive.Account, from, to int64) (
+	[]domain.ComputilityUsage, error,
+) {
+	var result []computilityUsageDO
+
+	sql := fmt.Sprintf(
+		`%s = ? and %s < ? and (%s = 0 or %s > ?)`,
+		filedOrgName, fieldStartedAt, fieldEndedAt, fieldEndedAt,
+	)
+
+	err := adapter.db().Where(sql, org.Account(), to, from).Order(fieldStartedAt).Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]domain.ComputilityUsage, len(result))
+	for i := range result {
+		r[i] = result[i].toComputilityUsage()
+	}
+
+	return r, nil
+}
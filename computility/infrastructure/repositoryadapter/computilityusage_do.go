@@ -0,0 +1,64 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package repositoryadapter
+
+import (
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/computility/domain"
+)
+
+const (
+	fieldStartedAt = "started_at"
+	fieldEndedAt   = "ended_at"
+)
+
+var (
+	computilityUsageTableName = ""
+)
+
+func (do *computilityUsageDO) TableName() string {
+	return computilityUsageTableName
+}
+
+type computilityUsageDO struct {
+	Id          int64  `gorm:"primaryKey"`
+	SpaceId     int64  `gorm:"column:space_id;index"`
+	UserName    string `gorm:"column:user_name"`
+	OrgName     string `gorm:"column:org_name;index:usage_org_index,priority:1"`
+	ComputeType string `gorm:"column:compute_type"`
+	QuotaCount  int    `gorm:"column:quota_count"`
+	StartedAt   int64  `gorm:"column:started_at;index:usage_org_index,priority:2"`
+	EndedAt     int64  `gorm:"column:ended_at"`
+
+	Version int `gorm:"column:version"`
+}
+
+func toComputilityUsageDO(d *domain.ComputilityUsage) computilityUsageDO {
+	return computilityUsageDO{
+		Id:          d.Id.Integer(),
+		SpaceId:     d.SpaceId.Integer(),
+		UserName:    d.UserName.Account(),
+		OrgName:     d.OrgName.Account(),
+		ComputeType: d.ComputeType.ComputilityType(),
+		QuotaCount:  d.QuotaCount,
+		StartedAt:   d.StartedAt,
+		EndedAt:     d.EndedAt,
+		Version:     d.Version,
+	}
+}
+
+func (do *computilityUsageDO) toComputilityUsage() domain.ComputilityUsage {
+	return domain.ComputilityUsage{
+		Id:          primitive.CreateIdentity(do.Id),
+		SpaceId:     primitive.CreateIdentity(do.SpaceId),
+		UserName:    primitive.CreateAccount(do.UserName),
+		OrgName:     primitive.CreateAccount(do.OrgName),
+		ComputeType: primitive.CreateComputilityType(do.ComputeType),
+		QuotaCount:  do.QuotaCount,
+		StartedAt:   do.StartedAt,
+		EndedAt:     do.EndedAt,
+		Version:     do.Version,
+	}
+}
@@ -11,4 +11,6 @@ type Tables struct {
 	ComputilityDetail        string `json:"computility_detail"         required:"true"`
 	ComputilityAccount       string `json:"computility_account"        required:"true"`
 	ComputilityAccountRecord string `json:"computility_account_record" required:"true"`
+	ComputilityUsage         string `json:"computility_usage"          required:"true"`
+	ComputilityLedger        string `json:"computility_ledger"         required:"true"`
 }
@@ -4,13 +4,19 @@ Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
 
 package repositoryadapter
 
-import "gorm.io/gorm"
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
 
 var (
 	computilityAdapterInstance              *computilityOrgAdapter
 	computilityDetailAdapterInstance        *computilityDetailAdapter
 	computilityAccountAdapterInstance       *computilityAccountAdapter
 	computilityAccountRecordAdapterInstance *computilityAccountRecordAdapter
+	computilityUsageAdapterInstance         *computilityUsageAdapter
+	computilityLedgerAdapterInstance        *computilityLedgerAdapter
 )
 
 // Init initializes the database and sets up the necessary adapters.
@@ -20,6 +26,8 @@ func Init(db *gorm.DB, tables *Tables) error {
 	computilityDetailTableName = tables.ComputilityDetail
 	computilityAccountTableName = tables.ComputilityAccount
 	computilityAccountRecordTableName = tables.ComputilityAccountRecord
+	computilityUsageTableName = tables.ComputilityUsage
+	computilityLedgerTableName = tables.ComputilityLedger
 
 	if err := db.AutoMigrate(&computilityOrgDO{}); err != nil {
 		return err
@@ -33,16 +41,30 @@ func Init(db *gorm.DB, tables *Tables) error {
 		return err
 	}
 
+	if err := backfillExpiredAt(db); err != nil {
+		return err
+	}
+
 	if err := db.AutoMigrate(&computilityAccountRecordDO{}); err != nil {
 		return err
 	}
 
+	if err := db.AutoMigrate(&computilityUsageDO{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&computilityLedgerDO{}); err != nil {
+		return err
+	}
+
 	dbInstance = db
 
 	computilityDao := daoImpl{table: computilityOrgTableName}
 	computilityDetailDao := daoImpl{table: computilityDetailTableName}
 	computilityAccountDao := daoImpl{table: computilityAccountTableName}
 	computilityAccountRecordDao := daoImpl{table: computilityAccountRecordTableName}
+	computilityUsageDao := daoImpl{table: computilityUsageTableName}
+	computilityLedgerDao := daoImpl{table: computilityLedgerTableName}
 
 	computilityAdapterInstance = &computilityOrgAdapter{
 		daoImpl: computilityDao,
@@ -56,6 +78,12 @@ func Init(db *gorm.DB, tables *Tables) error {
 	computilityAccountRecordAdapterInstance = &computilityAccountRecordAdapter{
 		daoImpl: computilityAccountRecordDao,
 	}
+	computilityUsageAdapterInstance = &computilityUsageAdapter{
+		daoImpl: computilityUsageDao,
+	}
+	computilityLedgerAdapterInstance = &computilityLedgerAdapter{
+		daoImpl: computilityLedgerDao,
+	}
 
 	return nil
 }
@@ -79,3 +107,25 @@ func ComputilityAccountAdapter() *computilityAccountAdapter {
 func ComputilityAccountRecordAdapter() *computilityAccountRecordAdapter {
 	return computilityAccountRecordAdapterInstance
 }
+
+// ComputilityUsageAdapter returns the instance of the computilityUsageAdapter.
+func ComputilityUsageAdapter() *computilityUsageAdapter {
+	return computilityUsageAdapterInstance
+}
+
+// ComputilityLedgerAdapter returns the instance of the computilityLedgerAdapter.
+func ComputilityLedgerAdapter() *computilityLedgerAdapter {
+	return computilityLedgerAdapterInstance
+}
+
+// backfillExpiredAt sets the lease of the records created before the lease was introduced to none,
+// so that the column can be made not null and the records will be granted a lease.
+func backfillExpiredAt(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&computilityAccountRecordDO{}, fieldExpiredAt) {
+		return nil
+	}
+
+	return db.Model(&computilityAccountRecordDO{}).Where(
+		fmt.Sprintf("%s is null", fieldExpiredAt),
+	).Update(fieldExpiredAt, 0).Error
+}
@@ -20,11 +20,15 @@ organization:
     max_invite_count: {{(ds "common").MAX_INVITE }}
 
 computility:
+  domain:
+    lease_ttl: 600
   tables:
     computility_org: computility_org
     computility_detail: computility_detail
     computility_account: computility_account
     computility_account_record: computility_account_record
+    computility_usage: computility_usage
+    computility_ledger: computility_ledger
   topics:
     computility_recalled: computility_recalled
 
@@ -56,6 +60,11 @@ space:
     space: "space"
     space_model: "space_model"
     space_env_secret: "space_env_secret"
+    space_custom_domain: "space_custom_domain"
+    space_secret_version: "space_secret_version"
+    org_env: "space_org_env"
+    space_env_reference: "space_env_reference"
+    base_image: "space_base_image"
   primitive:
     sdk:
   {{- range (ds "common").SPACE_SDK}}
@@ -81,6 +90,17 @@ space:
   {{- range (ds "common").SPACE_TASKS}}
     - {{.}}
   {{- end }}
+    hardware_catalog:
+  {{- range (ds "common").HARDWARE_CATALOG}}
+    - name: '{{.NAME}}'
+      compute_type: {{.COMPUTE_TYPE}}
+      quota_weight: {{.QUOTA_WEIGHT}}
+      capacity: {{.CAPACITY}}
+      aliases:
+    {{- range .ALIASES}}
+      - '{{.}}'
+    {{- end }}
+  {{- end }}
 
   topics:
     space_created: space_created
@@ -111,6 +131,9 @@ space:
     {{- end }}
     max_count_per_user: {{(ds "common").MAX_SPACE_PER_USER }}
     max_count_per_org: {{(ds "common").MAX_SPACE_PER_ORG }}
+    local_run:
+      git_url: {{(ds "common").SPACE_LOCAL_RUN_GIT_URL }}
+      image_registry: {{(ds "common").SPACE_LOCAL_RUN_IMAGE_REGISTRY }}
 permission:
   permissions:
     - object_type: member
@@ -393,6 +416,10 @@ git_access:
 space_app:
   tables:
     space_app: space_app
+    embed_token: space_app_embed_token
+    embed_token_usage: space_app_embed_token_usage
+    metric: space_app_metric
+    queue: space_app_queue
   topics:
     space_app_created: space_app_created
     space_code_changed: space_code_changed
@@ -405,12 +432,14 @@ space_app:
     space_app_sleep: space_app_sleep
     space_app_wakeup: space_app_wakeup
     space_force_event: space_force_event
+    space_app_preempt_warning: space_app_preempt_warning
   controller:
     sse_token: {{(ds "secret").data.SSE_TOKEN }}
     token_header: TOKEN
   domain:
     restart_over_time: 7200
     resume_over_time: 7200
+    embed_token_max_expiry: 2592000
 
 kafka:
   address: {{(ds "secret").data.KAFKA_ADDR }}
@@ -472,6 +501,15 @@ discussion:
   tables:
     issue: "discussion_issue"
     issue_comment: "discussion_issue_comment"
+    pull_request: "discussion_pull_request"
+    label: "discussion_label"
+    reaction: "discussion_reaction"
+    issue_template: "discussion_issue_template"
+    comment_revision: "discussion_comment_revision"
+    notification: "discussion_notification"
+    notification_preference: "discussion_notification_preference"
+    watch: "discussion_watch"
+    watch_event: "discussion_watch_event"
   primitive:
     max_title_length: 200
     max_content_length: 10000
@@ -481,4 +519,13 @@ discussion:
     report_title: "讨论区评论举报"
     report_email_receiver:
       - "yangwei266@h-partners.com"
-    root_url: https://modelfoundry.test.osinfra.cn/
\ No newline at end of file
+    root_url: https://modelfoundry.test.osinfra.cn/
+    digest_title: "关注动态摘要"
+    unsubscribe_url: https://modelfoundry.test.osinfra.cn/web/v1/watch/unsubscribe
+
+moderation:
+  tables:
+    report: "moderation_report"
+  email:
+    root_url: https://modelfoundry.test.osinfra.cn/
+    result_title: "举报处理结果"
\ No newline at end of file
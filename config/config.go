@@ -27,6 +27,7 @@ import (
 	"github.com/openmerlin/merlin-server/datasets"
 	"github.com/openmerlin/merlin-server/discussion"
 	"github.com/openmerlin/merlin-server/models"
+	"github.com/openmerlin/merlin-server/moderation"
 	"github.com/openmerlin/merlin-server/organization"
 	"github.com/openmerlin/merlin-server/organization/domain/permission"
 	"github.com/openmerlin/merlin-server/organization/domain/privilege"
@@ -78,6 +79,7 @@ type Config struct {
 	Internal     internal.Config      `json:"internal"`
 	Primitive    primitive.Config     `json:"primitive"`
 	Discussion   discussion.Config    `json:"discussion"`
+	Moderation   moderation.Config    `json:"moderation"`
 	Postgresql   postgresql.Config    `json:"postgresql"`
 	Permission   permission.Config    `json:"permission"`
 	RateLimiter  ratelimiter.Config   `json:"ratelimit"`
@@ -108,6 +110,8 @@ func (cfg *Config) Init() error {
 
 	cfg.SpaceApp.Init()
 
+	cfg.Computility.Init()
+
 	cfg.CodeRepo.Init()
 
 	cfg.Activity.Init()
@@ -140,10 +144,12 @@ func (cfg *Config) ConfigItems() []interface{} {
 		&cfg.Email,
 		&cfg.Session,
 		&cfg.SpaceApp,
+		&cfg.Computility,
 		&cfg.CodeRepo,
 		&cfg.Internal,
 		&cfg.Primitive,
 		&cfg.Discussion,
+		&cfg.Moderation,
 		&cfg.Postgresql,
 		&cfg.Vault,
 		&cfg.OtherConfig,
@@ -22,6 +22,9 @@ import (
 	"github.com/openmerlin/merlin-server/datasets/domain/email"
 	"github.com/openmerlin/merlin-server/datasets/domain/message"
 	"github.com/openmerlin/merlin-server/datasets/domain/repository"
+	moderationapp "github.com/openmerlin/merlin-server/moderation/app"
+	moderationdomain "github.com/openmerlin/merlin-server/moderation/domain"
+	moderationprimitive "github.com/openmerlin/merlin-server/moderation/domain/primitive"
 	orgapp "github.com/openmerlin/merlin-server/organization/app"
 	orgrepo "github.com/openmerlin/merlin-server/organization/domain/repository"
 	userapp "github.com/openmerlin/merlin-server/user/app"
@@ -51,6 +54,7 @@ func NewDatasetAppService(
 	disableOrg orgapp.PrivilegeOrg,
 	user userapp.UserService,
 	email email.Email,
+	report moderationapp.ReportAppService,
 ) DatasetAppService {
 	return &datasetAppService{
 		permission:  permission,
@@ -61,6 +65,7 @@ func NewDatasetAppService(
 		disableOrg:  disableOrg,
 		user:        user,
 		email:       email,
+		report:      report,
 	}
 }
 
@@ -73,6 +78,7 @@ type datasetAppService struct {
 	disableOrg  orgapp.PrivilegeOrg
 	user        userapp.UserService
 	email       email.Email
+	report      moderationapp.ReportAppService
 }
 
 // Create creates a new dataset.
@@ -454,6 +460,20 @@ func (s *datasetAppService) SendReportMail(user primitive.Account, cmd *CmdToRep
 		err := allerror.NewNoPermission(e.Error(), e)
 		return err
 	}
+	err = s.report.Report(&moderationapp.CmdToReport{
+		Reporter: user,
+		Target: moderationdomain.ReportTarget{
+			Type:         moderationprimitive.ReportTargetDataset,
+			Id:           data.Id.Integer(),
+			ResourceId:   data.Id,
+			ResourceType: primitive.ObjTypeDataset,
+		},
+		Reason: cmd.Msg,
+	})
+	if err != nil {
+		return err
+	}
+
 	safeMsg := utils.XSSEscapeString(cmd.Msg)
 	url := fmt.Sprintf("%s/datasets/%s/%s", s.email.GetRootUrl(), data.Owner.Account(), data.Name)
 	if err := s.email.Send(cmd.DataSetName.MSDName(), safeMsg, user.Account(), url); err != nil {
@@ -13,7 +13,12 @@ import (
 	"github.com/openmerlin/merlin-server/discussion/domain"
 	"github.com/openmerlin/merlin-server/discussion/domain/email"
 	"github.com/openmerlin/merlin-server/discussion/domain/message"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
 	"github.com/openmerlin/merlin-server/discussion/domain/repository"
+	moderationapp "github.com/openmerlin/merlin-server/moderation/app"
+	moderationdomain "github.com/openmerlin/merlin-server/moderation/domain"
+	moderationprimitive "github.com/openmerlin/merlin-server/moderation/domain/primitive"
+	orgapp "github.com/openmerlin/merlin-server/organization/app"
 )
 
 type CommentService interface {
@@ -21,6 +26,8 @@ type CommentService interface {
 	UpdateIssueComment(context.Context, CmdToUpdateIssueComment) error
 	DeleteIssueComment(context.Context, CmdToDeleteIssueComment) error
 	ReportComment(context.Context, CmdToReportComment) error
+	ToggleReaction(context.Context, CmdToToggleReaction) ([]ReactionDTO, error)
+	ListCommentRevisions(context.Context, CmdToListCommentRevisions) ([]CommentRevisionDTO, error)
 }
 
 func NewCommentService(
@@ -30,6 +37,11 @@ func NewCommentService(
 	c repository.IssueComment,
 	m message.CommentMessage,
 	e email.Email,
+	r repository.Reaction,
+	n Notifier,
+	cr repository.CommentRevision,
+	report moderationapp.ReportAppService,
+	moderator orgapp.PrivilegeOrg,
 ) *commentService {
 	rp := resourcePermission{
 		resource:   re,
@@ -41,6 +53,11 @@ func NewCommentService(
 		commentRepo:        c,
 		message:            m,
 		email:              e,
+		reactionRepo:       r,
+		notifier:           n,
+		revisionRepo:       cr,
+		report:             report,
+		moderator:          moderator,
 		resourcePermission: rp,
 	}
 }
@@ -50,11 +67,17 @@ type commentService struct {
 	message            message.CommentMessage
 	issueRepo          repository.Issue
 	commentRepo        repository.IssueComment
+	reactionRepo       repository.Reaction
+	notifier           Notifier
+	revisionRepo       repository.CommentRevision
+	report             moderationapp.ReportAppService
+	moderator          orgapp.PrivilegeOrg
 	resourcePermission resourcePermission
 }
 
 func (i *commentService) CreateIssueComment(ctx context.Context, cmd CmdToCreateIssueComment) (ItemDTO, error) {
-	if _, err := i.resourcePermission.CanRead(ctx, cmd.Resource.Id, cmd.Owner); err != nil {
+	r, err := i.resourcePermission.CanRead(ctx, cmd.Resource.Id, cmd.Owner)
+	if err != nil {
 		return ItemDTO{}, err
 	}
 
@@ -67,11 +90,32 @@ func (i *commentService) CreateIssueComment(ctx context.Context, cmd CmdToCreate
 		)
 	}
 
-	if err = issue.AllowComment(); err != nil {
+	// only the maintainers can comment on the locked issue
+	isMaintainer := false
+	if issue.Locked {
+		isMaintainer = i.resourcePermission.permission.CanUpdate(ctx, cmd.Owner, r) == nil
+	}
+
+	if err = issue.AllowComment(isMaintainer); err != nil {
 		return ItemDTO{}, err
 	}
 
 	comment := domain.NewIssueComment(cmd.Owner, cmd.IssueId, cmd.Content)
+	if cmd.ReplyTo > 0 {
+		parent, err := i.commentRepo.Find(ctx, cmd.ReplyTo)
+		if err != nil {
+			return ItemDTO{}, allerror.NewNotFound(
+				allerror.ErrorCodeCommentNotFound,
+				"not found",
+				xerrors.Errorf("failed to find comment by id, %w", err),
+			)
+		}
+
+		if err = comment.ReplyOf(&parent); err != nil {
+			return ItemDTO{}, err
+		}
+	}
+
 	savedComment, err := i.commentRepo.Save(comment)
 	if err != nil {
 		return ItemDTO{}, allerror.New(allerror.ErrorCodeFailToCreateComment, "failed to create comment", err)
@@ -82,6 +126,13 @@ func (i *commentService) CreateIssueComment(ctx context.Context, cmd CmdToCreate
 		logrus.Errorf("send update comment count +1 of issue %d failed: %s", cmd.IssueId, err.Error())
 	}
 
+	i.notifier.Notify(ctx, r, &domain.NotificationEvent{
+		Type:      discussionprimitive.NotificationTypeNewComment,
+		Actor:     cmd.Owner,
+		Issue:     &issue,
+		CommentId: savedComment.Id,
+	}, cmd.Content.CommentContent())
+
 	return commentToItemDTO(savedComment), nil
 }
 
@@ -100,10 +151,16 @@ func (i *commentService) UpdateIssueComment(ctx context.Context, cmd CmdToUpdate
 		)
 	}
 
-	if err = comment.UpdateContent(cmd.User, cmd.Content); err != nil {
+	revisions, err := comment.UpdateContent(cmd.User, cmd.Content)
+	if err != nil || len(revisions) == 0 {
 		return err
 	}
 
+	// the revisions are kept before the content is changed, so that no edit is lost
+	if err = i.revisionRepo.Add(revisions); err != nil {
+		return allerror.New(allerror.ErrorCodeFailToUpdateComment, "failed to update comment", err)
+	}
+
 	if _, err = i.commentRepo.Save(comment); err != nil {
 		return allerror.New(allerror.ErrorCodeFailToUpdateComment, "failed to update comment", err)
 	}
@@ -141,6 +198,8 @@ func (i *commentService) DeleteIssueComment(ctx context.Context, cmd CmdToDelete
 		return allerror.New(allerror.ErrorCodeFailToDeleteComment, "failed to delete comment", err)
 	}
 
+	i.unmarkDeletedAnswer(ctx, cmd.User, &comment)
+
 	event := domain.NewUpdateCommentCountEvent(comment.IssueId, -1)
 	if err = i.message.SendUpdateCommentCountEvent(event); err != nil {
 		logrus.Errorf("send update comment count -1 of issue %d failed: %s", comment.IssueId, err.Error())
@@ -149,6 +208,21 @@ func (i *commentService) DeleteIssueComment(ctx context.Context, cmd CmdToDelete
 	return nil
 }
 
+func (i *commentService) unmarkDeletedAnswer(
+	ctx context.Context, user primitive.Account, comment *domain.IssueComment,
+) {
+	issue, err := i.issueRepo.Find(ctx, comment.IssueId)
+	if err != nil || !issue.IsAnswer(comment.Id) {
+		return
+	}
+
+	issue.UnmarkAnswer(user)
+
+	if _, err = i.issueRepo.Save(issue); err != nil {
+		logrus.Errorf("unmark the deleted answer of issue %d failed: %s", issue.Id, err.Error())
+	}
+}
+
 func (i *commentService) ReportComment(ctx context.Context, cmd CmdToReportComment) error {
 	r, err := i.resourcePermission.CanRead(ctx, cmd.Resource.Id, cmd.User)
 	if err != nil {
@@ -173,6 +247,20 @@ func (i *commentService) ReportComment(ctx context.Context, cmd CmdToReportComme
 		)
 	}
 
+	err = i.report.Report(&moderationapp.CmdToReport{
+		Reporter: cmd.User,
+		Target: moderationdomain.ReportTarget{
+			Type:         moderationprimitive.ReportTargetComment,
+			Id:           comment.Id,
+			ResourceId:   cmd.Resource.Id,
+			ResourceType: r.ResourceType(),
+		},
+		Reason: cmd.Type + ": " + cmd.Content.CommentContent(),
+	})
+	if err != nil {
+		return err
+	}
+
 	param := email.ReportEmailParam{
 		User:          cmd.User,
 		Index:         r.RepoIndex(),
@@ -186,7 +274,126 @@ func (i *commentService) ReportComment(ctx context.Context, cmd CmdToReportComme
 	return i.email.SendReportEmail(param)
 }
 
+// ListCommentRevisions lists the revisions of comment, only the maintainers of resource and
+// the moderators can see them.
+func (i *commentService) ListCommentRevisions(ctx context.Context, cmd CmdToListCommentRevisions,
+) ([]CommentRevisionDTO, error) {
+	r, err := i.resourcePermission.CanRead(ctx, cmd.Resource.Id, cmd.User)
+	if err != nil {
+		return nil, err
+	}
+
+	if !i.isModerator(ctx, cmd.User) {
+		if err = i.resourcePermission.permission.CanUpdate(ctx, cmd.User, r); err != nil {
+			return nil, err
+		}
+	}
+
+	comment, err := i.commentRepo.Find(ctx, cmd.CommentId)
+	if err == nil {
+		var issue domain.Issue
+		if issue, err = i.issueRepo.Find(ctx, comment.IssueId); err == nil &&
+			issue.Resource.Id.Integer() != cmd.Resource.Id.Integer() {
+			err = xerrors.Errorf("comment %d is not of %s", cmd.CommentId, cmd.Resource.Id.Identity())
+		}
+	}
+
+	if err != nil {
+		return nil, allerror.NewNotFound(
+			allerror.ErrorCodeCommentNotFound,
+			"not found",
+			xerrors.Errorf("failed to find comment by id, %w", err),
+		)
+	}
+
+	revisions, err := i.revisionRepo.List(comment.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]CommentRevisionDTO, len(revisions))
+	for j := range revisions {
+		dtos[j] = toCommentRevisionDTO(&revisions[j])
+	}
+
+	return dtos, nil
+}
+
+// isModerator checks if the user is the admin of site who moderates the reports.
+func (i *commentService) isModerator(ctx context.Context, user primitive.Account) bool {
+	return i.moderator != nil && i.moderator.Contains(ctx, user) == nil
+}
+
 func (i *commentService) isSecurity(user primitive.Account) bool {
 	//todo check security user
 	return false
 }
+
+// ToggleReaction adds the reaction of user if the user has not reacted with the emoji, otherwise removes it.
+func (i *commentService) ToggleReaction(ctx context.Context, cmd CmdToToggleReaction) ([]ReactionDTO, error) {
+	if _, err := i.resourcePermission.CanRead(ctx, cmd.Resource.Id, cmd.User); err != nil {
+		return nil, err
+	}
+
+	if err := i.checkReactionTarget(ctx, cmd.Resource, cmd.Target); err != nil {
+		return nil, err
+	}
+
+	reaction := domain.Reaction{
+		Target: cmd.Target,
+		Emoji:  cmd.Emoji,
+		User:   cmd.User,
+	}
+
+	added, err := i.reactionRepo.Add(&reaction)
+	if err != nil {
+		return nil, err
+	}
+
+	if !added {
+		if err = i.reactionRepo.Remove(&reaction); err != nil {
+			return nil, err
+		}
+	}
+
+	counts, err := i.reactionRepo.Count(cmd.Target.Type, []int64{cmd.Target.Id}, cmd.User)
+	if err != nil {
+		return nil, err
+	}
+
+	return toReactionsDTO(counts)[cmd.Target.Id], nil
+}
+
+func (i *commentService) checkReactionTarget(
+	ctx context.Context, resource domain.Resource, target domain.ReactionTarget,
+) error {
+	issueId := target.Id
+
+	if target.Type == domain.ReactionTargetComment {
+		comment, err := i.commentRepo.Find(ctx, target.Id)
+		if err != nil {
+			return allerror.NewNotFound(
+				allerror.ErrorCodeCommentNotFound,
+				"not found",
+				xerrors.Errorf("failed to find comment by id, %w", err),
+			)
+		}
+
+		issueId = comment.IssueId
+	}
+
+	issue, err := i.issueRepo.Find(ctx, issueId)
+	if err == nil && issue.Resource.Id.Integer() != resource.Id.Integer() {
+		err = xerrors.Errorf("issue %d is not of %s", issueId, resource.Id.Identity())
+	}
+
+	if err != nil {
+		return allerror.NewNotFound(
+			allerror.ErrorCodeIssueNotFound,
+			"not found",
+			xerrors.Errorf("failed to find issue by id, %w", err),
+		)
+	}
+
+	return nil
+}
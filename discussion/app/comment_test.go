@@ -0,0 +1,141 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	coderepodomain "github.com/openmerlin/merlin-server/coderepo/domain"
+	coderepoprimitive "github.com/openmerlin/merlin-server/coderepo/domain/primitive"
+	"github.com/openmerlin/merlin-server/coderepo/domain/resourceadapter"
+	commonapp "github.com/openmerlin/merlin-server/common/app"
+	commondomain "github.com/openmerlin/merlin-server/common/domain"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain/repository"
+	orgapp "github.com/openmerlin/merlin-server/organization/app"
+)
+
+var errStub = errors.New("stub")
+
+type stubResource struct {
+	coderepodomain.Resource
+}
+
+func (r stubResource) DiscussionDisabled() bool {
+	return false
+}
+
+func (r stubResource) ResourceType() primitive.ObjType {
+	return primitive.ObjTypeModel
+}
+
+func (r stubResource) RepoIndex() commondomain.CodeRepoIndex {
+	return commondomain.CodeRepoIndex{Id: primitive.CreateIdentity(1)}
+}
+
+type stubResourceAdapter struct {
+	resourceadapter.ResourceAdapter
+}
+
+func (a stubResourceAdapter) GetByIndex(coderepoprimitive.Identity) (coderepodomain.Resource, error) {
+	return stubResource{}, nil
+}
+
+// stubPermission allows everyone to read and only the maintainer to update.
+type stubPermission struct {
+	commonapp.ResourcePermissionAppService
+	maintainer string
+}
+
+func (p stubPermission) CanRead(context.Context, primitive.Account, coderepodomain.Resource) error {
+	return nil
+}
+
+func (p stubPermission) CanUpdate(_ context.Context, user primitive.Account, _ coderepodomain.Resource) error {
+	if user.Account() != p.maintainer {
+		return errStub
+	}
+
+	return nil
+}
+
+type stubModerator struct {
+	orgapp.PrivilegeOrg
+	admin string
+}
+
+func (m stubModerator) Contains(_ context.Context, user primitive.Account) error {
+	if user.Account() != m.admin {
+		return errStub
+	}
+
+	return nil
+}
+
+type stubIssueRepo struct {
+	repository.Issue
+}
+
+func (r stubIssueRepo) Find(_ context.Context, id int64) (domain.Issue, error) {
+	return domain.Issue{Id: id, Resource: domain.Resource{Id: primitive.CreateIdentity(1)}}, nil
+}
+
+type stubCommentRepo struct {
+	repository.IssueComment
+}
+
+func (r stubCommentRepo) Find(_ context.Context, id int64) (domain.IssueComment, error) {
+	return domain.IssueComment{Id: id, IssueId: 1}, nil
+}
+
+type stubRevisionRepo struct {
+	repository.CommentRevision
+}
+
+func (r stubRevisionRepo) List(commentId int64) ([]domain.CommentRevision, error) {
+	return []domain.CommentRevision{{
+		Id:        1,
+		CommentId: commentId,
+		Content:   discussionprimitive.CreateCommentContent("first"),
+		Editor:    primitive.CreateAccount("author"),
+	}}, nil
+}
+
+// TestListCommentRevisions tests that the revisions are listed for the maintainers and the moderators only.
+func TestListCommentRevisions(t *testing.T) {
+	s := NewCommentService(
+		stubResourceAdapter{},
+		stubPermission{maintainer: "maintainer"},
+		stubIssueRepo{},
+		stubCommentRepo{},
+		nil, nil, nil, nil,
+		stubRevisionRepo{},
+		nil,
+		stubModerator{admin: "moderator"},
+	)
+
+	list := func(user string) ([]CommentRevisionDTO, error) {
+		return s.ListCommentRevisions(context.Background(), CmdToListCommentRevisions{
+			User:      primitive.CreateAccount(user),
+			Resource:  domain.Resource{Id: primitive.CreateIdentity(1)},
+			CommentId: 2,
+		})
+	}
+
+	for _, user := range []string{"maintainer", "moderator"} {
+		v, err := list(user)
+		if err != nil {
+			t.Fatalf("%s should see the revisions, %v", user, err)
+		}
+
+		if len(v) != 1 || v[0].Content != "first" {
+			t.Fatalf("unexpected revisions for %s: %v", user, v)
+		}
+	}
+
+	if _, err := list("other"); err == nil {
+		t.Fatal("the user who is neither maintainer nor moderator should not see the revisions")
+	}
+}
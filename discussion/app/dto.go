@@ -2,8 +2,10 @@ package app
 
 import (
 	"math"
+	"sort"
 	"time"
 
+	coderepoprimitive "github.com/openmerlin/merlin-server/coderepo/domain/primitive"
 	"github.com/openmerlin/merlin-server/common/domain/primitive"
 	"github.com/openmerlin/merlin-server/discussion/domain"
 	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
@@ -12,13 +14,21 @@ import (
 
 const (
 	TimeFormat = "2006-01-02T15:04:05Z"
+
+	itemTypeOperation = "operation"
+	itemTypeComment   = "comment"
 )
 
 type CmdToCreateIssue struct {
 	Resource domain.Resource
 	Owner    primitive.Account
 	Title    discussionprimitive.IssueTitle
-	Content  discussionprimitive.CommentContent
+	// Content is composed by the fields if the issue is created by the template
+	Content discussionprimitive.CommentContent
+
+	// Template is the name of template, it is empty if no template is used
+	Template string
+	Fields   map[string]string
 }
 
 type CmdToCloseIssue struct {
@@ -43,41 +53,123 @@ type CmdToListIssues struct {
 	Option   repository.IssueListOption
 }
 
+type CmdToListUserIssues struct {
+	User     primitive.Account
+	Relation discussionprimitive.IssueRelation
+	Option   repository.IssueListOption
+}
+
 type ListIssuesCountDTO struct {
 	All    int64 `json:"all"`
 	Open   int64 `json:"open"`
 	Closed int64 `json:"closed"`
+	Merged int64 `json:"merged"`
 }
 
 type ListIssuesDTO struct {
 	List []IssueDTO `json:"list"`
+
+	// Total is set only when the issues of different resources are listed together
+	Total int64 `json:"total,omitempty"`
 }
 
 type IssueDTO struct {
 	Id           int64  `json:"id"`
 	Title        string `json:"title"`
+	Type         string `json:"type"`
 	Owner        string `json:"owner"`
 	Status       string `json:"status"`
 	CommentCount int64  `json:"comment_count"`
 	CreatedAt    string `json:"created_at"`
+
+	Labels    []LabelDTO `json:"labels"`
+	Assignees []string   `json:"assignees"`
+
+	Locked bool  `json:"locked"`
+	Pinned bool  `json:"pinned"`
+	Answer int64 `json:"answer"`
+
+	// Resource is set only when the issues of different resources are listed together
+	Resource *IssueResourceDTO `json:"resource,omitempty"`
+}
+
+type IssueResourceDTO struct {
+	Id   string `json:"id"`
+	Type string `json:"type"`
+	Path string `json:"path"`
 }
 
 func ToIssueDTO(issue domain.Issue) IssueDTO {
+	assignees := make([]string, len(issue.Assignees))
+	for i := range issue.Assignees {
+		assignees[i] = issue.Assignees[i].Account()
+	}
+
 	return IssueDTO{
 		Id:           issue.Id,
 		Title:        issue.Title.Title(),
+		Type:         issue.Type.IssueType(),
 		Owner:        issue.Author.Account(),
 		Status:       issue.Status.IssueStatus(),
 		CommentCount: issue.CommentCount,
 		CreatedAt:    issue.CreatedAt.In(time.UTC).Format(TimeFormat),
+		Labels:       ToLabelIdsDTO(issue.Labels),
+		Assignees:    assignees,
+		Locked:       issue.Locked,
+		Pinned:       issue.Pinned,
+		Answer:       issue.Answer,
+	}
+}
+
+type LabelDTO struct {
+	Id    int64  `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+func toLabelDTO(l *domain.Label) LabelDTO {
+	return LabelDTO{
+		Id:    l.Id,
+		Name:  l.Name.LabelName(),
+		Color: l.Color.LabelColor(),
+	}
+}
+
+// ToLabelIdsDTO returns the labels with id only, the name and color are filled by fillLabels.
+func ToLabelIdsDTO(ids []int64) []LabelDTO {
+	v := make([]LabelDTO, len(ids))
+	for i := range ids {
+		v[i].Id = ids[i]
+	}
+
+	return v
+}
+
+// fillLabels fills the name and color of labels, the labels which have been deleted are dropped.
+func fillLabels(labels []domain.Label, issues []IssueDTO) {
+	m := make(map[int64]*domain.Label, len(labels))
+	for i := range labels {
+		m[labels[i].Id] = &labels[i]
+	}
+
+	for i := range issues {
+		v := make([]LabelDTO, 0, len(issues[i].Labels))
+		for _, l := range issues[i].Labels {
+			if item, ok := m[l.Id]; ok {
+				v = append(v, toLabelDTO(item))
+			}
+		}
+
+		issues[i].Labels = v
 	}
 }
 
 type IssueDetailDTO struct {
-	IsSecurity bool     `json:"is_security"`
-	IsOwner    bool     `json:"is_owner"`
-	Issue      IssueDTO `json:"issue"`
-	Items      ItemsDTO `json:"items"`
+	IsSecurity bool          `json:"is_security"`
+	IsOwner    bool          `json:"is_owner"`
+	Issue      IssueDTO      `json:"issue"`
+	Items      ItemsDTO      `json:"items"`
+	Reactions  []ReactionDTO `json:"reactions"`
 }
 
 type ItemsDTO []ItemDTO
@@ -99,18 +191,92 @@ type ItemDTO struct {
 	Type      string `json:"type"`
 	Owner     string `json:"owner"`
 	Content   string `json:"content"`
+	Detail    string `json:"detail"`
 	CreatedAt string `json:"created_at"`
 	createdAt time.Time
+
+	ReplyTo   int64         `json:"reply_to"`
+	Replies   ItemsDTO      `json:"replies"`
+	Reactions []ReactionDTO `json:"reactions"`
+
+	Edited   bool   `json:"edited"`
+	EditedAt string `json:"edited_at,omitempty"`
+	// Content of the hidden comment is not returned
+	Hidden bool `json:"hidden"`
+}
+
+type ReactionDTO struct {
+	Emoji   int    `json:"emoji"`
+	Name    string `json:"name"`
+	Count   int64  `json:"count"`
+	Reacted bool   `json:"reacted"`
+}
+
+func toReactionsDTO(counts []domain.ReactionCount) map[int64][]ReactionDTO {
+	m := make(map[int64][]ReactionDTO)
+	for i := range counts {
+		c := &counts[i]
+		m[c.TargetId] = append(m[c.TargetId], ReactionDTO{
+			Emoji:   c.Emoji.EmojiType(),
+			Name:    c.Emoji.EmojiName(),
+			Count:   c.Count,
+			Reacted: c.Reacted,
+		})
+	}
+
+	return m
+}
+
+// commentIds returns the ids of comments and their replies.
+func (d ItemsDTO) commentIds() []int64 {
+	var ids []int64
+	for i := range d {
+		if d[i].Type == itemTypeComment {
+			ids = append(ids, d[i].Id)
+			ids = append(ids, d[i].Replies.commentIds()...)
+		}
+	}
+
+	return ids
 }
 
+func (d ItemsDTO) setReactions(m map[int64][]ReactionDTO) {
+	for i := range d {
+		if d[i].Type == itemTypeComment {
+			d[i].Reactions = m[d[i].Id]
+			d[i].Replies.setReactions(m)
+		}
+	}
+}
+
+// mergeOperationAndComments merges the operations and the comments, the replies are nested
+// in the comments they reply, and the replies of the deleted comments are kept at the top level.
 func mergeOperationAndComments(operations []domain.Operation, comments []domain.IssueComment) ItemsDTO {
 	var data ItemsDTO
 	for _, v := range operations {
 		data = append(data, operationToItemDTO(v))
 	}
 
+	exists := make(map[int64]bool, len(comments))
+	for i := range comments {
+		exists[comments[i].Id] = true
+	}
+
+	replies := make(map[int64]ItemsDTO)
+
 	for _, v := range comments {
-		data = append(data, commentToItemDTO(v))
+		if v.IsReply() && exists[v.ReplyTo] {
+			replies[v.ReplyTo] = append(replies[v.ReplyTo], commentToItemDTO(v))
+		} else {
+			data = append(data, commentToItemDTO(v))
+		}
+	}
+
+	for i := range data {
+		if r, ok := replies[data[i].Id]; ok && data[i].Type == itemTypeComment {
+			sort.Sort(r)
+			data[i].Replies = r
+		}
 	}
 
 	return data
@@ -118,23 +284,59 @@ func mergeOperationAndComments(operations []domain.Operation, comments []domain.
 
 func operationToItemDTO(o domain.Operation) ItemDTO {
 	return ItemDTO{
-		Type:      "operation",
+		Type:      itemTypeOperation,
 		Owner:     o.User,
 		Content:   o.Action,
+		Detail:    o.Detail,
 		createdAt: o.CreatedAt,
 		CreatedAt: o.CreatedAt.In(time.UTC).Format(TimeFormat),
 	}
 }
 
 func commentToItemDTO(c domain.IssueComment) ItemDTO {
-	return ItemDTO{
+	dto := ItemDTO{
 		Id:        c.Id,
-		Type:      "comment",
+		Type:      itemTypeComment,
 		Owner:     c.Author.Account(),
 		Content:   c.Content.CommentContent(),
+		ReplyTo:   c.ReplyTo,
 		createdAt: c.CreatedAt,
 		CreatedAt: c.CreatedAt.In(time.UTC).Format(TimeFormat),
 	}
+
+	if c.Hidden {
+		dto.Hidden = true
+		dto.Content = ""
+	}
+
+	if c.IsEdited() {
+		dto.Edited = true
+		dto.EditedAt = c.EditedAt.In(time.UTC).Format(TimeFormat)
+	}
+
+	return dto
+}
+
+type CmdToListCommentRevisions struct {
+	User      primitive.Account
+	Resource  domain.Resource
+	CommentId int64
+}
+
+type CommentRevisionDTO struct {
+	Id        int64  `json:"id"`
+	Content   string `json:"content"`
+	Editor    string `json:"editor"`
+	CreatedAt string `json:"created_at"`
+}
+
+func toCommentRevisionDTO(r *domain.CommentRevision) CommentRevisionDTO {
+	return CommentRevisionDTO{
+		Id:        r.Id,
+		Content:   r.Content.CommentContent(),
+		Editor:    r.Editor.Account(),
+		CreatedAt: r.CreatedAt.In(time.UTC).Format(TimeFormat),
+	}
 }
 
 func (d ItemsDTO) paginate(pageNum, countPerPage int) ItemsDTO {
@@ -170,8 +372,126 @@ func (d ItemsDTO) slicePage(page, pageSize, nums int) (sliceStart int, sliceEnd
 	return sliceStart, sliceEnd
 }
 
+type CmdToCreatePullRequest struct {
+	CmdToCreateIssue
+
+	SourceBranch coderepoprimitive.BranchName
+	TargetBranch coderepoprimitive.BranchName
+}
+
+type CmdToReviewPullRequest struct {
+	CmdToCloseIssue
+
+	Action discussionprimitive.ReviewAction
+}
+
+type CmdToMergePullRequest = CmdToCloseIssue
+
+type PullRequestDetailDTO struct {
+	IssueDetailDTO
+
+	CanMerge    bool           `json:"can_merge"`
+	PullRequest PullRequestDTO `json:"pull_request"`
+}
+
+type PullRequestDTO struct {
+	Number       int64          `json:"number"`
+	SourceBranch string         `json:"source_branch"`
+	TargetBranch string         `json:"target_branch"`
+	Diff         DiffSummaryDTO `json:"diff"`
+}
+
+type DiffSummaryDTO struct {
+	Files     []DiffFileDTO `json:"files"`
+	Additions int           `json:"additions"`
+	Deletions int           `json:"deletions"`
+}
+
+type DiffFileDTO struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+func toPullRequestDTO(pr *domain.PullRequest, diff *domain.DiffSummary) PullRequestDTO {
+	files := make([]DiffFileDTO, 0, len(diff.Files))
+	for _, f := range diff.Files {
+		files = append(files, DiffFileDTO(f))
+	}
+
+	return PullRequestDTO{
+		Number:       pr.Number,
+		SourceBranch: pr.SourceBranch.BranchName(),
+		TargetBranch: pr.TargetBranch.BranchName(),
+		Diff: DiffSummaryDTO{
+			Files:     files,
+			Additions: diff.Additions,
+			Deletions: diff.Deletions,
+		},
+	}
+}
+
+type CmdToCreateLabel struct {
+	User     primitive.Account
+	Resource domain.Resource
+	Name     discussionprimitive.LabelName
+	Color    discussionprimitive.LabelColor
+}
+
+type CmdToUpdateLabel struct {
+	CmdToCreateLabel
+
+	LabelId int64
+}
+
+type CmdToDeleteLabel struct {
+	User     primitive.Account
+	Resource domain.Resource
+	LabelId  int64
+}
+
+type CmdToSetIssueLabels struct {
+	CmdToCloseIssue
+
+	Labels []int64
+}
+
+type CmdToSetIssueAssignees struct {
+	CmdToCloseIssue
+
+	Assignees []primitive.Account
+}
+
+type CmdToLockIssue struct {
+	CmdToCloseIssue
+
+	Locked bool
+}
+
+type CmdToPinIssue struct {
+	CmdToCloseIssue
+
+	Pinned bool
+}
+
+type CmdToMarkIssueAnswer struct {
+	CmdToCloseIssue
+
+	// CommentId is 0 if the answer is unmarked
+	CommentId int64
+}
+
+type CmdToToggleReaction struct {
+	User     primitive.Account
+	Resource domain.Resource
+	Target   domain.ReactionTarget
+	Emoji    discussionprimitive.EmojiType
+}
+
 type CmdToCreateIssueComment struct {
 	IssueId  int64
+	ReplyTo  int64
 	Resource domain.Resource
 	Owner    primitive.Account
 	Content  discussionprimitive.CommentContent
@@ -197,3 +517,154 @@ type CmdToReportComment struct {
 	Content   discussionprimitive.CommentContent
 	CommentId int64
 }
+
+type CmdToListNotifications struct {
+	User   primitive.Account
+	Option repository.NotificationListOption
+}
+
+type NotificationDTO struct {
+	Id           int64  `json:"id"`
+	Type         string `json:"type"`
+	Actor        string `json:"actor"`
+	ResourceId   string `json:"resource_id"`
+	ResourceType string `json:"resource_type"`
+	ResourcePath string `json:"resource_path"`
+	IssueId      int64  `json:"issue_id"`
+	CommentId    int64  `json:"comment_id"`
+	Title        string `json:"title"`
+	IsRead       bool   `json:"is_read"`
+	CreatedAt    string `json:"created_at"`
+}
+
+func toNotificationDTO(n *domain.Notification) NotificationDTO {
+	return NotificationDTO{
+		Id:           n.Id,
+		Type:         n.Type.NotificationType(),
+		Actor:        n.Actor.Account(),
+		ResourceId:   n.Resource.Id.Identity(),
+		ResourceType: string(n.Resource.Type),
+		ResourcePath: n.ResourcePath,
+		IssueId:      n.IssueId,
+		CommentId:    n.CommentId,
+		Title:        n.Title,
+		IsRead:       n.IsRead,
+		CreatedAt:    n.CreatedAt.In(time.UTC).Format(TimeFormat),
+	}
+}
+
+type ListNotificationsDTO struct {
+	Total  int64             `json:"total"`
+	Unread int64             `json:"unread"`
+	List   []NotificationDTO `json:"list"`
+}
+
+type CmdToMarkNotificationsRead struct {
+	User primitive.Account
+	// Ids is empty if all the notifications are marked as read
+	Ids []int64
+}
+
+type NotificationPreferenceDTO struct {
+	Type  string `json:"type"`
+	InApp bool   `json:"in_app"`
+	Email bool   `json:"email"`
+}
+
+func toNotificationPreferenceDTO(p *domain.NotificationPreference) NotificationPreferenceDTO {
+	return NotificationPreferenceDTO{
+		Type:  p.Type.NotificationType(),
+		InApp: p.InApp,
+		Email: p.Email,
+	}
+}
+
+type CmdToUpdateNotificationPreference = domain.NotificationPreference
+
+type CmdToWatch struct {
+	User      primitive.Account
+	Resource  primitive.Identity
+	Level     discussionprimitive.WatchLevel
+	Frequency discussionprimitive.DigestFrequency
+}
+
+// DigestDTO is the result of sending the due digests.
+type DigestDTO struct {
+	Users int `json:"users"`
+}
+
+type WatchDTO struct {
+	ResourceId   string `json:"resource_id"`
+	ResourceType string `json:"resource_type"`
+	Level        string `json:"level"`
+	Frequency    string `json:"frequency"`
+}
+
+func toWatchDTO(w *domain.Watch) WatchDTO {
+	return WatchDTO{
+		ResourceId:   w.Resource.Id.Identity(),
+		ResourceType: string(w.Resource.Type),
+		Level:        w.Level.WatchLevel(),
+		Frequency:    w.Frequency.DigestFrequency(),
+	}
+}
+
+type CmdToAddWatchEvent struct {
+	Resource primitive.Identity
+	Type     discussionprimitive.WatchEventType
+	// Actor is nil if it is unknown
+	Actor primitive.Account
+	Title string
+}
+
+type CmdToCreateIssueTemplate struct {
+	User        primitive.Account
+	Resource    domain.Resource
+	Name        string
+	Description string
+	Title       string
+	Fields      []domain.IssueTemplateField
+}
+
+func (cmd *CmdToCreateIssueTemplate) toIssueTemplate() domain.IssueTemplate {
+	return domain.IssueTemplate{
+		Resource:    cmd.Resource,
+		Name:        cmd.Name,
+		Description: cmd.Description,
+		Title:       cmd.Title,
+		Fields:      cmd.Fields,
+	}
+}
+
+type CmdToUpdateIssueTemplate struct {
+	CmdToCreateIssueTemplate
+
+	TemplateId int64
+}
+
+type CmdToDeleteIssueTemplate struct {
+	User       primitive.Account
+	Resource   domain.Resource
+	TemplateId int64
+}
+
+type IssueTemplateDTO struct {
+	// Id is 0 if the template is defined in the code repo
+	Id          int64                       `json:"id"`
+	Name        string                      `json:"name"`
+	Description string                      `json:"description"`
+	Title       string                      `json:"title"`
+	InRepo      bool                        `json:"in_repo"`
+	Fields      []domain.IssueTemplateField `json:"fields"`
+}
+
+func toIssueTemplateDTO(t *domain.IssueTemplate) IssueTemplateDTO {
+	return IssueTemplateDTO{
+		Id:          t.Id,
+		Name:        t.Name,
+		Description: t.Description,
+		Title:       t.Title,
+		InRepo:      t.InRepo(),
+		Fields:      t.Fields,
+	}
+}
@@ -12,24 +12,39 @@ import (
 	"github.com/openmerlin/merlin-server/common/domain/allerror"
 	"github.com/openmerlin/merlin-server/common/domain/primitive"
 	"github.com/openmerlin/merlin-server/discussion/domain"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
 	"github.com/openmerlin/merlin-server/discussion/domain/repository"
 )
 
 var discussionDisabledErr = allerror.New(
 	allerror.ErrorCodeDiscussionDisabled, "discussion disabled", errors.New("discussion disabled"))
 
+var errIssueIsPullRequest = allerror.New(
+	allerror.ErrorCodeIssueIsPullRequest, "issue is pull request", errors.New("pull request can't be changed as issue"))
+
 type IssueService interface {
-	ListIssuesCount(context.Context, primitive.Account, primitive.Identity) (ListIssuesCountDTO, error)
+	ListIssuesCount(context.Context, primitive.Account, primitive.Identity, discussionprimitive.IssueType,
+	) (ListIssuesCountDTO, error)
 	ListIssues(context.Context, primitive.Account, CmdToListIssues) (ListIssuesDTO, error)
+	ListUserIssues(context.Context, CmdToListUserIssues) (ListIssuesDTO, error)
 	CreateIssue(context.Context, CmdToCreateIssue) error
 	CloseIssue(context.Context, CmdToCloseIssue) error
 	ReopenIssue(context.Context, CmdToReopenIssue) error
 	GetIssue(context.Context, CmdToGetIssue) (IssueDetailDTO, error)
+	SetIssueLabels(context.Context, CmdToSetIssueLabels) error
+	SetIssueAssignees(context.Context, CmdToSetIssueAssignees) error
+	LockIssue(context.Context, CmdToLockIssue) error
+	PinIssue(context.Context, CmdToPinIssue) error
+	MarkIssueAnswer(context.Context, CmdToMarkIssueAnswer) error
 }
 
 type IssueRepoQuery interface {
 	List(primitive.Identity, repository.IssueListOption) ([]IssueDTO, error)
-	CountByStatus(primitive.Identity) (count ListIssuesCountDTO, err error)
+	ListOfUser(primitive.Account, discussionprimitive.IssueRelation, repository.IssueListOption,
+	) ([]IssueDTO, int64, error)
+	ResourcesOfUser(primitive.Account, discussionprimitive.IssueRelation, repository.IssueListOption,
+	) ([]primitive.Identity, error)
+	CountByStatus(primitive.Identity, discussionprimitive.IssueType) (count ListIssuesCountDTO, err error)
 }
 
 func NewIssueService(
@@ -38,6 +53,11 @@ func NewIssueService(
 	i repository.Issue,
 	iq IssueRepoQuery,
 	c repository.IssueComment,
+	l repository.Label,
+	r repository.Reaction,
+	n Notifier,
+	t repository.IssueTemplate,
+	tc repository.IssueTemplateClient,
 ) *issueService {
 	rp := resourcePermission{
 		resource:   re,
@@ -49,6 +69,10 @@ func NewIssueService(
 		issueRepo:          i,
 		issueRepoQuery:     iq,
 		commentRepo:        c,
+		labelRepo:          l,
+		reactionRepo:       r,
+		notifier:           n,
+		templates:          issueTemplates{repo: t, client: tc},
 	}
 }
 
@@ -57,16 +81,24 @@ type issueService struct {
 	issueRepo          repository.Issue
 	issueRepoQuery     IssueRepoQuery
 	commentRepo        repository.IssueComment
+	labelRepo          repository.Label
+	reactionRepo       repository.Reaction
+	notifier           Notifier
+	templates          issueTemplates
 }
 
 func (i *issueService) CreateIssue(ctx context.Context, cmd CmdToCreateIssue) error {
 	//todo sensitive check
 
-	_, err := i.resourcePermission.CanRead(ctx, cmd.Resource.Id, cmd.Owner)
+	r, err := i.resourcePermission.CanRead(ctx, cmd.Resource.Id, cmd.Owner)
 	if err != nil {
 		return err
 	}
 
+	if cmd.Content, err = i.templates.content(r, &cmd); err != nil {
+		return err
+	}
+
 	issue := domain.NewIssue(cmd.Resource, cmd.Owner, cmd.Title)
 	issueId, err := i.issueRepo.Save(issue)
 	if err != nil {
@@ -78,6 +110,13 @@ func (i *issueService) CreateIssue(ctx context.Context, cmd CmdToCreateIssue) er
 		return allerror.New(allerror.ErrorCodeFailToCreateComment, "failed to create comment", err)
 	}
 
+	issue.Id = issueId
+	i.notifier.Notify(ctx, r, &domain.NotificationEvent{
+		Type:  discussionprimitive.NotificationTypeNewIssue,
+		Actor: cmd.Owner,
+		Issue: &issue,
+	}, cmd.Title.Title()+"\n"+cmd.Content.CommentContent())
+
 	return nil
 }
 
@@ -105,6 +144,10 @@ func (i *issueService) CloseIssue(ctx context.Context, cmd CmdToCloseIssue) erro
 		return allerror.NewNoPermission("no permission", xerrors.Errorf("cant update"))
 	}
 
+	if issue.IsPullRequest() {
+		return errIssueIsPullRequest
+	}
+
 	if err = issue.Close(cmd.User); err != nil {
 		return err
 	}
@@ -140,6 +183,10 @@ func (i *issueService) ReopenIssue(ctx context.Context, cmd CmdToReopenIssue) er
 		return allerror.NewNoPermission("no permission", xerrors.Errorf("cant update"))
 	}
 
+	if issue.IsPullRequest() {
+		return errIssueIsPullRequest
+	}
+
 	if err = issue.Reopen(cmd.User); err != nil {
 		return err
 	}
@@ -152,13 +199,14 @@ func (i *issueService) ReopenIssue(ctx context.Context, cmd CmdToReopenIssue) er
 }
 
 func (i *issueService) ListIssuesCount(ctx context.Context, user primitive.Account, id primitive.Identity,
+	t discussionprimitive.IssueType,
 ) (dto ListIssuesCountDTO, err error) {
 	_, err = i.resourcePermission.CanRead(ctx, id, user)
 	if err != nil {
 		return
 	}
 
-	return i.issueRepoQuery.CountByStatus(id)
+	return i.issueRepoQuery.CountByStatus(id, t)
 }
 
 func (i *issueService) ListIssues(ctx context.Context, user primitive.Account, cmd CmdToListIssues,
@@ -169,10 +217,94 @@ func (i *issueService) ListIssues(ctx context.Context, user primitive.Account, c
 	}
 
 	issuesDTO, err := i.issueRepoQuery.List(cmd.Resource.Id, cmd.Option)
+	if err != nil {
+		return
+	}
+
+	labels, err := i.labelRepo.List(cmd.Resource.Id)
+	if err != nil {
+		return
+	}
+
+	fillLabels(labels, issuesDTO)
 
 	return ListIssuesDTO{
 		List: issuesDTO,
-	}, err
+	}, nil
+}
+
+// ListUserIssues lists the issues which the user is involved in among all the resources,
+// the issues of resources which the user can't read any more are skipped.
+func (i *issueService) ListUserIssues(ctx context.Context, cmd CmdToListUserIssues) (dto ListIssuesDTO, err error) {
+	ids, err := i.issueRepoQuery.ResourcesOfUser(cmd.User, cmd.Relation, cmd.Option)
+	if err != nil {
+		return
+	}
+
+	// the readable resources are filtered before the pagination, so the pages are full
+	resources := map[string]*IssueResourceDTO{}
+	readable := make([]primitive.Identity, 0, len(ids))
+
+	for _, id := range ids {
+		if v := i.readableResource(ctx, cmd.User, id); v != nil {
+			resources[id.Identity()] = v
+			readable = append(readable, id)
+		}
+	}
+
+	dto.List = []IssueDTO{}
+
+	if len(readable) == 0 {
+		return
+	}
+
+	option := cmd.Option
+	option.Resources = readable
+
+	issuesDTO, total, err := i.issueRepoQuery.ListOfUser(cmd.User, cmd.Relation, option)
+	if err != nil {
+		return
+	}
+
+	dto.Total = total
+
+	for j := range issuesDTO {
+		item := &issuesDTO[j]
+		if item.Resource == nil {
+			continue
+		}
+
+		if v, ok := resources[item.Resource.Id]; ok {
+			item.Resource = v
+			dto.List = append(dto.List, *item)
+		}
+	}
+
+	// the ids of label are unique among all the resources
+	labels, err := i.labelRepo.ListOfResources(readable)
+	if err != nil {
+		return
+	}
+
+	fillLabels(labels, dto.List)
+
+	return
+}
+
+func (i *issueService) readableResource(ctx context.Context, user primitive.Account, rid primitive.Identity,
+) *IssueResourceDTO {
+	r, err := i.resourcePermission.CanRead(ctx, rid, user)
+	if err != nil {
+		return nil
+	}
+
+	index := r.RepoIndex()
+
+	return &IssueResourceDTO{
+		Id:   rid.Identity(),
+		Type: string(r.ResourceType()),
+		Path: index.Owner.Account() + "/" + index.Name.MSDName(),
+	}
 }
 
 func (i *issueService) GetIssue(ctx context.Context, cmd CmdToGetIssue) (dto IssueDetailDTO, err error) {
@@ -204,15 +336,193 @@ func (i *issueService) GetIssue(ctx context.Context, cmd CmdToGetIssue) (dto Iss
 	itemsDTO := mergeOperationAndComments(issue.Operation, comments)
 	sort.Sort(itemsDTO)
 
+	labels, err := i.labelRepo.List(cmd.Resource.Id)
+	if err != nil {
+		return IssueDetailDTO{}, xerrors.Errorf("find labels error: %w", err)
+	}
+
+	issueDTO := []IssueDTO{ToIssueDTO(issue)}
+	fillLabels(labels, issueDTO)
+
 	itemsDTOPaginate := itemsDTO.paginate(cmd.PageNum, cmd.CountPerPage)
+
+	reactions, err := i.reactionRepo.Count(domain.ReactionTargetComment, itemsDTOPaginate.commentIds(), cmd.User)
+	if err != nil {
+		return IssueDetailDTO{}, xerrors.Errorf("count reactions of comments error: %w", err)
+	}
+
+	itemsDTOPaginate.setReactions(toReactionsDTO(reactions))
+
+	if reactions, err = i.reactionRepo.Count(domain.ReactionTargetIssue, []int64{issue.Id}, cmd.User); err != nil {
+		return IssueDetailDTO{}, xerrors.Errorf("count reactions of issue error: %w", err)
+	}
+
 	return IssueDetailDTO{
 		IsSecurity: i.isSecurity(cmd.User),
 		IsOwner:    isOwner || issue.IsIssueAuthor(cmd.User),
-		Issue:      ToIssueDTO(issue),
+		Issue:      issueDTO[0],
 		Items:      itemsDTOPaginate,
+		Reactions:  toReactionsDTO(reactions)[issue.Id],
 	}, nil
 }
 
+func (i *issueService) SetIssueLabels(ctx context.Context, cmd CmdToSetIssueLabels) error {
+	if err := i.resourcePermission.CanUpdate(ctx, cmd.Resource.Id, cmd.User); err != nil {
+		return err
+	}
+
+	issue, err := i.findIssueOfResource(ctx, cmd.Resource, cmd.IssueId)
+	if err != nil {
+		return err
+	}
+
+	labels, err := i.labelRepo.List(cmd.Resource.Id)
+	if err != nil {
+		return err
+	}
+
+	if err = issue.SetLabels(cmd.User, cmd.Labels, labels); err != nil {
+		return err
+	}
+
+	if _, err = i.issueRepo.Save(issue); err != nil {
+		return allerror.New(allerror.ErrorCodeFailToUpdateIssue, "failed to update issue", err)
+	}
+
+	return nil
+}
+
+func (i *issueService) SetIssueAssignees(ctx context.Context, cmd CmdToSetIssueAssignees) error {
+	r, err := i.resourcePermission.CanRead(ctx, cmd.Resource.Id, cmd.User)
+	if err != nil {
+		return err
+	}
+
+	if err = i.resourcePermission.permission.CanUpdate(ctx, cmd.User, r); err != nil {
+		return err
+	}
+
+	// only the users with write permission can be assigned
+	for _, a := range cmd.Assignees {
+		if err = i.resourcePermission.permission.CanUpdate(ctx, a, r); err != nil {
+			return allerror.NewInvalidParam("invalid assignee",
+				xerrors.Errorf("%s can't be assigned, %w", a.Account(), err))
+		}
+	}
+
+	issue, err := i.findIssueOfResource(ctx, cmd.Resource, cmd.IssueId)
+	if err != nil {
+		return err
+	}
+
+	if err = issue.SetAssignees(cmd.User, cmd.Assignees); err != nil {
+		return err
+	}
+
+	if _, err = i.issueRepo.Save(issue); err != nil {
+		return allerror.New(allerror.ErrorCodeFailToUpdateIssue, "failed to update issue", err)
+	}
+
+	return nil
+}
+
+func (i *issueService) LockIssue(ctx context.Context, cmd CmdToLockIssue) error {
+	if err := i.resourcePermission.CanUpdate(ctx, cmd.Resource.Id, cmd.User); err != nil {
+		return err
+	}
+
+	issue, err := i.findIssueOfResource(ctx, cmd.Resource, cmd.IssueId)
+	if err != nil {
+		return err
+	}
+
+	issue.SetLocked(cmd.User, cmd.Locked)
+
+	if _, err = i.issueRepo.Save(issue); err != nil {
+		return allerror.New(allerror.ErrorCodeFailToUpdateIssue, "failed to update issue", err)
+	}
+
+	return nil
+}
+
+func (i *issueService) PinIssue(ctx context.Context, cmd CmdToPinIssue) error {
+	if err := i.resourcePermission.CanUpdate(ctx, cmd.Resource.Id, cmd.User); err != nil {
+		return err
+	}
+
+	issue, err := i.findIssueOfResource(ctx, cmd.Resource, cmd.IssueId)
+	if err != nil {
+		return err
+	}
+
+	issue.SetPinned(cmd.User, cmd.Pinned)
+
+	if _, err = i.issueRepo.Save(issue); err != nil {
+		return allerror.New(allerror.ErrorCodeFailToUpdateIssue, "failed to update issue", err)
+	}
+
+	return nil
+}
+
+// MarkIssueAnswer marks or unmarks the answer of issue, it can be done by the author of issue or the maintainers.
+func (i *issueService) MarkIssueAnswer(ctx context.Context, cmd CmdToMarkIssueAnswer) error {
+	if _, err := i.resourcePermission.CanRead(ctx, cmd.Resource.Id, cmd.User); err != nil {
+		return err
+	}
+
+	issue, err := i.findIssueOfResource(ctx, cmd.Resource, cmd.IssueId)
+	if err != nil {
+		return err
+	}
+
+	if !issue.IsIssueAuthor(cmd.User) {
+		if err = i.resourcePermission.CanUpdate(ctx, cmd.Resource.Id, cmd.User); err != nil {
+			return err
+		}
+	}
+
+	if cmd.CommentId == 0 {
+		issue.UnmarkAnswer(cmd.User)
+	} else {
+		comment, err := i.commentRepo.Find(ctx, cmd.CommentId)
+		if err != nil {
+			return allerror.NewNotFound(
+				allerror.ErrorCodeCommentNotFound,
+				"not found",
+				xerrors.Errorf("failed to find comment by id, %w", err),
+			)
+		}
+
+		if err = issue.MarkAnswer(cmd.User, &comment); err != nil {
+			return err
+		}
+	}
+
+	if _, err = i.issueRepo.Save(issue); err != nil {
+		return allerror.New(allerror.ErrorCodeFailToUpdateIssue, "failed to update issue", err)
+	}
+
+	return nil
+}
+
+func (i *issueService) findIssueOfResource(ctx context.Context, resource domain.Resource, issueId int64,
+) (domain.Issue, error) {
+	issue, err := i.issueRepo.Find(ctx, issueId)
+	if err == nil && issue.Resource.Id.Integer() != resource.Id.Integer() {
+		err = xerrors.Errorf("issue %d is not of %s", issueId, resource.Id.Identity())
+	}
+
+	if err != nil {
+		return domain.Issue{}, allerror.NewNotFound(
+			allerror.ErrorCodeIssueNotFound,
+			"not found",
+			xerrors.Errorf("failed to find issue by id, %w", err),
+		)
+	}
+
+	return issue, nil
+}
+
 func (i *issueService) isSecurity(user primitive.Account) bool {
 	//todo check security user
 	return false
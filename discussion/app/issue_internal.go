@@ -5,11 +5,13 @@ import (
 
 	"golang.org/x/xerrors"
 
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
 	"github.com/openmerlin/merlin-server/discussion/domain/repository"
 )
 
 type IssueInternalService interface {
 	UpdateCommentCount(context.Context, int64, int64) error
+	HideComment(context.Context, int64) error
 }
 
 func NewIssueInternalService(i repository.Issue, c repository.IssueComment) *issueInternalService {
@@ -40,3 +42,23 @@ func (d *issueInternalService) UpdateCommentCount(ctx context.Context, issueId,
 
 	return nil
 }
+
+// HideComment hides the comment reported for the illegal content.
+func (d *issueInternalService) HideComment(ctx context.Context, commentId int64) error {
+	comment, err := d.comment.Find(ctx, commentId)
+	if err != nil {
+		return allerror.NewNotFound(
+			allerror.ErrorCodeCommentNotFound,
+			"not found",
+			xerrors.Errorf("failed to find comment by id, %w", err),
+		)
+	}
+
+	comment.Hidden = true
+
+	if _, err = d.comment.Save(comment); err != nil {
+		return allerror.New(allerror.ErrorCodeFailToUpdateComment, "failed to hide comment", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,244 @@
+package app
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+
+	coderepodomain "github.com/openmerlin/merlin-server/coderepo/domain"
+	"github.com/openmerlin/merlin-server/coderepo/domain/resourceadapter"
+	"github.com/openmerlin/merlin-server/common/app"
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain/repository"
+)
+
+const maxIssueTemplatesOfResource = 20
+
+type IssueTemplateService interface {
+	ListIssueTemplates(context.Context, primitive.Account, primitive.Identity) ([]IssueTemplateDTO, error)
+	CreateIssueTemplate(context.Context, CmdToCreateIssueTemplate) (IssueTemplateDTO, error)
+	UpdateIssueTemplate(context.Context, CmdToUpdateIssueTemplate) error
+	DeleteIssueTemplate(context.Context, CmdToDeleteIssueTemplate) error
+}
+
+func NewIssueTemplateService(
+	re resourceadapter.ResourceAdapter,
+	p app.ResourcePermissionAppService,
+	t repository.IssueTemplate,
+	tc repository.IssueTemplateClient,
+) *issueTemplateService {
+	rp := resourcePermission{
+		resource:   re,
+		permission: p,
+	}
+
+	return &issueTemplateService{
+		resourcePermission: rp,
+		templates:          issueTemplates{repo: t, client: tc},
+	}
+}
+
+type issueTemplateService struct {
+	resourcePermission resourcePermission
+	templates          issueTemplates
+}
+
+// ListIssueTemplates returns the templates for the form of creating issue.
+func (s *issueTemplateService) ListIssueTemplates(
+	ctx context.Context, user primitive.Account, resourceId primitive.Identity,
+) ([]IssueTemplateDTO, error) {
+	r, err := s.resourcePermission.CanRead(ctx, resourceId, user)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := s.templates.list(r, resourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]IssueTemplateDTO, len(templates))
+	for i := range templates {
+		dtos[i] = toIssueTemplateDTO(&templates[i])
+	}
+
+	return dtos, nil
+}
+
+func (s *issueTemplateService) CreateIssueTemplate(ctx context.Context, cmd CmdToCreateIssueTemplate,
+) (IssueTemplateDTO, error) {
+	if err := s.resourcePermission.CanUpdate(ctx, cmd.Resource.Id, cmd.User); err != nil {
+		return IssueTemplateDTO{}, err
+	}
+
+	templates, err := s.templates.repo.List(cmd.Resource.Id)
+	if err != nil {
+		return IssueTemplateDTO{}, err
+	}
+
+	if len(templates) >= maxIssueTemplatesOfResource {
+		return IssueTemplateDTO{}, allerror.NewCountExceeded("too many issue templates",
+			xerrors.Errorf("issue templates of %s exceed %d", cmd.Resource.Id.Identity(), maxIssueTemplatesOfResource))
+	}
+
+	t := cmd.toIssueTemplate()
+	if err = checkIssueTemplate(templates, &t); err != nil {
+		return IssueTemplateDTO{}, err
+	}
+
+	if err = s.templates.repo.Add(&t); err != nil {
+		return IssueTemplateDTO{}, err
+	}
+
+	return toIssueTemplateDTO(&t), nil
+}
+
+func (s *issueTemplateService) UpdateIssueTemplate(ctx context.Context, cmd CmdToUpdateIssueTemplate) error {
+	if err := s.resourcePermission.CanUpdate(ctx, cmd.Resource.Id, cmd.User); err != nil {
+		return err
+	}
+
+	if _, err := s.find(ctx, cmd.Resource.Id, cmd.TemplateId); err != nil {
+		return err
+	}
+
+	templates, err := s.templates.repo.List(cmd.Resource.Id)
+	if err != nil {
+		return err
+	}
+
+	t := cmd.toIssueTemplate()
+	t.Id = cmd.TemplateId
+
+	if err = checkIssueTemplate(templates, &t); err != nil {
+		return err
+	}
+
+	return s.templates.repo.Save(&t)
+}
+
+func (s *issueTemplateService) DeleteIssueTemplate(ctx context.Context, cmd CmdToDeleteIssueTemplate) error {
+	if err := s.resourcePermission.CanUpdate(ctx, cmd.Resource.Id, cmd.User); err != nil {
+		return err
+	}
+
+	if _, err := s.find(ctx, cmd.Resource.Id, cmd.TemplateId); err != nil {
+		return err
+	}
+
+	return s.templates.repo.Delete(cmd.TemplateId)
+}
+
+func (s *issueTemplateService) find(ctx context.Context, resourceId primitive.Identity, templateId int64,
+) (domain.IssueTemplate, error) {
+	t, err := s.templates.repo.Find(ctx, templateId)
+	if err == nil && t.Resource.Id.Integer() != resourceId.Integer() {
+		err = xerrors.Errorf("issue template %d is not of %s", templateId, resourceId.Identity())
+	}
+
+	if err != nil {
+		return domain.IssueTemplate{}, allerror.NewNotFound(
+			allerror.ErrorCodeIssueTemplateNotFound,
+			"not found",
+			xerrors.Errorf("failed to find issue template by id, %w", err),
+		)
+	}
+
+	return t, nil
+}
+
+func checkIssueTemplate(templates []domain.IssueTemplate, t *domain.IssueTemplate) error {
+	if err := t.Validate(); err != nil {
+		return allerror.NewInvalidParam(err.Error(), err)
+	}
+
+	for i := range templates {
+		if templates[i].Id != t.Id && templates[i].Name == t.Name {
+			return allerror.New(allerror.ErrorCodeIssueTemplateExists, "issue template exists",
+				xerrors.Errorf("issue template %s exists", t.Name))
+		}
+	}
+
+	return nil
+}
+
+// issueTemplates reads the templates in the code repo and the ones defined through the api.
+type issueTemplates struct {
+	repo   repository.IssueTemplate
+	client repository.IssueTemplateClient
+}
+
+func (it *issueTemplates) list(r coderepodomain.Resource, resourceId primitive.Identity,
+) ([]domain.IssueTemplate, error) {
+	index := r.RepoIndex()
+
+	inRepo, err := it.client.List(&index)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range inRepo {
+		inRepo[i].Resource = domain.Resource{Id: resourceId, Type: r.ResourceType()}
+	}
+
+	defined, err := it.repo.List(resourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.MergeIssueTemplates(inRepo, defined), nil
+}
+
+// content composes the content of issue by the fields submitted for the template,
+// the template is required if the resource has any. The free-form content is accepted
+// if the templates can't be read, so the issue can be created even if gitea is down.
+func (it *issueTemplates) content(r coderepodomain.Resource, cmd *CmdToCreateIssue,
+) (discussionprimitive.CommentContent, error) {
+	templates, err := it.list(r, cmd.Resource.Id)
+	if err != nil {
+		if cmd.Template == "" {
+			logrus.Errorf("list issue templates of %s failed: %s", cmd.Resource.Id.Identity(), err.Error())
+
+			return cmd.Content, nil
+		}
+
+		return nil, err
+	}
+
+	if cmd.Template == "" {
+		if len(templates) > 0 {
+			return nil, allerror.NewInvalidParam("missing template",
+				xerrors.Errorf("%s has issue templates", cmd.Resource.Id.Identity()))
+		}
+
+		return cmd.Content, nil
+	}
+
+	for i := range templates {
+		if templates[i].Name != cmd.Template {
+			continue
+		}
+
+		v, err := templates[i].Compose(cmd.Fields)
+		if err != nil {
+			return nil, allerror.NewInvalidParam(err.Error(), err)
+		}
+
+		content, err := discussionprimitive.NewCommentContent(v)
+		if err != nil {
+			return nil, allerror.NewInvalidParam(err.Error(), err)
+		}
+
+		return content, nil
+	}
+
+	return nil, allerror.NewNotFound(
+		allerror.ErrorCodeIssueTemplateNotFound,
+		"not found",
+		xerrors.Errorf("issue template %s not found", cmd.Template),
+	)
+}
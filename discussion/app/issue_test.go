@@ -0,0 +1,196 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	coderepodomain "github.com/openmerlin/merlin-server/coderepo/domain"
+	coderepoprimitive "github.com/openmerlin/merlin-server/coderepo/domain/primitive"
+	"github.com/openmerlin/merlin-server/coderepo/domain/resourceadapter"
+	commondomain "github.com/openmerlin/merlin-server/common/domain"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain/repository"
+)
+
+type savingIssueRepo struct {
+	repository.Issue
+	saved int
+}
+
+func (r *savingIssueRepo) Save(domain.Issue) (int64, error) {
+	r.saved++
+
+	return int64(r.saved), nil
+}
+
+func (r stubCommentRepo) Save(comment domain.IssueComment) (domain.IssueComment, error) {
+	return comment, nil
+}
+
+type stubTemplateRepo struct {
+	repository.IssueTemplate
+	templates []domain.IssueTemplate
+}
+
+func (r stubTemplateRepo) List(primitive.Identity) ([]domain.IssueTemplate, error) {
+	return r.templates, nil
+}
+
+type stubTemplateClient struct {
+	err error
+}
+
+func (c stubTemplateClient) List(*commondomain.CodeRepoIndex) ([]domain.IssueTemplate, error) {
+	return nil, c.err
+}
+
+type stubNotifier struct{}
+
+func (n stubNotifier) Notify(context.Context, coderepodomain.Resource, *domain.NotificationEvent, string) {
+}
+
+// TestCreateIssueRequiresTemplate tests that the issue must be created by a template
+// when the resource has any, and the free-form content is accepted only when it has none
+// or the templates can't be read.
+func TestCreateIssueRequiresTemplate(t *testing.T) {
+	cfg := discussionprimitive.Config{}
+	cfg.SetDefault()
+	discussionprimitive.InitConfig(&cfg)
+
+	bug := domain.IssueTemplate{
+		Id:     1,
+		Name:   "bug",
+		Fields: []domain.IssueTemplateField{{Id: "steps", Label: "Steps", Required: true}},
+	}
+
+	create := func(templates []domain.IssueTemplate, cmd CmdToCreateIssue, clientErr error) (*savingIssueRepo, error) {
+		issues := &savingIssueRepo{}
+
+		s := NewIssueService(
+			stubResourceAdapter{},
+			stubPermission{},
+			issues,
+			nil,
+			stubCommentRepo{},
+			nil, nil,
+			stubNotifier{},
+			stubTemplateRepo{templates: templates},
+			stubTemplateClient{err: clientErr},
+		)
+
+		cmd.Resource = domain.Resource{Id: primitive.CreateIdentity(1)}
+		cmd.Owner = primitive.CreateAccount("author")
+		cmd.Title = discussionprimitive.CreateIssueTitle("title")
+
+		return issues, s.CreateIssue(context.Background(), cmd)
+	}
+
+	content := discussionprimitive.CreateCommentContent("free-form")
+
+	issues, err := create([]domain.IssueTemplate{bug}, CmdToCreateIssue{Content: content}, nil)
+	if err == nil || issues.saved != 0 {
+		t.Fatal("the issue without template should be rejected when the resource has templates")
+	}
+
+	issues, err = create([]domain.IssueTemplate{bug}, CmdToCreateIssue{
+		Template: "bug",
+		Fields:   map[string]string{"steps": "run it"},
+	}, nil)
+	if err != nil || issues.saved != 1 {
+		t.Fatalf("the issue created by the template should be saved, %v", err)
+	}
+
+	issues, err = create(nil, CmdToCreateIssue{Content: content}, nil)
+	if err != nil || issues.saved != 1 {
+		t.Fatalf("the free-form issue should be saved when the resource has no templates, %v", err)
+	}
+
+	issues, err = create([]domain.IssueTemplate{bug}, CmdToCreateIssue{Content: content}, errors.New("gitea is down"))
+	if err != nil || issues.saved != 1 {
+		t.Fatalf("the free-form issue should be saved when the templates can't be read, %v", err)
+	}
+}
+
+// readableResourceAdapter returns the resource 1 only, the others are regarded deleted.
+type readableResourceAdapter struct {
+	resourceadapter.ResourceAdapter
+}
+
+func (a readableResourceAdapter) GetByIndex(id coderepoprimitive.Identity) (coderepodomain.Resource, error) {
+	if id.Integer() != 1 {
+		return nil, errStub
+	}
+
+	return namedResource{}, nil
+}
+
+type namedResource struct {
+	stubResource
+}
+
+func (r namedResource) RepoIndex() commondomain.CodeRepoIndex {
+	return commondomain.CodeRepoIndex{
+		Id:    primitive.CreateIdentity(1),
+		Owner: primitive.CreateAccount("owner"),
+		Name:  primitive.CreateMSDName("name"),
+	}
+}
+
+type userIssueRepoQuery struct {
+	IssueRepoQuery
+	option repository.IssueListOption
+}
+
+func (q *userIssueRepoQuery) ResourcesOfUser(
+	primitive.Account, discussionprimitive.IssueRelation, repository.IssueListOption,
+) ([]primitive.Identity, error) {
+	return []primitive.Identity{primitive.CreateIdentity(1), primitive.CreateIdentity(2)}, nil
+}
+
+func (q *userIssueRepoQuery) ListOfUser(
+	_ primitive.Account, _ discussionprimitive.IssueRelation, option repository.IssueListOption,
+) ([]IssueDTO, int64, error) {
+	q.option = option
+
+	return []IssueDTO{{Id: 1, Resource: &IssueResourceDTO{Id: "1"}}}, 21, nil
+}
+
+type stubLabelRepo struct {
+	repository.Label
+}
+
+func (r stubLabelRepo) ListOfResources([]primitive.Identity) ([]domain.Label, error) {
+	return nil, nil
+}
+
+// TestListUserIssuesFiltersReadableResources tests that the issues are listed only among
+// the readable resources before the pagination, and the total of them is returned.
+func TestListUserIssuesFiltersReadableResources(t *testing.T) {
+	query := &userIssueRepoQuery{}
+
+	s := NewIssueService(
+		readableResourceAdapter{},
+		stubPermission{},
+		nil,
+		query,
+		nil,
+		stubLabelRepo{},
+		nil, nil, nil, nil,
+	)
+
+	dto, err := s.ListUserIssues(context.Background(), CmdToListUserIssues{User: primitive.CreateAccount("user")})
+	if err != nil {
+		t.Fatalf("list user issues failed, %v", err)
+	}
+
+	if v := query.option.Resources; len(v) != 1 || v[0].Integer() != 1 {
+		t.Fatalf("expected the issues listed among the readable resource 1, got %v", v)
+	}
+
+	if dto.Total != 21 || len(dto.List) != 1 || dto.List[0].Resource.Path != "owner/name" {
+		t.Fatalf("unexpected result: %v", dto)
+	}
+}
@@ -0,0 +1,159 @@
+package app
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+
+	"github.com/openmerlin/merlin-server/coderepo/domain/resourceadapter"
+	"github.com/openmerlin/merlin-server/common/app"
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain/repository"
+)
+
+const maxLabelsOfResource = 100
+
+type LabelService interface {
+	ListLabels(context.Context, primitive.Account, primitive.Identity) ([]LabelDTO, error)
+	CreateLabel(context.Context, CmdToCreateLabel) (LabelDTO, error)
+	UpdateLabel(context.Context, CmdToUpdateLabel) error
+	DeleteLabel(context.Context, CmdToDeleteLabel) error
+}
+
+func NewLabelService(
+	re resourceadapter.ResourceAdapter,
+	p app.ResourcePermissionAppService,
+	l repository.Label,
+) *labelService {
+	rp := resourcePermission{
+		resource:   re,
+		permission: p,
+	}
+
+	return &labelService{
+		resourcePermission: rp,
+		labelRepo:          l,
+	}
+}
+
+type labelService struct {
+	resourcePermission resourcePermission
+	labelRepo          repository.Label
+}
+
+func (s *labelService) ListLabels(ctx context.Context, user primitive.Account, resourceId primitive.Identity,
+) ([]LabelDTO, error) {
+	if _, err := s.resourcePermission.CanRead(ctx, resourceId, user); err != nil {
+		return nil, err
+	}
+
+	labels, err := s.labelRepo.List(resourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]LabelDTO, len(labels))
+	for i := range labels {
+		dtos[i] = toLabelDTO(&labels[i])
+	}
+
+	return dtos, nil
+}
+
+func (s *labelService) CreateLabel(ctx context.Context, cmd CmdToCreateLabel) (LabelDTO, error) {
+	if err := s.resourcePermission.CanUpdate(ctx, cmd.Resource.Id, cmd.User); err != nil {
+		return LabelDTO{}, err
+	}
+
+	labels, err := s.labelRepo.List(cmd.Resource.Id)
+	if err != nil {
+		return LabelDTO{}, err
+	}
+
+	if len(labels) >= maxLabelsOfResource {
+		return LabelDTO{}, allerror.NewCountExceeded("too many labels",
+			xerrors.Errorf("labels of %s exceed %d", cmd.Resource.Id.Identity(), maxLabelsOfResource))
+	}
+
+	if err = checkLabelName(labels, 0, cmd.Name); err != nil {
+		return LabelDTO{}, err
+	}
+
+	label, err := s.labelRepo.Save(domain.NewLabel(cmd.Resource.Id, cmd.Name, cmd.Color))
+	if err != nil {
+		return LabelDTO{}, err
+	}
+
+	return toLabelDTO(&label), nil
+}
+
+func (s *labelService) UpdateLabel(ctx context.Context, cmd CmdToUpdateLabel) error {
+	if err := s.resourcePermission.CanUpdate(ctx, cmd.Resource.Id, cmd.User); err != nil {
+		return err
+	}
+
+	label, err := s.find(ctx, cmd.Resource.Id, cmd.LabelId)
+	if err != nil {
+		return err
+	}
+
+	labels, err := s.labelRepo.List(cmd.Resource.Id)
+	if err != nil {
+		return err
+	}
+
+	if err = checkLabelName(labels, label.Id, cmd.Name); err != nil {
+		return err
+	}
+
+	label.Name = cmd.Name
+	label.Color = cmd.Color
+
+	_, err = s.labelRepo.Save(label)
+
+	return err
+}
+
+func (s *labelService) DeleteLabel(ctx context.Context, cmd CmdToDeleteLabel) error {
+	if err := s.resourcePermission.CanUpdate(ctx, cmd.Resource.Id, cmd.User); err != nil {
+		return err
+	}
+
+	if _, err := s.find(ctx, cmd.Resource.Id, cmd.LabelId); err != nil {
+		return err
+	}
+
+	return s.labelRepo.Delete(ctx, cmd.LabelId)
+}
+
+func (s *labelService) find(ctx context.Context, resourceId primitive.Identity, labelId int64,
+) (domain.Label, error) {
+	label, err := s.labelRepo.Find(ctx, labelId)
+	if err == nil && label.ResourceId.Integer() != resourceId.Integer() {
+		err = xerrors.Errorf("label %d is not of %s", labelId, resourceId.Identity())
+	}
+
+	if err != nil {
+		return domain.Label{}, allerror.NewNotFound(
+			allerror.ErrorCodeLabelNotFound,
+			"not found",
+			xerrors.Errorf("failed to find label by id, %w", err),
+		)
+	}
+
+	return label, nil
+}
+
+func checkLabelName(labels []domain.Label, labelId int64, name discussionprimitive.LabelName) error {
+	for i := range labels {
+		if labels[i].Id != labelId && labels[i].IsSameName(name) {
+			return allerror.New(allerror.ErrorCodeLabelExists, "label exists",
+				xerrors.Errorf("label %s exists", name.LabelName()))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain/repository"
+)
+
+type NotificationService interface {
+	ListNotifications(CmdToListNotifications) (ListNotificationsDTO, error)
+	MarkNotificationsRead(CmdToMarkNotificationsRead) error
+	ListNotificationPreferences(primitive.Account) ([]NotificationPreferenceDTO, error)
+	UpdateNotificationPreference(*CmdToUpdateNotificationPreference) error
+}
+
+func NewNotificationService(
+	n repository.Notification,
+	p repository.NotificationPreference,
+) *notificationService {
+	return &notificationService{
+		notificationRepo: n,
+		preferenceRepo:   p,
+	}
+}
+
+type notificationService struct {
+	notificationRepo repository.Notification
+	preferenceRepo   repository.NotificationPreference
+}
+
+func (s *notificationService) ListNotifications(cmd CmdToListNotifications) (ListNotificationsDTO, error) {
+	v, total, err := s.notificationRepo.List(cmd.User, &cmd.Option)
+	if err != nil {
+		return ListNotificationsDTO{}, err
+	}
+
+	unread, err := s.notificationRepo.CountUnread(cmd.User)
+	if err != nil {
+		return ListNotificationsDTO{}, err
+	}
+
+	dtos := make([]NotificationDTO, len(v))
+	for i := range v {
+		dtos[i] = toNotificationDTO(&v[i])
+	}
+
+	return ListNotificationsDTO{Total: total, Unread: unread, List: dtos}, nil
+}
+
+func (s *notificationService) MarkNotificationsRead(cmd CmdToMarkNotificationsRead) error {
+	var err error
+	if len(cmd.Ids) == 0 {
+		err = s.notificationRepo.MarkAllRead(cmd.User)
+	} else {
+		err = s.notificationRepo.MarkRead(cmd.User, cmd.Ids)
+	}
+
+	if err != nil {
+		return allerror.New(allerror.ErrorCodeFailToUpdateNotification, "failed to mark notifications read", err)
+	}
+
+	return nil
+}
+
+// ListNotificationPreferences returns the preferences of all the notification types,
+// the default one is returned if the user has not set it.
+func (s *notificationService) ListNotificationPreferences(user primitive.Account,
+) ([]NotificationPreferenceDTO, error) {
+	prefs, err := s.preferenceRepo.List([]primitive.Account{user})
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]*domain.NotificationPreference, len(prefs))
+	for i := range prefs {
+		m[prefs[i].Type.NotificationType()] = &prefs[i]
+	}
+
+	types := discussionprimitive.NotificationTypes()
+	dtos := make([]NotificationPreferenceDTO, len(types))
+
+	for i, t := range types {
+		if p, ok := m[t.NotificationType()]; ok {
+			dtos[i] = toNotificationPreferenceDTO(p)
+		} else {
+			p := domain.DefaultNotificationPreference(user, t)
+			dtos[i] = toNotificationPreferenceDTO(&p)
+		}
+	}
+
+	return dtos, nil
+}
+
+func (s *notificationService) UpdateNotificationPreference(cmd *CmdToUpdateNotificationPreference) error {
+	if err := s.preferenceRepo.Save(cmd); err != nil {
+		return allerror.New(
+			allerror.ErrorCodeFailToUpdateNotification, "failed to update notification preference", err,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,270 @@
+package app
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	coderepodomain "github.com/openmerlin/merlin-server/coderepo/domain"
+	commonapp "github.com/openmerlin/merlin-server/common/app"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+	"github.com/openmerlin/merlin-server/discussion/domain/email"
+	"github.com/openmerlin/merlin-server/discussion/domain/repository"
+	orgrepo "github.com/openmerlin/merlin-server/organization/domain/repository"
+	userapp "github.com/openmerlin/merlin-server/user/app"
+	userdomain "github.com/openmerlin/merlin-server/user/domain"
+)
+
+const (
+	// notifyWorkers is the number of goroutines notifying the users in background
+	notifyWorkers = 4
+
+	// notifyQueueSize limits the events waiting to be notified, the ones beyond it are dropped
+	notifyQueueSize = 1000
+)
+
+// Notifier notifies the users involved in the discussion, the failure of notifying is only logged
+// and doesn't fail the discussion.
+type Notifier interface {
+	Notify(ctx context.Context, r coderepodomain.Resource, e *domain.NotificationEvent, text string)
+}
+
+func NewNotifier(
+	user userapp.UserService,
+	member orgrepo.OrgMember,
+	c repository.IssueComment,
+	n repository.Notification,
+	p repository.NotificationPreference,
+	e email.Email,
+	w repository.Watch,
+	we repository.WatchEvent,
+	perm commonapp.ResourcePermissionAppService,
+) *notifier {
+	v := &notifier{
+		user:         user,
+		member:       member,
+		commentRepo:  c,
+		notification: n,
+		preference:   p,
+		email:        e,
+		watch:        w,
+		watchEvent:   we,
+		permission:   perm,
+		tasks:        make(chan notifyTask, notifyQueueSize),
+	}
+
+	for i := 0; i < notifyWorkers; i++ {
+		go v.work()
+	}
+
+	return v
+}
+
+type notifyTask struct {
+	resource coderepodomain.Resource
+	event    domain.NotificationEvent
+	text     string
+}
+
+type notifier struct {
+	user         userapp.UserService
+	member       orgrepo.OrgMember
+	commentRepo  repository.IssueComment
+	notification repository.Notification
+	preference   repository.NotificationPreference
+	email        email.Email
+	watch        repository.Watch
+	watchEvent   repository.WatchEvent
+	permission   commonapp.ResourcePermissionAppService
+	tasks        chan notifyTask
+}
+
+// Notify notifies the users mentioned in the text, the author and the participants of issue
+// and the owners of resource except the ones ignoring or unable to read the resource, and records
+// the event for the digests of the watchers. It returns at once and the users are notified in
+// background, the event is dropped if too many are waiting.
+func (n *notifier) Notify(_ context.Context, r coderepodomain.Resource, e *domain.NotificationEvent, text string) {
+	task := notifyTask{resource: r, event: *e, text: text}
+
+	// the issue may be changed by the caller after it returns
+	issue := *e.Issue
+	task.event.Issue = &issue
+
+	select {
+	case n.tasks <- task:
+	default:
+		logrus.Errorf("too many notifications waiting, drop the one of issue %d", e.Issue.Id)
+	}
+}
+
+func (n *notifier) work() {
+	for t := range n.tasks {
+		n.notify(context.Background(), t.resource, &t.event, t.text)
+	}
+}
+
+func (n *notifier) notify(ctx context.Context, r coderepodomain.Resource, e *domain.NotificationEvent, text string) {
+	index := r.RepoIndex()
+
+	e.Resource = domain.Resource{Id: e.Issue.Resource.Id, Type: r.ResourceType()}
+	e.ResourcePath = index.Owner.Account() + "/" + index.Name.MSDName()
+
+	n.addWatchEvent(e)
+
+	notifications := e.Notifications(n.mentioned(ctx, text), n.involved(ctx, r, e.Issue))
+	if len(notifications) == 0 {
+		return
+	}
+
+	receivers := make([]primitive.Account, len(notifications))
+	for i := range notifications {
+		receivers[i] = notifications[i].Receiver
+	}
+
+	prefs := n.preferences(receivers)
+	ignoring := n.ignoring(e.Resource.Id, receivers)
+
+	inApp := make([]domain.Notification, 0, len(notifications))
+
+	for i := range notifications {
+		v := &notifications[i]
+
+		if ignoring[v.Receiver.Account()] {
+			continue
+		}
+
+		// the mentioned users may be unable to read the private resource
+		if err := n.permission.CanRead(ctx, v.Receiver, r); err != nil {
+			continue
+		}
+
+		p, ok := prefs[v.Receiver.Account()+"/"+v.Type.NotificationType()]
+		if !ok {
+			p = domain.DefaultNotificationPreference(v.Receiver, v.Type)
+		}
+
+		if p.InApp {
+			inApp = append(inApp, *v)
+		}
+
+		if p.Email {
+			n.sendEmail(ctx, v)
+		}
+	}
+
+	if err := n.notification.Add(inApp); err != nil {
+		logrus.Errorf("add notifications of issue %d failed: %s", e.Issue.Id, err.Error())
+	}
+}
+
+// mentioned returns the existing users mentioned in the text, the organizations are ignored.
+func (n *notifier) mentioned(ctx context.Context, text string) []primitive.Account {
+	names := domain.ParseMentions(text)
+
+	users := make([]primitive.Account, 0, len(names))
+
+	for _, name := range names {
+		acc, err := primitive.NewAccount(name)
+		if err != nil {
+			continue
+		}
+
+		u, err := n.user.GetByAccount(ctx, acc, acc)
+		if err != nil || u.Type != int(userdomain.UserTypeUser) {
+			continue
+		}
+
+		users = append(users, acc)
+	}
+
+	return users
+}
+
+// involved returns the author and the participants of issue and the owners of resource.
+func (n *notifier) involved(ctx context.Context, r coderepodomain.Resource, issue *domain.Issue) []primitive.Account {
+	users := []primitive.Account{issue.Author}
+
+	comments, err := n.commentRepo.List(issue.Id)
+	if err != nil {
+		logrus.Errorf("list comments of issue %d failed: %s", issue.Id, err.Error())
+	}
+
+	for i := range comments {
+		users = append(users, comments[i].Author)
+	}
+
+	if r.OwnedByPerson() {
+		return append(users, r.ResourceOwner())
+	}
+
+	admins, err := n.member.GetByOrgAndRole(r.ResourceOwner().Account(), primitive.NewAdminRole())
+	if err != nil {
+		logrus.Errorf("list admins of %s failed: %s", r.ResourceOwner().Account(), err.Error())
+	}
+
+	for i := range admins {
+		users = append(users, admins[i].Username)
+	}
+
+	return users
+}
+
+func (n *notifier) preferences(users []primitive.Account) map[string]domain.NotificationPreference {
+	prefs, err := n.preference.List(users)
+	if err != nil {
+		logrus.Errorf("list notification preferences failed: %s", err.Error())
+	}
+
+	m := make(map[string]domain.NotificationPreference, len(prefs))
+	for _, p := range prefs {
+		m[p.User.Account()+"/"+p.Type.NotificationType()] = p
+	}
+
+	return m
+}
+
+func (n *notifier) ignoring(resourceId primitive.Identity, users []primitive.Account) map[string]bool {
+	v, err := n.watch.ListIgnoring(resourceId, users)
+	if err != nil {
+		logrus.Errorf("list users ignoring resource %s failed: %s", resourceId.Identity(), err.Error())
+	}
+
+	m := make(map[string]bool, len(v))
+	for i := range v {
+		m[v[i].Account()] = true
+	}
+
+	return m
+}
+
+func (n *notifier) addWatchEvent(e *domain.NotificationEvent) {
+	v, ok := domain.WatchEventOf(e)
+	if !ok {
+		return
+	}
+
+	if err := n.watchEvent.Add(&v); err != nil {
+		logrus.Errorf("add watch event of issue %d failed: %s", e.Issue.Id, err.Error())
+	}
+}
+
+func (n *notifier) sendEmail(ctx context.Context, v *domain.Notification) {
+	u, err := n.user.GetByAccount(ctx, v.Receiver, v.Receiver)
+	if err != nil || u.Email == nil || *u.Email == "" {
+		return
+	}
+
+	err = n.email.SendNotificationEmail(email.NotificationEmailParam{
+		Receiver:     []string{*u.Email},
+		Actor:        v.Actor,
+		Type:         v.Type,
+		ResourceType: string(v.Resource.Type),
+		ResourcePath: v.ResourcePath,
+		IssueId:      v.IssueId,
+		Title:        v.Title,
+	})
+	if err != nil {
+		logrus.Errorf("send notification email to %s failed: %s", v.Receiver.Account(), err.Error())
+	}
+}
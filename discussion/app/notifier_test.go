@@ -0,0 +1,28 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openmerlin/merlin-server/discussion/domain"
+)
+
+// TestNotifyInBackground tests that Notify doesn't wait for the notifying, the issue is copied
+// so the change of caller doesn't affect it, and the event is dropped if the queue is full.
+func TestNotifyInBackground(t *testing.T) {
+	n := &notifier{tasks: make(chan notifyTask, 1)}
+
+	issue := domain.Issue{Id: 1}
+	n.Notify(context.Background(), stubResource{}, &domain.NotificationEvent{Issue: &issue}, "")
+
+	issue.Id = 2
+	n.Notify(context.Background(), stubResource{}, &domain.NotificationEvent{Issue: &issue}, "")
+
+	if len(n.tasks) != 1 {
+		t.Fatalf("expected the second event dropped, got %d waiting", len(n.tasks))
+	}
+
+	if task := <-n.tasks; task.event.Issue.Id != 1 {
+		t.Fatalf("expected the issue copied, got %d", task.event.Issue.Id)
+	}
+}
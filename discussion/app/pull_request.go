@@ -0,0 +1,300 @@
+package app
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+
+	coderepoapp "github.com/openmerlin/merlin-server/coderepo/app"
+	coderepodomain "github.com/openmerlin/merlin-server/coderepo/domain"
+	coderepoprimitive "github.com/openmerlin/merlin-server/coderepo/domain/primitive"
+	"github.com/openmerlin/merlin-server/coderepo/domain/resourceadapter"
+	"github.com/openmerlin/merlin-server/common/app"
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain/repository"
+)
+
+type PullRequestService interface {
+	CreatePullRequest(context.Context, CmdToCreatePullRequest) error
+	GetPullRequest(context.Context, CmdToGetIssue) (PullRequestDetailDTO, error)
+	ReviewPullRequest(context.Context, CmdToReviewPullRequest) error
+	MergePullRequest(context.Context, CmdToMergePullRequest) error
+	ClosePullRequest(context.Context, CmdToMergePullRequest) error
+	ReopenPullRequest(context.Context, CmdToMergePullRequest) error
+}
+
+func NewPullRequestService(
+	re resourceadapter.ResourceAdapter,
+	p app.ResourcePermissionAppService,
+	i repository.Issue,
+	c repository.IssueComment,
+	pr repository.PullRequest,
+	client repository.PullRequestClient,
+	branch coderepoapp.BranchAppService,
+	issue IssueService,
+	n Notifier,
+) *pullRequestService {
+	rp := resourcePermission{
+		resource:   re,
+		permission: p,
+	}
+
+	return &pullRequestService{
+		resourcePermission: rp,
+		issueRepo:          i,
+		commentRepo:        c,
+		prRepo:             pr,
+		client:             client,
+		branch:             branch,
+		issueService:       issue,
+		notifier:           n,
+	}
+}
+
+type pullRequestService struct {
+	resourcePermission resourcePermission
+	issueRepo          repository.Issue
+	commentRepo        repository.IssueComment
+	prRepo             repository.PullRequest
+	client             repository.PullRequestClient
+	branch             coderepoapp.BranchAppService
+	issueService       IssueService
+	notifier           Notifier
+}
+
+func (s *pullRequestService) CreatePullRequest(ctx context.Context, cmd CmdToCreatePullRequest) error {
+	r, err := s.resourcePermission.CanRead(ctx, cmd.Resource.Id, cmd.Owner)
+	if err != nil {
+		return err
+	}
+
+	// the source branch is created from the target branch, and the existing one is reused.
+	// the user without write permission can only use their own proposal branch.
+	if err = s.createSourceBranch(ctx, r, &cmd); err != nil {
+		return err
+	}
+
+	index := r.RepoIndex()
+	pr := domain.PullRequest{
+		SourceBranch: cmd.SourceBranch,
+		TargetBranch: cmd.TargetBranch,
+	}
+
+	if pr.Number, err = s.client.Create(&index, &pr, cmd.Title.Title(), cmd.Content.CommentContent()); err != nil {
+		return err
+	}
+
+	issue := domain.NewPullRequestIssue(cmd.Resource, cmd.Owner, cmd.Title)
+	if pr.IssueId, err = s.issueRepo.Save(issue); err != nil {
+		s.closeOrphan(&index, &pr)
+
+		return allerror.New(allerror.ErrorCodeFailToCreatePullRequest, "failed to create pull request", err)
+	}
+
+	if err = s.prRepo.Save(pr); err != nil {
+		s.closeOrphan(&index, &pr)
+
+		return allerror.New(allerror.ErrorCodeFailToCreatePullRequest, "failed to create pull request", err)
+	}
+
+	comment := domain.NewFirstIssueComment(cmd.Owner, pr.IssueId, cmd.Content)
+	if _, err = s.commentRepo.Save(comment); err != nil {
+		return allerror.New(allerror.ErrorCodeFailToCreateComment, "failed to create comment", err)
+	}
+
+	issue.Id = pr.IssueId
+	s.notifier.Notify(ctx, r, &domain.NotificationEvent{
+		Type:  discussionprimitive.NotificationTypeNewIssue,
+		Actor: cmd.Owner,
+		Issue: &issue,
+	}, cmd.Title.Title()+"\n"+cmd.Content.CommentContent())
+
+	return nil
+}
+
+func (s *pullRequestService) createSourceBranch(
+	ctx context.Context, r coderepodomain.Resource, cmd *CmdToCreatePullRequest,
+) error {
+	index := r.RepoIndex()
+
+	_, err := s.branch.Create(ctx, cmd.Owner, &coderepoapp.CmdToCreateBranch{
+		BranchIndex: coderepodomain.BranchIndex{
+			Repo:   index.Name,
+			Owner:  index.Owner,
+			Branch: cmd.SourceBranch,
+		},
+		RepoType:   coderepoprimitive.CreateRepoType(string(r.ResourceType())),
+		BaseBranch: cmd.TargetBranch,
+	})
+	if allerror.IsBranchExist(err) {
+		return nil
+	}
+
+	return err
+}
+
+func (s *pullRequestService) closeOrphan(index *coderepodomain.CodeRepoIndex, pr *domain.PullRequest) {
+	if err := s.client.Close(index, pr); err != nil {
+		logrus.Errorf("close pull request %d of %s/%s failed: %s",
+			pr.Number, index.Owner.Account(), index.Name.MSDName(), err.Error())
+	}
+}
+
+func (s *pullRequestService) GetPullRequest(ctx context.Context, cmd CmdToGetIssue) (
+	dto PullRequestDetailDTO, err error,
+) {
+	r, err := s.resourcePermission.CanRead(ctx, cmd.Resource.Id, cmd.User)
+	if err != nil {
+		return
+	}
+
+	issue, pr, err := s.find(ctx, cmd.Resource, cmd.IssueId)
+	if err != nil {
+		return
+	}
+
+	if dto.IssueDetailDTO, err = s.issueService.GetIssue(ctx, cmd); err != nil {
+		return
+	}
+
+	index := r.RepoIndex()
+
+	diff := domain.DiffSummary{}
+	if !issue.Status.IsMerged() {
+		if diff, err = s.client.DiffSummary(&index, &pr); err != nil {
+			return
+		}
+	}
+
+	dto.CanMerge = issue.Status.IsOpen() &&
+		s.resourcePermission.CanUpdate(ctx, cmd.Resource.Id, cmd.User) == nil
+	dto.PullRequest = toPullRequestDTO(&pr, &diff)
+
+	return
+}
+
+func (s *pullRequestService) ReviewPullRequest(ctx context.Context, cmd CmdToReviewPullRequest) error {
+	if err := s.resourcePermission.CanUpdate(ctx, cmd.Resource.Id, cmd.User); err != nil {
+		return err
+	}
+
+	issue, _, err := s.find(ctx, cmd.Resource, cmd.IssueId)
+	if err != nil {
+		return err
+	}
+
+	if err = issue.Review(cmd.User, cmd.Action); err != nil {
+		return err
+	}
+
+	if _, err = s.issueRepo.Save(issue); err != nil {
+		return allerror.New(allerror.ErrorCodeFailToUpdateIssue, "failed to review pull request", err)
+	}
+
+	return nil
+}
+
+func (s *pullRequestService) MergePullRequest(ctx context.Context, cmd CmdToMergePullRequest) error {
+	r, err := s.resourcePermission.CanRead(ctx, cmd.Resource.Id, cmd.User)
+	if err != nil {
+		return err
+	}
+
+	if err = s.resourcePermission.CanUpdate(ctx, cmd.Resource.Id, cmd.User); err != nil {
+		return err
+	}
+
+	issue, pr, err := s.find(ctx, cmd.Resource, cmd.IssueId)
+	if err != nil {
+		return err
+	}
+
+	if err = issue.Merge(cmd.User); err != nil {
+		return err
+	}
+
+	index := r.RepoIndex()
+	if err = s.client.Merge(&index, &pr, issue.Title.Title()); err != nil {
+		return err
+	}
+
+	if _, err = s.issueRepo.Save(issue); err != nil {
+		return allerror.New(allerror.ErrorCodeFailToUpdateIssue, "failed to merge pull request", err)
+	}
+
+	return nil
+}
+
+func (s *pullRequestService) ClosePullRequest(ctx context.Context, cmd CmdToMergePullRequest) error {
+	return s.changeState(ctx, cmd, false)
+}
+
+func (s *pullRequestService) ReopenPullRequest(ctx context.Context, cmd CmdToMergePullRequest) error {
+	return s.changeState(ctx, cmd, true)
+}
+
+func (s *pullRequestService) changeState(ctx context.Context, cmd CmdToMergePullRequest, reopen bool) error {
+	r, err := s.resourcePermission.CanRead(ctx, cmd.Resource.Id, cmd.User)
+	if err != nil {
+		return err
+	}
+
+	issue, pr, err := s.find(ctx, cmd.Resource, cmd.IssueId)
+	if err != nil {
+		return err
+	}
+
+	if !issue.IsIssueAuthor(cmd.User) {
+		if err = s.resourcePermission.CanUpdate(ctx, cmd.Resource.Id, cmd.User); err != nil {
+			return allerror.NewNoPermission("no permission", xerrors.Errorf("cant update"))
+		}
+	}
+
+	index := r.RepoIndex()
+
+	if reopen {
+		if err = issue.Reopen(cmd.User); err == nil {
+			err = s.client.Reopen(&index, &pr)
+		}
+	} else {
+		if err = issue.Close(cmd.User); err == nil {
+			err = s.client.Close(&index, &pr)
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.issueRepo.Save(issue); err != nil {
+		return allerror.New(allerror.ErrorCodeFailToUpdateIssue, "failed to update pull request", err)
+	}
+
+	return nil
+}
+
+func (s *pullRequestService) find(ctx context.Context, resource domain.Resource, issueId int64) (
+	issue domain.Issue, pr domain.PullRequest, err error,
+) {
+	issue, err = s.issueRepo.Find(ctx, issueId)
+	if err == nil && (!issue.IsPullRequest() || issue.Resource.Id.Integer() != resource.Id.Integer()) {
+		err = xerrors.Errorf("issue %d is not a pull request of %s", issueId, resource.Id.Identity())
+	}
+
+	if err == nil {
+		pr, err = s.prRepo.FindByIssueId(ctx, issueId)
+	}
+
+	if err != nil {
+		err = allerror.NewNotFound(
+			allerror.ErrorCodePullRequestNotFound,
+			"not found",
+			xerrors.Errorf("failed to find pull request, %w", err),
+		)
+	}
+
+	return
+}
@@ -0,0 +1,271 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+
+	coderepodomain "github.com/openmerlin/merlin-server/coderepo/domain"
+	"github.com/openmerlin/merlin-server/coderepo/domain/resourceadapter"
+	commonapp "github.com/openmerlin/merlin-server/common/app"
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+	"github.com/openmerlin/merlin-server/discussion/domain/email"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain/repository"
+	userapp "github.com/openmerlin/merlin-server/user/app"
+)
+
+type WatchService interface {
+	Watch(context.Context, *CmdToWatch) error
+	GetWatch(ctx context.Context, user primitive.Account, resourceId primitive.Identity) (WatchDTO, error)
+	ListWatches(primitive.Account) ([]WatchDTO, error)
+	Unsubscribe(ctx context.Context, token string) error
+}
+
+type WatchInternalService interface {
+	AddWatchEvent(*CmdToAddWatchEvent) error
+	SendDigests(ctx context.Context, now time.Time) (DigestDTO, error)
+}
+
+func NewWatchService(
+	re resourceadapter.ResourceAdapter,
+	p commonapp.ResourcePermissionAppService,
+	w repository.Watch,
+	we repository.WatchEvent,
+	user userapp.UserService,
+	e email.Email,
+) *watchService {
+	return &watchService{
+		resource:   re,
+		permission: p,
+		watchRepo:  w,
+		eventRepo:  we,
+		user:       user,
+		email:      e,
+	}
+}
+
+type watchService struct {
+	resource   resourceadapter.ResourceAdapter
+	permission commonapp.ResourcePermissionAppService
+	watchRepo  repository.Watch
+	eventRepo  repository.WatchEvent
+	user       userapp.UserService
+	email      email.Email
+}
+
+func (s *watchService) Watch(ctx context.Context, cmd *CmdToWatch) error {
+	r, err := s.canRead(ctx, cmd.User, cmd.Resource)
+	if err != nil {
+		return err
+	}
+
+	watch, err := s.watchRepo.Find(ctx, cmd.User, cmd.Resource)
+	if err == nil {
+		watch.Update(cmd.Level, cmd.Frequency)
+
+		err = s.watchRepo.Save(&watch)
+	} else if commonrepo.IsErrorResourceNotExists(err) {
+		resource := domain.Resource{Id: cmd.Resource, Type: r.ResourceType()}
+
+		if watch, err = domain.NewWatch(cmd.User, resource, cmd.Level, cmd.Frequency); err == nil {
+			err = s.watchRepo.Add(&watch)
+		}
+	}
+
+	if err != nil {
+		return allerror.New(allerror.ErrorCodeFailToUpdateWatch, "failed to watch", err)
+	}
+
+	return nil
+}
+
+// GetWatch returns the watch of user, the user who hasn't watched the resource is participating.
+func (s *watchService) GetWatch(ctx context.Context, user primitive.Account, resourceId primitive.Identity,
+) (WatchDTO, error) {
+	r, err := s.canRead(ctx, user, resourceId)
+	if err != nil {
+		return WatchDTO{}, err
+	}
+
+	watch, err := s.watchRepo.Find(ctx, user, resourceId)
+	if err != nil {
+		if !commonrepo.IsErrorResourceNotExists(err) {
+			return WatchDTO{}, err
+		}
+
+		watch = domain.Watch{
+			Resource:  domain.Resource{Id: resourceId, Type: r.ResourceType()},
+			Level:     discussionprimitive.WatchLevelParticipating,
+			Frequency: discussionprimitive.DigestFrequencyDaily,
+		}
+	}
+
+	return toWatchDTO(&watch), nil
+}
+
+func (s *watchService) ListWatches(user primitive.Account) ([]WatchDTO, error) {
+	watches, err := s.watchRepo.ListOfUser(user)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]WatchDTO, len(watches))
+	for i := range watches {
+		dtos[i] = toWatchDTO(&watches[i])
+	}
+
+	return dtos, nil
+}
+
+// Unsubscribe removes the watch by the token in the digest, the user becomes participating.
+func (s *watchService) Unsubscribe(ctx context.Context, token string) error {
+	watch, err := s.watchRepo.FindByToken(ctx, token)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = allerror.NewNotFound(allerror.ErrorCodeWatchNotFound, "not found", err)
+		}
+
+		return err
+	}
+
+	return s.watchRepo.Delete(watch.Id)
+}
+
+func (s *watchService) AddWatchEvent(cmd *CmdToAddWatchEvent) error {
+	r, err := s.resource.GetByIndex(cmd.Resource)
+	if err != nil {
+		return allerror.New(allerror.ErrorCodeRepoNotFound, "resource not found", err)
+	}
+
+	event := domain.WatchEvent{
+		Resource: domain.Resource{Id: cmd.Resource, Type: r.ResourceType()},
+		Type:     cmd.Type,
+		Actor:    cmd.Actor,
+		Title:    cmd.Title,
+	}
+
+	return s.eventRepo.Add(&event)
+}
+
+// SendDigests sends the digests which are due at the time, one email per user.
+// The due watches are claimed before sending, so the concurrent calls don't send the same digest.
+func (s *watchService) SendDigests(ctx context.Context, now time.Time) (DigestDTO, error) {
+	dto := DigestDTO{}
+
+	for _, f := range discussionprimitive.DigestFrequencies() {
+		watches, err := s.watchRepo.ClaimToDigest(f, now.Add(-f.Interval()), now)
+		if err != nil {
+			return dto, xerrors.Errorf("claim %s watches to digest failed, %w", f.DigestFrequency(), err)
+		}
+
+		// the watches are ordered by user
+		for i := 0; i < len(watches); {
+			j := i + 1
+			for j < len(watches) && watches[j].User.Account() == watches[i].User.Account() {
+				j++
+			}
+
+			if err := s.sendDigest(ctx, f, watches[i:j], now); err != nil {
+				logrus.Errorf("send digest to %s failed: %s", watches[i].User.Account(), err.Error())
+
+				// retry in the next round
+				if err := s.watchRepo.ResetDigestedAt(watches[i:j]); err != nil {
+					logrus.Errorf("reset digest of %s failed: %s", watches[i].User.Account(), err.Error())
+				}
+			} else {
+				dto.Users++
+			}
+
+			i = j
+		}
+	}
+
+	return dto, nil
+}
+
+func (s *watchService) sendDigest(
+	ctx context.Context, f discussionprimitive.DigestFrequency, watches []domain.Watch, now time.Time,
+) error {
+	user := watches[0].User
+
+	resourceIds := make([]primitive.Identity, len(watches))
+	since := now
+
+	for i := range watches {
+		resourceIds[i] = watches[i].Resource.Id
+
+		if watches[i].DigestedAt.Before(since) {
+			since = watches[i].DigestedAt
+		}
+	}
+
+	events, err := s.eventRepo.List(resourceIds, since)
+	if err != nil {
+		return xerrors.Errorf("list watch events failed, %w", err)
+	}
+
+	// the events after the watches were claimed are digested next time
+	events = slices.DeleteFunc(events, func(e domain.WatchEvent) bool {
+		return e.CreatedAt.After(now)
+	})
+
+	resources := make([]email.DigestResource, 0, len(watches))
+
+	for i := range watches {
+		w := &watches[i]
+
+		v := w.Digest(events)
+		if len(v) == 0 {
+			continue
+		}
+
+		// the resource may be private or deleted since it was watched
+		r, err := s.canRead(ctx, user, w.Resource.Id)
+		if err != nil {
+			continue
+		}
+
+		index := r.RepoIndex()
+
+		resources = append(resources, email.DigestResource{
+			ResourceType:     string(r.ResourceType()),
+			ResourcePath:     index.Owner.Account() + "/" + index.Name.MSDName(),
+			UnsubscribeToken: w.Token,
+			Events:           v,
+		})
+	}
+
+	if len(resources) > 0 {
+		u, err := s.user.GetByAccount(ctx, user, user)
+		if err == nil && u.Email != nil && *u.Email != "" {
+			err = s.email.SendDigestEmail(email.DigestEmailParam{
+				Receiver:  []string{*u.Email},
+				User:      user,
+				Frequency: f,
+				Resources: resources,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *watchService) canRead(ctx context.Context, user primitive.Account, resourceId primitive.Identity,
+) (coderepodomain.Resource, error) {
+	r, err := s.resource.GetByIndex(resourceId)
+	if err != nil {
+		return nil, allerror.New(allerror.ErrorCodeRepoNotFound, "resource not found", err)
+	}
+
+	return r, s.permission.CanRead(ctx, user, r)
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -14,17 +15,22 @@ func AddRouterForDiscussionInternalController(
 	r *gin.RouterGroup,
 	m middleware.UserMiddleWare,
 	s app.IssueInternalService,
+	w app.WatchInternalService,
 ) {
 
 	ctl := DiscussionInternalController{
-		app: s,
+		app:   s,
+		watch: w,
 	}
 
 	r.PUT("/v1/discussion/issue/:id", m.Write, ctl.UpdateCommentCount)
+	r.POST("/v1/watch/:resource_id/event", m.Write, ctl.AddWatchEvent)
+	r.POST("/v1/watch/digest", m.Write, ctl.SendDigests)
 }
 
 type DiscussionInternalController struct {
-	app app.IssueInternalService
+	app   app.IssueInternalService
+	watch app.WatchInternalService
 }
 
 // @Summary  Update comment
@@ -55,3 +61,45 @@ func (ctl *DiscussionInternalController) UpdateCommentCount(ctx *gin.Context) {
 		commonctl.SendRespOfPut(ctx, nil)
 	}
 }
+
+// @Summary  Add watch event
+// @Description  add the release or code update of resource for the digests of watchers
+// @Tags     DiscussionInternal
+// @Param    resource_id    path    string                true    "id of model/space/datasets"
+// @Param    body           body    reqToAddWatchEvent    true    "body of adding watch event"
+// @Success  201    {object}    commonctl.ResponseData{data=nil,msg=string,code=string}
+// @Router   /v1/watch/{resource_id}/event [post]
+func (ctl *DiscussionInternalController) AddWatchEvent(ctx *gin.Context) {
+	req := reqToAddWatchEvent{}
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	cmd, err := req.toCmd(ctx.Param("resource_id"))
+	if err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	if err = ctl.watch.AddWatchEvent(&cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, nil)
+	}
+}
+
+// @Summary  Send digests
+// @Description  send the due digests of the activity on the watched resources
+// @Tags     DiscussionInternal
+// @Success  201    {object}    commonctl.ResponseData{data=app.DigestDTO,msg=string,code=string}
+// @Router   /v1/watch/digest [post]
+func (ctl *DiscussionInternalController) SendDigests(ctx *gin.Context) {
+	if v, err := ctl.watch.SendDigests(ctx.Request.Context(), time.Now()); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, &v)
+	}
+}
@@ -1,8 +1,13 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	coderepoprimitive "github.com/openmerlin/merlin-server/coderepo/domain/primitive"
 	"github.com/openmerlin/merlin-server/common/controller"
 	"github.com/openmerlin/merlin-server/common/domain/primitive"
 	"github.com/openmerlin/merlin-server/discussion/app"
@@ -12,8 +17,12 @@ import (
 )
 
 type reqToCreateIssue struct {
-	Title   string `json:"title" binding:"required"`
-	Content string `json:"content" binding:"required"`
+	Title string `json:"title" binding:"required"`
+	// Content is required unless the issue is created by the template
+	Content string `json:"content"`
+
+	Template string            `json:"template"`
+	Fields   map[string]string `json:"fields"`
 }
 
 func (r reqToCreateIssue) action() string {
@@ -32,20 +41,28 @@ func (r reqToCreateIssue) toCreateIssueCmd(resourceId string, owner primitive.Ac
 		return
 	}
 
-	content, err := discussionprimitive.NewCommentContent(r.Content)
-	if err != nil {
-		return
-	}
-
 	cmd = app.CmdToCreateIssue{
 		Resource: domain.Resource{
 			Id: id,
 		},
-		Owner:   owner,
-		Title:   title,
-		Content: content,
+		Owner:    owner,
+		Title:    title,
+		Template: r.Template,
+		Fields:   r.Fields,
 	}
 
+	if r.Template != "" {
+		return
+	}
+
+	if r.Content == "" {
+		err = errors.New("missing content")
+
+		return
+	}
+
+	cmd.Content, err = discussionprimitive.NewCommentContent(r.Content)
+
 	return
 }
 
@@ -67,9 +84,22 @@ func toCloseIssueCmd(user primitive.Account, resourceId string, issueId int64,
 	return
 }
 
+const dateLayout = "2006-01-02"
+
 type reqToListIssue struct {
 	controller.CommonListRequest
 	Status string `form:"status"`
+	Type   string `form:"type"`
+
+	Label    int64  `form:"label"`
+	Assignee string `form:"assignee"`
+
+	Keyword   string `form:"keyword"`
+	Author    string `form:"author"`
+	Commenter string `form:"commenter"`
+	// CreatedFrom and CreatedTo are dates like 2006-01-02, and both of them are included
+	CreatedFrom string `form:"created_from"`
+	CreatedTo   string `form:"created_to"`
 }
 
 func (r reqToListIssue) toListIssuesCmd(resourceId string) (cmd app.CmdToListIssues, err error) {
@@ -78,25 +108,102 @@ func (r reqToListIssue) toListIssuesCmd(resourceId string) (cmd app.CmdToListIss
 		return
 	}
 
-	status, _ := discussionprimitive.NewIssueStatus(r.Status)
-
-	if r.PageNum <= 0 {
-		r.PageNum = 1
-	}
-
-	if r.CountPerPage <= 0 {
-		r.CountPerPage = 50
+	option, err := r.toIssueListOption()
+	if err != nil {
+		return
 	}
 
 	return app.CmdToListIssues{
 		Resource: domain.Resource{
 			Id: id,
 		},
-		Option: repository.IssueListOption{
-			Status:       status,
-			PageNum:      r.PageNum,
-			CountPerPage: r.CountPerPage,
-		},
+		Option: option,
+	}, nil
+}
+
+func (r reqToListIssue) toIssueListOption() (option repository.IssueListOption, err error) {
+	option.Status, _ = discussionprimitive.NewIssueStatus(r.Status)
+	option.Type, _ = discussionprimitive.NewIssueType(r.Type)
+	option.Label = r.Label
+	option.Keyword = strings.TrimSpace(r.Keyword)
+
+	if option.Assignee, err = toOptionalAccount(r.Assignee); err != nil {
+		return
+	}
+
+	if option.Author, err = toOptionalAccount(r.Author); err != nil {
+		return
+	}
+
+	if option.Commenter, err = toOptionalAccount(r.Commenter); err != nil {
+		return
+	}
+
+	if r.SortBy != "" {
+		if option.Sort, err = discussionprimitive.NewIssueSort(r.SortBy); err != nil {
+			return
+		}
+	}
+
+	if r.CreatedFrom != "" {
+		if option.CreatedAfter, err = time.Parse(dateLayout, r.CreatedFrom); err != nil {
+			return
+		}
+	}
+
+	if r.CreatedTo != "" {
+		var t time.Time
+		if t, err = time.Parse(dateLayout, r.CreatedTo); err != nil {
+			return
+		}
+
+		option.CreatedBefore = t.AddDate(0, 0, 1)
+	}
+
+	option.PageNum = r.PageNum
+	if option.PageNum <= 0 {
+		option.PageNum = 1
+	}
+
+	option.CountPerPage = r.CountPerPage
+	if option.CountPerPage <= 0 {
+		option.CountPerPage = 50
+	}
+
+	return
+}
+
+func toOptionalAccount(v string) (primitive.Account, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	return primitive.NewAccount(v)
+}
+
+type reqToListUserIssues struct {
+	reqToListIssue
+
+	Relation string `form:"relation"`
+}
+
+func (r reqToListUserIssues) toCmd(user primitive.Account) (cmd app.CmdToListUserIssues, err error) {
+	var relation discussionprimitive.IssueRelation = discussionprimitive.IssueRelationAuthored
+	if r.Relation != "" {
+		if relation, err = discussionprimitive.NewIssueRelation(r.Relation); err != nil {
+			return
+		}
+	}
+
+	option, err := r.toIssueListOption()
+	if err != nil {
+		return
+	}
+
+	return app.CmdToListUserIssues{
+		User:     user,
+		Relation: relation,
+		Option:   option,
 	}, nil
 }
 
@@ -134,6 +241,7 @@ func (r reqToGetIssue) toGetIssueCmd(user primitive.Account, resourceId string,
 
 type reqToCreateComment struct {
 	IssueId int64  `json:"issue_id" binding:"required"`
+	ReplyTo int64  `json:"reply_to"`
 	Content string `json:"content" binding:"required"`
 }
 
@@ -151,6 +259,7 @@ func (r reqToCreateComment) toCreateCommentCmd(user primitive.Account, resourceI
 
 	cmd = app.CmdToCreateIssueComment{
 		IssueId: r.IssueId,
+		ReplyTo: r.ReplyTo,
 		Owner:   user,
 		Content: content,
 		Resource: domain.Resource{
@@ -234,3 +343,398 @@ func (r reqToReportComment) toReportCommentCmd(user primitive.Account, commentId
 type reqToUpdateCommentCount struct {
 	Count int64 `json:"count" binding:"required"`
 }
+
+type reqToCreatePullRequest struct {
+	reqToCreateIssue
+
+	SourceBranch string `json:"source_branch" binding:"required"`
+	TargetBranch string `json:"target_branch" binding:"required"`
+}
+
+func (r reqToCreatePullRequest) action() string {
+	return fmt.Sprintf("create pull request of %s from %s to %s", r.Title, r.SourceBranch, r.TargetBranch)
+}
+
+func (r reqToCreatePullRequest) toCreatePullRequestCmd(resourceId string, owner primitive.Account,
+) (cmd app.CmdToCreatePullRequest, err error) {
+	if r.Template != "" {
+		err = errors.New("template is not supported by pull request")
+
+		return
+	}
+
+	if cmd.CmdToCreateIssue, err = r.toCreateIssueCmd(resourceId, owner); err != nil {
+		return
+	}
+
+	if cmd.SourceBranch, err = coderepoprimitive.NewBranchName(r.SourceBranch); err != nil {
+		return
+	}
+
+	if cmd.TargetBranch, err = coderepoprimitive.NewBranchName(r.TargetBranch); err != nil {
+		return
+	}
+
+	if r.SourceBranch == r.TargetBranch {
+		err = errors.New("source branch is the same as target branch")
+	}
+
+	return
+}
+
+type reqToReviewPullRequest struct {
+	Action string `json:"action" binding:"required"`
+}
+
+func (r reqToReviewPullRequest) toReviewPullRequestCmd(user primitive.Account, resourceId string, issueId int64,
+) (cmd app.CmdToReviewPullRequest, err error) {
+	if cmd.CmdToCloseIssue, err = toCloseIssueCmd(user, resourceId, issueId); err != nil {
+		return
+	}
+
+	cmd.Action, err = discussionprimitive.NewReviewAction(r.Action)
+
+	return
+}
+
+type reqToCreateLabel struct {
+	Name  string `json:"name" binding:"required"`
+	Color string `json:"color" binding:"required"`
+}
+
+func (r reqToCreateLabel) action() string {
+	return fmt.Sprintf("create label %s", r.Name)
+}
+
+func (r reqToCreateLabel) toCreateLabelCmd(user primitive.Account, resourceId string,
+) (cmd app.CmdToCreateLabel, err error) {
+	id, err := primitive.NewIdentity(resourceId)
+	if err != nil {
+		return
+	}
+
+	if cmd.Name, err = discussionprimitive.NewLabelName(r.Name); err != nil {
+		return
+	}
+
+	if cmd.Color, err = discussionprimitive.NewLabelColor(r.Color); err != nil {
+		return
+	}
+
+	cmd.User = user
+	cmd.Resource = domain.Resource{
+		Id: id,
+	}
+
+	return
+}
+
+func toDeleteLabelCmd(user primitive.Account, resourceId string, labelId int64,
+) (cmd app.CmdToDeleteLabel, err error) {
+	id, err := primitive.NewIdentity(resourceId)
+	if err != nil {
+		return
+	}
+
+	cmd = app.CmdToDeleteLabel{
+		User:    user,
+		LabelId: labelId,
+		Resource: domain.Resource{
+			Id: id,
+		},
+	}
+
+	return
+}
+
+type reqToSetIssueLabels struct {
+	Labels []int64 `json:"labels"`
+}
+
+func (r reqToSetIssueLabels) toSetIssueLabelsCmd(user primitive.Account, resourceId string, issueId int64,
+) (cmd app.CmdToSetIssueLabels, err error) {
+	if cmd.CmdToCloseIssue, err = toCloseIssueCmd(user, resourceId, issueId); err != nil {
+		return
+	}
+
+	cmd.Labels = r.Labels
+
+	return
+}
+
+type reqToSetIssueAssignees struct {
+	Assignees []string `json:"assignees"`
+}
+
+func (r reqToSetIssueAssignees) toSetIssueAssigneesCmd(user primitive.Account, resourceId string, issueId int64,
+) (cmd app.CmdToSetIssueAssignees, err error) {
+	if cmd.CmdToCloseIssue, err = toCloseIssueCmd(user, resourceId, issueId); err != nil {
+		return
+	}
+
+	cmd.Assignees = make([]primitive.Account, len(r.Assignees))
+
+	for i, v := range r.Assignees {
+		if cmd.Assignees[i], err = primitive.NewAccount(v); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+type reqToToggleReaction struct {
+	Emoji int `json:"emoji" binding:"required"`
+}
+
+func (r reqToToggleReaction) toToggleReactionCmd(
+	user primitive.Account, resourceId string, target domain.ReactionTarget,
+) (cmd app.CmdToToggleReaction, err error) {
+	id, err := primitive.NewIdentity(resourceId)
+	if err != nil {
+		return
+	}
+
+	if cmd.Emoji, err = discussionprimitive.NewEmojiType(r.Emoji); err != nil {
+		return
+	}
+
+	cmd.User = user
+	cmd.Target = target
+	cmd.Resource = domain.Resource{
+		Id: id,
+	}
+
+	return
+}
+
+type reqToListNotifications struct {
+	controller.CommonListRequest
+
+	// Read is empty if both the read and unread notifications are listed
+	Read string `form:"read"`
+}
+
+func (r reqToListNotifications) toListNotificationsCmd(user primitive.Account,
+) (cmd app.CmdToListNotifications, err error) {
+	if r.PageNum <= 0 {
+		r.PageNum = 1
+	}
+
+	if r.CountPerPage <= 0 {
+		r.CountPerPage = 50
+	}
+
+	cmd = app.CmdToListNotifications{
+		User: user,
+		Option: repository.NotificationListOption{
+			PageNum:      r.PageNum,
+			CountPerPage: r.CountPerPage,
+		},
+	}
+
+	if r.Read != "" {
+		var read bool
+		if read, err = strconv.ParseBool(r.Read); err != nil {
+			return
+		}
+
+		cmd.Option.IsRead = &read
+	}
+
+	return
+}
+
+type reqToMarkNotificationsRead struct {
+	Ids []int64 `json:"ids"`
+	All bool    `json:"all"`
+}
+
+func (r reqToMarkNotificationsRead) toCmd(user primitive.Account) (cmd app.CmdToMarkNotificationsRead, err error) {
+	if r.All {
+		return app.CmdToMarkNotificationsRead{User: user}, nil
+	}
+
+	if len(r.Ids) == 0 {
+		err = errors.New("no notifications to mark")
+
+		return
+	}
+
+	return app.CmdToMarkNotificationsRead{User: user, Ids: r.Ids}, nil
+}
+
+type reqToUpdateNotificationPreference struct {
+	Type  string `json:"type" binding:"required"`
+	InApp bool   `json:"in_app"`
+	Email bool   `json:"email"`
+}
+
+func (r reqToUpdateNotificationPreference) action() string {
+	return fmt.Sprintf("update notification preference of %s", r.Type)
+}
+
+func (r reqToUpdateNotificationPreference) toCmd(user primitive.Account,
+) (cmd app.CmdToUpdateNotificationPreference, err error) {
+	t, err := discussionprimitive.NewNotificationType(r.Type)
+	if err != nil {
+		return
+	}
+
+	return app.CmdToUpdateNotificationPreference{
+		User:  user,
+		Type:  t,
+		InApp: r.InApp,
+		Email: r.Email,
+	}, nil
+}
+
+type reqToLockIssue struct {
+	Locked bool `json:"locked"`
+}
+
+func (r reqToLockIssue) action(issueId int64) string {
+	if r.Locked {
+		return fmt.Sprintf("lock issue %d", issueId)
+	}
+
+	return fmt.Sprintf("unlock issue %d", issueId)
+}
+
+type reqToPinIssue struct {
+	Pinned bool `json:"pinned"`
+}
+
+func (r reqToPinIssue) action(issueId int64) string {
+	if r.Pinned {
+		return fmt.Sprintf("pin issue %d", issueId)
+	}
+
+	return fmt.Sprintf("unpin issue %d", issueId)
+}
+
+type reqToMarkIssueAnswer struct {
+	// CommentId is 0 if the answer is unmarked
+	CommentId int64 `json:"comment_id"`
+}
+
+func (r reqToMarkIssueAnswer) action(issueId int64) string {
+	if r.CommentId == 0 {
+		return fmt.Sprintf("unmark answer of issue %d", issueId)
+	}
+
+	return fmt.Sprintf("mark comment %d as answer of issue %d", r.CommentId, issueId)
+}
+
+type reqToWatch struct {
+	Level string `json:"level" binding:"required"`
+	// Frequency is daily by default
+	Frequency string `json:"frequency"`
+}
+
+func (r reqToWatch) action(resourceId string) string {
+	return fmt.Sprintf("watch %s of resource %s", r.Level, resourceId)
+}
+
+func (r reqToWatch) toCmd(user primitive.Account, resourceId string) (cmd app.CmdToWatch, err error) {
+	if cmd.Resource, err = primitive.NewIdentity(resourceId); err != nil {
+		return
+	}
+
+	if cmd.Level, err = discussionprimitive.NewWatchLevel(r.Level); err != nil {
+		return
+	}
+
+	cmd.Frequency = discussionprimitive.DigestFrequencyDaily
+	if r.Frequency != "" {
+		if cmd.Frequency, err = discussionprimitive.NewDigestFrequency(r.Frequency); err != nil {
+			return
+		}
+	}
+
+	cmd.User = user
+
+	return
+}
+
+type reqToAddWatchEvent struct {
+	Type  string `json:"type" binding:"required"`
+	Actor string `json:"actor"`
+	Title string `json:"title" binding:"required"`
+}
+
+func (r reqToAddWatchEvent) toCmd(resourceId string) (cmd app.CmdToAddWatchEvent, err error) {
+	if cmd.Resource, err = primitive.NewIdentity(resourceId); err != nil {
+		return
+	}
+
+	if cmd.Type, err = discussionprimitive.NewRepoWatchEventType(r.Type); err != nil {
+		return
+	}
+
+	if r.Actor != "" {
+		if cmd.Actor, err = primitive.NewAccount(r.Actor); err != nil {
+			return
+		}
+	}
+
+	cmd.Title = r.Title
+
+	return
+}
+
+type reqToCreateIssueTemplate struct {
+	Name        string                      `json:"name" binding:"required"`
+	Description string                      `json:"description"`
+	Title       string                      `json:"title"`
+	Fields      []domain.IssueTemplateField `json:"fields" binding:"required"`
+}
+
+func (r reqToCreateIssueTemplate) action() string {
+	return fmt.Sprintf("create issue template %s", r.Name)
+}
+
+func (r reqToCreateIssueTemplate) toCmd(user primitive.Account, resourceId string,
+) (cmd app.CmdToCreateIssueTemplate, err error) {
+	id, err := primitive.NewIdentity(resourceId)
+	if err != nil {
+		return
+	}
+
+	if r.Title != "" {
+		if _, err = discussionprimitive.NewIssueTitle(r.Title); err != nil {
+			return
+		}
+	}
+
+	cmd = app.CmdToCreateIssueTemplate{
+		User: user,
+		Resource: domain.Resource{
+			Id: id,
+		},
+		Name:        r.Name,
+		Description: r.Description,
+		Title:       r.Title,
+		Fields:      r.Fields,
+	}
+
+	return
+}
+
+func toDeleteIssueTemplateCmd(user primitive.Account, resourceId string, templateId int64,
+) (cmd app.CmdToDeleteIssueTemplate, err error) {
+	id, err := primitive.NewIdentity(resourceId)
+	if err != nil {
+		return
+	}
+
+	cmd = app.CmdToDeleteIssueTemplate{
+		User:       user,
+		TemplateId: templateId,
+		Resource: domain.Resource{
+			Id: id,
+		},
+	}
+
+	return
+}
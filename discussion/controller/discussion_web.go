@@ -10,6 +10,8 @@ import (
 	"github.com/openmerlin/merlin-server/common/controller/middleware"
 	"github.com/openmerlin/merlin-server/common/domain/primitive"
 	"github.com/openmerlin/merlin-server/discussion/app"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
 )
 
 func AddRouterForDiscussionWebController(
@@ -19,34 +21,85 @@ func AddRouterForDiscussionWebController(
 	i app.IssueService,
 	c app.CommentService,
 	d app.DiscussionService,
+	p app.PullRequestService,
+	lb app.LabelService,
+	n app.NotificationService,
+	w app.WatchService,
+	t app.IssueTemplateService,
 ) {
 	ctl := DiscussionWebController{
-		userMiddleWare:    m,
-		issueService:      i,
-		commentService:    c,
-		discussionService: d,
+		userMiddleWare:       m,
+		issueService:         i,
+		commentService:       c,
+		discussionService:    d,
+		pullRequestService:   p,
+		labelService:         lb,
+		notificationService:  n,
+		watchService:         w,
+		issueTemplateService: t,
 	}
 
 	r.POST("/v1/discussion/:resource_id/issue", m.Write, l.Write, ctl.CreateIssue)
 	r.GET("/v1/discussion/:resource_id/issue/:id", m.Optional, ctl.GetIssue)
 	r.GET("/v1/discussion/:resource_id/issue", m.Optional, ctl.ListIssue)
 	r.GET("/v1/discussion/:resource_id/issue/count", m.Optional, ctl.ListIssuesCount)
+	r.GET("/v1/issue", m.Read, ctl.ListUserIssues)
 	r.PUT("/v1/discussion/:resource_id/issue/:id/close", m.Write, l.Write, ctl.CloseIssue)
 	r.PUT("/v1/discussion/:resource_id/issue/:id/reopen", m.Write, l.Write, ctl.ReopenIssue)
+	r.PUT("/v1/discussion/:resource_id/issue/:id/label", m.Write, l.Write, ctl.SetIssueLabels)
+	r.PUT("/v1/discussion/:resource_id/issue/:id/assignee", m.Write, l.Write, ctl.SetIssueAssignees)
+	r.PUT("/v1/discussion/:resource_id/issue/:id/lock", m.Write, l.Write, ctl.LockIssue)
+	r.PUT("/v1/discussion/:resource_id/issue/:id/pin", m.Write, l.Write, ctl.PinIssue)
+	r.PUT("/v1/discussion/:resource_id/issue/:id/answer", m.Write, l.Write, ctl.MarkIssueAnswer)
 	r.PUT("/v1/discussion/:resource_id/close", m.Write, l.Write, ctl.CloseDiscussion)
 	r.PUT("/v1/discussion/:resource_id/open", m.Write, l.Write, ctl.OpenDiscussion)
 
 	r.POST("/v1/discussion/:resource_id/comment", m.Write, l.Write, ctl.CreateComment)
 	r.PUT("/v1/discussion/:resource_id/comment/:id", m.Write, l.Write, ctl.UpdateComment)
 	r.DELETE("/v1/discussion/:resource_id/comment/:id", m.Write, l.Write, ctl.DeleteComment)
+	r.GET("/v1/discussion/:resource_id/comment/:id/revision", m.Read, ctl.ListCommentRevisions)
 	r.POST("/v1/discussion/:resource_id/comment/report/:id", m.Write, l.Write, ctl.ReportComment)
+	r.POST("/v1/discussion/:resource_id/comment/:id/reaction", m.Write, l.Write, ctl.ToggleCommentReaction)
+	r.POST("/v1/discussion/:resource_id/issue/:id/reaction", m.Write, l.Write, ctl.ToggleIssueReaction)
+
+	r.POST("/v1/discussion/:resource_id/pull", m.Write, l.Write, ctl.CreatePullRequest)
+	r.GET("/v1/discussion/:resource_id/pull/:id", m.Optional, ctl.GetPullRequest)
+	r.POST("/v1/discussion/:resource_id/pull/:id/review", m.Write, l.Write, ctl.ReviewPullRequest)
+	r.PUT("/v1/discussion/:resource_id/pull/:id/merge", m.Write, l.Write, ctl.MergePullRequest)
+	r.PUT("/v1/discussion/:resource_id/pull/:id/close", m.Write, l.Write, ctl.ClosePullRequest)
+	r.PUT("/v1/discussion/:resource_id/pull/:id/reopen", m.Write, l.Write, ctl.ReopenPullRequest)
+
+	r.GET("/v1/discussion/:resource_id/label", m.Optional, ctl.ListLabels)
+	r.POST("/v1/discussion/:resource_id/label", m.Write, l.Write, ctl.CreateLabel)
+	r.PUT("/v1/discussion/:resource_id/label/:id", m.Write, l.Write, ctl.UpdateLabel)
+	r.DELETE("/v1/discussion/:resource_id/label/:id", m.Write, l.Write, ctl.DeleteLabel)
+
+	r.GET("/v1/discussion/:resource_id/issue/template", m.Optional, ctl.ListIssueTemplates)
+	r.POST("/v1/discussion/:resource_id/issue/template", m.Write, l.Write, ctl.CreateIssueTemplate)
+	r.PUT("/v1/discussion/:resource_id/issue/template/:id", m.Write, l.Write, ctl.UpdateIssueTemplate)
+	r.DELETE("/v1/discussion/:resource_id/issue/template/:id", m.Write, l.Write, ctl.DeleteIssueTemplate)
+
+	r.GET("/v1/notification", m.Read, ctl.ListNotifications)
+	r.PUT("/v1/notification/read", m.Write, l.Write, ctl.MarkNotificationsRead)
+	r.GET("/v1/notification/preference", m.Read, ctl.ListNotificationPreferences)
+	r.PUT("/v1/notification/preference", m.Write, l.Write, ctl.UpdateNotificationPreference)
+
+	r.GET("/v1/discussion/:resource_id/watch", m.Read, ctl.GetWatch)
+	r.PUT("/v1/discussion/:resource_id/watch", m.Write, l.Write, ctl.Watch)
+	r.GET("/v1/watch", m.Read, ctl.ListWatches)
+	r.GET("/v1/watch/unsubscribe", ctl.Unsubscribe)
 }
 
 type DiscussionWebController struct {
-	userMiddleWare    middleware.UserMiddleWare
-	issueService      app.IssueService
-	commentService    app.CommentService
-	discussionService app.DiscussionService
+	userMiddleWare       middleware.UserMiddleWare
+	issueService         app.IssueService
+	commentService       app.CommentService
+	discussionService    app.DiscussionService
+	pullRequestService   app.PullRequestService
+	labelService         app.LabelService
+	notificationService  app.NotificationService
+	watchService         app.WatchService
+	issueTemplateService app.IssueTemplateService
 }
 
 // @Summary  Create issue
@@ -245,7 +298,8 @@ func (ctl *DiscussionWebController) OpenDiscussion(ctx *gin.Context) {
 // @Summary  Issue count
 // @Description  get issue count
 // @Tags     DiscussionWeb
-// @Param    resource_id    path    string    true    "id of model/space/datasets"
+// @Param    resource_id    path     string    true     "id of model/space/datasets"
+// @Param    type           query    string    false    "type of issue, issue or pull_request"
 // @Success  200    {object}    commonctl.ResponseData{data=ListIssuesCountDTO,msg=string,code=string}
 // @Router   /v1/discussion/{resource_id}/issue/count [get]
 func (ctl *DiscussionWebController) ListIssuesCount(ctx *gin.Context) {
@@ -256,9 +310,11 @@ func (ctl *DiscussionWebController) ListIssuesCount(ctx *gin.Context) {
 		return
 	}
 
+	t, _ := discussionprimitive.NewIssueType(ctx.Query("type"))
+
 	user := ctl.userMiddleWare.GetUser(ctx)
 
-	data, err := ctl.issueService.ListIssuesCount(ctx.Request.Context(), user, id)
+	data, err := ctl.issueService.ListIssuesCount(ctx.Request.Context(), user, id, t)
 	if err != nil {
 		commonctl.SendError(ctx, err)
 	} else {
@@ -273,6 +329,15 @@ func (ctl *DiscussionWebController) ListIssuesCount(ctx *gin.Context) {
 // @Param    page_num          query    int       false    "page num which starts from 1" Mininum(1)
 // @Param    count_per_page    query    int       false    "count per page" MaxCountPerPage(100)
 // @Param    status            query    string    false    "status of issue"
+// @Param    type              query    string    false    "type of issue, issue or pull_request"
+// @Param    label             query    int       false    "id of label"
+// @Param    assignee          query    string    false    "account of assignee"
+// @Param    keyword           query    string    false    "keyword in the title or comments"
+// @Param    author            query    string    false    "account of author"
+// @Param    commenter         query    string    false    "account of commenter"
+// @Param    created_from      query    string    false    "created on or after the date, like 2006-01-02"
+// @Param    created_to        query    string    false    "created on or before the date, like 2006-01-02"
+// @Param    sort_by           query    string    false    "recently_created, recently_active or most_comments"
 // @Accept   json
 // @Success  200    {object}    commonctl.ResponseData{data=ListIssuesDTO,msg=string,code=string}
 // @Router   /v1/discussion/{resource_id}/issue [get]
@@ -300,6 +365,42 @@ func (ctl *DiscussionWebController) ListIssue(ctx *gin.Context) {
 	}
 }
 
+// @Summary  List issues of user
+// @Description  list the issues of all resources which the user authored, commented or was mentioned in
+// @Tags     DiscussionWeb
+// @Param    relation          query    string    false    "authored, commented or mentioned, default is authored"
+// @Param    page_num          query    int       false    "page num which starts from 1" Mininum(1)
+// @Param    count_per_page    query    int       false    "count per page" MaxCountPerPage(100)
+// @Param    status            query    string    false    "status of issue"
+// @Param    type              query    string    false    "type of issue, issue or pull_request"
+// @Param    keyword           query    string    false    "keyword in the title or comments"
+// @Param    sort_by           query    string    false    "recently_created, recently_active or most_comments"
+// @Accept   json
+// @Security Bearer
+// @Success  200    {object}    commonctl.ResponseData{data=ListIssuesDTO,msg=string,code=string}
+// @Router   /v1/issue [get]
+func (ctl *DiscussionWebController) ListUserIssues(ctx *gin.Context) {
+	var req reqToListUserIssues
+	if err := ctx.BindQuery(&req); err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	cmd, err := req.toCmd(ctl.userMiddleWare.GetUser(ctx))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if data, err := ctl.issueService.ListUserIssues(ctx.Request.Context(), cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, &data)
+	}
+}
+
 // @Summary  Create comment
 // @Description  create comment
 // @Tags     DiscussionWeb
@@ -376,6 +477,43 @@ func (ctl *DiscussionWebController) UpdateComment(ctx *gin.Context) {
 	}
 }
 
+// @Summary  List revisions of comment
+// @Description  list the revisions of comment, only for the maintainers of resource and the moderators
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string    true    "id of model/space/datasets"
+// @Param    id             path    string    true    "id of comment"
+// @Accept   json
+// @Security Bearer
+// @Success  200    {object}    commonctl.ResponseData{data=[]CommentRevisionDTO,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/comment/{id}/revision [get]
+func (ctl *DiscussionWebController) ListCommentRevisions(ctx *gin.Context) {
+	commentId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	id, err := primitive.NewIdentity(ctx.Param("resource_id"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	cmd := app.CmdToListCommentRevisions{
+		User:      ctl.userMiddleWare.GetUser(ctx),
+		Resource:  domain.Resource{Id: id},
+		CommentId: commentId,
+	}
+
+	if data, err := ctl.commentService.ListCommentRevisions(ctx.Request.Context(), cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, data)
+	}
+}
+
 // @Summary  Delete comment
 // @Description  delete comment
 // @Tags     DiscussionWeb
@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	commonctl "github.com/openmerlin/merlin-server/common/controller"
+	"github.com/openmerlin/merlin-server/common/controller/middleware"
+	"github.com/openmerlin/merlin-server/discussion/app"
+)
+
+// @Summary  Lock issue
+// @Description  lock or unlock issue, only the maintainers can comment on the locked issue
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string            true    "id of model/space/datasets"
+// @Param    id             path    string            true    "id of issue"
+// @Param    body           body    reqToLockIssue    true    "body of locking issue"
+// @Accept   json
+// @Security Bearer
+// @Success  202    {object}    commonctl.ResponseData{data=string,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/issue/{id}/lock [put]
+func (ctl *DiscussionWebController) LockIssue(ctx *gin.Context) {
+	issueId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	var req reqToLockIssue
+	if err = ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	middleware.SetAction(ctx, req.action(issueId))
+
+	cmd := app.CmdToLockIssue{Locked: req.Locked}
+	cmd.CmdToCloseIssue, err = toCloseIssueCmd(ctl.userMiddleWare.GetUser(ctx), ctx.Param("resource_id"), issueId)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if err = ctl.issueService.LockIssue(ctx.Request.Context(), cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, nil)
+	}
+}
+
+// @Summary  Pin issue
+// @Description  pin or unpin issue, the pinned issues are listed at the top
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string           true    "id of model/space/datasets"
+// @Param    id             path    string           true    "id of issue"
+// @Param    body           body    reqToPinIssue    true    "body of pinning issue"
+// @Accept   json
+// @Security Bearer
+// @Success  202    {object}    commonctl.ResponseData{data=string,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/issue/{id}/pin [put]
+func (ctl *DiscussionWebController) PinIssue(ctx *gin.Context) {
+	issueId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	var req reqToPinIssue
+	if err = ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	middleware.SetAction(ctx, req.action(issueId))
+
+	cmd := app.CmdToPinIssue{Pinned: req.Pinned}
+	cmd.CmdToCloseIssue, err = toCloseIssueCmd(ctl.userMiddleWare.GetUser(ctx), ctx.Param("resource_id"), issueId)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if err = ctl.issueService.PinIssue(ctx.Request.Context(), cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, nil)
+	}
+}
+
+// @Summary  Mark answer of issue
+// @Description  mark the comment as the answer of issue, or unmark it if comment_id is 0
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string                  true    "id of model/space/datasets"
+// @Param    id             path    string                  true    "id of issue"
+// @Param    body           body    reqToMarkIssueAnswer    true    "body of marking answer"
+// @Accept   json
+// @Security Bearer
+// @Success  202    {object}    commonctl.ResponseData{data=string,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/issue/{id}/answer [put]
+func (ctl *DiscussionWebController) MarkIssueAnswer(ctx *gin.Context) {
+	issueId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	var req reqToMarkIssueAnswer
+	if err = ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	middleware.SetAction(ctx, req.action(issueId))
+
+	cmd := app.CmdToMarkIssueAnswer{CommentId: req.CommentId}
+	cmd.CmdToCloseIssue, err = toCloseIssueCmd(ctl.userMiddleWare.GetUser(ctx), ctx.Param("resource_id"), issueId)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if err = ctl.issueService.MarkIssueAnswer(ctx.Request.Context(), cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, nil)
+	}
+}
@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	commonctl "github.com/openmerlin/merlin-server/common/controller"
+	"github.com/openmerlin/merlin-server/common/controller/middleware"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/app"
+)
+
+// @Summary  List issue templates
+// @Description  list the issue templates of model/space/datasets for the form of creating issue,
+// @Description  including the ones in the code repo and the ones created by the maintainers
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string    true    "id of model/space/datasets"
+// @Accept   json
+// @Success  200    {object}    commonctl.ResponseData{data=[]app.IssueTemplateDTO,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/issue/template [get]
+func (ctl *DiscussionWebController) ListIssueTemplates(ctx *gin.Context) {
+	id, err := primitive.NewIdentity(ctx.Param("resource_id"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	if data, err := ctl.issueTemplateService.ListIssueTemplates(ctx.Request.Context(), user, id); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, data)
+	}
+}
+
+// @Summary  Create issue template
+// @Description  create issue template of model/space/datasets
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string                      true    "id of model/space/datasets"
+// @Param    body           body    reqToCreateIssueTemplate    true    "body of creating issue template"
+// @Accept   json
+// @Security Bearer
+// @Success  201    {object}    commonctl.ResponseData{data=app.IssueTemplateDTO,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/issue/template [post]
+func (ctl *DiscussionWebController) CreateIssueTemplate(ctx *gin.Context) {
+	middleware.SetAction(ctx, "create issue template")
+
+	var req reqToCreateIssueTemplate
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	middleware.SetAction(ctx, req.action())
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	cmd, err := req.toCmd(user, ctx.Param("resource_id"))
+	if err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	if dto, err := ctl.issueTemplateService.CreateIssueTemplate(ctx.Request.Context(), cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, &dto)
+	}
+}
+
+// @Summary  Update issue template
+// @Description  update issue template of model/space/datasets
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string                      true    "id of model/space/datasets"
+// @Param    id             path    string                      true    "id of issue template"
+// @Param    body           body    reqToCreateIssueTemplate    true    "body of updating issue template"
+// @Accept   json
+// @Security Bearer
+// @Success  202    {object}    commonctl.ResponseData{data=string,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/issue/template/{id} [put]
+func (ctl *DiscussionWebController) UpdateIssueTemplate(ctx *gin.Context) {
+	templateId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	middleware.SetAction(ctx, fmt.Sprintf("update issue template %d", templateId))
+
+	var req reqToCreateIssueTemplate
+	if err = ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	cmd, err := req.toCmd(user, ctx.Param("resource_id"))
+	if err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	cmdToUpdate := app.CmdToUpdateIssueTemplate{
+		CmdToCreateIssueTemplate: cmd,
+		TemplateId:               templateId,
+	}
+
+	if err = ctl.issueTemplateService.UpdateIssueTemplate(ctx.Request.Context(), cmdToUpdate); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, nil)
+	}
+}
+
+// @Summary  Delete issue template
+// @Description  delete issue template of model/space/datasets
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string    true    "id of model/space/datasets"
+// @Param    id             path    string    true    "id of issue template"
+// @Security Bearer
+// @Success  204    {object}    commonctl.ResponseData{data=string,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/issue/template/{id} [delete]
+func (ctl *DiscussionWebController) DeleteIssueTemplate(ctx *gin.Context) {
+	templateId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	middleware.SetAction(ctx, fmt.Sprintf("delete issue template %d", templateId))
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	cmd, err := toDeleteIssueTemplateCmd(user, ctx.Param("resource_id"), templateId)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if err = ctl.issueTemplateService.DeleteIssueTemplate(ctx.Request.Context(), cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfDelete(ctx)
+	}
+}
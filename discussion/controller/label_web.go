@@ -0,0 +1,237 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	commonctl "github.com/openmerlin/merlin-server/common/controller"
+	"github.com/openmerlin/merlin-server/common/controller/middleware"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/app"
+)
+
+// @Summary  List labels
+// @Description  list labels of model/space/datasets
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string    true    "id of model/space/datasets"
+// @Accept   json
+// @Success  200    {object}    commonctl.ResponseData{data=[]LabelDTO,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/label [get]
+func (ctl *DiscussionWebController) ListLabels(ctx *gin.Context) {
+	id, err := primitive.NewIdentity(ctx.Param("resource_id"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	if data, err := ctl.labelService.ListLabels(ctx.Request.Context(), user, id); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, data)
+	}
+}
+
+// @Summary  Create label
+// @Description  create label of model/space/datasets
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string              true    "id of model/space/datasets"
+// @Param    body           body    reqToCreateLabel    true    "body of creating label"
+// @Accept   json
+// @Security Bearer
+// @Success  201    {object}    commonctl.ResponseData{data=LabelDTO,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/label [post]
+func (ctl *DiscussionWebController) CreateLabel(ctx *gin.Context) {
+	middleware.SetAction(ctx, "create label")
+
+	var req reqToCreateLabel
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	middleware.SetAction(ctx, req.action())
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	cmd, err := req.toCreateLabelCmd(user, ctx.Param("resource_id"))
+	if err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	if dto, err := ctl.labelService.CreateLabel(ctx.Request.Context(), cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, &dto)
+	}
+}
+
+// @Summary  Update label
+// @Description  update label of model/space/datasets
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string              true    "id of model/space/datasets"
+// @Param    id             path    string              true    "id of label"
+// @Param    body           body    reqToCreateLabel    true    "body of updating label"
+// @Accept   json
+// @Security Bearer
+// @Success  202    {object}    commonctl.ResponseData{data=string,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/label/{id} [put]
+func (ctl *DiscussionWebController) UpdateLabel(ctx *gin.Context) {
+	labelId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	middleware.SetAction(ctx, fmt.Sprintf("update label %d", labelId))
+
+	var req reqToCreateLabel
+	if err = ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	cmd, err := req.toCreateLabelCmd(user, ctx.Param("resource_id"))
+	if err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	cmdToUpdate := app.CmdToUpdateLabel{
+		CmdToCreateLabel: cmd,
+		LabelId:          labelId,
+	}
+
+	if err = ctl.labelService.UpdateLabel(ctx.Request.Context(), cmdToUpdate); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, nil)
+	}
+}
+
+// @Summary  Delete label
+// @Description  delete label of model/space/datasets
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string    true    "id of model/space/datasets"
+// @Param    id             path    string    true    "id of label"
+// @Security Bearer
+// @Success  204    {object}    commonctl.ResponseData{data=string,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/label/{id} [delete]
+func (ctl *DiscussionWebController) DeleteLabel(ctx *gin.Context) {
+	labelId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	middleware.SetAction(ctx, fmt.Sprintf("delete label %d", labelId))
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	cmd, err := toDeleteLabelCmd(user, ctx.Param("resource_id"), labelId)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if err = ctl.labelService.DeleteLabel(ctx.Request.Context(), cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfDelete(ctx)
+	}
+}
+
+// @Summary  Set labels of issue
+// @Description  replace the labels of issue
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string                 true    "id of model/space/datasets"
+// @Param    id             path    string                 true    "id of issue"
+// @Param    body           body    reqToSetIssueLabels    true    "body of setting labels"
+// @Accept   json
+// @Security Bearer
+// @Success  202    {object}    commonctl.ResponseData{data=string,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/issue/{id}/label [put]
+func (ctl *DiscussionWebController) SetIssueLabels(ctx *gin.Context) {
+	issueId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	middleware.SetAction(ctx, fmt.Sprintf("set labels of issue %d", issueId))
+
+	var req reqToSetIssueLabels
+	if err = ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	cmd, err := req.toSetIssueLabelsCmd(user, ctx.Param("resource_id"), issueId)
+	if err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	if err = ctl.issueService.SetIssueLabels(ctx.Request.Context(), cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, nil)
+	}
+}
+
+// @Summary  Set assignees of issue
+// @Description  replace the assignees of issue, the assignees must have write permission
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string                    true    "id of model/space/datasets"
+// @Param    id             path    string                    true    "id of issue"
+// @Param    body           body    reqToSetIssueAssignees    true    "body of setting assignees"
+// @Accept   json
+// @Security Bearer
+// @Success  202    {object}    commonctl.ResponseData{data=string,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/issue/{id}/assignee [put]
+func (ctl *DiscussionWebController) SetIssueAssignees(ctx *gin.Context) {
+	issueId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	middleware.SetAction(ctx, fmt.Sprintf("set assignees of issue %d", issueId))
+
+	var req reqToSetIssueAssignees
+	if err = ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	cmd, err := req.toSetIssueAssigneesCmd(user, ctx.Param("resource_id"), issueId)
+	if err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	if err = ctl.issueService.SetIssueAssignees(ctx.Request.Context(), cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, nil)
+	}
+}
@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	commonctl "github.com/openmerlin/merlin-server/common/controller"
+	"github.com/openmerlin/merlin-server/common/controller/middleware"
+)
+
+// @Summary  List notifications
+// @Description  list discussion notifications of user
+// @Tags     DiscussionWeb
+// @Param    page_num          query    int       false    "page num which starts from 1" Mininum(1)
+// @Param    count_per_page    query    int       false    "count per page" MaxCountPerPage(100)
+// @Param    read              query    bool      false    "list the read or unread notifications only"
+// @Accept   json
+// @Security Bearer
+// @Success  200    {object}    commonctl.ResponseData{data=ListNotificationsDTO,msg=string,code=string}
+// @Router   /v1/notification [get]
+func (ctl *DiscussionWebController) ListNotifications(ctx *gin.Context) {
+	var req reqToListNotifications
+	if err := ctx.BindQuery(&req); err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	cmd, err := req.toListNotificationsCmd(ctl.userMiddleWare.GetUser(ctx))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if data, err := ctl.notificationService.ListNotifications(cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, &data)
+	}
+}
+
+// @Summary  Mark notifications read
+// @Description  mark the notifications or all of them read
+// @Tags     DiscussionWeb
+// @Param    body    body    reqToMarkNotificationsRead    true    "body of marking notifications read"
+// @Accept   json
+// @Security Bearer
+// @Success  202    {object}    commonctl.ResponseData{data=nil,msg=string,code=string}
+// @Router   /v1/notification/read [put]
+func (ctl *DiscussionWebController) MarkNotificationsRead(ctx *gin.Context) {
+	middleware.SetAction(ctx, "mark notifications read")
+
+	var req reqToMarkNotificationsRead
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	cmd, err := req.toCmd(ctl.userMiddleWare.GetUser(ctx))
+	if err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	if err := ctl.notificationService.MarkNotificationsRead(cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, nil)
+	}
+}
+
+// @Summary  List notification preferences
+// @Description  list the delivery preferences of all notification types
+// @Tags     DiscussionWeb
+// @Accept   json
+// @Security Bearer
+// @Success  200    {object}    commonctl.ResponseData{data=[]NotificationPreferenceDTO,msg=string,code=string}
+// @Router   /v1/notification/preference [get]
+func (ctl *DiscussionWebController) ListNotificationPreferences(ctx *gin.Context) {
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	if data, err := ctl.notificationService.ListNotificationPreferences(user); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, data)
+	}
+}
+
+// @Summary  Update notification preference
+// @Description  update the delivery preference of notification type
+// @Tags     DiscussionWeb
+// @Param    body    body    reqToUpdateNotificationPreference    true    "body of updating preference"
+// @Accept   json
+// @Security Bearer
+// @Success  202    {object}    commonctl.ResponseData{data=nil,msg=string,code=string}
+// @Router   /v1/notification/preference [put]
+func (ctl *DiscussionWebController) UpdateNotificationPreference(ctx *gin.Context) {
+	middleware.SetAction(ctx, "update notification preference")
+
+	var req reqToUpdateNotificationPreference
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	middleware.SetAction(ctx, req.action())
+
+	cmd, err := req.toCmd(ctl.userMiddleWare.GetUser(ctx))
+	if err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	if err := ctl.notificationService.UpdateNotificationPreference(&cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, nil)
+	}
+}
@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	commonctl "github.com/openmerlin/merlin-server/common/controller"
+	"github.com/openmerlin/merlin-server/common/controller/middleware"
+	"github.com/openmerlin/merlin-server/discussion/app"
+)
+
+// @Summary  Create pull request
+// @Description  create pull request from the source branch to the target branch
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string                    true    "id of model/space/datasets"
+// @Param    body           body    reqToCreatePullRequest    true    "body of creating pull request"
+// @Accept   json
+// @Security Bearer
+// @Success  201    {object}    commonctl.ResponseData{data=string,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/pull [post]
+func (ctl *DiscussionWebController) CreatePullRequest(ctx *gin.Context) {
+	middleware.SetAction(ctx, "create pull request")
+
+	req := reqToCreatePullRequest{}
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	middleware.SetAction(ctx, req.action())
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	cmd, err := req.toCreatePullRequestCmd(ctx.Param("resource_id"), user)
+	if err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	if err = ctl.pullRequestService.CreatePullRequest(ctx.Request.Context(), cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, nil)
+	}
+}
+
+// @Summary  Get pull request
+// @Description  get pull request with the diff summary
+// @Tags     DiscussionWeb
+// @Param    resource_id       path     string    true     "id of model/space/datasets"
+// @Param    id                path     string    true     "id of pull request"
+// @Param    page_num          query    int       false    "page num which starts from 1" Mininum(1)
+// @Param    count_per_page    query    int       false    "count per page" MaxCountPerPage(100)
+// @Accept   json
+// @Success  200    {object}    commonctl.ResponseData{data=PullRequestDetailDTO,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/pull/{id} [get]
+func (ctl *DiscussionWebController) GetPullRequest(ctx *gin.Context) {
+	issueId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	var req reqToGetIssue
+	if err = ctx.BindQuery(&req); err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	cmd, err := req.toGetIssueCmd(user, ctx.Param("resource_id"), issueId)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	dto, err := ctl.pullRequestService.GetPullRequest(ctx.Request.Context(), cmd)
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, &dto)
+	}
+}
+
+// @Summary  Review pull request
+// @Description  approve or request changes of pull request
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string                    true    "id of model/space/datasets"
+// @Param    id             path    string                    true    "id of pull request"
+// @Param    body           body    reqToReviewPullRequest    true    "body of reviewing pull request"
+// @Accept   json
+// @Security Bearer
+// @Success  201    {object}    commonctl.ResponseData{data=string,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/pull/{id}/review [post]
+func (ctl *DiscussionWebController) ReviewPullRequest(ctx *gin.Context) {
+	issueId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	middleware.SetAction(ctx, fmt.Sprintf("review pull request %d", issueId))
+
+	var req reqToReviewPullRequest
+	if err = ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	cmd, err := req.toReviewPullRequestCmd(user, ctx.Param("resource_id"), issueId)
+	if err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	if err = ctl.pullRequestService.ReviewPullRequest(ctx.Request.Context(), cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, nil)
+	}
+}
+
+// @Summary  Merge pull request
+// @Description  merge pull request
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string    true    "id of model/space/datasets"
+// @Param    id             path    string    true    "id of pull request"
+// @Success  202    {object}    commonctl.ResponseData{data=string,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/pull/{id}/merge [put]
+func (ctl *DiscussionWebController) MergePullRequest(ctx *gin.Context) {
+	ctl.changePullRequest(ctx, "merge", ctl.pullRequestService.MergePullRequest)
+}
+
+// @Summary  Close pull request
+// @Description  close pull request
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string    true    "id of model/space/datasets"
+// @Param    id             path    string    true    "id of pull request"
+// @Success  202    {object}    commonctl.ResponseData{data=string,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/pull/{id}/close [put]
+func (ctl *DiscussionWebController) ClosePullRequest(ctx *gin.Context) {
+	ctl.changePullRequest(ctx, "close", ctl.pullRequestService.ClosePullRequest)
+}
+
+// @Summary  Reopen pull request
+// @Description  reopen pull request
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string    true    "id of model/space/datasets"
+// @Param    id             path    string    true    "id of pull request"
+// @Success  202    {object}    commonctl.ResponseData{data=string,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/pull/{id}/reopen [put]
+func (ctl *DiscussionWebController) ReopenPullRequest(ctx *gin.Context) {
+	ctl.changePullRequest(ctx, "reopen", ctl.pullRequestService.ReopenPullRequest)
+}
+
+func (ctl *DiscussionWebController) changePullRequest(
+	ctx *gin.Context, op string, handle func(context.Context, app.CmdToMergePullRequest) error,
+) {
+	issueId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	middleware.SetAction(ctx, fmt.Sprintf("%s pull request %d", op, issueId))
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	cmd, err := toCloseIssueCmd(user, ctx.Param("resource_id"), issueId)
+	if err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	if err = handle(ctx.Request.Context(), cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, nil)
+	}
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	commonctl "github.com/openmerlin/merlin-server/common/controller"
+	"github.com/openmerlin/merlin-server/common/controller/middleware"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+)
+
+// @Summary  Toggle reaction of issue
+// @Description  add the emoji reaction to issue, or remove it if the user has reacted
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string                 true    "id of model/space/datasets"
+// @Param    id             path    string                 true    "id of issue"
+// @Param    body           body    reqToToggleReaction    true    "body of toggling reaction"
+// @Accept   json
+// @Security Bearer
+// @Success  201    {object}    commonctl.ResponseData{data=[]ReactionDTO,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/issue/{id}/reaction [post]
+func (ctl *DiscussionWebController) ToggleIssueReaction(ctx *gin.Context) {
+	ctl.toggleReaction(ctx, domain.ReactionTargetIssue)
+}
+
+// @Summary  Toggle reaction of comment
+// @Description  add the emoji reaction to comment, or remove it if the user has reacted
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string                 true    "id of model/space/datasets"
+// @Param    id             path    string                 true    "id of comment"
+// @Param    body           body    reqToToggleReaction    true    "body of toggling reaction"
+// @Accept   json
+// @Security Bearer
+// @Success  201    {object}    commonctl.ResponseData{data=[]ReactionDTO,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/comment/{id}/reaction [post]
+func (ctl *DiscussionWebController) ToggleCommentReaction(ctx *gin.Context) {
+	ctl.toggleReaction(ctx, domain.ReactionTargetComment)
+}
+
+func (ctl *DiscussionWebController) toggleReaction(ctx *gin.Context, targetType string) {
+	targetId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	middleware.SetAction(ctx, fmt.Sprintf("toggle reaction of %s %d", targetType, targetId))
+
+	var req reqToToggleReaction
+	if err = ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	cmd, err := req.toToggleReactionCmd(user, ctx.Param("resource_id"), domain.ReactionTarget{
+		Type: targetType,
+		Id:   targetId,
+	})
+	if err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	if data, err := ctl.commentService.ToggleReaction(ctx.Request.Context(), cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, data)
+	}
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	commonctl "github.com/openmerlin/merlin-server/common/controller"
+	"github.com/openmerlin/merlin-server/common/controller/middleware"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+)
+
+// @Summary  Watch resource
+// @Description  set the watch level of resource and the frequency of digest
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string        true    "id of model/space/datasets"
+// @Param    body           body    reqToWatch    true    "body of watching resource"
+// @Accept   json
+// @Security Bearer
+// @Success  202    {object}    commonctl.ResponseData{data=nil,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/watch [put]
+func (ctl *DiscussionWebController) Watch(ctx *gin.Context) {
+	middleware.SetAction(ctx, "watch resource")
+
+	var req reqToWatch
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	middleware.SetAction(ctx, req.action(ctx.Param("resource_id")))
+
+	cmd, err := req.toCmd(ctl.userMiddleWare.GetUser(ctx), ctx.Param("resource_id"))
+	if err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	if err := ctl.watchService.Watch(ctx.Request.Context(), &cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, nil)
+	}
+}
+
+// @Summary  Get watch
+// @Description  get the watch of resource by user
+// @Tags     DiscussionWeb
+// @Param    resource_id    path    string    true    "id of model/space/datasets"
+// @Accept   json
+// @Security Bearer
+// @Success  200    {object}    commonctl.ResponseData{data=app.WatchDTO,msg=string,code=string}
+// @Router   /v1/discussion/{resource_id}/watch [get]
+func (ctl *DiscussionWebController) GetWatch(ctx *gin.Context) {
+	resourceId, err := primitive.NewIdentity(ctx.Param("resource_id"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	if data, err := ctl.watchService.GetWatch(ctx.Request.Context(), user, resourceId); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, &data)
+	}
+}
+
+// @Summary  List watches
+// @Description  list the resources watched by user
+// @Tags     DiscussionWeb
+// @Accept   json
+// @Security Bearer
+// @Success  200    {object}    commonctl.ResponseData{data=[]app.WatchDTO,msg=string,code=string}
+// @Router   /v1/watch [get]
+func (ctl *DiscussionWebController) ListWatches(ctx *gin.Context) {
+	if data, err := ctl.watchService.ListWatches(ctl.userMiddleWare.GetUser(ctx)); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, data)
+	}
+}
+
+// @Summary  Unsubscribe
+// @Description  unsubscribe the digest by the token in the digest email without login
+// @Tags     DiscussionWeb
+// @Param    token    query    string    true    "token of unsubscribing"
+// @Accept   json
+// @Success  202    {object}    commonctl.ResponseData{data=nil,msg=string,code=string}
+// @Router   /v1/watch/unsubscribe [get]
+func (ctl *DiscussionWebController) Unsubscribe(ctx *gin.Context) {
+	token := ctx.Query("token")
+	if token == "" {
+		commonctl.SendBadRequestParam(ctx, errors.New("missing token"))
+
+		return
+	}
+
+	if err := ctl.watchService.Unsubscribe(ctx.Request.Context(), token); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, nil)
+	}
+}
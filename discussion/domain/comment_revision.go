@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+)
+
+// CommentRevision is a version of the content of comment.
+type CommentRevision struct {
+	Id        int64
+	CommentId int64
+	Content   discussionprimitive.CommentContent
+	Editor    primitive.Account
+	CreatedAt time.Time
+}
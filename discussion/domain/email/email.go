@@ -3,6 +3,7 @@ package email
 import (
 	"github.com/openmerlin/merlin-server/coderepo/domain"
 	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	discussiondomain "github.com/openmerlin/merlin-server/discussion/domain"
 	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
 )
 
@@ -18,4 +19,31 @@ type ReportEmailParam struct {
 
 type Email interface {
 	SendReportEmail(param ReportEmailParam) error
+	SendNotificationEmail(param NotificationEmailParam) error
+	SendDigestEmail(param DigestEmailParam) error
+}
+
+type NotificationEmailParam struct {
+	Receiver     []string
+	Actor        primitive.Account
+	Type         discussionprimitive.NotificationType
+	ResourceType string
+	ResourcePath string
+	IssueId      int64
+	Title        string
+}
+
+type DigestEmailParam struct {
+	Receiver  []string
+	User      primitive.Account
+	Frequency discussionprimitive.DigestFrequency
+	Resources []DigestResource
+}
+
+// DigestResource is the activity on a watched resource since the last digest.
+type DigestResource struct {
+	ResourceType     string
+	ResourcePath     string
+	UnsubscribeToken string
+	Events           []discussiondomain.WatchEvent
 }
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"strconv"
 	"time"
 
 	"golang.org/x/xerrors"
@@ -14,17 +15,42 @@ import (
 const (
 	operationReopen = "reopen"
 	operationClose  = "close"
+	operationMerge  = "merge"
+
+	operationAddLabel    = "add_label"
+	operationRemoveLabel = "remove_label"
+	operationAssign      = "assign"
+	operationUnassign    = "unassign"
+
+	operationLock         = "lock"
+	operationUnlock       = "unlock"
+	operationPin          = "pin"
+	operationUnpin        = "unpin"
+	operationMarkAnswer   = "mark_answer"
+	operationUnmarkAnswer = "unmark_answer"
+
+	maxIssueLabels    = 10
+	maxIssueAssignees = 10
 )
 
 type Issue struct {
 	Id           int64
 	Title        discussionprimitive.IssueTitle
+	Type         discussionprimitive.IssueType
 	Author       primitive.Account
 	Status       discussionprimitive.IssueStatus
 	Operation    []Operation
 	Resource     Resource
+	Labels       []int64
+	Assignees    []primitive.Account
 	CommentCount int64
 	CreatedAt    time.Time
+
+	// Locked issue can only be commented by the maintainers
+	Locked bool
+	Pinned bool
+	// Answer is the id of comment accepted as the answer, 0 if there is not
+	Answer int64
 }
 
 type Resource struct {
@@ -35,6 +61,7 @@ type Resource struct {
 type Operation struct {
 	User      string
 	Action    string
+	Detail    string
 	CreatedAt time.Time
 }
 
@@ -45,12 +72,28 @@ func NewIssue(
 ) Issue {
 	return Issue{
 		Title:    title,
+		Type:     discussionprimitive.IssueTypeIssue,
 		Author:   author,
 		Status:   discussionprimitive.IssueStatusOpen,
 		Resource: resource,
 	}
 }
 
+func NewPullRequestIssue(
+	resource Resource,
+	author primitive.Account,
+	title discussionprimitive.IssueTitle,
+) Issue {
+	issue := NewIssue(resource, author, title)
+	issue.Type = discussionprimitive.IssueTypePullRequest
+
+	return issue
+}
+
+func (i *Issue) IsPullRequest() bool {
+	return i.Type != nil && i.Type.IsPullRequest()
+}
+
 func (i *Issue) Close(user primitive.Account) error {
 	if !i.Status.IsOpen() {
 		return allerror.New(
@@ -72,6 +115,14 @@ func (i *Issue) Close(user primitive.Account) error {
 }
 
 func (i *Issue) Reopen(user primitive.Account) error {
+	if i.Status.IsMerged() {
+		return allerror.New(
+			allerror.ErrorCodePullRequestMerged,
+			"failed to reopen pull request",
+			errors.New("pull request is merged"),
+		)
+	}
+
 	if i.Status.IsOpen() {
 		return allerror.New(
 			allerror.ErrorCodeIssueIsOpen,
@@ -91,6 +142,213 @@ func (i *Issue) Reopen(user primitive.Account) error {
 	return nil
 }
 
+func (i *Issue) Merge(user primitive.Account) error {
+	if !i.IsPullRequest() {
+		return allerror.New(
+			allerror.ErrorCodePullRequestNotFound,
+			"not a pull request",
+			errors.New("issue is not a pull request"),
+		)
+	}
+
+	if !i.Status.IsOpen() {
+		return allerror.New(
+			allerror.ErrorCodeIssueClosed,
+			"failed to merge pull request",
+			errors.New("pull request is not open"),
+		)
+	}
+
+	i.Status = discussionprimitive.IssueStatusMerged
+
+	i.Operation = append(i.Operation, Operation{
+		User:      user.Account(),
+		Action:    operationMerge,
+		CreatedAt: time.Now(),
+	})
+
+	return nil
+}
+
+// Review is done by the maintainers.
+func (i *Issue) Review(user primitive.Account, action discussionprimitive.ReviewAction) error {
+	if err := i.AllowComment(true); err != nil {
+		return err
+	}
+
+	i.Operation = append(i.Operation, Operation{
+		User:      user.Account(),
+		Action:    action.ReviewAction(),
+		CreatedAt: time.Now(),
+	})
+
+	return nil
+}
+
+// SetLabels replaces the labels of issue with the selected ones which must be defined by the resource,
+// the labels removed from the definitions are dropped silently.
+func (i *Issue) SetLabels(user primitive.Account, selected []int64, defined []Label) error {
+	if len(selected) > maxIssueLabels {
+		return allerror.NewInvalidParam("too many labels", xerrors.Errorf("labels exceed %d", maxIssueLabels))
+	}
+
+	names := make(map[int64]string, len(defined))
+	for _, l := range defined {
+		names[l.Id] = l.Name.LabelName()
+	}
+
+	now := time.Now()
+	chosen := make(map[int64]bool, len(selected))
+	labels := make([]int64, 0, len(selected))
+
+	for _, id := range selected {
+		if _, ok := names[id]; !ok {
+			return allerror.NewInvalidParam("invalid label", xerrors.Errorf("label %d is not defined", id))
+		}
+
+		if !chosen[id] {
+			chosen[id] = true
+			labels = append(labels, id)
+		}
+	}
+
+	for _, id := range i.Labels {
+		if name, ok := names[id]; ok && !chosen[id] {
+			i.addOperation(user, operationRemoveLabel, name, now)
+		}
+
+		delete(chosen, id)
+	}
+
+	for _, id := range labels {
+		if chosen[id] {
+			i.addOperation(user, operationAddLabel, names[id], now)
+		}
+	}
+
+	i.Labels = labels
+
+	return nil
+}
+
+// SetAssignees replaces the assignees of issue, the assignees must be checked to have write permission.
+func (i *Issue) SetAssignees(user primitive.Account, assignees []primitive.Account) error {
+	if len(assignees) > maxIssueAssignees {
+		return allerror.NewInvalidParam("too many assignees",
+			xerrors.Errorf("assignees exceed %d", maxIssueAssignees))
+	}
+
+	now := time.Now()
+	chosen := make(map[string]bool, len(assignees))
+	v := make([]primitive.Account, 0, len(assignees))
+
+	for _, a := range assignees {
+		if !chosen[a.Account()] {
+			chosen[a.Account()] = true
+			v = append(v, a)
+		}
+	}
+
+	for _, a := range i.Assignees {
+		if !chosen[a.Account()] {
+			i.addOperation(user, operationUnassign, a.Account(), now)
+		}
+
+		delete(chosen, a.Account())
+	}
+
+	for _, a := range v {
+		if chosen[a.Account()] {
+			i.addOperation(user, operationAssign, a.Account(), now)
+		}
+	}
+
+	i.Assignees = v
+
+	return nil
+}
+
+// SetLocked locks or unlocks the issue, nothing is changed if it is locked or unlocked already.
+func (i *Issue) SetLocked(user primitive.Account, locked bool) {
+	if i.Locked == locked {
+		return
+	}
+
+	i.Locked = locked
+
+	action := operationUnlock
+	if locked {
+		action = operationLock
+	}
+
+	i.addOperation(user, action, "", time.Now())
+}
+
+// SetPinned pins or unpins the issue, nothing is changed if it is pinned or unpinned already.
+func (i *Issue) SetPinned(user primitive.Account, pinned bool) {
+	if i.Pinned == pinned {
+		return
+	}
+
+	i.Pinned = pinned
+
+	action := operationUnpin
+	if pinned {
+		action = operationPin
+	}
+
+	i.addOperation(user, action, "", time.Now())
+}
+
+// MarkAnswer accepts the comment as the answer of issue, the previous one is replaced.
+func (i *Issue) MarkAnswer(user primitive.Account, comment *IssueComment) error {
+	if i.IsPullRequest() {
+		return allerror.New(
+			allerror.ErrorCodeIssueIsPullRequest,
+			"pull request has no answer",
+			errors.New("can't mark answer of pull request"),
+		)
+	}
+
+	if comment.IssueId != i.Id || comment.IsFirstComment {
+		return allerror.NewInvalidParam(
+			"invalid answer",
+			xerrors.Errorf("comment %d can't be the answer of issue %d", comment.Id, i.Id),
+		)
+	}
+
+	if i.Answer == comment.Id {
+		return nil
+	}
+
+	i.Answer = comment.Id
+	i.addOperation(user, operationMarkAnswer, strconv.FormatInt(comment.Id, 10), time.Now())
+
+	return nil
+}
+
+func (i *Issue) UnmarkAnswer(user primitive.Account) {
+	if i.Answer == 0 {
+		return
+	}
+
+	i.addOperation(user, operationUnmarkAnswer, strconv.FormatInt(i.Answer, 10), time.Now())
+	i.Answer = 0
+}
+
+func (i *Issue) IsAnswer(commentId int64) bool {
+	return i.Answer != 0 && i.Answer == commentId
+}
+
+func (i *Issue) addOperation(user primitive.Account, action, detail string, now time.Time) {
+	i.Operation = append(i.Operation, Operation{
+		User:      user.Account(),
+		Action:    action,
+		Detail:    detail,
+		CreatedAt: now,
+	})
+}
+
 func (i *Issue) IsStatusChanged(status discussionprimitive.IssueStatus) bool {
 	return status != i.Status
 }
@@ -103,16 +361,26 @@ func (i *Issue) SetCommentCount(count int64) {
 	i.CommentCount = count
 }
 
-func (i *Issue) AllowComment() error {
-	if i.Status.IsOpen() {
-		return nil
+// AllowComment checks whether the issue can be commented, the locked issue can only be commented
+// by the maintainers.
+func (i *Issue) AllowComment(isMaintainer bool) error {
+	if !i.Status.IsOpen() {
+		return allerror.New(
+			allerror.ErrorCodeIssueClosed,
+			"issue is closed",
+			xerrors.Errorf("issue is closed, cant comment"),
+		)
 	}
 
-	return allerror.New(
-		allerror.ErrorCodeIssueClosed,
-		"issue is closed",
-		xerrors.Errorf("issue is closed, cant comment"),
-	)
+	if i.Locked && !isMaintainer {
+		return allerror.New(
+			allerror.ErrorCodeIssueLocked,
+			"issue is locked",
+			xerrors.Errorf("issue is locked, only maintainers can comment"),
+		)
+	}
+
+	return nil
 }
 
 func (i *Issue) IsIssueAuthor(user primitive.Account) bool {
@@ -13,11 +13,15 @@ import (
 type IssueComment struct {
 	Id             int64
 	Author         primitive.Account
-	emojis         []Emoji
 	IssueId        int64
+	ReplyTo        int64
 	Content        discussionprimitive.CommentContent
 	CreatedAt      time.Time
 	IsFirstComment bool
+	// EditedAt is zero if the comment has never been edited
+	EditedAt time.Time
+	// Hidden comment is hidden by the admin for the illegal content
+	Hidden bool
 }
 
 func NewFirstIssueComment(author primitive.Account, issueId int64, content discussionprimitive.CommentContent,
@@ -39,50 +43,76 @@ func NewIssueComment(author primitive.Account, issueId int64, content discussion
 	}
 }
 
-func (c *IssueComment) AddEmoji(emojiType discussionprimitive.EmojiType, user primitive.Account) {
-	for k := range c.emojis {
-		if c.emojis[k].addUser(emojiType, user) {
-			return
-		}
+// ReplyOf makes the comment a reply of parent, the replies are nested in one level,
+// so replying to a reply is attached to the comment it replies.
+func (c *IssueComment) ReplyOf(parent *IssueComment) error {
+	if parent.IssueId != c.IssueId {
+		return allerror.NewInvalidParam("invalid reply", errors.New("not the comment of the same issue"))
 	}
 
-	c.emojis = append(c.emojis, Emoji{
-		Type:  emojiType,
-		Users: []primitive.Account{user},
-	})
+	c.ReplyTo = parent.Id
+	if parent.IsReply() {
+		c.ReplyTo = parent.ReplyTo
+	}
+
+	return nil
+}
+
+func (c *IssueComment) IsReply() bool {
+	return c.ReplyTo > 0
 }
 
 func (c *IssueComment) IsCommentOwner(user primitive.Account) bool {
 	return c.Author == user
 }
 
-func (c *IssueComment) UpdateContent(user primitive.Account, content discussionprimitive.CommentContent) error {
+// UpdateContent updates the content and returns the revisions to be kept,
+// the original content is kept as the first revision when the comment is edited for the first time.
+func (c *IssueComment) UpdateContent(user primitive.Account, content discussionprimitive.CommentContent,
+) ([]CommentRevision, error) {
 	if !c.IsCommentOwner(user) {
-		return allerror.NewNoPermission("no permission", errors.New("not comment owner"))
+		return nil, allerror.NewNoPermission("no permission", errors.New("not comment owner"))
 	}
 
-	c.Content = content
+	if c.Hidden {
+		return nil, allerror.NewNoPermission("no permission", errors.New("comment is hidden"))
+	}
 
-	return nil
-}
+	if c.Content.CommentContent() == content.CommentContent() {
+		return nil, nil
+	}
 
-func (c *IssueComment) IsFirstCommentOfIssue() bool {
-	return c.IsFirstComment
-}
+	now := time.Now()
+	v := make([]CommentRevision, 0, 2)
 
-type Emoji struct {
-	Type  discussionprimitive.EmojiType
-	Users []primitive.Account
-}
+	if !c.IsEdited() {
+		v = append(v, CommentRevision{
+			CommentId: c.Id,
+			Content:   c.Content,
+			Editor:    c.Author,
+			CreatedAt: c.CreatedAt,
+		})
+	}
 
-func (e *Emoji) addUser(t discussionprimitive.EmojiType, user primitive.Account) bool {
-	if e.Type == t {
-		e.Users = append(e.Users, user)
+	v = append(v, CommentRevision{
+		CommentId: c.Id,
+		Content:   content,
+		Editor:    user,
+		CreatedAt: now,
+	})
 
-		return true
-	}
+	c.Content = content
+	c.EditedAt = now
 
-	return false
+	return v, nil
+}
+
+func (c *IssueComment) IsEdited() bool {
+	return !c.EditedAt.IsZero()
+}
+
+func (c *IssueComment) IsFirstCommentOfIssue() bool {
+	return c.IsFirstComment
 }
 
 type updateCommentCountEvent struct {
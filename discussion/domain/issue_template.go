@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// IssueTemplate is the form which the users fill in when opening an issue, it is defined
+// in the code repo or by the maintainers through the api.
+type IssueTemplate struct {
+	// Id is 0 if it is defined in the code repo
+	Id          int64
+	Resource    Resource
+	Name        string
+	Description string
+	// Title is the default title of issue
+	Title  string
+	Fields []IssueTemplateField
+}
+
+type IssueTemplateField struct {
+	Id          string `json:"id"`
+	Label       string `json:"label"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+}
+
+func (t *IssueTemplate) InRepo() bool {
+	return t.Id == 0
+}
+
+func (t *IssueTemplate) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return errors.New("missing template name")
+	}
+
+	if len(t.Fields) == 0 {
+		return errors.New("missing template fields")
+	}
+
+	ids := make(map[string]bool, len(t.Fields))
+
+	for i := range t.Fields {
+		f := &t.Fields[i]
+
+		if f.Id == "" || f.Label == "" {
+			return errors.New("missing id or label of template field")
+		}
+
+		if ids[f.Id] {
+			return fmt.Errorf("duplicate template field %s", f.Id)
+		}
+
+		ids[f.Id] = true
+	}
+
+	return nil
+}
+
+// Compose validates the values of fields submitted and composes the content of issue by them
+// in the order of fields.
+func (t *IssueTemplate) Compose(values map[string]string) (string, error) {
+	fields := make(map[string]bool, len(t.Fields))
+	for i := range t.Fields {
+		fields[t.Fields[i].Id] = true
+	}
+
+	for k := range values {
+		if !fields[k] {
+			return "", fmt.Errorf("unknown field %s", k)
+		}
+	}
+
+	var b strings.Builder
+
+	for i := range t.Fields {
+		f := &t.Fields[i]
+
+		v := strings.TrimSpace(values[f.Id])
+		if v == "" {
+			if f.Required {
+				return "", fmt.Errorf("field %s is required", f.Label)
+			}
+
+			continue
+		}
+
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+
+		fmt.Fprintf(&b, "### %s\n\n%s", f.Label, v)
+	}
+
+	return b.String(), nil
+}
+
+// MergeIssueTemplates returns the templates in the code repo and the ones defined through the api,
+// the latter overrides the former of the same name.
+func MergeIssueTemplates(inRepo, defined []IssueTemplate) []IssueTemplate {
+	names := make(map[string]bool, len(defined))
+	for i := range defined {
+		names[defined[i].Name] = true
+	}
+
+	v := make([]IssueTemplate, 0, len(inRepo)+len(defined))
+
+	for i := range inRepo {
+		if !names[inRepo[i].Name] {
+			v = append(v, inRepo[i])
+		}
+	}
+
+	return append(v, defined...)
+}
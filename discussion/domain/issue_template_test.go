@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestIssueTemplateCompose tests that the content is composed by the fields
+// and the missing or unknown fields are rejected.
+func TestIssueTemplateCompose(t *testing.T) {
+	template := IssueTemplate{
+		Name: "bug",
+		Fields: []IssueTemplateField{
+			{Id: "steps", Label: "Steps", Required: true},
+			{Id: "env", Label: "Environment"},
+		},
+	}
+
+	if err := template.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := template.Compose(map[string]string{"env": "linux"}); err == nil {
+		t.Fatal("missing required field should be rejected")
+	}
+
+	if _, err := template.Compose(map[string]string{"steps": "run", "other": "x"}); err == nil {
+		t.Fatal("unknown field should be rejected")
+	}
+
+	content, err := template.Compose(map[string]string{"steps": "run it"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(content, "### Steps\n\nrun it") {
+		t.Fatalf("unexpected content: %s", content)
+	}
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+)
+
+// TestIssueAllowComment tests that the locked issue is commented by the maintainers only and the closed one by nobody.
+func TestIssueAllowComment(t *testing.T) {
+	user := primitive.CreateAccount("alice")
+	issue := NewIssue(Resource{}, user, nil)
+
+	if err := issue.AllowComment(false); err != nil {
+		t.Fatalf("open issue should be commented, got %v", err)
+	}
+
+	issue.SetLocked(user, true)
+
+	if err := issue.AllowComment(false); err == nil {
+		t.Fatal("locked issue should not be commented by others")
+	}
+
+	if err := issue.AllowComment(true); err != nil {
+		t.Fatalf("locked issue should be commented by maintainers, got %v", err)
+	}
+
+	if err := issue.Close(user); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := issue.AllowComment(true); err == nil {
+		t.Fatal("closed issue should not be commented")
+	}
+
+	if n := len(issue.Operation); n != 2 {
+		t.Fatalf("expect 2 operations, got %d", n)
+	}
+}
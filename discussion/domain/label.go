@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+)
+
+type Label struct {
+	Id         int64
+	ResourceId primitive.Identity
+	Name       discussionprimitive.LabelName
+	Color      discussionprimitive.LabelColor
+	CreatedAt  time.Time
+}
+
+func NewLabel(
+	resourceId primitive.Identity,
+	name discussionprimitive.LabelName,
+	color discussionprimitive.LabelColor,
+) Label {
+	return Label{
+		ResourceId: resourceId,
+		Name:       name,
+		Color:      color,
+	}
+}
+
+func (l *Label) IsSameName(name discussionprimitive.LabelName) bool {
+	return strings.EqualFold(l.Name.LabelName(), name.LabelName())
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"regexp"
+)
+
+const maxMentions = 20
+
+// the mention must not follow a word character, so the email address is not a mention,
+// the period is a part of account only if it is followed by a word character.
+var mentionRegexp = regexp.MustCompile(`(?:^|[^a-zA-Z0-9_@-])@([a-zA-Z0-9_-]+(?:\.[a-zA-Z0-9_-]+)*)`)
+
+// ParseMentions returns the distinct accounts mentioned by @account in the text.
+func ParseMentions(text string) []string {
+	matches := mentionRegexp.FindAllStringSubmatch(text, -1)
+
+	seen := make(map[string]bool, len(matches))
+	accounts := make([]string, 0, len(matches))
+
+	for _, m := range matches {
+		if seen[m[1]] {
+			continue
+		}
+
+		seen[m[1]] = true
+		accounts = append(accounts, m[1])
+
+		if len(accounts) >= maxMentions {
+			break
+		}
+	}
+
+	return accounts
+}
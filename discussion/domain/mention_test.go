@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseMentions tests that the distinct accounts are parsed from the mentions and the email address is skipped.
+func TestParseMentions(t *testing.T) {
+	cases := []struct {
+		text string
+		want []string
+	}{
+		{"@alice please review", []string{"alice"}},
+		{"cc @bob, @alice-1 and @bob", []string{"bob", "alice-1"}},
+		{"mail to carol@example.com", []string{}},
+		{"(@dave_2)", []string{"dave_2"}},
+		{"thanks @bob.smith.", []string{"bob.smith"}},
+	}
+
+	for _, c := range cases {
+		if v := ParseMentions(c.text); !reflect.DeepEqual(v, c.want) {
+			t.Fatalf("parse %q, expect %v, got %v", c.text, c.want, v)
+		}
+	}
+}
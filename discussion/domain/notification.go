@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+)
+
+type Notification struct {
+	Id           int64
+	Receiver     primitive.Account
+	Type         discussionprimitive.NotificationType
+	Actor        primitive.Account
+	Resource     Resource
+	ResourcePath string
+	IssueId      int64
+	CommentId    int64
+	Title        string
+	IsRead       bool
+	CreatedAt    time.Time
+}
+
+// NotificationEvent is the discussion activity which the involved users are notified of.
+type NotificationEvent struct {
+	Type         discussionprimitive.NotificationType
+	Actor        primitive.Account
+	Issue        *Issue
+	CommentId    int64
+	Resource     Resource
+	ResourcePath string
+}
+
+// Notifications builds the notifications of the event, the mentioned users are notified of the mention
+// instead of the event, and the actor is never notified.
+func (e *NotificationEvent) Notifications(mentioned, involved []primitive.Account) []Notification {
+	done := map[string]bool{e.Actor.Account(): true}
+	v := make([]Notification, 0, len(mentioned)+len(involved))
+
+	add := func(users []primitive.Account, t discussionprimitive.NotificationType) {
+		for _, u := range users {
+			if done[u.Account()] {
+				continue
+			}
+
+			done[u.Account()] = true
+			v = append(v, e.notification(u, t))
+		}
+	}
+
+	add(mentioned, discussionprimitive.NotificationTypeMention)
+	add(involved, e.Type)
+
+	return v
+}
+
+func (e *NotificationEvent) notification(receiver primitive.Account, t discussionprimitive.NotificationType,
+) Notification {
+	return Notification{
+		Receiver:     receiver,
+		Type:         t,
+		Actor:        e.Actor,
+		Resource:     e.Resource,
+		ResourcePath: e.ResourcePath,
+		IssueId:      e.Issue.Id,
+		CommentId:    e.CommentId,
+		Title:        e.Issue.Title.Title(),
+	}
+}
+
+type NotificationPreference struct {
+	User  primitive.Account
+	Type  discussionprimitive.NotificationType
+	InApp bool
+	Email bool
+}
+
+// DefaultNotificationPreference returns the preference of the user who has not set it,
+// only the mention is delivered by email by default.
+func DefaultNotificationPreference(user primitive.Account, t discussionprimitive.NotificationType,
+) NotificationPreference {
+	return NotificationPreference{
+		User:  user,
+		Type:  t,
+		InApp: true,
+		Email: t.IsMention(),
+	}
+}
@@ -0,0 +1,52 @@
+package primitive
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	digestFrequencyDaily  = "daily"
+	digestFrequencyWeekly = "weekly"
+
+	DigestFrequencyDaily  = digestFrequency(digestFrequencyDaily)
+	DigestFrequencyWeekly = digestFrequency(digestFrequencyWeekly)
+)
+
+type DigestFrequency interface {
+	DigestFrequency() string
+	Interval() time.Duration
+}
+
+func NewDigestFrequency(v string) (DigestFrequency, error) {
+	if v != digestFrequencyDaily && v != digestFrequencyWeekly {
+		return nil, errors.New("invalid digest frequency")
+	}
+
+	return digestFrequency(v), nil
+}
+
+func CreateDigestFrequency(v string) DigestFrequency {
+	return digestFrequency(v)
+}
+
+func DigestFrequencies() []DigestFrequency {
+	return []DigestFrequency{
+		DigestFrequencyDaily,
+		DigestFrequencyWeekly,
+	}
+}
+
+type digestFrequency string
+
+func (f digestFrequency) DigestFrequency() string {
+	return string(f)
+}
+
+func (f digestFrequency) Interval() time.Duration {
+	if string(f) == digestFrequencyWeekly {
+		return 7 * 24 * time.Hour
+	}
+
+	return 24 * time.Hour
+}
@@ -1,10 +1,40 @@
 package primitive
 
+import "errors"
+
+// the fixed set of emoji reactions
+const (
+	emojiThumbsUp = iota + 1
+	emojiThumbsDown
+	emojiLaugh
+	emojiHooray
+	emojiConfused
+	emojiHeart
+	emojiRocket
+	emojiEyes
+)
+
+var emojiNames = map[int]string{
+	emojiThumbsUp:   "thumbs_up",
+	emojiThumbsDown: "thumbs_down",
+	emojiLaugh:      "laugh",
+	emojiHooray:     "hooray",
+	emojiConfused:   "confused",
+	emojiHeart:      "heart",
+	emojiRocket:     "rocket",
+	emojiEyes:       "eyes",
+}
+
 type EmojiType interface {
 	EmojiType() int
+	EmojiName() string
 }
 
 func NewEmojiType(v int) (EmojiType, error) {
+	if _, ok := emojiNames[v]; !ok {
+		return nil, errors.New("unsupported emoji")
+	}
+
 	return emojiType(v), nil
 }
 
@@ -18,6 +48,6 @@ func (t emojiType) EmojiType() int {
 	return int(t)
 }
 
-func (t emojiType) IsEqual() {
-
+func (t emojiType) EmojiName() string {
+	return emojiNames[int(t)]
 }
@@ -0,0 +1,32 @@
+package primitive
+
+import "errors"
+
+const (
+	relationAuthored  = "authored"
+	relationCommented = "commented"
+	relationMentioned = "mentioned"
+
+	IssueRelationAuthored  = issueRelation(relationAuthored)
+	IssueRelationCommented = issueRelation(relationCommented)
+	IssueRelationMentioned = issueRelation(relationMentioned)
+)
+
+// IssueRelation is how the user is involved in the issue.
+type IssueRelation interface {
+	IssueRelation() string
+}
+
+func NewIssueRelation(v string) (IssueRelation, error) {
+	if v != relationAuthored && v != relationCommented && v != relationMentioned {
+		return nil, errors.New("invalid relation")
+	}
+
+	return issueRelation(v), nil
+}
+
+type issueRelation string
+
+func (r issueRelation) IssueRelation() string {
+	return string(r)
+}
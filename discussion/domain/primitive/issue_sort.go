@@ -0,0 +1,31 @@
+package primitive
+
+import "errors"
+
+const (
+	sortRecentlyCreated = "recently_created"
+	sortRecentlyActive  = "recently_active"
+	sortMostComments    = "most_comments"
+
+	IssueSortRecentlyCreated = issueSort(sortRecentlyCreated)
+	IssueSortRecentlyActive  = issueSort(sortRecentlyActive)
+	IssueSortMostComments    = issueSort(sortMostComments)
+)
+
+type IssueSort interface {
+	IssueSort() string
+}
+
+func NewIssueSort(v string) (IssueSort, error) {
+	if v != sortRecentlyCreated && v != sortRecentlyActive && v != sortMostComments {
+		return nil, errors.New("invalid sort")
+	}
+
+	return issueSort(v), nil
+}
+
+type issueSort string
+
+func (s issueSort) IssueSort() string {
+	return string(s)
+}
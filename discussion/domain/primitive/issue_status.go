@@ -5,18 +5,21 @@ import "errors"
 const (
 	statusOpen   = "open"
 	statusClosed = "closed"
+	statusMerged = "merged"
 
 	IssueStatusOpen   = issueStatus(statusOpen)
 	IssueStatusClosed = issueStatus(statusClosed)
+	IssueStatusMerged = issueStatus(statusMerged)
 )
 
 type IssueStatus interface {
 	IssueStatus() string
 	IsOpen() bool
+	IsMerged() bool
 }
 
 func NewIssueStatus(v string) (IssueStatus, error) {
-	if v != statusOpen && v != statusClosed {
+	if v != statusOpen && v != statusClosed && v != statusMerged {
 		return nil, errors.New("invalid status")
 	}
 
@@ -36,3 +39,7 @@ func (i issueStatus) IssueStatus() string {
 func (i issueStatus) IsOpen() bool {
 	return i.IssueStatus() == statusOpen
 }
+
+func (i issueStatus) IsMerged() bool {
+	return i.IssueStatus() == statusMerged
+}
@@ -0,0 +1,42 @@
+package primitive
+
+import "errors"
+
+const (
+	typeIssue       = "issue"
+	typePullRequest = "pull_request"
+
+	IssueTypeIssue       = issueType(typeIssue)
+	IssueTypePullRequest = issueType(typePullRequest)
+)
+
+type IssueType interface {
+	IssueType() string
+	IsPullRequest() bool
+}
+
+func NewIssueType(v string) (IssueType, error) {
+	if v != typeIssue && v != typePullRequest {
+		return nil, errors.New("invalid type")
+	}
+
+	return issueType(v), nil
+}
+
+func CreateIssueType(v string) IssueType {
+	if v == "" {
+		return IssueTypeIssue
+	}
+
+	return issueType(v)
+}
+
+type issueType string
+
+func (i issueType) IssueType() string {
+	return string(i)
+}
+
+func (i issueType) IsPullRequest() bool {
+	return i.IssueType() == typePullRequest
+}
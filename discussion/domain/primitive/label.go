@@ -0,0 +1,66 @@
+package primitive
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/openmerlin/merlin-server/utils"
+)
+
+const maxLabelNameLength = 50
+
+var labelColorRegexp = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+type LabelName interface {
+	LabelName() string
+}
+
+func NewLabelName(v string) (LabelName, error) {
+	v = strings.TrimSpace(v)
+
+	if v == "" {
+		return nil, errors.New("empty label name")
+	}
+
+	if utils.StrLen(v) > maxLabelNameLength ||
+		utils.StrLen(utils.XSSEscapeString(v)) > maxLabelNameLength {
+		return nil, errors.New("label name is too long")
+	}
+
+	return labelName(v), nil
+}
+
+func CreateLabelName(v string) LabelName {
+	return labelName(v)
+}
+
+type labelName string
+
+func (l labelName) LabelName() string {
+	return string(l)
+}
+
+type LabelColor interface {
+	LabelColor() string
+}
+
+func NewLabelColor(v string) (LabelColor, error) {
+	v = strings.ToLower(strings.TrimSpace(v))
+
+	if !labelColorRegexp.MatchString(v) {
+		return nil, errors.New("invalid label color")
+	}
+
+	return labelColor(v), nil
+}
+
+func CreateLabelColor(v string) LabelColor {
+	return labelColor(v)
+}
+
+type labelColor string
+
+func (l labelColor) LabelColor() string {
+	return string(l)
+}
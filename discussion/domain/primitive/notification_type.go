@@ -0,0 +1,48 @@
+package primitive
+
+import "errors"
+
+const (
+	notificationTypeMention    = "mention"
+	notificationTypeNewIssue   = "new_issue"
+	notificationTypeNewComment = "new_comment"
+
+	NotificationTypeMention    = notificationType(notificationTypeMention)
+	NotificationTypeNewIssue   = notificationType(notificationTypeNewIssue)
+	NotificationTypeNewComment = notificationType(notificationTypeNewComment)
+)
+
+type NotificationType interface {
+	NotificationType() string
+	IsMention() bool
+}
+
+func NewNotificationType(v string) (NotificationType, error) {
+	if v != notificationTypeMention && v != notificationTypeNewIssue && v != notificationTypeNewComment {
+		return nil, errors.New("invalid notification type")
+	}
+
+	return notificationType(v), nil
+}
+
+func CreateNotificationType(v string) NotificationType {
+	return notificationType(v)
+}
+
+func NotificationTypes() []NotificationType {
+	return []NotificationType{
+		NotificationTypeMention,
+		NotificationTypeNewIssue,
+		NotificationTypeNewComment,
+	}
+}
+
+type notificationType string
+
+func (t notificationType) NotificationType() string {
+	return string(t)
+}
+
+func (t notificationType) IsMention() bool {
+	return string(t) == notificationTypeMention
+}
@@ -0,0 +1,26 @@
+package primitive
+
+import "errors"
+
+const (
+	reviewActionApprove        = "approve"
+	reviewActionRequestChanges = "request_changes"
+)
+
+type ReviewAction interface {
+	ReviewAction() string
+}
+
+func NewReviewAction(v string) (ReviewAction, error) {
+	if v != reviewActionApprove && v != reviewActionRequestChanges {
+		return nil, errors.New("invalid review action")
+	}
+
+	return reviewAction(v), nil
+}
+
+type reviewAction string
+
+func (r reviewAction) ReviewAction() string {
+	return string(r)
+}
@@ -0,0 +1,44 @@
+package primitive
+
+import "errors"
+
+const (
+	watchEventTypeNewIssue   = "new_issue"
+	watchEventTypeNewComment = "new_comment"
+	watchEventTypeRelease    = "release"
+	watchEventTypeCodeUpdate = "code_update"
+
+	WatchEventTypeNewIssue   = watchEventType(watchEventTypeNewIssue)
+	WatchEventTypeNewComment = watchEventType(watchEventTypeNewComment)
+	WatchEventTypeRelease    = watchEventType(watchEventTypeRelease)
+	WatchEventTypeCodeUpdate = watchEventType(watchEventTypeCodeUpdate)
+)
+
+type WatchEventType interface {
+	WatchEventType() string
+	IsDiscussion() bool
+}
+
+// NewRepoWatchEventType accepts the events happening on the code repo, which are reported
+// by the code repo service, the discussion events are recorded by the discussion itself.
+func NewRepoWatchEventType(v string) (WatchEventType, error) {
+	if v != watchEventTypeRelease && v != watchEventTypeCodeUpdate {
+		return nil, errors.New("invalid watch event type")
+	}
+
+	return watchEventType(v), nil
+}
+
+func CreateWatchEventType(v string) WatchEventType {
+	return watchEventType(v)
+}
+
+type watchEventType string
+
+func (t watchEventType) WatchEventType() string {
+	return string(t)
+}
+
+func (t watchEventType) IsDiscussion() bool {
+	return string(t) == watchEventTypeNewIssue || string(t) == watchEventTypeNewComment
+}
@@ -0,0 +1,48 @@
+package primitive
+
+import "errors"
+
+const (
+	watchLevelAll           = "all"
+	watchLevelParticipating = "participating"
+	watchLevelIgnore        = "ignore"
+
+	WatchLevelAll           = watchLevel(watchLevelAll)
+	WatchLevelParticipating = watchLevel(watchLevelParticipating)
+	WatchLevelIgnore        = watchLevel(watchLevelIgnore)
+)
+
+// WatchLevel is how much of the activity on the watched resource the user is told about.
+// all: the digest of all activity, participating: the notifications of the discussions the user
+// is involved in, ignore: nothing.
+type WatchLevel interface {
+	WatchLevel() string
+	IsAll() bool
+	IsIgnore() bool
+}
+
+func NewWatchLevel(v string) (WatchLevel, error) {
+	if v != watchLevelAll && v != watchLevelParticipating && v != watchLevelIgnore {
+		return nil, errors.New("invalid watch level")
+	}
+
+	return watchLevel(v), nil
+}
+
+func CreateWatchLevel(v string) WatchLevel {
+	return watchLevel(v)
+}
+
+type watchLevel string
+
+func (l watchLevel) WatchLevel() string {
+	return string(l)
+}
+
+func (l watchLevel) IsAll() bool {
+	return string(l) == watchLevelAll
+}
+
+func (l watchLevel) IsIgnore() bool {
+	return string(l) == watchLevelIgnore
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	coderepoprimitive "github.com/openmerlin/merlin-server/coderepo/domain/primitive"
+)
+
+type PullRequest struct {
+	Id           int64
+	IssueId      int64
+	Number       int64
+	SourceBranch coderepoprimitive.BranchName
+	TargetBranch coderepoprimitive.BranchName
+}
+
+type DiffSummary struct {
+	Files     []DiffFile
+	Additions int
+	Deletions int
+}
+
+type DiffFile struct {
+	Name      string
+	Status    string
+	Additions int
+	Deletions int
+}
+
+func (d *DiffSummary) Add(f DiffFile) {
+	d.Files = append(d.Files, f)
+	d.Additions += f.Additions
+	d.Deletions += f.Deletions
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+)
+
+const (
+	ReactionTargetIssue   = "issue"
+	ReactionTargetComment = "comment"
+)
+
+type ReactionTarget struct {
+	Type string
+	Id   int64
+}
+
+type Reaction struct {
+	Target ReactionTarget
+	Emoji  discussionprimitive.EmojiType
+	User   primitive.Account
+}
+
+// ReactionCount is the aggregate count of an emoji on the target,
+// Reacted is true if the user who queries has reacted with the emoji.
+type ReactionCount struct {
+	TargetId int64
+	Emoji    discussionprimitive.EmojiType
+	Count    int64
+	Reacted  bool
+}
@@ -2,13 +2,33 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	commonprimitive "github.com/openmerlin/merlin-server/common/domain/primitive"
 	"github.com/openmerlin/merlin-server/discussion/domain"
 	"github.com/openmerlin/merlin-server/discussion/domain/primitive"
 )
 
 type IssueListOption struct {
 	Status primitive.IssueStatus
+	Type   primitive.IssueType
+
+	Label    int64
+	Assignee commonprimitive.Account
+
+	// Keyword matches the title and the content of comments
+	Keyword   string
+	Author    commonprimitive.Account
+	Commenter commonprimitive.Account
+
+	// CreatedAfter and CreatedBefore are zero if there is no limit
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	// Resources limits the issues to the resources if it is not nil
+	Resources []commonprimitive.Identity
+
+	Sort primitive.IssueSort
 
 	PageNum      int
 	CountPerPage int
@@ -31,3 +51,8 @@ type IssueComment interface {
 	Delete(context.Context, int64) error
 	Find(context.Context, int64) (domain.IssueComment, error)
 }
+
+type CommentRevision interface {
+	Add([]domain.CommentRevision) error
+	List(commentId int64) ([]domain.CommentRevision, error)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	commondomain "github.com/openmerlin/merlin-server/common/domain"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+)
+
+// IssueTemplate keeps the templates defined through the api.
+type IssueTemplate interface {
+	Add(*domain.IssueTemplate) error
+	Save(*domain.IssueTemplate) error
+	Delete(id int64) error
+	Find(ctx context.Context, id int64) (domain.IssueTemplate, error)
+	List(resourceId primitive.Identity) ([]domain.IssueTemplate, error)
+}
+
+// IssueTemplateClient reads the templates defined in the code repo.
+type IssueTemplateClient interface {
+	List(repo *commondomain.CodeRepoIndex) ([]domain.IssueTemplate, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+)
+
+type Label interface {
+	Save(domain.Label) (domain.Label, error)
+	Find(context.Context, int64) (domain.Label, error)
+	Delete(context.Context, int64) error
+	List(primitive.Identity) ([]domain.Label, error)
+	ListOfResources([]primitive.Identity) ([]domain.Label, error)
+}
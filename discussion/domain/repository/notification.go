@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+)
+
+type NotificationListOption struct {
+	// IsRead is nil if both the read and unread notifications are listed
+	IsRead *bool
+
+	PageNum      int
+	CountPerPage int
+}
+
+func (o NotificationListOption) Paginate() (int, int) {
+	offset := (o.PageNum - 1) * o.CountPerPage
+
+	return o.CountPerPage, offset
+}
+
+type Notification interface {
+	Add([]domain.Notification) error
+	List(primitive.Account, *NotificationListOption) ([]domain.Notification, int64, error)
+	CountUnread(primitive.Account) (int64, error)
+	MarkRead(user primitive.Account, ids []int64) error
+	MarkAllRead(primitive.Account) error
+}
+
+type NotificationPreference interface {
+	Save(*domain.NotificationPreference) error
+	// List returns the preferences which have been set by the users
+	List(users []primitive.Account) ([]domain.NotificationPreference, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	commondomain "github.com/openmerlin/merlin-server/common/domain"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+)
+
+type PullRequest interface {
+	Save(domain.PullRequest) error
+	FindByIssueId(context.Context, int64) (domain.PullRequest, error)
+}
+
+type PullRequestClient interface {
+	Create(repo *commondomain.CodeRepoIndex, pr *domain.PullRequest, title, body string) (int64, error)
+	DiffSummary(repo *commondomain.CodeRepoIndex, pr *domain.PullRequest) (domain.DiffSummary, error)
+	Merge(repo *commondomain.CodeRepoIndex, pr *domain.PullRequest, title string) error
+	Close(repo *commondomain.CodeRepoIndex, pr *domain.PullRequest) error
+	Reopen(repo *commondomain.CodeRepoIndex, pr *domain.PullRequest) error
+}
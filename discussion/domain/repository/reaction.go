@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+)
+
+type Reaction interface {
+	// Add returns false if the user has reacted with the emoji.
+	Add(*domain.Reaction) (bool, error)
+	Remove(*domain.Reaction) error
+	// Count counts the reactions of the targets of the same type in one query.
+	Count(targetType string, targetIds []int64, user primitive.Account) ([]domain.ReactionCount, error)
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+)
+
+type Watch interface {
+	Add(*domain.Watch) error
+	Save(*domain.Watch) error
+	Delete(id int64) error
+	Find(ctx context.Context, user primitive.Account, resourceId primitive.Identity) (domain.Watch, error)
+	FindByToken(ctx context.Context, token string) (domain.Watch, error)
+	ListOfUser(primitive.Account) ([]domain.Watch, error)
+	// ListIgnoring returns the users who ignore the resource among the users
+	ListIgnoring(resourceId primitive.Identity, users []primitive.Account) ([]primitive.Account, error)
+	// ClaimToDigest returns the watches of all the activity with the frequency digested before the time
+	// and marks them digested at now, so that a watch is claimed by one digest job only. The returned
+	// watches keep the time they were digested at before.
+	ClaimToDigest(f discussionprimitive.DigestFrequency, before, now time.Time) ([]domain.Watch, error)
+	// ResetDigestedAt restores the time the watches were digested at, so that they are digested again.
+	ResetDigestedAt([]domain.Watch) error
+}
+
+type WatchEvent interface {
+	Add(*domain.WatchEvent) error
+	List(resourceIds []primitive.Identity, since time.Time) ([]domain.WatchEvent, error)
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+)
+
+// Watch is the subscription of user to the activity on a resource.
+type Watch struct {
+	Id        int64
+	User      primitive.Account
+	Resource  Resource
+	Level     discussionprimitive.WatchLevel
+	Frequency discussionprimitive.DigestFrequency
+
+	// Token is used to unsubscribe by the link in the digest without login
+	Token      string
+	DigestedAt time.Time
+	CreatedAt  time.Time
+}
+
+func NewWatch(
+	user primitive.Account,
+	resource Resource,
+	level discussionprimitive.WatchLevel,
+	frequency discussionprimitive.DigestFrequency,
+) (Watch, error) {
+	token, err := primitive.NewRandomId()
+	if err != nil {
+		return Watch{}, err
+	}
+
+	return Watch{
+		User:       user,
+		Resource:   resource,
+		Level:      level,
+		Frequency:  frequency,
+		Token:      token.RandomId(),
+		DigestedAt: time.Now(),
+	}, nil
+}
+
+func (w *Watch) Update(level discussionprimitive.WatchLevel, frequency discussionprimitive.DigestFrequency) {
+	if !w.Level.IsAll() && level.IsAll() {
+		// the activity happened before watching all of it is not digested
+		w.DigestedAt = time.Now()
+	}
+
+	w.Level = level
+	w.Frequency = frequency
+}
+
+// IsDigestDue returns true if the digest should be sent at the time, only the users watching
+// all the activity get digests.
+func (w *Watch) IsDigestDue(now time.Time) bool {
+	return w.Level.IsAll() && !now.Before(w.DigestedAt.Add(w.Frequency.Interval()))
+}
+
+// Digest returns the events on the watched resource which the user is told about in the digest,
+// the events by the user are not included.
+func (w *Watch) Digest(events []WatchEvent) []WatchEvent {
+	v := make([]WatchEvent, 0, len(events))
+
+	for i := range events {
+		e := &events[i]
+
+		if e.Resource.Id.Integer() != w.Resource.Id.Integer() {
+			continue
+		}
+
+		if e.Actor != nil && e.Actor.Account() == w.User.Account() {
+			continue
+		}
+
+		if e.CreatedAt.After(w.DigestedAt) {
+			v = append(v, *e)
+		}
+	}
+
+	return v
+}
+
+// WatchEvent is the activity on a resource which is digested for the watchers.
+type WatchEvent struct {
+	Id       int64
+	Resource Resource
+	Type     discussionprimitive.WatchEventType
+	// Actor is nil if it is unknown
+	Actor     primitive.Account
+	IssueId   int64
+	Title     string
+	CreatedAt time.Time
+}
+
+// WatchEventOf returns the event for the watchers of the notification event,
+// the mention is not an activity of the resource.
+func WatchEventOf(e *NotificationEvent) (WatchEvent, bool) {
+	var t discussionprimitive.WatchEventType
+
+	switch e.Type {
+	case discussionprimitive.NotificationTypeNewIssue:
+		t = discussionprimitive.WatchEventTypeNewIssue
+	case discussionprimitive.NotificationTypeNewComment:
+		t = discussionprimitive.WatchEventTypeNewComment
+	default:
+		return WatchEvent{}, false
+	}
+
+	return WatchEvent{
+		Resource: e.Resource,
+		Type:     t,
+		Actor:    e.Actor,
+		IssueId:  e.Issue.Id,
+		Title:    e.Issue.Title.Title(),
+	}, true
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+)
+
+// TestWatchDigest tests when the digest is due and which events of the watched resource are in it.
+func TestWatchDigest(t *testing.T) {
+	user := primitive.CreateAccount("alice")
+	resource := Resource{Id: primitive.CreateIdentity(1)}
+
+	watch, err := NewWatch(
+		user, resource, discussionprimitive.WatchLevelAll, discussionprimitive.DigestFrequencyDaily,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if watch.IsDigestDue(time.Now()) {
+		t.Fatal("digest should not be due right after watching")
+	}
+
+	if !watch.IsDigestDue(watch.DigestedAt.Add(24 * time.Hour)) {
+		t.Fatal("daily digest should be due after a day")
+	}
+
+	after := watch.DigestedAt.Add(time.Minute)
+	events := []WatchEvent{
+		{Resource: resource, Actor: primitive.CreateAccount("bob"), CreatedAt: after},
+		{Resource: resource, Actor: user, CreatedAt: after},
+		{Resource: resource, CreatedAt: after},
+		{Resource: Resource{Id: primitive.CreateIdentity(2)}, CreatedAt: after},
+		{Resource: resource, CreatedAt: watch.DigestedAt.Add(-time.Minute)},
+	}
+
+	if n := len(watch.Digest(events)); n != 2 {
+		t.Fatalf("expect 2 events in digest, got %d", n)
+	}
+
+	watch.Update(discussionprimitive.WatchLevelIgnore, discussionprimitive.DigestFrequencyDaily)
+
+	if watch.IsDigestDue(watch.DigestedAt.Add(24 * time.Hour)) {
+		t.Fatal("ignoring user should not get digest")
+	}
+}
@@ -4,4 +4,7 @@ type Config struct {
 	RootUrl             string   `json:"root_url" required:"true"`
 	ReportTitle         string   `json:"report_title" required:"true"`
 	ReportEmailReceiver []string `json:"report_email_receiver" required:"true"`
+	DigestTitle         string   `json:"digest_title" required:"true"`
+	// UnsubscribeUrl is the url of unsubscribing api which the token is appended to
+	UnsubscribeUrl string `json:"unsubscribe_url" required:"true"`
 }
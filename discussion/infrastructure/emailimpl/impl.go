@@ -2,8 +2,12 @@ package emailimpl
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
 	"github.com/openmerlin/merlin-server/discussion/domain/email"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
 	"github.com/openmerlin/merlin-server/utils"
 )
 
@@ -55,3 +59,115 @@ func (impl *emailImpl) buildContent(param email.ReportEmailParam) string {
 `
 	return fmt.Sprintf(template, url, comment, param.ReportType, param.ReportContent, param.User.Account())
 }
+
+func (impl *emailImpl) SendNotificationEmail(param email.NotificationEmailParam) error {
+	subject := fmt.Sprintf("[%s] %s (#%d)", param.ResourcePath, param.Title, param.IssueId)
+
+	return impl.email.Send(param.Receiver, subject, impl.buildNotificationContent(param))
+}
+
+func (impl *emailImpl) buildNotificationContent(param email.NotificationEmailParam) string {
+	url := fmt.Sprintf("%s%ss/%s/issues/detail/%d",
+		impl.cfg.RootUrl,
+		param.ResourceType,
+		param.ResourcePath,
+		param.IssueId,
+	)
+
+	action := "有新的动态"
+	switch {
+	case param.Type.IsMention():
+		action = "提到了你"
+	case param.Type == discussionprimitive.NotificationTypeNewIssue:
+		action = "创建了讨论"
+	case param.Type == discussionprimitive.NotificationTypeNewComment:
+		action = "发表了评论"
+	}
+
+	template := `
+<html>
+<body>
+<p>%s 在 %s %s</p>
+<h3>%s</h3>
+<p><a href="%s">%s</a></p>
+</body>
+</html>
+`
+	return fmt.Sprintf(template,
+		param.Actor.Account(), param.ResourcePath, action,
+		utils.XSSEscapeString(param.Title), url, url,
+	)
+}
+
+func (impl *emailImpl) SendDigestEmail(param email.DigestEmailParam) error {
+	subject := fmt.Sprintf("%s (%s)", impl.cfg.DigestTitle, digestPeriod(param.Frequency))
+
+	return impl.email.Send(param.Receiver, subject, impl.buildDigestContent(param))
+}
+
+func (impl *emailImpl) buildDigestContent(param email.DigestEmailParam) string {
+	var b strings.Builder
+
+	for i := range param.Resources {
+		r := &param.Resources[i]
+
+		resourceUrl := fmt.Sprintf("%s%ss/%s", impl.cfg.RootUrl, r.ResourceType, r.ResourcePath)
+		unsubscribeUrl := impl.cfg.UnsubscribeUrl + "?token=" + url.QueryEscape(r.UnsubscribeToken)
+
+		fmt.Fprintf(&b, "<h3><a href=\"%s\">%s</a></h3>\n<ul>\n", resourceUrl, r.ResourcePath)
+
+		for j := range r.Events {
+			e := &r.Events[j]
+
+			link := resourceUrl
+			if e.Type.IsDiscussion() {
+				link = fmt.Sprintf("%s/issues/detail/%d", resourceUrl, e.IssueId)
+			}
+
+			fmt.Fprintf(&b, "<li>%s <a href=\"%s\">%s</a>%s</li>\n",
+				digestAction(e.Type), link, utils.XSSEscapeString(e.Title), digestActor(e.Actor),
+			)
+		}
+
+		fmt.Fprintf(&b, "</ul>\n<p><a href=\"%s\">取消关注 %s</a></p>\n", unsubscribeUrl, r.ResourcePath)
+	}
+
+	template := `
+<html>
+<body>
+<p>您好，%s：以下是您关注的仓库%s的动态。</p>
+%s
+</body>
+</html>
+`
+	return fmt.Sprintf(template, param.User.Account(), digestPeriod(param.Frequency), b.String())
+}
+
+func digestPeriod(f discussionprimitive.DigestFrequency) string {
+	if f == discussionprimitive.DigestFrequencyWeekly {
+		return "本周"
+	}
+
+	return "今日"
+}
+
+func digestAction(t discussionprimitive.WatchEventType) string {
+	switch t {
+	case discussionprimitive.WatchEventTypeNewIssue:
+		return "新讨论"
+	case discussionprimitive.WatchEventTypeNewComment:
+		return "新评论"
+	case discussionprimitive.WatchEventTypeRelease:
+		return "新版本"
+	default:
+		return "代码更新"
+	}
+}
+
+func digestActor(actor primitive.Account) string {
+	if actor == nil {
+		return ""
+	}
+
+	return " - " + actor.Account()
+}
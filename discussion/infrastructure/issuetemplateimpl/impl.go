@@ -0,0 +1,179 @@
+package issuetemplateimpl
+
+import (
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openmerlin/go-sdk/gitea"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"sigs.k8s.io/yaml"
+
+	commondomain "github.com/openmerlin/merlin-server/common/domain"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+)
+
+const (
+	templateDir = ".discussion/ISSUE_TEMPLATE"
+
+	// maxTemplateSize limits the file read from the repo
+	maxTemplateSize = 64 * 1024
+
+	// cacheTTL is how long the templates read from the repo are reused,
+	// the change in the repo is visible after it at most.
+	cacheTTL = 5 * time.Minute
+
+	// maxCachedRepos limits the memory used by the cache
+	maxCachedRepos = 10000
+)
+
+func NewIssueTemplateImpl(c *gitea.Client) *issueTemplateImpl {
+	return &issueTemplateImpl{
+		client: c,
+		cache:  map[string]cachedTemplates{},
+	}
+}
+
+type issueTemplateImpl struct {
+	client *gitea.Client
+
+	lock  sync.Mutex
+	cache map[string]cachedTemplates
+}
+
+type cachedTemplates struct {
+	templates []domain.IssueTemplate
+	expiry    time.Time
+}
+
+// templateFile is the yaml file of template in the repo, for example
+//
+//	name: Bug report
+//	description: report a bug
+//	title: "[Bug] "
+//	fields:
+//	  - id: version
+//	    label: Version
+//	    required: true
+type templateFile struct {
+	Name        string                      `json:"name"`
+	Description string                      `json:"description"`
+	Title       string                      `json:"title"`
+	Fields      []domain.IssueTemplateField `json:"fields"`
+}
+
+// List returns the valid templates in the default branch of repo, the invalid files are skipped.
+// The templates are cached for cacheTTL to avoid reading the repo on every request.
+func (impl *issueTemplateImpl) List(repo *commondomain.CodeRepoIndex) ([]domain.IssueTemplate, error) {
+	key := repo.Owner.Account() + "/" + repo.Name.MSDName()
+
+	if v, ok := impl.get(key); ok {
+		return v, nil
+	}
+
+	v, err := impl.list(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	impl.set(key, v)
+
+	return v, nil
+}
+
+// get returns a copy of the cached templates, so the caller can change them freely.
+func (impl *issueTemplateImpl) get(key string) ([]domain.IssueTemplate, bool) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+
+	item, ok := impl.cache[key]
+	if !ok || time.Now().After(item.expiry) {
+		return nil, false
+	}
+
+	return append([]domain.IssueTemplate(nil), item.templates...), true
+}
+
+func (impl *issueTemplateImpl) set(key string, v []domain.IssueTemplate) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+
+	now := time.Now()
+
+	if len(impl.cache) >= maxCachedRepos {
+		for k, item := range impl.cache {
+			if now.After(item.expiry) {
+				delete(impl.cache, k)
+			}
+		}
+
+		if len(impl.cache) >= maxCachedRepos {
+			impl.cache = map[string]cachedTemplates{}
+		}
+	}
+
+	impl.cache[key] = cachedTemplates{
+		templates: append([]domain.IssueTemplate(nil), v...),
+		expiry:    now.Add(cacheTTL),
+	}
+}
+
+func (impl *issueTemplateImpl) list(repo *commondomain.CodeRepoIndex) ([]domain.IssueTemplate, error) {
+	owner, name := repo.Owner.Account(), repo.Name.MSDName()
+
+	files, resp, err := impl.client.ListContents(owner, name, "", templateDir)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+
+		return nil, xerrors.Errorf("list issue templates of %s/%s failed: %w", owner, name, err)
+	}
+
+	v := make([]domain.IssueTemplate, 0, len(files))
+
+	for _, f := range files {
+		if f.Type != "file" || f.Size > maxTemplateSize || !isYaml(f.Name) {
+			continue
+		}
+
+		data, _, err := impl.client.GetFile(owner, name, "", f.Path)
+		if err != nil {
+			return nil, xerrors.Errorf("get issue template %s of %s/%s failed: %w", f.Path, owner, name, err)
+		}
+
+		var tf templateFile
+		if err := yaml.Unmarshal(data, &tf); err != nil {
+			logrus.Warnf("invalid issue template %s of %s/%s: %s", f.Path, owner, name, err.Error())
+
+			continue
+		}
+
+		t := domain.IssueTemplate{
+			Resource:    domain.Resource{Id: repo.Id},
+			Name:        tf.Name,
+			Description: tf.Description,
+			Title:       tf.Title,
+			Fields:      tf.Fields,
+		}
+
+		if err := t.Validate(); err != nil {
+			logrus.Warnf("invalid issue template %s of %s/%s: %s", f.Path, owner, name, err.Error())
+
+			continue
+		}
+
+		v = append(v, t)
+	}
+
+	return v, nil
+}
+
+func isYaml(name string) bool {
+	ext := strings.ToLower(path.Ext(name))
+
+	return ext == ".yaml" || ext == ".yml"
+}
@@ -0,0 +1,106 @@
+package pullrequestimpl
+
+import (
+	"errors"
+
+	"github.com/openmerlin/go-sdk/gitea"
+	"golang.org/x/xerrors"
+
+	commondomain "github.com/openmerlin/merlin-server/common/domain"
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+)
+
+const filesPageSize = 50
+
+func NewPullRequestImpl(c *gitea.Client) *pullRequestImpl {
+	return &pullRequestImpl{client: c}
+}
+
+type pullRequestImpl struct {
+	client *gitea.Client
+}
+
+func (impl *pullRequestImpl) Create(
+	repo *commondomain.CodeRepoIndex, pr *domain.PullRequest, title, body string,
+) (int64, error) {
+	v, _, err := impl.client.CreatePullRequest(repo.Owner.Account(), repo.Name.MSDName(), gitea.CreatePullRequestOption{
+		Head:  pr.SourceBranch.BranchName(),
+		Base:  pr.TargetBranch.BranchName(),
+		Title: title,
+		Body:  body,
+	})
+	if err != nil {
+		return 0, allerror.New(allerror.ErrorCodeFailToCreatePullRequest, "failed to create pull request", err)
+	}
+
+	return v.Index, nil
+}
+
+func (impl *pullRequestImpl) DiffSummary(
+	repo *commondomain.CodeRepoIndex, pr *domain.PullRequest,
+) (summary domain.DiffSummary, err error) {
+	opt := gitea.ListPullRequestFilesOptions{}
+	opt.PageSize = filesPageSize
+
+	for page := 1; ; page++ {
+		opt.Page = page
+
+		files, _, err := impl.client.ListPullRequestFiles(repo.Owner.Account(), repo.Name.MSDName(), pr.Number, opt)
+		if err != nil {
+			return summary, xerrors.Errorf("list files of pull request %d failed: %w", pr.Number, err)
+		}
+
+		for _, f := range files {
+			summary.Add(domain.DiffFile{
+				Name:      f.Filename,
+				Status:    f.Status,
+				Additions: f.Additions,
+				Deletions: f.Deletions,
+			})
+		}
+
+		if len(files) < filesPageSize {
+			return summary, nil
+		}
+	}
+}
+
+func (impl *pullRequestImpl) Merge(repo *commondomain.CodeRepoIndex, pr *domain.PullRequest, title string) error {
+	merged, _, err := impl.client.MergePullRequest(repo.Owner.Account(), repo.Name.MSDName(), pr.Number,
+		gitea.MergePullRequestOption{
+			Style: gitea.MergeStyleMerge,
+			Title: title,
+		},
+	)
+	if err == nil && !merged {
+		err = errors.New("pull request can't be merged")
+	}
+
+	if err != nil {
+		return allerror.New(allerror.ErrorCodeFailToMergePullRequest, "failed to merge pull request", err)
+	}
+
+	return nil
+}
+
+func (impl *pullRequestImpl) Close(repo *commondomain.CodeRepoIndex, pr *domain.PullRequest) error {
+	return impl.setState(repo, pr, gitea.StateClosed)
+}
+
+func (impl *pullRequestImpl) Reopen(repo *commondomain.CodeRepoIndex, pr *domain.PullRequest) error {
+	return impl.setState(repo, pr, gitea.StateOpen)
+}
+
+func (impl *pullRequestImpl) setState(
+	repo *commondomain.CodeRepoIndex, pr *domain.PullRequest, state gitea.StateType,
+) error {
+	_, _, err := impl.client.EditPullRequest(repo.Owner.Account(), repo.Name.MSDName(), pr.Number,
+		gitea.EditPullRequestOption{State: &state},
+	)
+	if err != nil {
+		return xerrors.Errorf("set state of pull request %d to %s failed: %w", pr.Number, state, err)
+	}
+
+	return nil
+}
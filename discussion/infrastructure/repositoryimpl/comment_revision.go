@@ -0,0 +1,53 @@
+package repositoryimpl
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/openmerlin/merlin-server/common/infrastructure/postgresql"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+)
+
+const fieldCommentId = "comment_id"
+
+func NewCommentRevisionImpl(db postgresql.Impl) *commentRevisionImpl {
+	commentRevisionTableName = db.TableName()
+	err := db.DB().AutoMigrate(&CommentRevisionDO{})
+	if err != nil {
+		logrus.Fatalf("failed to auto migrate %s table: %v", commentRevisionTableName, err)
+	}
+
+	return &commentRevisionImpl{Impl: db}
+}
+
+type commentRevisionImpl struct {
+	postgresql.Impl
+}
+
+func (impl *commentRevisionImpl) Add(revisions []domain.CommentRevision) error {
+	if len(revisions) == 0 {
+		return nil
+	}
+
+	dos := make([]CommentRevisionDO, len(revisions))
+	for i := range revisions {
+		dos[i] = toCommentRevisionDO(&revisions[i])
+	}
+
+	return impl.DB().Create(&dos).Error
+}
+
+func (impl *commentRevisionImpl) List(commentId int64) ([]domain.CommentRevision, error) {
+	var dos []CommentRevisionDO
+
+	err := impl.DB().Where(impl.EqualQuery(fieldCommentId), commentId).Order(fieldId).Find(&dos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	v := make([]domain.CommentRevision, len(dos))
+	for i := range dos {
+		v[i] = dos[i].toCommentRevision()
+	}
+
+	return v, nil
+}
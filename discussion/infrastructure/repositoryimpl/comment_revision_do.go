@@ -0,0 +1,44 @@
+package repositoryimpl
+
+import (
+	"time"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+)
+
+var commentRevisionTableName string
+
+type CommentRevisionDO struct {
+	Id int64 `gorm:"primaryKey;autoIncrement"`
+
+	CommentId int64     `gorm:"column:comment_id;index"`
+	Content   string    `gorm:"column:content"`
+	Editor    string    `gorm:"column:editor"`
+	CreatedAt time.Time `gorm:"column:created_at;<-:create"`
+}
+
+func (do CommentRevisionDO) TableName() string {
+	return commentRevisionTableName
+}
+
+func toCommentRevisionDO(r *domain.CommentRevision) CommentRevisionDO {
+	return CommentRevisionDO{
+		Id:        r.Id,
+		CommentId: r.CommentId,
+		Content:   r.Content.CommentContent(),
+		Editor:    r.Editor.Account(),
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func (do CommentRevisionDO) toCommentRevision() domain.CommentRevision {
+	return domain.CommentRevision{
+		Id:        do.Id,
+		CommentId: do.CommentId,
+		Content:   discussionprimitive.CreateCommentContent(do.Content),
+		Editor:    primitive.CreateAccount(do.Editor),
+		CreatedAt: do.CreatedAt,
+	}
+}
@@ -3,4 +3,17 @@ package repositoryimpl
 type Tables struct {
 	Issue        string `json:"issue" required:"true"`
 	IssueComment string `json:"issue_comment" required:"true"`
+	PullRequest  string `json:"pull_request" required:"true"`
+	Label        string `json:"label" required:"true"`
+	Reaction     string `json:"reaction" required:"true"`
+
+	IssueTemplate string `json:"issue_template" required:"true"`
+
+	CommentRevision string `json:"comment_revision" required:"true"`
+
+	Notification           string `json:"notification" required:"true"`
+	NotificationPreference string `json:"notification_preference" required:"true"`
+
+	Watch      string `json:"watch" required:"true"`
+	WatchEvent string `json:"watch_event" required:"true"`
 }
@@ -2,8 +2,12 @@ package repositoryimpl
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"strconv"
 
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 
 	"github.com/openmerlin/merlin-server/common/domain/primitive"
 	"github.com/openmerlin/merlin-server/common/infrastructure/postgresql"
@@ -14,12 +18,24 @@ import (
 )
 
 const (
-	fieldId     = "id"
-	fieldStatus = "status"
+	fieldId        = "id"
+	fieldStatus    = "status"
+	fieldLabels    = "labels"
+	fieldAssignees = "assignees"
+	fieldAuthor    = "author"
+	fieldTitle     = "title"
+	fieldCreatedAt = "created_at"
+	fieldPinned    = "pinned"
+
+	fieldCommentCount = "comment_count"
+
+	// the first comment is the content of issue written by the author
+	commenterCondition = "c.author = ? AND NOT c.is_first_comment"
 )
 
 func NewIssueImpl(db postgresql.Impl) *issueImpl {
 	issueTableName = db.TableName()
+	createTrigramExtension(db)
 	err := db.DB().AutoMigrate(&IssueDO{})
 	if err != nil {
 		logrus.Fatalf("failed to auto migrate %s table: %v", issueTableName, err)
@@ -28,6 +44,14 @@ func NewIssueImpl(db postgresql.Impl) *issueImpl {
 	return &issueImpl{Impl: db}
 }
 
+// createTrigramExtension creates the extension used by the trigram indexes,
+// which speed up the keyword search and the mention matching of issues and comments.
+func createTrigramExtension(db postgresql.Impl) {
+	if err := db.DB().Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		logrus.Fatalf("failed to create extension pg_trgm: %v", err)
+	}
+}
+
 type issueImpl struct {
 	postgresql.Impl
 }
@@ -51,40 +75,205 @@ func (impl *issueImpl) Find(ctx context.Context, issueId int64) (issue domain.Is
 }
 
 func (impl *issueImpl) List(resourceId primitive.Identity, option repository.IssueListOption,
-) (data []app.IssueDTO, err error) {
+) ([]app.IssueDTO, error) {
 	do := IssueDO{
 		ResourceId: resourceId.Integer(),
 	}
 
-	if option.Status != nil {
-		do.Status = option.Status.IssueStatus()
+	// the pinned issues are always at the top of resource
+	list, err := impl.list(impl.DB().Where(&do).Order(impl.OrderByDesc(fieldPinned)), &option)
+	if err != nil {
+		return nil, err
 	}
 
-	limit, offset := option.Paginate()
+	data := make([]app.IssueDTO, len(list))
+	for i := range list {
+		data[i] = list[i].toIssueDTO()
+	}
 
-	var list []IssueDO
-	err = impl.DB().Order(impl.OrderByDesc(fieldId)).Limit(limit).Offset(offset).Find(&list, &do).Error
+	return data, nil
+}
+
+// ListOfUser lists the issues of all the resources which the user authored, commented or was mentioned in,
+// and returns the total number of them.
+func (impl *issueImpl) ListOfUser(
+	user primitive.Account, relation discussionprimitive.IssueRelation, option repository.IssueListOption,
+) ([]app.IssueDTO, int64, error) {
+	query := impl.filter(impl.relationQuery(user, relation), &option)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	list, err := impl.paginate(query, &option)
 	if err != nil {
-		return
+		return nil, 0, err
+	}
+
+	data := make([]app.IssueDTO, len(list))
+	for i := range list {
+		data[i] = list[i].toIssueDTO()
+		data[i].Resource = &app.IssueResourceDTO{
+			Id:   primitive.CreateIdentity(list[i].ResourceId).Identity(),
+			Type: list[i].ResourceType,
+		}
+	}
+
+	return data, total, nil
+}
+
+// ResourcesOfUser returns the resources of the issues which the user authored, commented or was mentioned in.
+func (impl *issueImpl) ResourcesOfUser(
+	user primitive.Account, relation discussionprimitive.IssueRelation, option repository.IssueListOption,
+) ([]primitive.Identity, error) {
+	var ids []int64
+
+	err := impl.filter(impl.relationQuery(user, relation), &option).
+		Distinct(fieldResourceId).Pluck(fieldResourceId, &ids).Error
+	if err != nil {
+		return nil, err
 	}
 
-	for _, v := range list {
-		data = append(data, v.toIssueDTO())
+	v := make([]primitive.Identity, len(ids))
+	for i := range ids {
+		v[i] = primitive.CreateIdentity(ids[i])
+	}
+
+	return v, nil
+}
+
+func (impl *issueImpl) relationQuery(user primitive.Account, relation discussionprimitive.IssueRelation) *gorm.DB {
+	query := impl.DB().Model(&IssueDO{})
+
+	switch relation.IssueRelation() {
+	case discussionprimitive.IssueRelationAuthored.IssueRelation():
+		return query.Where(impl.EqualQuery(fieldAuthor), user.Account())
+
+	case discussionprimitive.IssueRelationCommented.IssueRelation():
+		return query.Where(impl.commentExists(commenterCondition), user.Account())
+
+	default:
+		pattern := mentionPattern(user)
+
+		return query.Where("title ~ ? OR "+impl.commentExists("c.content ~ ?"), pattern, pattern)
 	}
+}
+
+// mentionPattern returns the regular expression of postgres matching the mentions of the user,
+// "@bob" doesn't mention "bobby" or "bob.smith", but the period ending a sentence is allowed.
+func mentionPattern(user primitive.Account) string {
+	return `(^|[^a-zA-Z0-9_@-])@` + regexp.QuoteMeta(user.Account()) +
+		`([^a-zA-Z0-9_.-]|\.([^a-zA-Z0-9_-]|$)|$)`
+}
+
+func (impl *issueImpl) list(query *gorm.DB, option *repository.IssueListOption) ([]IssueDO, error) {
+	return impl.paginate(impl.filter(query, option), option)
+}
+
+func (impl *issueImpl) paginate(query *gorm.DB, option *repository.IssueListOption) (list []IssueDO, err error) {
+	limit, offset := option.Paginate()
+
+	err = query.Order(impl.order(option.Sort)).Limit(limit).Offset(offset).Find(&list).Error
 
 	return
 }
 
+func (impl *issueImpl) filter(query *gorm.DB, option *repository.IssueListOption) *gorm.DB {
+	do := IssueDO{}
+
+	if option.Status != nil {
+		do.Status = option.Status.IssueStatus()
+	}
+
+	if option.Type != nil {
+		do.Type = option.Type.IssueType()
+	}
+
+	query = query.Where(&do)
+
+	if option.Label > 0 {
+		query = query.Where(impl.IntersectionFilter(fieldLabels, []string{strconv.FormatInt(option.Label, 10)}))
+	}
+
+	if option.Assignee != nil {
+		query = query.Where(impl.IntersectionFilter(fieldAssignees, []string{option.Assignee.Account()}))
+	}
+
+	if option.Author != nil {
+		query = query.Where(impl.EqualQuery(fieldAuthor), option.Author.Account())
+	}
+
+	if option.Commenter != nil {
+		query = query.Where(impl.commentExists(commenterCondition), option.Commenter.Account())
+	}
+
+	if option.Keyword != "" {
+		q, arg := impl.LikeFilter(fieldTitle, option.Keyword)
+		query = query.Where(q+" OR "+impl.commentExists("c.content ilike ?"), arg, arg)
+	}
+
+	if !option.CreatedAfter.IsZero() {
+		query = query.Where(fieldCreatedAt+" >= ?", option.CreatedAfter)
+	}
+
+	if !option.CreatedBefore.IsZero() {
+		query = query.Where(fieldCreatedAt+" < ?", option.CreatedBefore)
+	}
+
+	if option.Resources != nil {
+		ids := make([]int64, len(option.Resources))
+		for i := range option.Resources {
+			ids[i] = option.Resources[i].Integer()
+		}
+
+		query = query.Where(impl.InFilter(fieldResourceId), ids)
+	}
+
+	return query
+}
+
+// commentExists returns the condition that the issue has a comment which matches the condition.
+func (impl *issueImpl) commentExists(condition string) string {
+	return fmt.Sprintf(
+		"EXISTS (SELECT 1 FROM %s c WHERE c.issue_id = %s.id AND %s)",
+		issueCommentTableName, impl.TableName(), condition,
+	)
+}
+
+func (impl *issueImpl) order(sort discussionprimitive.IssueSort) string {
+	if sort == nil {
+		return impl.OrderByDesc(fieldId)
+	}
+
+	switch sort.IssueSort() {
+	case discussionprimitive.IssueSortRecentlyActive.IssueSort():
+		// updated_at is not set until the issue is updated
+		return "COALESCE(updated_at, created_at) desc, id desc"
+
+	case discussionprimitive.IssueSortMostComments.IssueSort():
+		return impl.OrderByDesc(fieldCommentCount) + ", " + impl.OrderByDesc(fieldId)
+
+	default:
+		return impl.OrderByDesc(fieldId)
+	}
+}
+
 type CountResult struct {
 	Status string `json:"status"`
 	Count  int64  `json:"count"`
 }
 
-func (impl *issueImpl) CountByStatus(resourceId primitive.Identity) (count app.ListIssuesCountDTO, err error) {
+func (impl *issueImpl) CountByStatus(resourceId primitive.Identity, t discussionprimitive.IssueType,
+) (count app.ListIssuesCountDTO, err error) {
 	do := IssueDO{
 		ResourceId: resourceId.Integer(),
 	}
 
+	if t != nil {
+		do.Type = t.IssueType()
+	}
+
 	var results []CountResult
 	err = impl.DB().Select("status, count(status) as count").Where(&do).Group(fieldStatus).Scan(&results).Error
 
@@ -94,12 +283,14 @@ func (impl *issueImpl) CountByStatus(resourceId primitive.Identity) (count app.L
 			count.Open = v.Count
 		case discussionprimitive.IssueStatusClosed.IssueStatus():
 			count.Closed = v.Count
+		case discussionprimitive.IssueStatusMerged.IssueStatus():
+			count.Merged = v.Count
 		default:
 
 		}
 	}
 
-	count.All = count.Open + count.Closed
+	count.All = count.Open + count.Closed + count.Merged
 
 	return
 }
@@ -11,6 +11,7 @@ import (
 
 func NewIssueCommentImpl(db postgresql.Impl) *issueCommentImpl {
 	issueCommentTableName = db.TableName()
+	createTrigramExtension(db)
 	err := db.DB().AutoMigrate(&IssueCommentDO{})
 	if err != nil {
 		logrus.Fatalf("failed to auto migrate %s table: %v", issueCommentTableName, err)
@@ -15,8 +15,11 @@ type IssueCommentDO struct {
 
 	Author         string    `gorm:"column:author"`
 	IssueId        int64     `gorm:"column:issue_id;index"`
-	Content        string    `gorm:"column:content"`
+	ReplyTo        int64     `gorm:"column:reply_to;default:0"`
+	Content        string    `gorm:"column:content;index:content_trgm,type:gin,expression:content gin_trgm_ops"`
 	IsFirstComment bool      `gorm:"column:is_first_comment"`
+	EditedAt       int64     `gorm:"column:edited_at;default:0"`
+	Hidden         bool      `gorm:"column:hidden;default:false"`
 	CreatedAt      time.Time `gorm:"column:created_at;<-:create"`
 	UpdatedAt      time.Time `gorm:"column:updated_at;<-:update"`
 }
@@ -30,8 +33,11 @@ func toIssueCommentDO(comment domain.IssueComment) IssueCommentDO {
 		Id:             comment.Id,
 		Author:         comment.Author.Account(),
 		IssueId:        comment.IssueId,
+		ReplyTo:        comment.ReplyTo,
 		Content:        comment.Content.CommentContent(),
 		IsFirstComment: comment.IsFirstComment,
+		EditedAt:       toEditedAtDO(comment.EditedAt),
+		Hidden:         comment.Hidden,
 	}
 }
 
@@ -40,8 +46,27 @@ func (do IssueCommentDO) toIssueComment() domain.IssueComment {
 		Id:             do.Id,
 		Author:         primitive.CreateAccount(do.Author),
 		IssueId:        do.IssueId,
+		ReplyTo:        do.ReplyTo,
 		Content:        discussionprimitive.CreateCommentContent(do.Content),
 		CreatedAt:      do.CreatedAt,
 		IsFirstComment: do.IsFirstComment,
+		EditedAt:       do.editedAt(),
+		Hidden:         do.Hidden,
 	}
 }
+
+func toEditedAtDO(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.Unix()
+}
+
+func (do IssueCommentDO) editedAt() time.Time {
+	if do.EditedAt == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(do.EditedAt, 0)
+}
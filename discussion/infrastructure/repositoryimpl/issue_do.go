@@ -1,8 +1,11 @@
 package repositoryimpl
 
 import (
+	"strconv"
 	"time"
 
+	"github.com/lib/pq"
+
 	"github.com/openmerlin/merlin-server/common/domain/primitive"
 	"github.com/openmerlin/merlin-server/discussion/app"
 	"github.com/openmerlin/merlin-server/discussion/domain"
@@ -14,12 +17,18 @@ var issueTableName string
 type IssueDO struct {
 	Id           int64              `gorm:"primaryKey;autoIncrement"`
 	Author       string             `gorm:"column:author"`
-	Title        string             `gorm:"column:title"`
+	Title        string             `gorm:"column:title;index:title_trgm,type:gin,expression:title gin_trgm_ops"`
+	Type         string             `gorm:"column:type;default:'issue'"`
 	Status       string             `gorm:"column:status"`
 	Operation    []domain.Operation `gorm:"column:operation;serializer:json"`
 	ResourceId   int64              `gorm:"column:resource_id;index"`
 	ResourceType string             `gorm:"column:resource_type"`
+	Labels       pq.StringArray     `gorm:"column:labels;type:text[];default:'{}';index:labels,type:gin"`
+	Assignees    pq.StringArray     `gorm:"column:assignees;type:text[];default:'{}';index:assignees,type:gin"`
 	CommentCount int64              `gorm:"column:comment_count"`
+	Locked       bool               `gorm:"column:locked;default:false"`
+	Pinned       bool               `gorm:"column:pinned;default:false"`
+	Answer       int64              `gorm:"column:answer;default:0"`
 	CreatedAt    time.Time          `gorm:"column:created_at;<-:create"`
 	UpdatedAt    time.Time          `gorm:"column:updated_at;<-:update"`
 }
@@ -33,23 +42,73 @@ func toIssueDO(issue domain.Issue) IssueDO {
 		Id:           issue.Id,
 		Author:       issue.Author.Account(),
 		Title:        issue.Title.Title(),
+		Type:         issue.Type.IssueType(),
 		Status:       issue.Status.IssueStatus(),
 		ResourceId:   issue.Resource.Id.Integer(),
 		ResourceType: string(issue.Resource.Type),
 		Operation:    issue.Operation,
+		Labels:       toLabelsDO(issue.Labels),
+		Assignees:    toAssigneesDO(issue.Assignees),
 		CommentCount: issue.CommentCount,
+		Locked:       issue.Locked,
+		Pinned:       issue.Pinned,
+		Answer:       issue.Answer,
 	}
 }
 
+func toLabelsDO(labels []int64) pq.StringArray {
+	v := make(pq.StringArray, len(labels))
+	for i := range labels {
+		v[i] = strconv.FormatInt(labels[i], 10)
+	}
+
+	return v
+}
+
+func toAssigneesDO(assignees []primitive.Account) pq.StringArray {
+	v := make(pq.StringArray, len(assignees))
+	for i := range assignees {
+		v[i] = assignees[i].Account()
+	}
+
+	return v
+}
+
+func (do IssueDO) assignees() []primitive.Account {
+	v := make([]primitive.Account, len(do.Assignees))
+	for i := range do.Assignees {
+		v[i] = primitive.CreateAccount(do.Assignees[i])
+	}
+
+	return v
+}
+
+func (do IssueDO) labels() []int64 {
+	v := make([]int64, 0, len(do.Labels))
+	for _, s := range do.Labels {
+		if id, err := strconv.ParseInt(s, 10, 64); err == nil {
+			v = append(v, id)
+		}
+	}
+
+	return v
+}
+
 func (do IssueDO) toIssue() domain.Issue {
 	return domain.Issue{
 		Id:           do.Id,
 		Author:       primitive.CreateAccount(do.Author),
 		Title:        discussionprimitive.CreateIssueTitle(do.Title),
+		Type:         discussionprimitive.CreateIssueType(do.Type),
 		Status:       discussionprimitive.CreateIssueStatus(do.Status),
 		Operation:    do.Operation,
+		Labels:       do.labels(),
+		Assignees:    do.assignees(),
 		CommentCount: do.CommentCount,
 		CreatedAt:    do.CreatedAt,
+		Locked:       do.Locked,
+		Pinned:       do.Pinned,
+		Answer:       do.Answer,
 		Resource: domain.Resource{
 			Id:   primitive.CreateIdentity(do.ResourceId),
 			Type: primitive.ObjType(do.ResourceType),
@@ -61,9 +120,15 @@ func (do IssueDO) toIssueDTO() app.IssueDTO {
 	return app.IssueDTO{
 		Id:           do.Id,
 		Title:        do.Title,
+		Type:         discussionprimitive.CreateIssueType(do.Type).IssueType(),
 		Owner:        do.Author,
 		Status:       do.Status,
+		Labels:       app.ToLabelIdsDTO(do.labels()),
+		Assignees:    append([]string{}, do.Assignees...),
 		CommentCount: do.CommentCount,
 		CreatedAt:    do.CreatedAt.In(time.UTC).Format(app.TimeFormat),
+		Locked:       do.Locked,
+		Pinned:       do.Pinned,
+		Answer:       do.Answer,
 	}
 }
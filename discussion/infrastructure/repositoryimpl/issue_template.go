@@ -0,0 +1,72 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/common/infrastructure/postgresql"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+)
+
+func NewIssueTemplateImpl(db postgresql.Impl) *issueTemplateImpl {
+	issueTemplateTableName = db.TableName()
+	err := db.DB().AutoMigrate(&IssueTemplateDO{})
+	if err != nil {
+		logrus.Fatalf("failed to auto migrate %s table: %v", issueTemplateTableName, err)
+	}
+
+	return &issueTemplateImpl{Impl: db}
+}
+
+type issueTemplateImpl struct {
+	postgresql.Impl
+}
+
+func (impl *issueTemplateImpl) Add(t *domain.IssueTemplate) error {
+	do := toIssueTemplateDO(t)
+
+	if err := impl.DB().Create(&do).Error; err != nil {
+		return err
+	}
+
+	t.Id = do.Id
+
+	return nil
+}
+
+func (impl *issueTemplateImpl) Save(t *domain.IssueTemplate) error {
+	do := toIssueTemplateDO(t)
+
+	return impl.DB().Save(&do).Error
+}
+
+func (impl *issueTemplateImpl) Delete(id int64) error {
+	return impl.DB().Delete(&IssueTemplateDO{Id: id}).Error
+}
+
+func (impl *issueTemplateImpl) Find(ctx context.Context, id int64) (domain.IssueTemplate, error) {
+	do := IssueTemplateDO{Id: id}
+	if err := impl.GetByPrimaryKey(ctx, &do); err != nil {
+		return domain.IssueTemplate{}, err
+	}
+
+	return do.toIssueTemplate(), nil
+}
+
+func (impl *issueTemplateImpl) List(resourceId primitive.Identity) ([]domain.IssueTemplate, error) {
+	do := IssueTemplateDO{ResourceId: resourceId.Integer()}
+
+	var list []IssueTemplateDO
+	if err := impl.DB().Order(fieldId).Find(&list, &do).Error; err != nil {
+		return nil, err
+	}
+
+	v := make([]domain.IssueTemplate, len(list))
+	for i := range list {
+		v[i] = list[i].toIssueTemplate()
+	}
+
+	return v, nil
+}
@@ -0,0 +1,49 @@
+package repositoryimpl
+
+import (
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+)
+
+var issueTemplateTableName string
+
+type IssueTemplateDO struct {
+	Id int64 `gorm:"primaryKey;autoIncrement"`
+
+	ResourceId   int64                       `gorm:"column:resource_id;index"`
+	ResourceType string                      `gorm:"column:resource_type"`
+	Name         string                      `gorm:"column:name"`
+	Description  string                      `gorm:"column:description"`
+	Title        string                      `gorm:"column:title"`
+	Fields       []domain.IssueTemplateField `gorm:"column:fields;serializer:json"`
+}
+
+func (do IssueTemplateDO) TableName() string {
+	return issueTemplateTableName
+}
+
+func toIssueTemplateDO(t *domain.IssueTemplate) IssueTemplateDO {
+	return IssueTemplateDO{
+		Id:           t.Id,
+		ResourceId:   t.Resource.Id.Integer(),
+		ResourceType: string(t.Resource.Type),
+		Name:         t.Name,
+		Description:  t.Description,
+		Title:        t.Title,
+		Fields:       t.Fields,
+	}
+}
+
+func (do IssueTemplateDO) toIssueTemplate() domain.IssueTemplate {
+	return domain.IssueTemplate{
+		Id: do.Id,
+		Resource: domain.Resource{
+			Id:   primitive.CreateIdentity(do.ResourceId),
+			Type: primitive.ObjType(do.ResourceType),
+		},
+		Name:        do.Name,
+		Description: do.Description,
+		Title:       do.Title,
+		Fields:      do.Fields,
+	}
+}
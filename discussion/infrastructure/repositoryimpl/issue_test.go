@@ -0,0 +1,33 @@
+package repositoryimpl
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+)
+
+// TestMentionPattern tests that the pattern matches the mentions of the dotted account only,
+// the pattern is in the syntax shared by postgres and go.
+func TestMentionPattern(t *testing.T) {
+	re := regexp.MustCompile(mentionPattern(primitive.CreateAccount("bob.smith")))
+
+	cases := []struct {
+		text string
+		want bool
+	}{
+		{"@bob.smith please review", true},
+		{"thanks @bob.smith.", true},
+		{"(@bob.smith)", true},
+		{"@bobxsmith please review", false},
+		{"@bob.smithy please review", false},
+		{"@bob.smith.jr please review", false},
+		{"mail to carol@bob.smith", false},
+	}
+
+	for _, c := range cases {
+		if v := re.MatchString(c.text); v != c.want {
+			t.Fatalf("match %q, expect %v, got %v", c.text, c.want, v)
+		}
+	}
+}
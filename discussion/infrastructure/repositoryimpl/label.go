@@ -0,0 +1,84 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/common/infrastructure/postgresql"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+)
+
+func NewLabelImpl(db postgresql.Impl) *labelImpl {
+	labelTableName = db.TableName()
+	err := db.DB().AutoMigrate(&LabelDO{})
+	if err != nil {
+		logrus.Fatalf("failed to auto migrate %s table: %v", labelTableName, err)
+	}
+
+	return &labelImpl{Impl: db}
+}
+
+type labelImpl struct {
+	postgresql.Impl
+}
+
+func (impl *labelImpl) Save(label domain.Label) (domain.Label, error) {
+	do := toLabelDO(label)
+
+	err := impl.DB().Save(&do).Error
+
+	return do.toLabel(), err
+}
+
+func (impl *labelImpl) Find(ctx context.Context, labelId int64) (label domain.Label, err error) {
+	do := LabelDO{Id: labelId}
+	if err = impl.GetByPrimaryKey(ctx, &do); err != nil {
+		return
+	}
+
+	label = do.toLabel()
+
+	return
+}
+
+func (impl *labelImpl) Delete(ctx context.Context, labelId int64) error {
+	do := LabelDO{Id: labelId}
+
+	return impl.DeleteByPrimaryKey(ctx, &do)
+}
+
+func (impl *labelImpl) List(resourceId primitive.Identity) (labels []domain.Label, err error) {
+	do := LabelDO{ResourceId: resourceId.Integer()}
+
+	var list []LabelDO
+	if err = impl.DB().Order(fieldId).Find(&list, &do).Error; err != nil {
+		return
+	}
+
+	for _, v := range list {
+		labels = append(labels, v.toLabel())
+	}
+
+	return
+}
+
+// ListOfResources lists the labels of all the resources.
+func (impl *labelImpl) ListOfResources(resourceIds []primitive.Identity) (labels []domain.Label, err error) {
+	ids := make([]int64, len(resourceIds))
+	for i := range resourceIds {
+		ids[i] = resourceIds[i].Integer()
+	}
+
+	var list []LabelDO
+	if err = impl.DB().Where(impl.InFilter(fieldResourceId), ids).Order(fieldId).Find(&list).Error; err != nil {
+		return
+	}
+
+	for _, v := range list {
+		labels = append(labels, v.toLabel())
+	}
+
+	return
+}
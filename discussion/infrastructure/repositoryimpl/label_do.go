@@ -0,0 +1,44 @@
+package repositoryimpl
+
+import (
+	"time"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+)
+
+var labelTableName string
+
+type LabelDO struct {
+	Id int64 `gorm:"primaryKey;autoIncrement"`
+
+	ResourceId int64     `gorm:"column:resource_id;index"`
+	Name       string    `gorm:"column:name"`
+	Color      string    `gorm:"column:color"`
+	CreatedAt  time.Time `gorm:"column:created_at;<-:create"`
+	UpdatedAt  time.Time `gorm:"column:updated_at;<-:update"`
+}
+
+func (do LabelDO) TableName() string {
+	return labelTableName
+}
+
+func toLabelDO(label domain.Label) LabelDO {
+	return LabelDO{
+		Id:         label.Id,
+		ResourceId: label.ResourceId.Integer(),
+		Name:       label.Name.LabelName(),
+		Color:      label.Color.LabelColor(),
+	}
+}
+
+func (do LabelDO) toLabel() domain.Label {
+	return domain.Label{
+		Id:         do.Id,
+		ResourceId: primitive.CreateIdentity(do.ResourceId),
+		Name:       discussionprimitive.CreateLabelName(do.Name),
+		Color:      discussionprimitive.CreateLabelColor(do.Color),
+		CreatedAt:  do.CreatedAt,
+	}
+}
@@ -0,0 +1,141 @@
+package repositoryimpl
+
+import (
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm/clause"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/common/infrastructure/postgresql"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+	"github.com/openmerlin/merlin-server/discussion/domain/repository"
+)
+
+const (
+	fieldReceiver = "receiver"
+	fieldIsRead   = "is_read"
+	fieldType     = "type"
+)
+
+func NewNotificationImpl(db postgresql.Impl) *notificationImpl {
+	notificationTableName = db.TableName()
+	err := db.DB().AutoMigrate(&NotificationDO{})
+	if err != nil {
+		logrus.Fatalf("failed to auto migrate %s table: %v", notificationTableName, err)
+	}
+
+	return &notificationImpl{Impl: db}
+}
+
+type notificationImpl struct {
+	postgresql.Impl
+}
+
+func (impl *notificationImpl) Add(notifications []domain.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	dos := make([]NotificationDO, len(notifications))
+	for i := range notifications {
+		dos[i] = toNotificationDO(&notifications[i])
+	}
+
+	return impl.DB().Create(&dos).Error
+}
+
+func (impl *notificationImpl) List(user primitive.Account, option *repository.NotificationListOption,
+) ([]domain.Notification, int64, error) {
+	query := impl.DB().Where(impl.EqualQuery(fieldReceiver), user.Account())
+	if option.IsRead != nil {
+		query = query.Where(impl.EqualQuery(fieldIsRead), *option.IsRead)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	limit, offset := option.Paginate()
+
+	var dos []NotificationDO
+	if err := query.Order(impl.OrderByDesc(fieldId)).Limit(limit).Offset(offset).Find(&dos).Error; err != nil {
+		return nil, 0, err
+	}
+
+	v := make([]domain.Notification, len(dos))
+	for i := range dos {
+		v[i] = dos[i].toNotification()
+	}
+
+	return v, total, nil
+}
+
+func (impl *notificationImpl) CountUnread(user primitive.Account) (count int64, err error) {
+	err = impl.DB().Where(impl.EqualQuery(fieldReceiver), user.Account()).
+		Where(impl.EqualQuery(fieldIsRead), false).
+		Count(&count).Error
+
+	return
+}
+
+func (impl *notificationImpl) MarkRead(user primitive.Account, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return impl.DB().Where(impl.EqualQuery(fieldReceiver), user.Account()).
+		Where(impl.InFilter(fieldId), ids).
+		Update(fieldIsRead, true).Error
+}
+
+func (impl *notificationImpl) MarkAllRead(user primitive.Account) error {
+	return impl.DB().Where(impl.EqualQuery(fieldReceiver), user.Account()).
+		Where(impl.EqualQuery(fieldIsRead), false).
+		Update(fieldIsRead, true).Error
+}
+
+func NewNotificationPreferenceImpl(db postgresql.Impl) *notificationPreferenceImpl {
+	notificationPreferenceTableName = db.TableName()
+	err := db.DB().AutoMigrate(&NotificationPreferenceDO{})
+	if err != nil {
+		logrus.Fatalf("failed to auto migrate %s table: %v", notificationPreferenceTableName, err)
+	}
+
+	return &notificationPreferenceImpl{Impl: db}
+}
+
+type notificationPreferenceImpl struct {
+	postgresql.Impl
+}
+
+func (impl *notificationPreferenceImpl) Save(p *domain.NotificationPreference) error {
+	do := toNotificationPreferenceDO(p)
+
+	return impl.DB().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: fieldUserName}, {Name: fieldType}},
+		DoUpdates: clause.AssignmentColumns([]string{"in_app", "email"}),
+	}).Create(&do).Error
+}
+
+func (impl *notificationPreferenceImpl) List(users []primitive.Account) ([]domain.NotificationPreference, error) {
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, len(users))
+	for i := range users {
+		names[i] = users[i].Account()
+	}
+
+	var dos []NotificationPreferenceDO
+	if err := impl.DB().Where(impl.InFilter(fieldUserName), names).Find(&dos).Error; err != nil {
+		return nil, err
+	}
+
+	v := make([]domain.NotificationPreference, len(dos))
+	for i := range dos {
+		v[i] = dos[i].toNotificationPreference()
+	}
+
+	return v, nil
+}
@@ -0,0 +1,100 @@
+package repositoryimpl
+
+import (
+	"time"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+)
+
+var (
+	notificationTableName           string
+	notificationPreferenceTableName string
+)
+
+type NotificationDO struct {
+	Id int64 `gorm:"primaryKey;autoIncrement"`
+
+	Receiver     string    `gorm:"column:receiver;index:notification_receiver,priority:1"`
+	IsRead       bool      `gorm:"column:is_read;index:notification_receiver,priority:2"`
+	Type         string    `gorm:"column:type"`
+	Actor        string    `gorm:"column:actor"`
+	ResourceId   int64     `gorm:"column:resource_id"`
+	ResourceType string    `gorm:"column:resource_type"`
+	ResourcePath string    `gorm:"column:resource_path"`
+	IssueId      int64     `gorm:"column:issue_id"`
+	CommentId    int64     `gorm:"column:comment_id"`
+	Title        string    `gorm:"column:title"`
+	CreatedAt    time.Time `gorm:"column:created_at;<-:create"`
+}
+
+func (do NotificationDO) TableName() string {
+	return notificationTableName
+}
+
+func toNotificationDO(n *domain.Notification) NotificationDO {
+	return NotificationDO{
+		Id:           n.Id,
+		Receiver:     n.Receiver.Account(),
+		IsRead:       n.IsRead,
+		Type:         n.Type.NotificationType(),
+		Actor:        n.Actor.Account(),
+		ResourceId:   n.Resource.Id.Integer(),
+		ResourceType: string(n.Resource.Type),
+		ResourcePath: n.ResourcePath,
+		IssueId:      n.IssueId,
+		CommentId:    n.CommentId,
+		Title:        n.Title,
+	}
+}
+
+func (do NotificationDO) toNotification() domain.Notification {
+	return domain.Notification{
+		Id:       do.Id,
+		Receiver: primitive.CreateAccount(do.Receiver),
+		IsRead:   do.IsRead,
+		Type:     discussionprimitive.CreateNotificationType(do.Type),
+		Actor:    primitive.CreateAccount(do.Actor),
+		Resource: domain.Resource{
+			Id:   primitive.CreateIdentity(do.ResourceId),
+			Type: primitive.ObjType(do.ResourceType),
+		},
+		ResourcePath: do.ResourcePath,
+		IssueId:      do.IssueId,
+		CommentId:    do.CommentId,
+		Title:        do.Title,
+		CreatedAt:    do.CreatedAt,
+	}
+}
+
+type NotificationPreferenceDO struct {
+	Id int64 `gorm:"primaryKey;autoIncrement"`
+
+	User  string `gorm:"column:user_name;uniqueIndex:notification_preference_index,priority:1"`
+	Type  string `gorm:"column:type;uniqueIndex:notification_preference_index,priority:2"`
+	InApp bool   `gorm:"column:in_app"`
+	Email bool   `gorm:"column:email"`
+}
+
+func (do NotificationPreferenceDO) TableName() string {
+	return notificationPreferenceTableName
+}
+
+func toNotificationPreferenceDO(p *domain.NotificationPreference) NotificationPreferenceDO {
+	return NotificationPreferenceDO{
+		User:  p.User.Account(),
+		Type:  p.Type.NotificationType(),
+		InApp: p.InApp,
+		Email: p.Email,
+	}
+}
+
+func (do NotificationPreferenceDO) toNotificationPreference() domain.NotificationPreference {
+	return domain.NotificationPreference{
+		User:  primitive.CreateAccount(do.User),
+		Type:  discussionprimitive.CreateNotificationType(do.Type),
+		InApp: do.InApp,
+		Email: do.Email,
+	}
+}
@@ -0,0 +1,41 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openmerlin/merlin-server/common/infrastructure/postgresql"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+)
+
+func NewPullRequestImpl(db postgresql.Impl) *pullRequestImpl {
+	pullRequestTableName = db.TableName()
+	err := db.DB().AutoMigrate(&PullRequestDO{})
+	if err != nil {
+		logrus.Fatalf("failed to auto migrate %s table: %v", pullRequestTableName, err)
+	}
+
+	return &pullRequestImpl{Impl: db}
+}
+
+type pullRequestImpl struct {
+	postgresql.Impl
+}
+
+func (impl *pullRequestImpl) Save(pr domain.PullRequest) error {
+	do := toPullRequestDO(pr)
+
+	return impl.DB().Save(&do).Error
+}
+
+func (impl *pullRequestImpl) FindByIssueId(ctx context.Context, issueId int64) (pr domain.PullRequest, err error) {
+	do := PullRequestDO{}
+	if err = impl.GetRecord(ctx, &PullRequestDO{IssueId: issueId}, &do); err != nil {
+		return
+	}
+
+	pr = do.toPullRequest()
+
+	return
+}
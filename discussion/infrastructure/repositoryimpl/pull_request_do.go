@@ -0,0 +1,45 @@
+package repositoryimpl
+
+import (
+	"time"
+
+	coderepoprimitive "github.com/openmerlin/merlin-server/coderepo/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+)
+
+var pullRequestTableName string
+
+type PullRequestDO struct {
+	Id int64 `gorm:"primaryKey;autoIncrement"`
+
+	IssueId      int64     `gorm:"column:issue_id;uniqueIndex"`
+	Number       int64     `gorm:"column:number"`
+	SourceBranch string    `gorm:"column:source_branch"`
+	TargetBranch string    `gorm:"column:target_branch"`
+	CreatedAt    time.Time `gorm:"column:created_at;<-:create"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;<-:update"`
+}
+
+func (do PullRequestDO) TableName() string {
+	return pullRequestTableName
+}
+
+func toPullRequestDO(pr domain.PullRequest) PullRequestDO {
+	return PullRequestDO{
+		Id:           pr.Id,
+		IssueId:      pr.IssueId,
+		Number:       pr.Number,
+		SourceBranch: pr.SourceBranch.BranchName(),
+		TargetBranch: pr.TargetBranch.BranchName(),
+	}
+}
+
+func (do PullRequestDO) toPullRequest() domain.PullRequest {
+	return domain.PullRequest{
+		Id:           do.Id,
+		IssueId:      do.IssueId,
+		Number:       do.Number,
+		SourceBranch: coderepoprimitive.CreateBranchName(do.SourceBranch),
+		TargetBranch: coderepoprimitive.CreateBranchName(do.TargetBranch),
+	}
+}
@@ -0,0 +1,82 @@
+package repositoryimpl
+
+import (
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm/clause"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/common/infrastructure/postgresql"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+)
+
+const (
+	fieldTargetType = "target_type"
+	fieldTargetId   = "target_id"
+	fieldUserName   = "user_name"
+)
+
+func NewReactionImpl(db postgresql.Impl) *reactionImpl {
+	reactionTableName = db.TableName()
+	err := db.DB().AutoMigrate(&ReactionDO{})
+	if err != nil {
+		logrus.Fatalf("failed to auto migrate %s table: %v", reactionTableName, err)
+	}
+
+	return &reactionImpl{Impl: db}
+}
+
+type reactionImpl struct {
+	postgresql.Impl
+}
+
+func (impl *reactionImpl) Add(r *domain.Reaction) (bool, error) {
+	do := toReactionDO(r)
+
+	v := impl.DB().Clauses(clause.OnConflict{DoNothing: true}).Create(&do)
+
+	return v.RowsAffected > 0, v.Error
+}
+
+func (impl *reactionImpl) Remove(r *domain.Reaction) error {
+	do := toReactionDO(r)
+
+	return impl.DB().Where(&do).Delete(&ReactionDO{}).Error
+}
+
+func (impl *reactionImpl) Count(targetType string, targetIds []int64, user primitive.Account,
+) ([]domain.ReactionCount, error) {
+	if len(targetIds) == 0 {
+		return nil, nil
+	}
+
+	account := ""
+	if user != nil {
+		account = user.Account()
+	}
+
+	var results []reactionCountResult
+
+	err := impl.DB().
+		Select("target_id, emoji, count(*) as count, bool_or(user_name = ?) as reacted", account).
+		Where(impl.EqualQuery(fieldTargetType), targetType).
+		Where(impl.InFilter(fieldTargetId), targetIds).
+		Group("target_id, emoji").
+		Order("target_id, emoji").
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]domain.ReactionCount, len(results))
+	for i := range results {
+		counts[i] = domain.ReactionCount{
+			TargetId: results[i].TargetId,
+			Emoji:    discussionprimitive.CreateEmojiType(results[i].Emoji),
+			Count:    results[i].Count,
+			Reacted:  results[i].Reacted,
+		}
+	}
+
+	return counts, nil
+}
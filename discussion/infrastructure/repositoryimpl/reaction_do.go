@@ -0,0 +1,39 @@
+package repositoryimpl
+
+import (
+	"time"
+
+	"github.com/openmerlin/merlin-server/discussion/domain"
+)
+
+var reactionTableName string
+
+type ReactionDO struct {
+	Id int64 `gorm:"primaryKey;autoIncrement"`
+
+	TargetType string    `gorm:"column:target_type;uniqueIndex:reaction_index,priority:1"`
+	TargetId   int64     `gorm:"column:target_id;uniqueIndex:reaction_index,priority:2"`
+	Emoji      int       `gorm:"column:emoji;uniqueIndex:reaction_index,priority:3"`
+	User       string    `gorm:"column:user_name;uniqueIndex:reaction_index,priority:4"`
+	CreatedAt  time.Time `gorm:"column:created_at;<-:create"`
+}
+
+func (do ReactionDO) TableName() string {
+	return reactionTableName
+}
+
+func toReactionDO(r *domain.Reaction) ReactionDO {
+	return ReactionDO{
+		TargetType: r.Target.Type,
+		TargetId:   r.Target.Id,
+		Emoji:      r.Emoji.EmojiType(),
+		User:       r.User.Account(),
+	}
+}
+
+type reactionCountResult struct {
+	TargetId int64 `gorm:"column:target_id"`
+	Emoji    int   `gorm:"column:emoji"`
+	Count    int64 `gorm:"column:count"`
+	Reacted  bool  `gorm:"column:reacted"`
+}
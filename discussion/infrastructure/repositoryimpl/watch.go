@@ -0,0 +1,222 @@
+package repositoryimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/common/infrastructure/postgresql"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+)
+
+const (
+	fieldResourceId = "resource_id"
+	fieldLevel      = "level"
+	fieldFrequency  = "frequency"
+	fieldDigestedAt = "digested_at"
+)
+
+func NewWatchImpl(db postgresql.Impl) *watchImpl {
+	watchTableName = db.TableName()
+	err := db.DB().AutoMigrate(&WatchDO{})
+	if err != nil {
+		logrus.Fatalf("failed to auto migrate %s table: %v", watchTableName, err)
+	}
+
+	return &watchImpl{Impl: db}
+}
+
+type watchImpl struct {
+	postgresql.Impl
+}
+
+func (impl *watchImpl) Add(w *domain.Watch) error {
+	do := toWatchDO(w)
+
+	if err := impl.DB().Create(&do).Error; err != nil {
+		return err
+	}
+
+	w.Id = do.Id
+
+	return nil
+}
+
+func (impl *watchImpl) Save(w *domain.Watch) error {
+	do := toWatchDO(w)
+
+	return impl.DB().Save(&do).Error
+}
+
+func (impl *watchImpl) Delete(id int64) error {
+	return impl.DB().Delete(&WatchDO{Id: id}).Error
+}
+
+func (impl *watchImpl) Find(ctx context.Context, user primitive.Account, resourceId primitive.Identity,
+) (domain.Watch, error) {
+	return impl.find(ctx, &WatchDO{User: user.Account(), ResourceId: resourceId.Integer()})
+}
+
+func (impl *watchImpl) FindByToken(ctx context.Context, token string) (domain.Watch, error) {
+	return impl.find(ctx, &WatchDO{Token: token})
+}
+
+func (impl *watchImpl) find(ctx context.Context, filter *WatchDO) (domain.Watch, error) {
+	var do WatchDO
+
+	if err := impl.GetRecord(ctx, filter, &do); err != nil {
+		return domain.Watch{}, err
+	}
+
+	return do.toWatch(), nil
+}
+
+func (impl *watchImpl) ListOfUser(user primitive.Account) ([]domain.Watch, error) {
+	var dos []WatchDO
+
+	err := impl.DB().Where(impl.EqualQuery(fieldUserName), user.Account()).
+		Order(impl.OrderByDesc(fieldId)).Find(&dos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toWatches(dos), nil
+}
+
+func (impl *watchImpl) ListIgnoring(resourceId primitive.Identity, users []primitive.Account,
+) ([]primitive.Account, error) {
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, len(users))
+	for i := range users {
+		names[i] = users[i].Account()
+	}
+
+	var dos []WatchDO
+
+	err := impl.DB().Where(impl.EqualQuery(fieldResourceId), resourceId.Integer()).
+		Where(impl.EqualQuery(fieldLevel), discussionprimitive.WatchLevelIgnore.WatchLevel()).
+		Where(impl.InFilter(fieldUserName), names).
+		Find(&dos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	v := make([]primitive.Account, len(dos))
+	for i := range dos {
+		v[i] = primitive.CreateAccount(dos[i].User)
+	}
+
+	return v, nil
+}
+
+// ClaimToDigest locks the due watches skipping the ones locked by the other replicas
+// and updates them in one transaction, so that each digest is sent once.
+func (impl *watchImpl) ClaimToDigest(frequency discussionprimitive.DigestFrequency, before, now time.Time,
+) ([]domain.Watch, error) {
+	var dos []WatchDO
+
+	err := impl.DB().Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where(impl.EqualQuery(fieldLevel), discussionprimitive.WatchLevelAll.WatchLevel()).
+			Where(impl.EqualQuery(fieldFrequency), frequency.DigestFrequency()).
+			Where(fieldDigestedAt+" <= ?", before).
+			Order(fieldUserName).Find(&dos).Error
+		if err != nil || len(dos) == 0 {
+			return err
+		}
+
+		ids := make([]int64, len(dos))
+		for i := range dos {
+			ids[i] = dos[i].Id
+		}
+
+		return tx.Model(&WatchDO{}).Where(impl.InFilter(fieldId), ids).Update(fieldDigestedAt, now).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toWatches(dos), nil
+}
+
+func (impl *watchImpl) ResetDigestedAt(watches []domain.Watch) error {
+	for i := range watches {
+		err := impl.DB().Where(impl.EqualQuery(fieldId), watches[i].Id).
+			Update(fieldDigestedAt, watches[i].DigestedAt).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func toWatches(dos []WatchDO) []domain.Watch {
+	v := make([]domain.Watch, len(dos))
+	for i := range dos {
+		v[i] = dos[i].toWatch()
+	}
+
+	return v
+}
+
+func NewWatchEventImpl(db postgresql.Impl) *watchEventImpl {
+	watchEventTableName = db.TableName()
+	err := db.DB().AutoMigrate(&WatchEventDO{})
+	if err != nil {
+		logrus.Fatalf("failed to auto migrate %s table: %v", watchEventTableName, err)
+	}
+
+	return &watchEventImpl{Impl: db}
+}
+
+type watchEventImpl struct {
+	postgresql.Impl
+}
+
+func (impl *watchEventImpl) Add(e *domain.WatchEvent) error {
+	do := toWatchEventDO(e)
+
+	if err := impl.DB().Create(&do).Error; err != nil {
+		return err
+	}
+
+	e.Id = do.Id
+
+	return nil
+}
+
+func (impl *watchEventImpl) List(resourceIds []primitive.Identity, since time.Time) ([]domain.WatchEvent, error) {
+	if len(resourceIds) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, len(resourceIds))
+	for i := range resourceIds {
+		ids[i] = resourceIds[i].Integer()
+	}
+
+	var dos []WatchEventDO
+
+	err := impl.DB().Where(impl.InFilter(fieldResourceId), ids).
+		Where(fieldCreatedAt+" > ?", since).
+		Order(fieldId).Find(&dos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	v := make([]domain.WatchEvent, len(dos))
+	for i := range dos {
+		v[i] = dos[i].toWatchEvent()
+	}
+
+	return v, nil
+}
@@ -0,0 +1,114 @@
+package repositoryimpl
+
+import (
+	"time"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/discussion/domain"
+	discussionprimitive "github.com/openmerlin/merlin-server/discussion/domain/primitive"
+)
+
+var (
+	watchTableName      string
+	watchEventTableName string
+)
+
+type WatchDO struct {
+	Id int64 `gorm:"primaryKey;autoIncrement"`
+
+	User         string    `gorm:"column:user_name;uniqueIndex:watch_index,priority:1"`
+	ResourceId   int64     `gorm:"column:resource_id;uniqueIndex:watch_index,priority:2"`
+	ResourceType string    `gorm:"column:resource_type"`
+	Level        string    `gorm:"column:level;index"`
+	Frequency    string    `gorm:"column:frequency"`
+	Token        string    `gorm:"column:token;uniqueIndex"`
+	DigestedAt   time.Time `gorm:"column:digested_at"`
+	CreatedAt    time.Time `gorm:"column:created_at;<-:create"`
+}
+
+func (do WatchDO) TableName() string {
+	return watchTableName
+}
+
+func toWatchDO(w *domain.Watch) WatchDO {
+	return WatchDO{
+		Id:           w.Id,
+		User:         w.User.Account(),
+		ResourceId:   w.Resource.Id.Integer(),
+		ResourceType: string(w.Resource.Type),
+		Level:        w.Level.WatchLevel(),
+		Frequency:    w.Frequency.DigestFrequency(),
+		Token:        w.Token,
+		DigestedAt:   w.DigestedAt,
+		CreatedAt:    w.CreatedAt,
+	}
+}
+
+func (do WatchDO) toWatch() domain.Watch {
+	return domain.Watch{
+		Id:   do.Id,
+		User: primitive.CreateAccount(do.User),
+		Resource: domain.Resource{
+			Id:   primitive.CreateIdentity(do.ResourceId),
+			Type: primitive.ObjType(do.ResourceType),
+		},
+		Level:      discussionprimitive.CreateWatchLevel(do.Level),
+		Frequency:  discussionprimitive.CreateDigestFrequency(do.Frequency),
+		Token:      do.Token,
+		DigestedAt: do.DigestedAt,
+		CreatedAt:  do.CreatedAt,
+	}
+}
+
+type WatchEventDO struct {
+	Id int64 `gorm:"primaryKey;autoIncrement"`
+
+	ResourceId   int64     `gorm:"column:resource_id;index:watch_event_index,priority:1"`
+	ResourceType string    `gorm:"column:resource_type"`
+	Type         string    `gorm:"column:type"`
+	Actor        string    `gorm:"column:actor"`
+	IssueId      int64     `gorm:"column:issue_id"`
+	Title        string    `gorm:"column:title"`
+	CreatedAt    time.Time `gorm:"column:created_at;<-:create;index:watch_event_index,priority:2"`
+}
+
+func (do WatchEventDO) TableName() string {
+	return watchEventTableName
+}
+
+func toWatchEventDO(e *domain.WatchEvent) WatchEventDO {
+	do := WatchEventDO{
+		Id:           e.Id,
+		ResourceId:   e.Resource.Id.Integer(),
+		ResourceType: string(e.Resource.Type),
+		Type:         e.Type.WatchEventType(),
+		IssueId:      e.IssueId,
+		Title:        e.Title,
+	}
+
+	if e.Actor != nil {
+		do.Actor = e.Actor.Account()
+	}
+
+	return do
+}
+
+func (do WatchEventDO) toWatchEvent() domain.WatchEvent {
+	e := domain.WatchEvent{
+		Id: do.Id,
+		Resource: domain.Resource{
+			Id:   primitive.CreateIdentity(do.ResourceId),
+			Type: primitive.ObjType(do.ResourceType),
+		},
+		Type:      discussionprimitive.CreateWatchEventType(do.Type),
+		IssueId:   do.IssueId,
+		Title:     do.Title,
+		CreatedAt: do.CreatedAt,
+	}
+
+	if do.Actor != "" {
+		e.Actor = primitive.CreateAccount(do.Actor)
+	}
+
+	return e
+}
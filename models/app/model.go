@@ -22,6 +22,9 @@ import (
 	"github.com/openmerlin/merlin-server/models/domain/email"
 	"github.com/openmerlin/merlin-server/models/domain/message"
 	"github.com/openmerlin/merlin-server/models/domain/repository"
+	moderationapp "github.com/openmerlin/merlin-server/moderation/app"
+	moderationdomain "github.com/openmerlin/merlin-server/moderation/domain"
+	moderationprimitive "github.com/openmerlin/merlin-server/moderation/domain/primitive"
 	orgapp "github.com/openmerlin/merlin-server/organization/app"
 	orgrepo "github.com/openmerlin/merlin-server/organization/domain/repository"
 	userapp "github.com/openmerlin/merlin-server/user/app"
@@ -53,6 +56,7 @@ func NewModelAppService(
 	user userapp.UserService,
 	email email.Email,
 	deploy repository.ModelDeployRepoAdapter,
+	report moderationapp.ReportAppService,
 ) ModelAppService {
 	return &modelAppService{
 		permission:  permission,
@@ -64,6 +68,7 @@ func NewModelAppService(
 		user:        user,
 		email:       email,
 		deploy:      deploy,
+		report:      report,
 	}
 }
 
@@ -77,6 +82,7 @@ type modelAppService struct {
 	user        userapp.UserService
 	email       email.Email
 	deploy      repository.ModelDeployRepoAdapter
+	report      moderationapp.ReportAppService
 }
 
 // Create creates a new model.
@@ -476,6 +482,20 @@ func (s *modelAppService) SendReportmail(user primitive.Account, cmd *CmdToRepor
 		err := allerror.NewNoPermission(e.Error(), e)
 		return err
 	}
+	err = s.report.Report(&moderationapp.CmdToReport{
+		Reporter: user,
+		Target: moderationdomain.ReportTarget{
+			Type:         moderationprimitive.ReportTargetModel,
+			Id:           data.Id.Integer(),
+			ResourceId:   data.Id,
+			ResourceType: primitive.ObjTypeModel,
+		},
+		Reason: cmd.Msg,
+	})
+	if err != nil {
+		return err
+	}
+
 	safeMsg := utils.XSSEscapeString(cmd.Msg)
 	url := fmt.Sprintf("%s/models/%s/%s", s.email.GetRootUrl(), data.Owner.Account(), data.Name)
 	if err := s.email.Send(cmd.Model.MSDName(), safeMsg, user.Account(), url); err != nil {
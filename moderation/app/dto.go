@@ -0,0 +1,77 @@
+package app
+
+import (
+	"time"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/moderation/domain"
+	moderationprimitive "github.com/openmerlin/merlin-server/moderation/domain/primitive"
+	"github.com/openmerlin/merlin-server/moderation/domain/repository"
+)
+
+const timeFormat = "2006-01-02 15:04:05"
+
+type CmdToReport struct {
+	Reporter primitive.Account
+	Target   domain.ReportTarget
+	Reason   string
+}
+
+type CmdToListReports struct {
+	Admin  primitive.Account
+	Option repository.ReportListOption
+}
+
+type CmdToHandleReport struct {
+	Admin    primitive.Account
+	ReportId int64
+	Action   moderationprimitive.ReportAction
+}
+
+type ReportDTO struct {
+	Id           int64  `json:"id"`
+	Reporter     string `json:"reporter"`
+	TargetType   string `json:"target_type"`
+	TargetId     int64  `json:"target_id"`
+	ResourceId   string `json:"resource_id"`
+	ResourceType string `json:"resource_type"`
+	Reason       string `json:"reason"`
+	Status       string `json:"status"`
+	Handler      string `json:"handler,omitempty"`
+	Action       string `json:"action,omitempty"`
+	CreatedAt    string `json:"created_at"`
+	HandledAt    string `json:"handled_at,omitempty"`
+}
+
+func toReportDTO(r *domain.Report) ReportDTO {
+	dto := ReportDTO{
+		Id:           r.Id,
+		Reporter:     r.Reporter.Account(),
+		TargetType:   r.Target.Type.ReportTargetType(),
+		TargetId:     r.Target.Id,
+		ResourceId:   r.Target.ResourceId.Identity(),
+		ResourceType: string(r.Target.ResourceType),
+		Reason:       r.Reason,
+		Status:       r.Status.ReportStatus(),
+		CreatedAt:    r.CreatedAt.In(time.UTC).Format(timeFormat),
+	}
+
+	if r.Handler != nil {
+		dto.Handler = r.Handler.Account()
+	}
+
+	if r.Action != nil {
+		dto.Action = r.Action.ReportAction()
+	}
+
+	if !r.HandledAt.IsZero() {
+		dto.HandledAt = r.HandledAt.In(time.UTC).Format(timeFormat)
+	}
+
+	return dto
+}
+
+type ReportsDTO struct {
+	Total   int64       `json:"total"`
+	Reports []ReportDTO `json:"reports"`
+}
@@ -0,0 +1,156 @@
+package app
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	"github.com/openmerlin/merlin-server/moderation/domain"
+	"github.com/openmerlin/merlin-server/moderation/domain/email"
+	"github.com/openmerlin/merlin-server/moderation/domain/moderator"
+	"github.com/openmerlin/merlin-server/moderation/domain/repository"
+	orgapp "github.com/openmerlin/merlin-server/organization/app"
+	userapp "github.com/openmerlin/merlin-server/user/app"
+)
+
+// ModerationAppService is the queue of reports for the admins.
+type ModerationAppService interface {
+	ListReports(context.Context, *CmdToListReports) (ReportsDTO, error)
+	HandleReport(context.Context, *CmdToHandleReport) error
+}
+
+func NewModerationAppService(
+	admin orgapp.PrivilegeOrg,
+	r repository.Report,
+	m moderator.Moderator,
+	e email.Email,
+	user userapp.UserService,
+) *moderationAppService {
+	return &moderationAppService{
+		admin:     admin,
+		repo:      r,
+		moderator: m,
+		email:     e,
+		user:      user,
+	}
+}
+
+type moderationAppService struct {
+	admin     orgapp.PrivilegeOrg
+	repo      repository.Report
+	moderator moderator.Moderator
+	email     email.Email
+	user      userapp.UserService
+}
+
+func (s *moderationAppService) ListReports(ctx context.Context, cmd *CmdToListReports) (ReportsDTO, error) {
+	if err := s.checkAdmin(ctx, cmd.Admin); err != nil {
+		return ReportsDTO{}, err
+	}
+
+	reports, total, err := s.repo.List(&cmd.Option)
+	if err != nil {
+		return ReportsDTO{}, err
+	}
+
+	dtos := make([]ReportDTO, len(reports))
+	for i := range reports {
+		dtos[i] = toReportDTO(&reports[i])
+	}
+
+	return ReportsDTO{Total: total, Reports: dtos}, nil
+}
+
+// HandleReport takes the action on the content reported and tells the reporter the outcome.
+func (s *moderationAppService) HandleReport(ctx context.Context, cmd *CmdToHandleReport) error {
+	if err := s.checkAdmin(ctx, cmd.Admin); err != nil {
+		return err
+	}
+
+	var report domain.Report
+
+	handled := false
+
+	// the report is locked while being handled and stays open if the action or the saving fails,
+	// retrying it is safe since the action taken already is regarded as done.
+	err := s.repo.Handle(ctx, cmd.ReportId, func(r *domain.Report) error {
+		if err := r.Handle(cmd.Admin, cmd.Action); err != nil {
+			return err
+		}
+
+		if err := s.takeAction(ctx, cmd.Admin, r); err != nil {
+			return err
+		}
+
+		report, handled = *r, true
+
+		return nil
+	})
+
+	if commonrepo.IsErrorResourceNotExists(err) {
+		return allerror.NewNotFound(
+			allerror.ErrorCodeReportNotFound,
+			"not found",
+			xerrors.Errorf("failed to find report by id, %w", err),
+		)
+	}
+
+	if err != nil && handled {
+		return allerror.New(allerror.ErrorCodeFailToUpdateReport, "failed to update report", err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	s.notifyReporter(ctx, &report)
+
+	return nil
+}
+
+// takeAction takes the action on the target, the target which has been acted on by another report
+// is regarded as done, so all the reports of the same target can be closed by the action.
+func (s *moderationAppService) takeAction(ctx context.Context, admin primitive.Account, report *domain.Report) error {
+	switch {
+	case report.Action.IsDismiss():
+		return nil
+
+	case report.Action.IsHideComment():
+		return s.moderator.HideComment(ctx, report.Target.Id)
+
+	default:
+		err := s.moderator.DisableResource(ctx, admin, report.Target.ResourceType, report.Target.ResourceId)
+		if allerror.IsResourceAlreadyDisabled(err) {
+			return nil
+		}
+
+		return err
+	}
+}
+
+func (s *moderationAppService) notifyReporter(ctx context.Context, report *domain.Report) {
+	u, err := s.user.GetByAccount(ctx, report.Reporter, report.Reporter)
+	if err != nil || u.Email == nil || *u.Email == "" {
+		return
+	}
+
+	if err = s.email.SendReportResultEmail(*u.Email, report); err != nil {
+		logrus.Errorf("send result of report %d to %s failed: %s", report.Id, report.Reporter.Account(), err.Error())
+	}
+}
+
+func (s *moderationAppService) checkAdmin(ctx context.Context, user primitive.Account) error {
+	if s.admin == nil {
+		return allerror.NewNoPermission("no permission", xerrors.New("admin is not configured"))
+	}
+
+	if err := s.admin.Contains(ctx, user); err != nil {
+		return allerror.NewNoPermission("no permission", xerrors.Errorf("not admin, %w", err))
+	}
+
+	return nil
+}
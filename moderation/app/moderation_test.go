@@ -0,0 +1,101 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/moderation/domain"
+	"github.com/openmerlin/merlin-server/moderation/domain/moderator"
+	moderationprimitive "github.com/openmerlin/merlin-server/moderation/domain/primitive"
+	"github.com/openmerlin/merlin-server/moderation/domain/repository"
+	orgapp "github.com/openmerlin/merlin-server/organization/app"
+	userapp "github.com/openmerlin/merlin-server/user/app"
+)
+
+type stubAdmin struct {
+	orgapp.PrivilegeOrg
+}
+
+func (a stubAdmin) Contains(context.Context, primitive.Account) error {
+	return nil
+}
+
+// stubReportRepo keeps the reports in memory and saves the report only if it is handled successfully.
+type stubReportRepo struct {
+	repository.Report
+	reports map[int64]domain.Report
+}
+
+func (r *stubReportRepo) Handle(_ context.Context, reportId int64, handle func(*domain.Report) error) error {
+	v := r.reports[reportId]
+	if err := handle(&v); err != nil {
+		return err
+	}
+
+	r.reports[reportId] = v
+
+	return nil
+}
+
+// stubModerator disables the resource once, as the app services of resources do.
+type stubModerator struct {
+	moderator.Moderator
+	disabled bool
+}
+
+func (m *stubModerator) DisableResource(context.Context, primitive.Account, primitive.ObjType, primitive.Identity,
+) error {
+	if m.disabled {
+		return allerror.NewResourceDisabled(
+			allerror.ErrorCodeResourceAlreadyDisabled, "already been disabled", errors.New("disabled"),
+		)
+	}
+
+	m.disabled = true
+
+	return nil
+}
+
+type stubUser struct {
+	userapp.UserService
+}
+
+func (u stubUser) GetByAccount(context.Context, primitive.Account, primitive.Account) (userapp.UserDTO, error) {
+	return userapp.UserDTO{}, errors.New("no email")
+}
+
+// TestHandleReportsOfDisabledResource tests that the reports of the same resource are all closed by disabling it,
+// though the resource has been disabled by the first one.
+func TestHandleReportsOfDisabledResource(t *testing.T) {
+	target := domain.ReportTarget{
+		Type:         moderationprimitive.ReportTargetModel,
+		Id:           1,
+		ResourceId:   primitive.CreateIdentity(1),
+		ResourceType: primitive.ObjTypeModel,
+	}
+
+	repo := &stubReportRepo{reports: map[int64]domain.Report{
+		1: domain.NewReport(primitive.CreateAccount("alice"), target, "spam"),
+		2: domain.NewReport(primitive.CreateAccount("bob"), target, "spam"),
+	}}
+
+	s := NewModerationAppService(stubAdmin{}, repo, &stubModerator{}, nil, stubUser{})
+
+	for id := range repo.reports {
+		err := s.HandleReport(context.Background(), &CmdToHandleReport{
+			Admin:    primitive.CreateAccount("admin"),
+			ReportId: id,
+			Action:   moderationprimitive.ReportActionDisableResource,
+		})
+		if err != nil {
+			t.Fatalf("report %d should be handled, %v", id, err)
+		}
+
+		if v := repo.reports[id]; v.Status != moderationprimitive.ReportStatusActioned {
+			t.Fatalf("report %d should be actioned, got %s", id, v.Status.ReportStatus())
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/moderation/domain"
+	"github.com/openmerlin/merlin-server/moderation/domain/repository"
+)
+
+// ReportAppService keeps the reports submitted by users for the admins to handle.
+type ReportAppService interface {
+	Report(*CmdToReport) error
+}
+
+func NewReportAppService(r repository.Report) *reportAppService {
+	return &reportAppService{repo: r}
+}
+
+type reportAppService struct {
+	repo repository.Report
+}
+
+func (s *reportAppService) Report(cmd *CmdToReport) error {
+	report := domain.NewReport(cmd.Reporter, cmd.Target, cmd.Reason)
+
+	if err := s.repo.Add(&report); err != nil {
+		return allerror.New(allerror.ErrorCodeFailToCreateReport, "failed to create report", err)
+	}
+
+	return nil
+}
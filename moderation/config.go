@@ -0,0 +1,18 @@
+package moderation
+
+import (
+	"github.com/openmerlin/merlin-server/moderation/infrastructure/emailimpl"
+	"github.com/openmerlin/merlin-server/moderation/infrastructure/repositoryimpl"
+)
+
+type Config struct {
+	Tables repositoryimpl.Tables `json:"tables"`
+	Email  emailimpl.Config      `json:"email"`
+}
+
+func (cfg *Config) ConfigItems() []interface{} {
+	return []interface{}{
+		&cfg.Tables,
+		&cfg.Email,
+	}
+}
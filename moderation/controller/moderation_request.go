@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/openmerlin/merlin-server/common/controller"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/moderation/app"
+	moderationprimitive "github.com/openmerlin/merlin-server/moderation/domain/primitive"
+	"github.com/openmerlin/merlin-server/moderation/domain/repository"
+)
+
+type reqToListReports struct {
+	controller.CommonListRequest
+
+	// Status is empty if the reports of all status are listed
+	Status string `form:"status"`
+}
+
+func (r reqToListReports) toCmd(admin primitive.Account) (cmd app.CmdToListReports, err error) {
+	if r.PageNum <= 0 {
+		r.PageNum = 1
+	}
+
+	if r.CountPerPage <= 0 {
+		r.CountPerPage = 50
+	}
+
+	cmd = app.CmdToListReports{
+		Admin: admin,
+		Option: repository.ReportListOption{
+			PageNum:      r.PageNum,
+			CountPerPage: r.CountPerPage,
+		},
+	}
+
+	if r.Status != "" {
+		cmd.Option.Status, err = moderationprimitive.NewReportStatus(r.Status)
+	}
+
+	return
+}
+
+type reqToHandleReport struct {
+	Action string `json:"action" binding:"required"`
+}
+
+func (r reqToHandleReport) action(reportId int64) string {
+	return fmt.Sprintf("handle report %d with action %s", reportId, r.Action)
+}
+
+func (r reqToHandleReport) toCmd(admin primitive.Account, reportId int64) (cmd app.CmdToHandleReport, err error) {
+	cmd.Action, err = moderationprimitive.NewReportAction(r.Action)
+	if err != nil {
+		return
+	}
+
+	cmd.Admin = admin
+	cmd.ReportId = reportId
+
+	return
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	commonctl "github.com/openmerlin/merlin-server/common/controller"
+	"github.com/openmerlin/merlin-server/common/controller/middleware"
+	"github.com/openmerlin/merlin-server/moderation/app"
+)
+
+func AddRouterForModerationWebController(
+	r *gin.RouterGroup,
+	m middleware.UserMiddleWare,
+	l middleware.OperationLog,
+	s app.ModerationAppService,
+) {
+	ctl := ModerationWebController{
+		userMiddleWare:    m,
+		moderationService: s,
+	}
+
+	r.GET("/v1/moderation/report", m.Read, ctl.ListReports)
+	r.PUT("/v1/moderation/report/:id", m.Write, l.Write, ctl.HandleReport)
+}
+
+type ModerationWebController struct {
+	userMiddleWare    middleware.UserMiddleWare
+	moderationService app.ModerationAppService
+}
+
+// @Summary  List reports
+// @Description  list the reports submitted by users, only for admins
+// @Tags     ModerationWeb
+// @Param    status            query    string    false    "status of report" Enums(open, actioned, dismissed)
+// @Param    page_num          query    int       false    "page num which starts from 1" Mininum(1)
+// @Param    count_per_page    query    int       false    "count per page" MaxCountPerPage(100)
+// @Accept   json
+// @Security Bearer
+// @Success  200    {object}    commonctl.ResponseData{data=app.ReportsDTO,msg=string,code=string}
+// @Router   /v1/moderation/report [get]
+func (ctl *ModerationWebController) ListReports(ctx *gin.Context) {
+	var req reqToListReports
+	if err := ctx.BindQuery(&req); err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	cmd, err := req.toCmd(ctl.userMiddleWare.GetUser(ctx))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if data, err := ctl.moderationService.ListReports(ctx.Request.Context(), &cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, &data)
+	}
+}
+
+// @Summary  Handle report
+// @Description  dismiss the report, hide the comment or disable the resource reported
+// @Tags     ModerationWeb
+// @Param    id      path    int                  true    "id of report"
+// @Param    body    body    reqToHandleReport    true    "body of handling report"
+// @Accept   json
+// @Security Bearer
+// @Success  202    {object}    commonctl.ResponseData{data=nil,msg=string,code=string}
+// @Router   /v1/moderation/report/{id} [put]
+func (ctl *ModerationWebController) HandleReport(ctx *gin.Context) {
+	middleware.SetAction(ctx, "handle report")
+
+	reportId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	var req reqToHandleReport
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	middleware.SetAction(ctx, req.action(reportId))
+
+	cmd, err := req.toCmd(ctl.userMiddleWare.GetUser(ctx), reportId)
+	if err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	if err := ctl.moderationService.HandleReport(ctx.Request.Context(), &cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, nil)
+	}
+}
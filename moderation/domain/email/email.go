@@ -0,0 +1,9 @@
+package email
+
+import (
+	"github.com/openmerlin/merlin-server/moderation/domain"
+)
+
+type Email interface {
+	SendReportResultEmail(receiver string, report *domain.Report) error
+}
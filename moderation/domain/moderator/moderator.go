@@ -0,0 +1,13 @@
+package moderator
+
+import (
+	"context"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+)
+
+// Moderator takes the actions on the content reported.
+type Moderator interface {
+	HideComment(ctx context.Context, commentId int64) error
+	DisableResource(ctx context.Context, admin primitive.Account, t primitive.ObjType, id primitive.Identity) error
+}
@@ -0,0 +1,50 @@
+package primitive
+
+import "errors"
+
+const (
+	actionDismiss         = "dismiss"
+	actionHideComment     = "hide_comment"
+	actionDisableResource = "disable_resource"
+
+	ReportActionDismiss         = reportAction(actionDismiss)
+	ReportActionHideComment     = reportAction(actionHideComment)
+	ReportActionDisableResource = reportAction(actionDisableResource)
+)
+
+// ReportAction is the action taken by the admin to handle the report.
+type ReportAction interface {
+	ReportAction() string
+	IsDismiss() bool
+	IsHideComment() bool
+}
+
+func NewReportAction(v string) (ReportAction, error) {
+	if v != actionDismiss && v != actionHideComment && v != actionDisableResource {
+		return nil, errors.New("invalid report action")
+	}
+
+	return reportAction(v), nil
+}
+
+func CreateReportAction(v string) ReportAction {
+	if v == "" {
+		return nil
+	}
+
+	return reportAction(v)
+}
+
+type reportAction string
+
+func (a reportAction) ReportAction() string {
+	return string(a)
+}
+
+func (a reportAction) IsDismiss() bool {
+	return string(a) == actionDismiss
+}
+
+func (a reportAction) IsHideComment() bool {
+	return string(a) == actionHideComment
+}
@@ -0,0 +1,40 @@
+package primitive
+
+import "errors"
+
+const (
+	statusOpen      = "open"
+	statusActioned  = "actioned"
+	statusDismissed = "dismissed"
+
+	ReportStatusOpen      = reportStatus(statusOpen)
+	ReportStatusActioned  = reportStatus(statusActioned)
+	ReportStatusDismissed = reportStatus(statusDismissed)
+)
+
+type ReportStatus interface {
+	ReportStatus() string
+	IsOpen() bool
+}
+
+func NewReportStatus(v string) (ReportStatus, error) {
+	if v != statusOpen && v != statusActioned && v != statusDismissed {
+		return nil, errors.New("invalid report status")
+	}
+
+	return reportStatus(v), nil
+}
+
+func CreateReportStatus(v string) ReportStatus {
+	return reportStatus(v)
+}
+
+type reportStatus string
+
+func (s reportStatus) ReportStatus() string {
+	return string(s)
+}
+
+func (s reportStatus) IsOpen() bool {
+	return string(s) == statusOpen
+}
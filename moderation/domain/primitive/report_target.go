@@ -0,0 +1,42 @@
+package primitive
+
+import "errors"
+
+const (
+	targetComment = "comment"
+	targetModel   = "model"
+	targetDataset = "dataset"
+	targetSpace   = "space"
+
+	ReportTargetComment = reportTargetType(targetComment)
+	ReportTargetModel   = reportTargetType(targetModel)
+	ReportTargetDataset = reportTargetType(targetDataset)
+	ReportTargetSpace   = reportTargetType(targetSpace)
+)
+
+type ReportTargetType interface {
+	ReportTargetType() string
+	IsComment() bool
+}
+
+func NewReportTargetType(v string) (ReportTargetType, error) {
+	if v != targetComment && v != targetModel && v != targetDataset && v != targetSpace {
+		return nil, errors.New("invalid report target")
+	}
+
+	return reportTargetType(v), nil
+}
+
+func CreateReportTargetType(v string) ReportTargetType {
+	return reportTargetType(v)
+}
+
+type reportTargetType string
+
+func (t reportTargetType) ReportTargetType() string {
+	return string(t)
+}
+
+func (t reportTargetType) IsComment() bool {
+	return string(t) == targetComment
+}
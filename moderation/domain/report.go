@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	commonprimitive "github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/moderation/domain/primitive"
+)
+
+type Report struct {
+	Id        int64
+	Reporter  commonprimitive.Account
+	Target    ReportTarget
+	Reason    string
+	Status    primitive.ReportStatus
+	Handler   commonprimitive.Account
+	Action    primitive.ReportAction
+	CreatedAt time.Time
+	HandledAt time.Time
+}
+
+// ReportTarget is the comment or the resource reported, the resource is the one where the comment is
+// if the comment is reported.
+type ReportTarget struct {
+	Type         primitive.ReportTargetType
+	Id           int64
+	ResourceId   commonprimitive.Identity
+	ResourceType commonprimitive.ObjType
+}
+
+func NewReport(reporter commonprimitive.Account, target ReportTarget, reason string) Report {
+	return Report{
+		Reporter: reporter,
+		Target:   target,
+		Reason:   reason,
+		Status:   primitive.ReportStatusOpen,
+	}
+}
+
+// Handle closes the report with the action taken by the admin.
+func (r *Report) Handle(handler commonprimitive.Account, action primitive.ReportAction) error {
+	if !r.Status.IsOpen() {
+		return allerror.New(
+			allerror.ErrorCodeReportHandled,
+			"report is handled",
+			errors.New("report is not open"),
+		)
+	}
+
+	if action.IsHideComment() && !r.Target.Type.IsComment() {
+		return allerror.NewInvalidParam("invalid action", errors.New("only comment can be hidden"))
+	}
+
+	r.Status = primitive.ReportStatusActioned
+	if action.IsDismiss() {
+		r.Status = primitive.ReportStatusDismissed
+	}
+
+	r.Handler = handler
+	r.Action = action
+	r.HandledAt = time.Now()
+
+	return nil
+}
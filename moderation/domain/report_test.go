@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+
+	commonprimitive "github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/moderation/domain/primitive"
+)
+
+// TestReportHandle tests that the report is handled once only by the action allowed for its target.
+func TestReportHandle(t *testing.T) {
+	admin := commonprimitive.CreateAccount("admin")
+	target := ReportTarget{Type: primitive.ReportTargetModel, Id: 1}
+
+	report := NewReport(commonprimitive.CreateAccount("alice"), target, "spam")
+
+	if err := report.Handle(admin, primitive.ReportActionHideComment); err == nil {
+		t.Fatal("only comment can be hidden")
+	}
+
+	if err := report.Handle(admin, primitive.ReportActionDismiss); err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Status != primitive.ReportStatusDismissed {
+		t.Fatalf("expect dismissed, got %s", report.Status.ReportStatus())
+	}
+
+	if err := report.Handle(admin, primitive.ReportActionDisableResource); err == nil {
+		t.Fatal("handled report should not be handled again")
+	}
+}
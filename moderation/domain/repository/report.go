@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/openmerlin/merlin-server/moderation/domain"
+	"github.com/openmerlin/merlin-server/moderation/domain/primitive"
+)
+
+type ReportListOption struct {
+	// Status is nil if the reports of all status are listed
+	Status primitive.ReportStatus
+
+	PageNum      int
+	CountPerPage int
+}
+
+func (o ReportListOption) Paginate() (int, int) {
+	offset := (o.PageNum - 1) * o.CountPerPage
+
+	return o.CountPerPage, offset
+}
+
+type Report interface {
+	Add(*domain.Report) error
+	// Handle saves the report handled by the func in a transaction, nothing is saved if the func fails
+	Handle(ctx context.Context, reportId int64, handle func(*domain.Report) error) error
+	Find(context.Context, int64) (domain.Report, error)
+	List(*ReportListOption) ([]domain.Report, int64, error)
+}
@@ -0,0 +1,6 @@
+package emailimpl
+
+type Config struct {
+	RootUrl     string `json:"root_url" required:"true"`
+	ResultTitle string `json:"result_title" required:"true"`
+}
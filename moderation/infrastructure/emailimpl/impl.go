@@ -0,0 +1,57 @@
+package emailimpl
+
+import (
+	"fmt"
+
+	"github.com/openmerlin/merlin-server/moderation/domain"
+	"github.com/openmerlin/merlin-server/utils"
+)
+
+type Email interface {
+	Send(receiver []string, subject, content string) error
+}
+
+func NewEmailImpl(e Email, c *Config) *emailImpl {
+	return &emailImpl{
+		email: e,
+		cfg:   c,
+	}
+}
+
+type emailImpl struct {
+	email Email
+	cfg   *Config
+}
+
+// SendReportResultEmail tells the reporter how the report is handled.
+func (impl *emailImpl) SendReportResultEmail(receiver string, report *domain.Report) error {
+	return impl.email.Send([]string{receiver}, impl.cfg.ResultTitle, impl.buildContent(report))
+}
+
+func (impl *emailImpl) buildContent(report *domain.Report) string {
+	result := "经核实，您举报的内容已被处理，感谢您的反馈。"
+	if report.Action != nil && report.Action.IsDismiss() {
+		result = "经核实，您举报的内容未发现违规，感谢您的反馈。"
+	}
+
+	template := `
+<html>
+<body>
+<p>您好，%s：</p>
+<p>您于 %s 提交的举报（编号 %d）已处理完毕。</p>
+<p>举报原因：%s</p>
+<p>%s</p>
+<p><a href="%s">%s</a></p>
+</body>
+</html>
+`
+
+	return fmt.Sprintf(template,
+		report.Reporter.Account(),
+		report.CreatedAt.Format("2006-01-02 15:04:05"),
+		report.Id,
+		utils.XSSEscapeString(report.Reason),
+		result,
+		impl.cfg.RootUrl, impl.cfg.RootUrl,
+	)
+}
@@ -0,0 +1,68 @@
+package moderatorimpl
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	datasetapp "github.com/openmerlin/merlin-server/datasets/app"
+	discussionapp "github.com/openmerlin/merlin-server/discussion/app"
+	modelapp "github.com/openmerlin/merlin-server/models/app"
+	spaceapp "github.com/openmerlin/merlin-server/space/app"
+)
+
+func NewModeratorImpl(
+	c discussionapp.IssueInternalService,
+	m modelapp.ModelAppService,
+	d datasetapp.DatasetAppService,
+	s spaceapp.SpaceAppService,
+) *moderatorImpl {
+	return &moderatorImpl{
+		comment: c,
+		model:   m,
+		dataset: d,
+		space:   s,
+	}
+}
+
+type moderatorImpl struct {
+	comment discussionapp.IssueInternalService
+	model   modelapp.ModelAppService
+	dataset datasetapp.DatasetAppService
+	space   spaceapp.SpaceAppService
+}
+
+func (impl *moderatorImpl) HideComment(ctx context.Context, commentId int64) error {
+	return impl.comment.HideComment(ctx, commentId)
+}
+
+// DisableResource disables the resource for the illegal content, the admin must be allowed to disable it.
+func (impl *moderatorImpl) DisableResource(
+	ctx context.Context, admin primitive.Account, t primitive.ObjType, id primitive.Identity,
+) (err error) {
+	switch t {
+	case primitive.ObjTypeModel:
+		_, err = impl.model.Disable(ctx, admin, id, &modelapp.CmdToDisableModel{
+			Disable:       true,
+			DisableReason: primitive.ReasonIllegalContent,
+		})
+
+	case primitive.ObjTypeDataset:
+		_, err = impl.dataset.Disable(ctx, admin, id, &datasetapp.CmdToDisableDataset{
+			Disable:       true,
+			DisableReason: primitive.ReasonIllegalContent,
+		})
+
+	case primitive.ObjTypeSpace:
+		_, err = impl.space.Disable(ctx, admin, id, &spaceapp.CmdToDisableSpace{
+			Disable:       true,
+			DisableReason: primitive.ReasonIllegalContent,
+		})
+
+	default:
+		err = xerrors.Errorf("unknown resource type %s", t)
+	}
+
+	return
+}
@@ -0,0 +1,5 @@
+package repositoryimpl
+
+type Tables struct {
+	Report string `json:"report" required:"true"`
+}
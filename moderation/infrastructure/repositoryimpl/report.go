@@ -0,0 +1,110 @@
+package repositoryimpl
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+
+	"github.com/openmerlin/merlin-server/common/infrastructure/postgresql"
+	"github.com/openmerlin/merlin-server/moderation/domain"
+	"github.com/openmerlin/merlin-server/moderation/domain/repository"
+)
+
+const (
+	fieldId     = "id"
+	fieldStatus = "status"
+)
+
+func NewReportImpl(db postgresql.Impl) *reportImpl {
+	reportTableName = db.TableName()
+	err := db.DB().AutoMigrate(&ReportDO{})
+	if err != nil {
+		logrus.Fatalf("failed to auto migrate %s table: %v", reportTableName, err)
+	}
+
+	return &reportImpl{Impl: db}
+}
+
+type reportImpl struct {
+	postgresql.Impl
+}
+
+func (impl *reportImpl) Add(r *domain.Report) error {
+	do := toReportDO(r)
+
+	if err := impl.DB().Create(&do).Error; err != nil {
+		return err
+	}
+
+	r.Id = do.Id
+
+	return nil
+}
+
+// Handle locks the report and saves it after it is handled in one transaction, so the report
+// is handled once and stays as it was if the handling fails.
+func (impl *reportImpl) Handle(ctx context.Context, reportId int64, handle func(*domain.Report) error) error {
+	return impl.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		do := ReportDO{Id: reportId}
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&do).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return commonrepo.NewErrorResourceNotExists(err)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		r := do.toReport()
+		if err = handle(&r); err != nil {
+			return err
+		}
+
+		do = toReportDO(&r)
+
+		return tx.Save(&do).Error
+	})
+}
+
+func (impl *reportImpl) Find(ctx context.Context, reportId int64) (domain.Report, error) {
+	do := ReportDO{Id: reportId}
+	if err := impl.GetByPrimaryKey(ctx, &do); err != nil {
+		return domain.Report{}, err
+	}
+
+	return do.toReport(), nil
+}
+
+func (impl *reportImpl) List(option *repository.ReportListOption) ([]domain.Report, int64, error) {
+	query := impl.DB()
+	if option.Status != nil {
+		query = query.Where(impl.EqualQuery(fieldStatus), option.Status.ReportStatus())
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	limit, offset := option.Paginate()
+
+	var dos []ReportDO
+
+	err := query.Order(impl.OrderByDesc(fieldId)).Limit(limit).Offset(offset).Find(&dos).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	v := make([]domain.Report, len(dos))
+	for i := range dos {
+		v[i] = dos[i].toReport()
+	}
+
+	return v, total, nil
+}
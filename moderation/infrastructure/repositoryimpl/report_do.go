@@ -0,0 +1,86 @@
+package repositoryimpl
+
+import (
+	"time"
+
+	commonprimitive "github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/moderation/domain"
+	"github.com/openmerlin/merlin-server/moderation/domain/primitive"
+)
+
+var reportTableName string
+
+type ReportDO struct {
+	Id int64 `gorm:"primaryKey;autoIncrement"`
+
+	Reporter     string    `gorm:"column:reporter"`
+	TargetType   string    `gorm:"column:target_type"`
+	TargetId     int64     `gorm:"column:target_id"`
+	ResourceId   int64     `gorm:"column:resource_id"`
+	ResourceType string    `gorm:"column:resource_type"`
+	Reason       string    `gorm:"column:reason"`
+	Status       string    `gorm:"column:status;index"`
+	Handler      string    `gorm:"column:handler"`
+	Action       string    `gorm:"column:action"`
+	HandledAt    int64     `gorm:"column:handled_at"`
+	CreatedAt    time.Time `gorm:"column:created_at;<-:create"`
+}
+
+func (do ReportDO) TableName() string {
+	return reportTableName
+}
+
+func toReportDO(r *domain.Report) ReportDO {
+	do := ReportDO{
+		Id:           r.Id,
+		Reporter:     r.Reporter.Account(),
+		TargetType:   r.Target.Type.ReportTargetType(),
+		TargetId:     r.Target.Id,
+		ResourceId:   r.Target.ResourceId.Integer(),
+		ResourceType: string(r.Target.ResourceType),
+		Reason:       r.Reason,
+		Status:       r.Status.ReportStatus(),
+		CreatedAt:    r.CreatedAt,
+	}
+
+	if r.Handler != nil {
+		do.Handler = r.Handler.Account()
+	}
+
+	if r.Action != nil {
+		do.Action = r.Action.ReportAction()
+	}
+
+	if !r.HandledAt.IsZero() {
+		do.HandledAt = r.HandledAt.Unix()
+	}
+
+	return do
+}
+
+func (do ReportDO) toReport() domain.Report {
+	r := domain.Report{
+		Id:       do.Id,
+		Reporter: commonprimitive.CreateAccount(do.Reporter),
+		Target: domain.ReportTarget{
+			Type:         primitive.CreateReportTargetType(do.TargetType),
+			Id:           do.TargetId,
+			ResourceId:   commonprimitive.CreateIdentity(do.ResourceId),
+			ResourceType: commonprimitive.ObjType(do.ResourceType),
+		},
+		Reason:    do.Reason,
+		Status:    primitive.CreateReportStatus(do.Status),
+		Action:    primitive.CreateReportAction(do.Action),
+		CreatedAt: do.CreatedAt,
+	}
+
+	if do.Handler != "" {
+		r.Handler = commonprimitive.CreateAccount(do.Handler)
+	}
+
+	if do.HandledAt > 0 {
+		r.HandledAt = time.Unix(do.HandledAt, 0)
+	}
+
+	return r
+}
@@ -24,7 +24,6 @@ import (
 	spaceapp "github.com/openmerlin/merlin-server/space/app"
 	"github.com/openmerlin/merlin-server/space/infrastructure/messageadapter"
 	"github.com/openmerlin/merlin-server/space/infrastructure/spacerepositoryadapter"
-	"github.com/openmerlin/merlin-server/spaceapp/infrastructure/repositoryadapter"
 )
 
 func initCodeRepo(cfg *config.Config, services *allServices) error {
@@ -86,6 +85,7 @@ func setRouterOfCodeRepoPermissionInternal(rg *gin.RouterGroup, services *allSer
 			datasetrepositoryadapter.DatasetAdapter(),
 			spacerepositoryadapter.SpaceAdapter(),
 		),
+		branchrepositoryadapter.BranchAdapter(),
 		services.userMiddleWare,
 	)
 }
@@ -111,7 +111,7 @@ func setRouterOfCodeRepoStatisticInternal(rg *gin.RouterGroup, services *allServ
 		spaceapp.NewSpaceInternalAppService(
 			spacerepositoryadapter.SpaceAdapter(),
 			messageadapter.MessageAdapter(&cfg.Space.Topics),
-			repositoryadapter.AppRepositoryAdapter(),
+			services.spaceappRepo,
 			spacerepositoryadapter.ModelSpaceRelationAdapter(),
 			modelrepositoryadapter.ModelAdapter(),
 		),
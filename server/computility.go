@@ -14,6 +14,7 @@ import (
 	"github.com/openmerlin/merlin-server/computility/infrastructure/messageadapter"
 	"github.com/openmerlin/merlin-server/computility/infrastructure/repositoryadapter"
 	"github.com/openmerlin/merlin-server/config"
+	orgrepoimpl "github.com/openmerlin/merlin-server/organization/infrastructure/repositoryimpl"
 )
 
 func initComputilityApp(cfg *config.Config, services *allServices) error {
@@ -23,6 +24,8 @@ func initComputilityApp(cfg *config.Config, services *allServices) error {
 		repositoryadapter.ComputilityDetailAdapter(),
 		repositoryadapter.ComputilityAccountAdapter(),
 		repositoryadapter.ComputilityAccountRecordAdapter(),
+		repositoryadapter.ComputilityUsageAdapter(),
+		repositoryadapter.ComputilityLedgerAdapter(),
 		messageadapter.MessageAdapter(&cfg.Computility.Topics),
 		services.npuGatekeeper,
 	)
@@ -38,11 +41,14 @@ func setRouterOfComputilityAppInternal(rg *gin.RouterGroup, services *allService
 	)
 }
 
-func setRouterOfComputilityAppWeb(rg *gin.RouterGroup, services *allServices) {
+func setRouterOfComputilityAppWeb(rg *gin.RouterGroup, services *allServices, cfg *config.Config) {
 	s := app.NewComputilityAppService(
 		repositoryadapter.ComputilityOrgAdapter(),
 		repositoryadapter.ComputilityDetailAdapter(),
 		repositoryadapter.ComputilityAccountAdapter(),
+		repositoryadapter.ComputilityUsageAdapter(),
+		repositoryadapter.ComputilityLedgerAdapter(),
+		orgrepoimpl.NewMemberRepo(postgresql.DAO(cfg.Org.Domain.Tables.Member)),
 	)
 
 	controller.AddRouterForComputilityWebController(
@@ -34,6 +34,7 @@ func initDataset(cfg *config.Config, services *allServices) error {
 		services.disable,
 		services.userApp,
 		emailimpl.NewEmailImpl(email.GetEmailInst(), cfg.Email.ReportEmail, cfg.Email.RootUrl, cfg.Email.MailTemplate),
+		services.reportApp,
 	)
 
 	return nil
@@ -3,35 +3,70 @@ package server
 import (
 	"github.com/gin-gonic/gin"
 
+	coderepoapp "github.com/openmerlin/merlin-server/coderepo/app"
+	"github.com/openmerlin/merlin-server/coderepo/infrastructure/branchclientadapter"
+	"github.com/openmerlin/merlin-server/coderepo/infrastructure/branchrepositoryadapter"
 	"github.com/openmerlin/merlin-server/coderepo/infrastructure/resourceadapterimpl"
 	"github.com/openmerlin/merlin-server/common/infrastructure/email"
+	"github.com/openmerlin/merlin-server/common/infrastructure/gitea"
 	"github.com/openmerlin/merlin-server/common/infrastructure/postgresql"
 	"github.com/openmerlin/merlin-server/config"
 	"github.com/openmerlin/merlin-server/datasets/infrastructure/datasetrepositoryadapter"
 	"github.com/openmerlin/merlin-server/discussion/app"
 	"github.com/openmerlin/merlin-server/discussion/controller"
 	"github.com/openmerlin/merlin-server/discussion/infrastructure/emailimpl"
+	"github.com/openmerlin/merlin-server/discussion/infrastructure/issuetemplateimpl"
 	"github.com/openmerlin/merlin-server/discussion/infrastructure/messageimpl"
+	"github.com/openmerlin/merlin-server/discussion/infrastructure/pullrequestimpl"
 	"github.com/openmerlin/merlin-server/discussion/infrastructure/repositoryimpl"
 	"github.com/openmerlin/merlin-server/models/infrastructure/modelrepositoryadapter"
+	orgrepoimpl "github.com/openmerlin/merlin-server/organization/infrastructure/repositoryimpl"
 	"github.com/openmerlin/merlin-server/space/infrastructure/spacerepositoryadapter"
 )
 
 func initDiscussion(cfg *config.Config, services *allServices) {
 	issueRepoImpl := repositoryimpl.NewIssueImpl(postgresql.DAO(cfg.Discussion.Tables.Issue))
 	commentRepoImpl := repositoryimpl.NewIssueCommentImpl(postgresql.DAO(cfg.Discussion.Tables.IssueComment))
+	reactionRepoImpl := repositoryimpl.NewReactionImpl(postgresql.DAO(cfg.Discussion.Tables.Reaction))
+	labelRepoImpl := repositoryimpl.NewLabelImpl(postgresql.DAO(cfg.Discussion.Tables.Label))
+	notificationRepoImpl := repositoryimpl.NewNotificationImpl(postgresql.DAO(cfg.Discussion.Tables.Notification))
+	preferenceRepoImpl := repositoryimpl.NewNotificationPreferenceImpl(
+		postgresql.DAO(cfg.Discussion.Tables.NotificationPreference),
+	)
+	issueTemplateRepoImpl := repositoryimpl.NewIssueTemplateImpl(postgresql.DAO(cfg.Discussion.Tables.IssueTemplate))
+	issueTemplateClient := issuetemplateimpl.NewIssueTemplateImpl(gitea.Client())
+	watchRepoImpl := repositoryimpl.NewWatchImpl(postgresql.DAO(cfg.Discussion.Tables.Watch))
+	watchEventRepoImpl := repositoryimpl.NewWatchEventImpl(postgresql.DAO(cfg.Discussion.Tables.WatchEvent))
+	emailImpl := emailimpl.NewEmailImpl(email.GetEmailInst(), &cfg.Discussion.Report)
 	resourceImpl := resourceadapterimpl.NewResourceAdapterImpl(
 		modelrepositoryadapter.ModelAdapter(),
 		datasetrepositoryadapter.DatasetAdapter(),
 		spacerepositoryadapter.SpaceAdapter(),
 	)
 
+	notifier := app.NewNotifier(
+		services.userApp,
+		orgrepoimpl.NewMemberRepo(postgresql.DAO(cfg.Org.Domain.Tables.Member)),
+		commentRepoImpl,
+		notificationRepoImpl,
+		preferenceRepoImpl,
+		emailImpl,
+		watchRepoImpl,
+		watchEventRepoImpl,
+		services.permissionApp,
+	)
+
 	services.discussionIssue = app.NewIssueService(
 		resourceImpl,
 		services.permissionApp,
 		issueRepoImpl,
 		issueRepoImpl,
 		commentRepoImpl,
+		labelRepoImpl,
+		reactionRepoImpl,
+		notifier,
+		issueTemplateRepoImpl,
+		issueTemplateClient,
 	)
 
 	services.discussionComment = app.NewCommentService(
@@ -40,9 +75,54 @@ func initDiscussion(cfg *config.Config, services *allServices) {
 		issueRepoImpl,
 		commentRepoImpl,
 		messageimpl.NewMessageImpl(cfg.Discussion.Topics),
-		emailimpl.NewEmailImpl(email.GetEmailInst(), &cfg.Discussion.Report),
+		emailImpl,
+		reactionRepoImpl,
+		notifier,
+		repositoryimpl.NewCommentRevisionImpl(postgresql.DAO(cfg.Discussion.Tables.CommentRevision)),
+		services.reportApp,
+		services.disable,
+	)
+
+	services.discussionPullRequest = app.NewPullRequestService(
+		resourceImpl,
+		services.permissionApp,
+		issueRepoImpl,
+		commentRepoImpl,
+		repositoryimpl.NewPullRequestImpl(postgresql.DAO(cfg.Discussion.Tables.PullRequest)),
+		pullrequestimpl.NewPullRequestImpl(gitea.Client()),
+		coderepoapp.NewBranchAppService(
+			services.permissionApp,
+			branchrepositoryadapter.BranchAdapter(),
+			resourceImpl,
+			branchclientadapter.NewBranchClientAdapter(gitea.Client()),
+		),
+		services.discussionIssue,
+		notifier,
 	)
 
+	services.discussionLabel = app.NewLabelService(resourceImpl, services.permissionApp, labelRepoImpl)
+
+	services.discussionIssueTemplate = app.NewIssueTemplateService(
+		resourceImpl,
+		services.permissionApp,
+		issueTemplateRepoImpl,
+		issueTemplateClient,
+	)
+
+	services.discussionNotification = app.NewNotificationService(notificationRepoImpl, preferenceRepoImpl)
+
+	watchService := app.NewWatchService(
+		resourceImpl,
+		services.permissionApp,
+		watchRepoImpl,
+		watchEventRepoImpl,
+		services.userApp,
+		emailImpl,
+	)
+
+	services.discussionWatch = watchService
+	services.discussionWatchEvent = watchService
+
 	services.discussion = app.NewDiscussionService(
 		resourceImpl,
 		services.permissionApp,
@@ -60,6 +140,11 @@ func setRouterOfDiscussionWeb(rg *gin.RouterGroup, services *allServices) {
 		services.discussionIssue,
 		services.discussionComment,
 		services.discussion,
+		services.discussionPullRequest,
+		services.discussionLabel,
+		services.discussionNotification,
+		services.discussionWatch,
+		services.discussionIssueTemplate,
 	)
 }
 
@@ -74,5 +159,7 @@ func setRouterOfDiscussionInternal(cfg *config.Config, rg *gin.RouterGroup, serv
 			repositoryimpl.NewIssueCommentImpl(
 				postgresql.DAO(cfg.Discussion.Tables.IssueComment),
 			),
-		))
+		),
+		services.discussionWatchEvent,
+	)
 }
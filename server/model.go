@@ -35,6 +35,7 @@ func initModel(cfg *config.Config, services *allServices) error {
 		services.userApp,
 		emailimpl.NewEmailImpl(email.GetEmailInst(), cfg.Email.ReportEmail, cfg.Email.RootUrl, cfg.Email.MailTemplate),
 		modelrepositoryadapter.ModelDeployAdapter(),
+		services.reportApp,
 	)
 
 	return nil
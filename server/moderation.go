@@ -0,0 +1,49 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/openmerlin/merlin-server/common/infrastructure/email"
+	"github.com/openmerlin/merlin-server/common/infrastructure/postgresql"
+	"github.com/openmerlin/merlin-server/config"
+	discussionapp "github.com/openmerlin/merlin-server/discussion/app"
+	discussionrepoimpl "github.com/openmerlin/merlin-server/discussion/infrastructure/repositoryimpl"
+	"github.com/openmerlin/merlin-server/moderation/app"
+	"github.com/openmerlin/merlin-server/moderation/controller"
+	"github.com/openmerlin/merlin-server/moderation/infrastructure/emailimpl"
+	"github.com/openmerlin/merlin-server/moderation/infrastructure/moderatorimpl"
+	"github.com/openmerlin/merlin-server/moderation/infrastructure/repositoryimpl"
+)
+
+func initReport(cfg *config.Config, services *allServices) {
+	services.reportApp = app.NewReportAppService(
+		repositoryimpl.NewReportImpl(postgresql.DAO(cfg.Moderation.Tables.Report)),
+	)
+}
+
+func initModeration(cfg *config.Config, services *allServices) {
+	services.moderationApp = app.NewModerationAppService(
+		services.disable,
+		repositoryimpl.NewReportImpl(postgresql.DAO(cfg.Moderation.Tables.Report)),
+		moderatorimpl.NewModeratorImpl(
+			discussionapp.NewIssueInternalService(
+				discussionrepoimpl.NewIssueImpl(postgresql.DAO(cfg.Discussion.Tables.Issue)),
+				discussionrepoimpl.NewIssueCommentImpl(postgresql.DAO(cfg.Discussion.Tables.IssueComment)),
+			),
+			services.modelApp,
+			services.datasetApp,
+			services.spaceApp,
+		),
+		emailimpl.NewEmailImpl(email.GetEmailInst(), &cfg.Moderation.Email),
+		services.userApp,
+	)
+}
+
+func setRouterOfModerationWeb(rg *gin.RouterGroup, services *allServices) {
+	controller.AddRouterForModerationWebController(
+		rg,
+		services.userMiddleWare,
+		services.operationLog,
+		services.moderationApp,
+	)
+}
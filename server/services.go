@@ -15,10 +15,12 @@ import (
 	datasetapp "github.com/openmerlin/merlin-server/datasets/app"
 	"github.com/openmerlin/merlin-server/discussion/app"
 	modelapp "github.com/openmerlin/merlin-server/models/app"
+	moderationapp "github.com/openmerlin/merlin-server/moderation/app"
 	orgapp "github.com/openmerlin/merlin-server/organization/app"
 	sessionapp "github.com/openmerlin/merlin-server/session/app"
 	spaceapp "github.com/openmerlin/merlin-server/space/app"
 	spaceappApp "github.com/openmerlin/merlin-server/spaceapp/app"
+	spaceapprepo "github.com/openmerlin/merlin-server/spaceapp/domain/repository"
 	userapp "github.com/openmerlin/merlin-server/user/app"
 	"github.com/openmerlin/merlin-server/user/controller"
 	userrepo "github.com/openmerlin/merlin-server/user/domain/repository"
@@ -55,6 +57,14 @@ type allServices struct {
 
 	spaceappApp spaceappApp.SpaceappAppService
 
+	spaceappRepo spaceapprepo.Repository
+
+	spaceappMetric spaceappApp.SpaceAppMetricAppService
+	spaceappQueue  spaceappApp.SpaceAppQueueAppService
+
+	spaceappInternal   spaceappApp.SpaceappInternalAppService
+	spaceappPreemption spaceappApp.SpaceAppPreemptionAppService
+
 	activityApp activityapp.ActivityAppService
 
 	modelSpace spaceapp.ModelSpaceAppService
@@ -63,13 +73,30 @@ type allServices struct {
 
 	spaceSecret spaceapp.SpaceSecretService
 
+	spaceCustomDomain spaceapp.SpaceCustomDomainService
+
+	orgEnv spaceapp.OrgEnvAppService
+
+	baseImage spaceapp.BaseImageAppService
+
+	spacePriority spaceapp.SpacePriorityAppService
+
 	computilityApp computilityapp.ComputilityInternalAppService
 
 	privacyClear controller.PrivacyClear
 
-	discussionIssue   app.IssueService
-	discussionComment app.CommentService
-	discussion        app.DiscussionService
+	discussionIssue         app.IssueService
+	discussionComment       app.CommentService
+	discussion              app.DiscussionService
+	discussionPullRequest   app.PullRequestService
+	discussionLabel         app.LabelService
+	discussionNotification  app.NotificationService
+	discussionWatch         app.WatchService
+	discussionWatchEvent    app.WatchInternalService
+	discussionIssueTemplate app.IssueTemplateService
+
+	reportApp     moderationapp.ReportAppService
+	moderationApp moderationapp.ModerationAppService
 }
 
 func initServices(cfg *config.Config) (services allServices, err error) {
@@ -87,7 +114,9 @@ func initServices(cfg *config.Config) (services allServices, err error) {
 		return
 	}
 
-	// initModel depends on initCodeRepo and initOrg
+	initReport(cfg, &services)
+
+	// initModel depends on initCodeRepo and initOrg and initReport
 	if err = initModel(cfg, &services); err != nil {
 		return
 	}
@@ -119,5 +148,8 @@ func initServices(cfg *config.Config) (services allServices, err error) {
 	// initDiscussion depends on init initOrg
 	initDiscussion(cfg, &services)
 
+	// initModeration depends on initModel, initDataset and initSpace
+	initModeration(cfg, &services)
+
 	return
 }
@@ -18,12 +18,13 @@ import (
 	orgrepoimpl "github.com/openmerlin/merlin-server/organization/infrastructure/repositoryimpl"
 	"github.com/openmerlin/merlin-server/space/app"
 	"github.com/openmerlin/merlin-server/space/controller"
+	"github.com/openmerlin/merlin-server/space/infrastructure/dnsresolveradapter"
 	emailimpl "github.com/openmerlin/merlin-server/space/infrastructure/emailadapter"
 	"github.com/openmerlin/merlin-server/space/infrastructure/messageadapter"
 	"github.com/openmerlin/merlin-server/space/infrastructure/obsadapter"
 	"github.com/openmerlin/merlin-server/space/infrastructure/securestoragadapter"
 	"github.com/openmerlin/merlin-server/space/infrastructure/spacerepositoryadapter"
-	"github.com/openmerlin/merlin-server/spaceapp/infrastructure/repositoryadapter"
+	spaceapprepositoryadapter "github.com/openmerlin/merlin-server/spaceapp/infrastructure/repositoryadapter"
 )
 
 func initSpace(cfg *config.Config, services *allServices) error {
@@ -36,7 +37,7 @@ func initSpace(cfg *config.Config, services *allServices) error {
 		services.permissionApp,
 		messageadapter.MessageAdapter(&cfg.Space.Topics),
 		services.codeRepoApp,
-		repositoryadapter.AppRepositoryAdapter(),
+		services.spaceappRepo,
 		spacerepositoryadapter.SpaceVariableAdapter(),
 		spacerepositoryadapter.SpaceSecretAdapter(),
 		securestoragadapter.SecureStorageAdapter(securestorage.GetClient(), cfg.Vault.BasePath),
@@ -49,6 +50,11 @@ func initSpace(cfg *config.Config, services *allServices) error {
 		services.userApp,
 		obsadapter.NewClient(obs.Client()),
 		emailimpl.NewEmailImpl(email.GetEmailInst(), cfg.Email.ReportEmail, cfg.Email.RootUrl, cfg.Email.MailTemplate),
+		spacerepositoryadapter.SpaceCustomDomainAdapter(),
+		spacerepositoryadapter.OrgEnvAdapter(),
+		spacerepositoryadapter.BaseImageAdapter(),
+		services.reportApp,
+		spaceapprepositoryadapter.QueueAdapter(),
 	)
 
 	services.modelSpace = app.NewModelSpaceAppService(
@@ -56,7 +62,7 @@ func initSpace(cfg *config.Config, services *allServices) error {
 		spacerepositoryadapter.ModelSpaceRelationAdapter(),
 		modelrepositoryadapter.ModelAdapter(),
 		spacerepositoryadapter.SpaceAdapter(),
-		repositoryadapter.AppRepositoryAdapter(),
+		services.spaceappRepo,
 		modelapp.NewModelInternalAppService(
 			modelrepositoryadapter.ModelLabelsAdapter(),
 			modelrepositoryadapter.ModelAdapter(),
@@ -67,19 +73,51 @@ func initSpace(cfg *config.Config, services *allServices) error {
 	services.spaceVariable = app.NewSpaceVariableService(
 		services.permissionApp,
 		spacerepositoryadapter.SpaceAdapter(),
-		repositoryadapter.AppRepositoryAdapter(),
+		services.spaceappRepo,
 		spacerepositoryadapter.SpaceVariableAdapter(),
 		securestoragadapter.SecureStorageAdapter(securestorage.GetClient(), cfg.Vault.BasePath),
 		messageadapter.MessageAdapter(&cfg.Space.Topics),
+		spacerepositoryadapter.OrgEnvAdapter(),
 	)
 
 	services.spaceSecret = app.NewSpaceSecretService(
 		services.permissionApp,
 		spacerepositoryadapter.SpaceAdapter(),
-		repositoryadapter.AppRepositoryAdapter(),
+		services.spaceappRepo,
 		spacerepositoryadapter.SpaceSecretAdapter(),
 		securestoragadapter.SecureStorageAdapter(securestorage.GetClient(), cfg.Vault.BasePath),
 		messageadapter.MessageAdapter(&cfg.Space.Topics),
+		spacerepositoryadapter.OrgEnvAdapter(),
+	)
+
+	services.spaceCustomDomain = app.NewSpaceCustomDomainService(
+		services.permissionApp,
+		spacerepositoryadapter.SpaceAdapter(),
+		services.spaceappRepo,
+		spacerepositoryadapter.SpaceCustomDomainAdapter(),
+		dnsresolveradapter.NewResolver(&cfg.Space.DNSResolver),
+	)
+
+	services.orgEnv = app.NewOrgEnvAppService(
+		services.permissionApp,
+		spacerepositoryadapter.SpaceAdapter(),
+		services.spaceappRepo,
+		orgrepoimpl.NewMemberRepo(postgresql.DAO(cfg.Org.Domain.Tables.Member)),
+		spacerepositoryadapter.OrgEnvAdapter(),
+		spacerepositoryadapter.SpaceVariableAdapter(),
+		securestoragadapter.SecureStorageAdapter(securestorage.GetClient(), cfg.Vault.BasePath),
+		messageadapter.MessageAdapter(&cfg.Space.Topics),
+	)
+
+	services.baseImage = app.NewBaseImageAppService(
+		services.disable,
+		spacerepositoryadapter.SpaceAdapter(),
+		spacerepositoryadapter.BaseImageAdapter(),
+	)
+
+	services.spacePriority = app.NewSpacePriorityAppService(
+		spacerepositoryadapter.SpaceAdapter(),
+		orgrepoimpl.NewMemberRepo(postgresql.DAO(cfg.Org.Domain.Tables.Member)),
 	)
 
 	return nil
@@ -92,6 +130,10 @@ func setRouterOfSpaceWeb(rg *gin.RouterGroup, services *allServices) {
 		services.modelSpace,
 		services.spaceVariable,
 		services.spaceSecret,
+		services.spaceCustomDomain,
+		services.orgEnv,
+		services.baseImage,
+		services.spacePriority,
 		services.userMiddleWare,
 		services.operationLog,
 		services.securityLog,
@@ -122,11 +164,13 @@ func setRouterOfSpaceInternal(rg *gin.RouterGroup, services *allServices, cfg *c
 		app.NewSpaceInternalAppService(
 			spacerepositoryadapter.SpaceAdapter(),
 			messageadapter.MessageAdapter(&cfg.Space.Topics),
-			repositoryadapter.AppRepositoryAdapter(),
+			services.spaceappRepo,
 			spacerepositoryadapter.ModelSpaceRelationAdapter(),
 			modelrepositoryadapter.ModelAdapter(),
 		),
 		services.modelSpace,
+		services.spaceCustomDomain,
+		services.orgEnv,
 		services.userMiddleWare,
 	)
 }
@@ -8,12 +8,14 @@ package server
 import (
 	"github.com/gin-gonic/gin"
 
+	"github.com/openmerlin/merlin-server/common/infrastructure/email"
 	"github.com/openmerlin/merlin-server/common/infrastructure/postgresql"
 	"github.com/openmerlin/merlin-server/config"
 	"github.com/openmerlin/merlin-server/models/infrastructure/modelrepositoryadapter"
 	"github.com/openmerlin/merlin-server/space/infrastructure/spacerepositoryadapter"
 	"github.com/openmerlin/merlin-server/spaceapp/app"
 	"github.com/openmerlin/merlin-server/spaceapp/controller"
+	"github.com/openmerlin/merlin-server/spaceapp/infrastructure/emailadapter"
 	"github.com/openmerlin/merlin-server/spaceapp/infrastructure/messageadapter"
 	"github.com/openmerlin/merlin-server/spaceapp/infrastructure/repositoryadapter"
 	"github.com/openmerlin/merlin-server/spaceapp/infrastructure/sseadapter"
@@ -30,9 +32,19 @@ func initSpaceApp(cfg *config.Config, services *allServices) error {
 		return err
 	}
 
+	// the queued space apps are dispatched whenever the quota is released
+	quotaReleaseNotifier := app.NewQuotaReleaseNotifier(services.computilityApp)
+	services.computilityApp = quotaReleaseNotifier
+
+	services.spaceappRepo = app.NewMeteredRepository(
+		repositoryadapter.AppRepositoryAdapter(),
+		spacerepositoryadapter.SpaceAdapter(),
+		services.computilityApp,
+	)
+
 	services.spaceappApp = app.NewSpaceappAppService(
 		messageadapter.MessageAdapter(&cfg.SpaceApp.Topics),
-		repositoryadapter.AppRepositoryAdapter(),
+		services.spaceappRepo,
 		spacerepositoryadapter.SpaceAdapter(),
 		services.permissionApp,
 		sseadapter.StreamSentAdapter(),
@@ -40,6 +52,45 @@ func initSpaceApp(cfg *config.Config, services *allServices) error {
 		spacerepositoryadapter.ModelSpaceRelationAdapter(),
 		modelrepositoryadapter.ModelAdapter(),
 		repositoryadapter.BuildLogAdapter(),
+		repositoryadapter.EmbedTokenAdapter(),
+	)
+
+	services.spaceappMetric = app.NewSpaceAppMetricAppService(
+		services.spaceappRepo,
+		spacerepositoryadapter.SpaceAdapter(),
+		services.permissionApp,
+		repositoryadapter.MetricAdapter(),
+	)
+
+	queue := app.NewSpaceAppQueueAppService(
+		services.spaceappApp,
+		spacerepositoryadapter.SpaceAdapter(),
+		services.permissionApp,
+		repositoryadapter.QueueAdapter(),
+	)
+	quotaReleaseNotifier.Listen(queue.DispatchOnRelease)
+
+	services.spaceappQueue = queue
+
+	services.spaceappInternal = app.NewSpaceappInternalAppService(
+		messageadapter.MessageAdapter(&cfg.SpaceApp.Topics),
+		services.spaceappRepo,
+		repositoryadapter.BuildLogAdapter(),
+		spacerepositoryadapter.SpaceAdapter(),
+		services.computilityApp,
+	)
+
+	services.spaceappPreemption = app.NewSpaceAppPreemptionAppService(
+		services.spaceappApp,
+		services.spaceappInternal,
+		spacerepositoryadapter.SpaceAdapter(),
+		services.permissionApp,
+		services.spaceappRepo,
+		repositoryadapter.MetricAdapter(),
+		services.computilityApp,
+		messageadapter.MessageAdapter(&cfg.SpaceApp.Topics),
+		emailadapter.NewEmailImpl(email.GetEmailInst(), cfg.Email.RootUrl),
+		services.userApp,
 	)
 
 	return nil
@@ -49,6 +100,14 @@ func setRouterOfSpaceAppWeb(rg *gin.RouterGroup, services *allServices) {
 	controller.AddRouterForSpaceappWebController(
 		rg,
 		services.spaceappApp,
+		app.NewSpaceEmbedTokenAppService(
+			spacerepositoryadapter.SpaceAdapter(),
+			services.permissionApp,
+			repositoryadapter.EmbedTokenAdapter(),
+		),
+		services.spaceappMetric,
+		services.spaceappQueue,
+		services.spaceappPreemption,
 		services.userMiddleWare,
 		services.tokenMiddleWare,
 		services.rateLimiterMiddleWare,
@@ -66,15 +125,7 @@ func setRouterOfSpaceAppRestful(rg *gin.RouterGroup, services *allServices) {
 }
 
 func setRouterOfSpaceAppInternal(rg *gin.RouterGroup, services *allServices, cfg *config.Config) {
-	s := app.NewSpaceappInternalAppService(
-		messageadapter.MessageAdapter(&cfg.SpaceApp.Topics),
-		repositoryadapter.AppRepositoryAdapter(),
-		repositoryadapter.BuildLogAdapter(),
-		spacerepositoryadapter.SpaceAdapter(),
-		services.computilityApp,
-	)
-
 	controller.AddRouteForSpaceappInternalController(
-		rg, s, services.userMiddleWare,
+		rg, services.spaceappInternal, services.spaceappMetric, services.spaceappQueue, services.userMiddleWare,
 	)
 }
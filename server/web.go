@@ -56,9 +56,11 @@ func setRouterOfWeb(prefix string, engine *gin.Engine, cfg *config.Config, servi
 
 	setRouterOfActivityWeb(rg, services)
 
-	setRouterOfComputilityAppWeb(rg, services)
+	setRouterOfComputilityAppWeb(rg, services, cfg)
 
 	setRouterOfOther(rg, cfg)
 
 	setRouterOfDiscussionWeb(rg, services)
+
+	setRouterOfModerationWeb(rg, services)
 }
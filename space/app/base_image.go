@@ -0,0 +1,435 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	orgapp "github.com/openmerlin/merlin-server/organization/app"
+	"github.com/openmerlin/merlin-server/space/domain"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+	spacerepo "github.com/openmerlin/merlin-server/space/domain/repository"
+	"github.com/openmerlin/merlin-server/utils"
+)
+
+func newBaseImageNotFound(err error) error {
+	return allerror.NewNotFound(allerror.ErrorCodeBaseImageNotFound, "not found", err)
+}
+
+// BaseImageAppService is an interface for the base image catalog service,
+// the catalog is managed by the administrator and validated on space creation.
+type BaseImageAppService interface {
+	Create(context.Context, primitive.Account, *CmdToCreateBaseImage) (BaseImageDTO, string, error)
+	Update(context.Context, primitive.Account, primitive.Identity, *CmdToUpdateBaseImage) (BaseImageDTO, string, error)
+	Delete(context.Context, primitive.Account, primitive.Identity) (string, error)
+	List(*CmdToListBaseImages) ([]BaseImageDTO, error)
+	ListDeprecatedSpaces(
+		context.Context, primitive.Account, *CmdToListDeprecatedBaseImageSpaces,
+	) (DeprecatedBaseImageSpacesDTO, error)
+}
+
+// NewBaseImageAppService creates a new instance of the base image catalog service.
+func NewBaseImageAppService(
+	admin orgapp.PrivilegeOrg,
+	repoAdapter spacerepo.SpaceRepositoryAdapter,
+	baseImageAdapter spacerepo.BaseImageRepositoryAdapter,
+) BaseImageAppService {
+	return &baseImageAppService{
+		admin:            admin,
+		repoAdapter:      repoAdapter,
+		baseImageAdapter: baseImageAdapter,
+	}
+}
+
+type baseImageAppService struct {
+	admin            orgapp.PrivilegeOrg
+	repoAdapter      spacerepo.SpaceRepositoryAdapter
+	baseImageAdapter spacerepo.BaseImageRepositoryAdapter
+}
+
+// canManage checks whether the user is the administrator who can manage the catalog.
+func (s *baseImageAppService) canManage(ctx context.Context, user primitive.Account) error {
+	if user == nil {
+		return allerror.NewNoPermission("no permission", fmt.Errorf("anonymous user"))
+	}
+
+	if s.admin == nil {
+		logrus.Errorf("do not config admin org, no permit to manage base image")
+
+		return allerror.NewNoPermission("no permission", fmt.Errorf("cant manage base image"))
+	}
+
+	if err := s.admin.Contains(ctx, user); err != nil {
+		logrus.Errorf("user:%s cant manage base image err:%s", user.Account(), err)
+
+		return allerror.NewNoPermission("no permission", fmt.Errorf("cant manage base image"))
+	}
+
+	return nil
+}
+
+// Create adds a base image to the catalog.
+func (s *baseImageAppService) Create(
+	ctx context.Context, user primitive.Account, cmd *CmdToCreateBaseImage,
+) (dto BaseImageDTO, action string, err error) {
+	action = fmt.Sprintf("add base image %s to catalog", cmd.Name.BaseImage())
+
+	if err = s.canManage(ctx, user); err != nil {
+		return
+	}
+
+	sdks, hardware, err := domain.NewBaseImageCompatibility(cmd.SDKs, cmd.Hardware)
+	if err != nil {
+		err = allerror.NewInvalidParam(err.Error(), err)
+
+		return
+	}
+
+	if _, err = s.baseImageAdapter.FindBaseImageByName(cmd.Name); err == nil {
+		err = allerror.New(allerror.ErrorCodeBaseImageExists, "base image exists",
+			xerrors.Errorf("base image %s exists", cmd.Name.BaseImage()))
+
+		return
+	} else if !commonrepo.IsErrorResourceNotExists(err) {
+		return
+	}
+
+	now := utils.Now()
+	e := domain.BaseImageEntry{
+		Name:      cmd.Name,
+		SDKs:      sdks,
+		Hardware:  hardware,
+		CreatedBy: user,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if cmd.IsDefault {
+		_ = e.SetDefault()
+	}
+
+	if err = s.baseImageAdapter.AddBaseImage(&e); err != nil {
+		return
+	}
+
+	if e.IsDefault {
+		s.clearOverlappedDefault(&e)
+	}
+
+	dto = toBaseImageDTO(&e)
+
+	return
+}
+
+// Update updates the compatibility, the default flag or the deprecation state of a base image.
+func (s *baseImageAppService) Update(
+	ctx context.Context, user primitive.Account, id primitive.Identity, cmd *CmdToUpdateBaseImage,
+) (dto BaseImageDTO, action string, err error) {
+	action = fmt.Sprintf("update base image of %s", id.Identity())
+
+	if err = s.canManage(ctx, user); err != nil {
+		return
+	}
+
+	e, err := s.baseImageAdapter.FindBaseImageById(id)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newBaseImageNotFound(err)
+		}
+
+		return
+	}
+
+	action = fmt.Sprintf("update base image %s", e.Name.BaseImage())
+
+	if cmd.SDKs != nil || cmd.Hardware != nil {
+		sdks, hardware := e.SDKs, e.Hardware
+		if cmd.SDKs != nil {
+			sdks = cmd.SDKs
+		}
+
+		if cmd.Hardware != nil {
+			hardware = cmd.Hardware
+		}
+
+		if e.SDKs, e.Hardware, err = domain.NewBaseImageCompatibility(sdks, hardware); err != nil {
+			err = allerror.NewInvalidParam(err.Error(), err)
+
+			return
+		}
+	}
+
+	if err = s.updateDeprecation(&e, cmd); err != nil {
+		return
+	}
+
+	if cmd.IsDefault != nil {
+		if !*cmd.IsDefault {
+			e.IsDefault = false
+		} else if err = e.SetDefault(); err != nil {
+			err = allerror.NewInvalidParam(err.Error(), err)
+
+			return
+		}
+	}
+
+	e.UpdatedAt = utils.Now()
+
+	if err = s.baseImageAdapter.SaveBaseImage(&e); err != nil {
+		return
+	}
+
+	e.Version += 1
+
+	if e.IsDefault {
+		s.clearOverlappedDefault(&e)
+	}
+
+	dto = toBaseImageDTO(&e)
+
+	return
+}
+
+func (s *baseImageAppService) updateDeprecation(e *domain.BaseImageEntry, cmd *CmdToUpdateBaseImage) error {
+	if cmd.Deprecated == nil {
+		return nil
+	}
+
+	if !*cmd.Deprecated {
+		e.Undeprecate()
+
+		return nil
+	}
+
+	replacement := ""
+	if cmd.Replacement != nil {
+		if cmd.Replacement.BaseImage() == e.Name.BaseImage() {
+			return allerror.NewInvalidParam("invalid replacement", xerrors.New("replaced by itself"))
+		}
+
+		r, err := s.baseImageAdapter.FindBaseImageByName(cmd.Replacement)
+		if err != nil {
+			if commonrepo.IsErrorResourceNotExists(err) {
+				err = newBaseImageNotFound(err)
+			}
+
+			return err
+		}
+
+		if r.Deprecated {
+			return allerror.New(allerror.ErrorCodeBaseImageDeprecated, "replacement is deprecated",
+				xerrors.Errorf("replacement %s is deprecated", r.Name.BaseImage()))
+		}
+
+		replacement = r.Name.BaseImage()
+	}
+
+	e.Deprecate(replacement, utils.Now())
+
+	return nil
+}
+
+// clearOverlappedDefault makes sure there is only one default base image for each hardware.
+func (s *baseImageAppService) clearOverlappedDefault(e *domain.BaseImageEntry) {
+	entries, err := s.baseImageAdapter.ListBaseImages(&spacerepo.BaseImageListOption{})
+	if err != nil {
+		logrus.Errorf("list base images failed, err:%s", err.Error())
+
+		return
+	}
+
+	for i := range entries {
+		item := &entries[i]
+		if !e.OverlapDefault(item) {
+			continue
+		}
+
+		item.IsDefault = false
+		item.UpdatedAt = utils.Now()
+
+		if err := s.baseImageAdapter.SaveBaseImage(item); err != nil {
+			logrus.Errorf("clear default of base image %s failed, err:%s", item.Name.BaseImage(), err.Error())
+		}
+	}
+}
+
+// Delete deletes a base image from the catalog, it is refused if there are spaces using it.
+func (s *baseImageAppService) Delete(
+	ctx context.Context, user primitive.Account, id primitive.Identity,
+) (action string, err error) {
+	action = fmt.Sprintf("delete base image of %s", id.Identity())
+
+	if err = s.canManage(ctx, user); err != nil {
+		return
+	}
+
+	e, err := s.baseImageAdapter.FindBaseImageById(id)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = nil
+		}
+
+		return
+	}
+
+	action = fmt.Sprintf("delete base image %s", e.Name.BaseImage())
+
+	n, err := s.repoAdapter.Count(&spacerepo.ListOption{BaseImages: []string{e.Name.BaseImage()}})
+	if err != nil {
+		return
+	}
+
+	if n > 0 {
+		err = allerror.New(allerror.ErrorCodeBaseImageInUse, "base image is in use, deprecate it instead",
+			xerrors.Errorf("base image %s is used by %d spaces", e.Name.BaseImage(), n))
+
+		return
+	}
+
+	err = s.baseImageAdapter.DeleteBaseImage(id)
+
+	return
+}
+
+// List lists the base images of the catalog.
+func (s *baseImageAppService) List(cmd *CmdToListBaseImages) ([]BaseImageDTO, error) {
+	entries, err := s.baseImageAdapter.ListBaseImages(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]BaseImageDTO, len(entries))
+	for i := range entries {
+		dtos[i] = toBaseImageDTO(&entries[i])
+	}
+
+	return dtos, nil
+}
+
+// ListDeprecatedSpaces lists the spaces using deprecated base images, so that the owners can be
+// nudged to migrate. The administrator can list all the spaces, others can list their own spaces only.
+func (s *baseImageAppService) ListDeprecatedSpaces(
+	ctx context.Context, user primitive.Account, cmd *CmdToListDeprecatedBaseImageSpaces,
+) (dto DeprecatedBaseImageSpacesDTO, err error) {
+	if user == nil || cmd.Owner == nil || cmd.Owner != user {
+		if err = s.canManage(ctx, user); err != nil {
+			return
+		}
+	}
+
+	entries, err := s.baseImageAdapter.ListBaseImages(&spacerepo.BaseImageListOption{OnlyDeprecated: true})
+	if err != nil || len(entries) == 0 {
+		return
+	}
+
+	deprecated := make(map[string]*domain.BaseImageEntry, len(entries))
+	names := make([]string, len(entries))
+	for i := range entries {
+		names[i] = entries[i].Name.BaseImage()
+		deprecated[names[i]] = &entries[i]
+	}
+
+	sortType, _ := primitive.NewSortType(primitive.SortByRecentlyUpdated)
+
+	spaces, total, err := s.repoAdapter.List(&spacerepo.ListOption{
+		Owner:        cmd.Owner,
+		BaseImages:   names,
+		SortType:     sortType,
+		Count:        true,
+		PageNum:      cmd.PageNum,
+		CountPerPage: cmd.CountPerPage,
+	}, nil, nil)
+	if err != nil {
+		return
+	}
+
+	dto.Total = total
+	dto.Spaces = make([]DeprecatedBaseImageSpaceDTO, len(spaces))
+	for i := range spaces {
+		item := &spaces[i]
+		e := deprecated[item.BaseImage]
+
+		dto.Spaces[i] = DeprecatedBaseImageSpaceDTO{
+			Id:        item.Id,
+			Owner:     item.Owner,
+			Name:      item.Name,
+			BaseImage: item.BaseImage,
+			UpdatedAt: item.UpdatedAt,
+		}
+
+		if e != nil {
+			dto.Spaces[i].Replacement = e.Replacement
+			dto.Spaces[i].DeprecatedAt = e.DeprecatedAt
+		}
+	}
+
+	return
+}
+
+// checkBaseImageOfSpace validates the base image of the new space against the catalog,
+// the default base image of the hardware is used if it is not specified.
+func checkBaseImageOfSpace(
+	adapter spacerepo.BaseImageRepositoryAdapter, cmd *CmdToCreateSpace,
+) error {
+	// the docker space is built from its Dockerfile and the static space has no runtime
+	if cmd.SDK.IsDocker() || cmd.SDK.IsStatic() {
+		cmd.BaseImage = spaceprimitive.CreateBaseImage("")
+
+		return nil
+	}
+
+	sdk, hardware := cmd.SDK.SDK(), cmd.Hardware.Hardware()
+
+	if cmd.BaseImage == nil {
+		entries, err := adapter.ListBaseImages(&spacerepo.BaseImageListOption{
+			SDK:               sdk,
+			Hardware:          hardware,
+			ExcludeDeprecated: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		for i := range entries {
+			if entries[i].IsDefault {
+				cmd.BaseImage = entries[i].Name
+
+				return nil
+			}
+		}
+
+		return allerror.NewInvalidParam("base image is required",
+			xerrors.Errorf("no default base image for %s/%s", sdk, hardware))
+	}
+
+	e, err := adapter.FindBaseImageByName(cmd.BaseImage)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = allerror.NewInvalidParam("invalid base image",
+				xerrors.Errorf("base image %s is not in the catalog", cmd.BaseImage.BaseImage()))
+		}
+
+		return err
+	}
+
+	if e.Deprecated {
+		return allerror.New(allerror.ErrorCodeBaseImageDeprecated, "base image is deprecated",
+			xerrors.Errorf("base image %s is deprecated", e.Name.BaseImage()))
+	}
+
+	if !e.Support(sdk, hardware) {
+		return allerror.NewInvalidParam("invalid base image",
+			xerrors.Errorf("base image %s doesn't support %s/%s", e.Name.BaseImage(), sdk, hardware))
+	}
+
+	return nil
+}
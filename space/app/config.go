@@ -37,9 +37,16 @@ type Config struct {
 	RecommendSpaces       []RecommendIndex `json:"recommend_spaces"`
 	BoutiqueSpaces        []BoutiqueIndex  `json:"boutique_spaces"`
 	RegexpRule            string           `json:"regexp_rule"`
+	LocalRun              LocalRunConfig   `json:"local_run"`
 	avatarIdsSet          sets.Set[string]
 }
 
+// LocalRunConfig is the config to generate the instructions of running space locally.
+type LocalRunConfig struct {
+	GitURL        string `json:"git_url"`
+	ImageRegistry string `json:"image_registry"`
+}
+
 type RecommendIndex struct {
 	Owner    string `json:"owner" required:"true"`
 	Reponame string `json:"reponame" required:"true"`
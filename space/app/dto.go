@@ -30,6 +30,7 @@ type CmdToCreateSpace struct {
 	HardwareType string
 	BaseImage    spaceprimitive.BaseImage
 	AvatarId     primitive.Avatar
+	Docker       spaceprimitive.DockerOption
 }
 
 func (cmd *CmdToCreateSpace) toSpace() domain.Space {
@@ -39,8 +40,12 @@ func (cmd *CmdToCreateSpace) toSpace() domain.Space {
 	}
 
 	label := domain.SpaceLabels{
-		Framework: cmd.BaseImage.Type(),
-		Licenses:  cmd.License,
+		Licenses: cmd.License,
+	}
+
+	// the space of docker or static sdk doesn't use the base image
+	if cmd.BaseImage.BaseImage() != "" {
+		label.Framework = cmd.BaseImage.Type()
 	}
 
 	s := domain.Space{
@@ -51,6 +56,7 @@ func (cmd *CmdToCreateSpace) toSpace() domain.Space {
 		Fullname:  cmd.Fullname,
 		AvatarId:  cmd.AvatarId,
 		Labels:    label,
+		Docker:    cmd.Docker,
 	}
 
 	return s
@@ -65,6 +71,10 @@ type CmdToUpdateSpace struct {
 	Fullname primitive.MSDFullname
 	Hardware spaceprimitive.Hardware
 	AvatarId primitive.Avatar
+
+	// docker option of the space of docker sdk
+	AppPort   *int
+	RunAsUser *int
 }
 
 func (cmd *CmdToUpdateSpace) toSpace(space *domain.Space) (b bool) {
@@ -136,11 +146,15 @@ type SpaceDTO struct {
 	Disable       bool           `json:"disable"`
 	DisableReason string         `json:"disable_reason"`
 	Exception     string         `json:"exception"`
+	AppPort       int            `json:"app_port,omitempty"`
+	RunAsUser     int            `json:"run_as_user,omitempty"`
+	LocalRun      *LocalRunDTO   `json:"local_run,omitempty"`
 
 	IsNpu                bool `json:"is_npu"`
 	CompPowerAllocated   bool `json:"comp_power_allocated"`
 	NoApplicationFile    bool `json:"no_application_file"`
 	IsDiscussionDisabled bool `json:"is_discussion_disabled"`
+	Priority             int  `json:"priority"`
 }
 
 // SpaceLabelsDTO is a struct used to represent labels of a space.
@@ -186,6 +200,7 @@ func toSpaceDTO(space *domain.Space) SpaceDTO {
 		CompPowerAllocated:   space.CompPowerAllocated,
 		NoApplicationFile:    space.NoApplicationFile,
 		IsDiscussionDisabled: space.IsDiscussionDisabled,
+		Priority:             space.Priority,
 	}
 
 	if space.Desc != nil {
@@ -196,6 +211,11 @@ func toSpaceDTO(space *domain.Space) SpaceDTO {
 		dto.Fullname = space.Fullname.MSDFullname()
 	}
 
+	if space.Docker != nil {
+		dto.AppPort = space.Docker.AppPort()
+		dto.RunAsUser = space.Docker.RunAsUser()
+	}
+
 	return dto
 }
 
@@ -303,33 +323,130 @@ type SpaceVariableSecretDTO struct {
 	SpaceVariableSecret []repository.SpaceVariableSecretSummary `json:"space_variable_secret"`
 }
 
+// CmdToImportSpaceVariables is a struct used to import space variables from the content of dotenv file.
+type CmdToImportSpaceVariables struct {
+	Content string
+	DryRun  bool
+}
+
+// SpaceVariableChangeDTO represents a variable which will be added or updated by import.
+type SpaceVariableChangeDTO struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	OldValue string `json:"old_value,omitempty"`
+}
+
+// SpaceVariableImportDTO represents the diff of importing space variables.
+type SpaceVariableImportDTO struct {
+	DryRun    bool                     `json:"dry_run"`
+	Added     []SpaceVariableChangeDTO `json:"added"`
+	Updated   []SpaceVariableChangeDTO `json:"updated"`
+	Unchanged []string                 `json:"unchanged"`
+}
+
+func toSpaceVariableImportDTO(imp *domain.SpaceVariableImport, dryRun bool) SpaceVariableImportDTO {
+	dto := SpaceVariableImportDTO{
+		DryRun:    dryRun,
+		Added:     make([]SpaceVariableChangeDTO, len(imp.Added)),
+		Updated:   make([]SpaceVariableChangeDTO, len(imp.Updated)),
+		Unchanged: imp.Unchanged,
+	}
+
+	for i := range imp.Added {
+		dto.Added[i] = SpaceVariableChangeDTO{
+			Name:  imp.Added[i].Name.ENVName(),
+			Value: imp.Added[i].Value.ENVValue(),
+		}
+	}
+
+	for i := range imp.Updated {
+		item := SpaceVariableChangeDTO{
+			Name:  imp.Updated[i].Name.ENVName(),
+			Value: imp.Updated[i].Value.ENVValue(),
+		}
+
+		if v := imp.Previous[item.Name]; v != nil {
+			item.OldValue = v.ENVValue()
+		}
+
+		dto.Updated[i] = item
+	}
+
+	if dto.Unchanged == nil {
+		dto.Unchanged = []string{}
+	}
+
+	return dto
+}
+
+// SpaceVariableExportDTO represents the space variables in the format of dotenv file.
+type SpaceVariableExportDTO struct {
+	Content string `json:"content"`
+	Total   int    `json:"total"`
+}
+
 // CmdToCreateSpaceSecret is a struct used to create a space secret.
 type CmdToCreateSpaceSecret struct {
-	Name  spaceprimitive.ENVName
-	Desc  primitive.MSDDesc
-	Value spaceprimitive.ENVValue
+	Name        spaceprimitive.ENVName
+	Desc        primitive.MSDDesc
+	Value       spaceprimitive.ENVValue
+	AutoRestart bool
 }
 
 // CmdToUpdateSpaceSecret is a struct used to update a space secret.
 type CmdToUpdateSpaceSecret struct {
-	Desc  primitive.MSDDesc
-	Value spaceprimitive.ENVValue
+	Desc        primitive.MSDDesc
+	Value       spaceprimitive.ENVValue
+	AutoRestart *bool
 }
 
-func (cmd *CmdToUpdateSpaceSecret) toSpaceSecret(spaceSecret *domain.SpaceSecret) (b bool) {
+// toSpaceSecret updates the space secret, rotated is true if a new version of value is set.
+func (cmd *CmdToUpdateSpaceSecret) toSpaceSecret(spaceSecret *domain.SpaceSecret) (b, rotated bool) {
 	if v := cmd.Desc; v != nil && v != spaceSecret.Desc {
 		spaceSecret.Desc = v
 		b = true
 	}
 
+	if v := cmd.AutoRestart; v != nil && *v != spaceSecret.AutoRestart {
+		spaceSecret.AutoRestart = *v
+		b = true
+	}
+
 	if v := cmd.Value; v != nil && v != spaceSecret.Value {
-		spaceSecret.Value = v
+		spaceSecret.Rotate(v, utils.Now())
 		b = true
+		rotated = true
 	}
 
 	return
 }
 
+// CmdToRollbackSpaceSecret is a struct used to roll back a space secret to an old version.
+type CmdToRollbackSpaceSecret struct {
+	Version int
+}
+
+// SpaceSecretVersionDTO represents a version of space secret, the value is never returned.
+type SpaceSecretVersionDTO struct {
+	Version      int    `json:"version"`
+	Action       string `json:"action"`
+	RollbackFrom int    `json:"rollback_from,omitempty"`
+	CreatedBy    string `json:"created_by"`
+	CreatedAt    int64  `json:"created_at"`
+	IsCurrent    bool   `json:"is_current"`
+}
+
+func toSpaceSecretVersionDTO(v *domain.SpaceSecretVersion, current int) SpaceSecretVersionDTO {
+	return SpaceSecretVersionDTO{
+		Version:      v.Version,
+		Action:       v.Action,
+		RollbackFrom: v.RollbackFrom,
+		CreatedBy:    v.CreatedBy.Account(),
+		CreatedAt:    v.CreatedAt,
+		IsCurrent:    v.Version == current,
+	}
+}
+
 // CmdToUpdateStatistics is to update download count
 type CmdToUpdateStatistics struct {
 	DownloadCount int `json:"download_count"`
@@ -348,9 +465,10 @@ type CmdToResetLabels struct {
 
 // CmdToNotifyUpdateCode is to update no application file and commitId
 type CmdToNotifyUpdateCode struct {
-	CommitId string
-	HasHtml  bool
-	HasApp   bool
+	CommitId      string
+	HasHtml       bool
+	HasApp        bool
+	HasDockerfile bool
 }
 
 // CmdToUploadCover is to update no application file and commitId
@@ -369,3 +487,294 @@ func toSpaceCoverDTO(u string) SpaceCoverDTO {
 		URL: u,
 	}
 }
+
+// CmdToAddSpaceCustomDomain is a struct used to add a custom domain to space.
+type CmdToAddSpaceCustomDomain struct {
+	Domain spaceprimitive.CustomDomain
+}
+
+// SpaceCustomDomainDTO represents the data transfer object for space custom domain.
+type SpaceCustomDomainDTO struct {
+	Id         string `json:"id"`
+	Domain     string `json:"domain"`
+	Status     string `json:"status"`
+	TXTHost    string `json:"txt_host"`
+	TXTValue   string `json:"txt_value"`
+	CreatedAt  int64  `json:"created_at"`
+	VerifiedAt int64  `json:"verified_at"`
+}
+
+func toSpaceCustomDomainDTO(d *domain.SpaceCustomDomain) SpaceCustomDomainDTO {
+	return SpaceCustomDomainDTO{
+		Id:         d.Id.Identity(),
+		Domain:     d.Domain.CustomDomain(),
+		Status:     d.Status.DomainStatus(),
+		TXTHost:    d.ChallengeHost(),
+		TXTValue:   d.ChallengeValue(),
+		CreatedAt:  d.CreatedAt,
+		VerifiedAt: d.VerifiedAt,
+	}
+}
+
+// SpaceCustomDomainMappingDTO represents the mapping from a verified custom domain to the space app.
+type SpaceCustomDomainMappingDTO struct {
+	Domain  string `json:"domain"`
+	SpaceId string `json:"space_id"`
+	Owner   string `json:"owner"`
+	Name    string `json:"name"`
+	AppURL  string `json:"app_url"`
+}
+
+// CmdToCreateOrgEnv is a struct used to create an org env.
+type CmdToCreateOrgEnv struct {
+	Owner primitive.Account
+	Type  string
+	Name  spaceprimitive.ENVName
+	Desc  primitive.MSDDesc
+	Value spaceprimitive.ENVValue
+}
+
+// CmdToUpdateOrgEnv is a struct used to update an org env.
+type CmdToUpdateOrgEnv struct {
+	Desc              primitive.MSDDesc
+	Value             spaceprimitive.ENVValue
+	RestartDependents bool
+}
+
+func (cmd *CmdToUpdateOrgEnv) toOrgEnv(e *domain.OrgEnv) (b, valueChanged bool) {
+	if v := cmd.Desc; v != nil && v != e.Desc {
+		e.Desc = v
+		b = true
+	}
+
+	if v := cmd.Value; v != nil && v != e.Value {
+		e.Value = v
+		b = true
+		valueChanged = true
+	}
+
+	if b {
+		e.UpdatedAt = utils.Now()
+	}
+
+	return
+}
+
+// OrgEnvDTO represents the org env, the value of secret is never returned.
+type OrgEnvDTO struct {
+	Id        string `json:"id"`
+	Owner     string `json:"owner"`
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Desc      string `json:"desc"`
+	Value     string `json:"value,omitempty"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+func toOrgEnvDTO(e *domain.OrgEnv) OrgEnvDTO {
+	dto := OrgEnvDTO{
+		Id:        e.Id.Identity(),
+		Owner:     e.Owner.Account(),
+		Type:      e.Type,
+		Name:      e.Name.ENVName(),
+		CreatedBy: e.CreatedBy.Account(),
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+
+	if e.Desc != nil {
+		dto.Desc = e.Desc.MSDDesc()
+	}
+
+	if !e.IsSecret() && e.Value != nil {
+		dto.Value = e.Value.ENVValue()
+	}
+
+	return dto
+}
+
+// SpaceEnvDependentDTO represents a space which references the org env.
+type SpaceEnvDependentDTO struct {
+	SpaceId     string `json:"space_id"`
+	Owner       string `json:"owner"`
+	Name        string `json:"name"`
+	ReferenceId string `json:"reference_id"`
+	Restarted   bool   `json:"restarted"`
+}
+
+// OrgEnvUpdatedDTO represents the updated org env and the spaces depending on it.
+type OrgEnvUpdatedDTO struct {
+	OrgEnv     OrgEnvDTO              `json:"org_env"`
+	Dependents []SpaceEnvDependentDTO `json:"dependents"`
+}
+
+// CmdToAddSpaceEnvReference is a struct used to reference an org env from the space.
+type CmdToAddSpaceEnvReference struct {
+	Type string
+	Name spaceprimitive.ENVName
+}
+
+// SpaceEnvReferenceDTO represents a reference from the space to an org env.
+type SpaceEnvReferenceDTO struct {
+	Id        string `json:"id"`
+	Owner     string `json:"owner"`
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Resolved  bool   `json:"resolved"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+func toSpaceEnvReferenceDTO(r *domain.SpaceEnvReference, resolved bool) SpaceEnvReferenceDTO {
+	return SpaceEnvReferenceDTO{
+		Id:        r.Id.Identity(),
+		Owner:     r.Owner.Account(),
+		Type:      r.Type,
+		Name:      r.Name.ENVName(),
+		Resolved:  resolved,
+		CreatedBy: r.CreatedBy.Account(),
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+// SpaceEnvResolvedDTO tells the builder where to read the value of the referenced org env.
+type SpaceEnvResolvedDTO struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Path     string `json:"path,omitempty"`
+	Key      string `json:"key,omitempty"`
+	Resolved bool   `json:"resolved"`
+}
+
+// CmdToCreateBaseImage is a struct used to add a base image to the catalog.
+type CmdToCreateBaseImage struct {
+	Name      spaceprimitive.BaseImage
+	SDKs      []string
+	Hardware  []string
+	IsDefault bool
+}
+
+// CmdToUpdateBaseImage is a struct used to update a base image of the catalog,
+// the nil field is left unchanged.
+type CmdToUpdateBaseImage struct {
+	SDKs        []string
+	Hardware    []string
+	IsDefault   *bool
+	Deprecated  *bool
+	Replacement spaceprimitive.BaseImage
+}
+
+// BaseImageDTO represents a base image of the catalog.
+type BaseImageDTO struct {
+	Id           string   `json:"id"`
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	SDKs         []string `json:"sdks"`
+	Hardware     []string `json:"hardware"`
+	Deprecated   bool     `json:"deprecated"`
+	DeprecatedAt int64    `json:"deprecated_at"`
+	Replacement  string   `json:"replacement"`
+	IsDefault    bool     `json:"is_default"`
+	CreatedBy    string   `json:"created_by"`
+	CreatedAt    int64    `json:"created_at"`
+	UpdatedAt    int64    `json:"updated_at"`
+}
+
+func toBaseImageDTO(e *domain.BaseImageEntry) BaseImageDTO {
+	return BaseImageDTO{
+		Id:           e.Id.Identity(),
+		Name:         e.Name.BaseImage(),
+		Type:         e.Name.Type(),
+		SDKs:         e.SDKs,
+		Hardware:     e.Hardware,
+		Deprecated:   e.Deprecated,
+		DeprecatedAt: e.DeprecatedAt,
+		Replacement:  e.Replacement,
+		IsDefault:    e.IsDefault,
+		CreatedBy:    e.CreatedBy.Account(),
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
+	}
+}
+
+// CmdToListBaseImages is a command to list the base images of the catalog.
+type CmdToListBaseImages = repository.BaseImageListOption
+
+// CmdToListDeprecatedBaseImageSpaces is a command to list the spaces using deprecated base images.
+type CmdToListDeprecatedBaseImageSpaces struct {
+	Owner        primitive.Account
+	PageNum      int
+	CountPerPage int
+}
+
+// DeprecatedBaseImageSpaceDTO represents a space using a deprecated base image.
+type DeprecatedBaseImageSpaceDTO struct {
+	Id           string `json:"id"`
+	Owner        string `json:"owner"`
+	Name         string `json:"name"`
+	BaseImage    string `json:"base_image"`
+	Replacement  string `json:"replacement"`
+	DeprecatedAt int64  `json:"deprecated_at"`
+	UpdatedAt    int64  `json:"updated_at"`
+}
+
+// DeprecatedBaseImageSpacesDTO represents the spaces using deprecated base images.
+type DeprecatedBaseImageSpacesDTO struct {
+	Total  int                           `json:"total"`
+	Spaces []DeprecatedBaseImageSpaceDTO `json:"spaces"`
+}
+
+// LocalRunEnvDTO is an env needed to run the space locally, the value of secret or org env is a placeholder.
+type LocalRunEnvDTO struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Secret bool   `json:"secret"`
+}
+
+// LocalRunDTO is the instructions of running the space locally.
+type LocalRunDTO struct {
+	SDK          string           `json:"sdk"`
+	Hardware     string           `json:"hardware"`
+	BaseImage    string           `json:"base_image"`
+	Image        string           `json:"image"`
+	Port         int              `json:"port"`
+	Envs         []LocalRunEnvDTO `json:"envs"`
+	GitClone     string           `json:"git_clone"`
+	PipInstall   string           `json:"pip_install,omitempty"`
+	Requirements []string         `json:"requirements,omitempty"`
+	DockerBuild  string           `json:"docker_build,omitempty"`
+	DockerRun    string           `json:"docker_run,omitempty"`
+	Run          string           `json:"run,omitempty"`
+}
+
+func toLocalRunDTO(space *domain.Space, r *domain.LocalRunInstructions) LocalRunDTO {
+	dto := LocalRunDTO{
+		SDK:          space.SDK.SDK(),
+		Hardware:     space.Hardware.Hardware(),
+		Image:        r.Image,
+		Port:         r.Port,
+		Envs:         make([]LocalRunEnvDTO, len(r.Envs)),
+		GitClone:     r.GitClone,
+		PipInstall:   r.PipInstall,
+		Requirements: r.Requirements,
+		DockerBuild:  r.DockerBuild,
+		DockerRun:    r.DockerRun,
+		Run:          r.Run,
+	}
+
+	if space.BaseImage != nil {
+		dto.BaseImage = space.BaseImage.BaseImage()
+	}
+
+	for i := range r.Envs {
+		dto.Envs[i] = LocalRunEnvDTO{
+			Name:   r.Envs[i].Name,
+			Value:  r.Envs[i].Value,
+			Secret: r.Envs[i].Secret,
+		}
+	}
+
+	return dto
+}
@@ -0,0 +1,34 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package app
+
+import (
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+)
+
+// HardwareFlavorDTO is a flavor of hardware in the catalog.
+type HardwareFlavorDTO struct {
+	Name        string `json:"name"`
+	ComputeType string `json:"compute_type"`
+	QuotaWeight int    `json:"quota_weight"`
+	Capacity    int    `json:"capacity"`
+}
+
+// ListHardwareFlavors lists the flavors of hardware in the catalog.
+func ListHardwareFlavors() []HardwareFlavorDTO {
+	flavors := spaceprimitive.HardwareCatalog()
+
+	dtos := make([]HardwareFlavorDTO, len(flavors))
+	for i := range flavors {
+		dtos[i] = HardwareFlavorDTO{
+			Name:        flavors[i].Name,
+			ComputeType: flavors[i].ComputeType,
+			QuotaWeight: flavors[i].QuotaWeight,
+			Capacity:    flavors[i].Capacity,
+		}
+	}
+
+	return dtos
+}
@@ -0,0 +1,67 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package app
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openmerlin/merlin-server/space/domain"
+)
+
+// localRunInstructions generates the instructions of running the space locally,
+// the envs of space take precedence over the org envs referenced by it.
+func (s *spaceAppService) localRunInstructions(space *domain.Space) (LocalRunDTO, error) {
+	items, err := s.variableAdapter.ListVariableSecret(space.Id.Identity())
+	if err != nil {
+		return LocalRunDTO{}, err
+	}
+
+	names := sets.New[string]()
+	envs := make([]domain.LocalRunEnv, 0, len(items))
+
+	for i := range items {
+		names.Insert(items[i].Name)
+		envs = append(envs, domain.NewLocalRunEnv(items[i].Name, items[i].Value, items[i].Type == secretTypeName))
+	}
+
+	if !space.OwnedByPerson() {
+		if envs, err = s.appendOrgEnvsOfLocalRun(space, names, envs); err != nil {
+			return LocalRunDTO{}, err
+		}
+	}
+
+	r := domain.NewLocalRunInstructions(space, envs, &domain.LocalRunOption{
+		GitURL:        config.LocalRun.GitURL,
+		ImageRegistry: config.LocalRun.ImageRegistry,
+	})
+
+	return toLocalRunDTO(space, &r), nil
+}
+
+func (s *spaceAppService) appendOrgEnvsOfLocalRun(
+	space *domain.Space, names sets.Set[string], envs []domain.LocalRunEnv,
+) ([]domain.LocalRunEnv, error) {
+	refs, err := s.orgEnvAdapter.ListReferencesBySpaceId(space.Id)
+	if err != nil {
+		return envs, err
+	}
+
+	for i := range refs {
+		ref := &refs[i]
+
+		name := ref.Name.ENVName()
+		if names.Has(name) {
+			continue
+		}
+
+		names.Insert(name)
+
+		// the instructions are visible to everyone who can read the space,
+		// so only the names of org envs are shown.
+		envs = append(envs, domain.NewLocalRunEnvOfOrg(name, ref.Type == domain.OrgEnvTypeSecret))
+	}
+
+	return envs, nil
+}
@@ -0,0 +1,659 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openmerlin/merlin-server/common/app"
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	orgrepo "github.com/openmerlin/merlin-server/organization/domain/repository"
+	"github.com/openmerlin/merlin-server/space/domain"
+	"github.com/openmerlin/merlin-server/space/domain/message"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+	spacerepo "github.com/openmerlin/merlin-server/space/domain/repository"
+	"github.com/openmerlin/merlin-server/space/domain/securestorage"
+	appprimitive "github.com/openmerlin/merlin-server/spaceapp/domain/primitive"
+	"github.com/openmerlin/merlin-server/spaceapp/domain/repository"
+	"github.com/openmerlin/merlin-server/utils"
+)
+
+func newOrgEnvNotFound(err error) error {
+	return allerror.NewNotFound(allerror.ErrorCodeOrgEnvNotFound, "not found", err)
+}
+
+func newSpaceEnvReferenceNotFound(err error) error {
+	return allerror.NewNotFound(allerror.ErrorCodeSpaceEnvReferenceNotFound, "not found", err)
+}
+
+// OrgEnvAppService is an interface for the org env service,
+// the org env is shared by the spaces of organization through references.
+type OrgEnvAppService interface {
+	CreateOrgEnv(context.Context, primitive.Account, *CmdToCreateOrgEnv) (OrgEnvDTO, string, error)
+	UpdateOrgEnv(
+		context.Context, primitive.Account, primitive.Identity, *CmdToUpdateOrgEnv,
+	) (OrgEnvUpdatedDTO, string, error)
+	DeleteOrgEnv(context.Context, primitive.Account, primitive.Identity) (string, error)
+	ListOrgEnv(context.Context, primitive.Account, primitive.Account) ([]OrgEnvDTO, error)
+	ListDependents(context.Context, primitive.Account, primitive.Identity) ([]SpaceEnvDependentDTO, error)
+
+	AddReference(
+		context.Context, primitive.Account, primitive.Identity, *CmdToAddSpaceEnvReference,
+	) (SpaceEnvReferenceDTO, string, error)
+	DeleteReference(context.Context, primitive.Account, primitive.Identity, primitive.Identity) (string, error)
+	ListReferences(context.Context, primitive.Account, primitive.Identity) ([]SpaceEnvReferenceDTO, error)
+	ResolveReferences(primitive.Identity) ([]SpaceEnvResolvedDTO, error)
+}
+
+// NewOrgEnvAppService creates a new instance of the org env service.
+func NewOrgEnvAppService(
+	permission app.ResourcePermissionAppService,
+	repoAdapter spacerepo.SpaceRepositoryAdapter,
+	repo repository.Repository,
+	member orgrepo.OrgMember,
+	orgEnvAdapter spacerepo.OrgEnvRepositoryAdapter,
+	variableAdapter spacerepo.SpaceVariableRepositoryAdapter,
+	secureStorageAdapter securestorage.SpaceSecureManager,
+	msgAdapter message.SpaceMessage,
+) OrgEnvAppService {
+	return &orgEnvAppService{
+		permission:           permission,
+		repoAdapter:          repoAdapter,
+		repo:                 repo,
+		member:               member,
+		orgEnvAdapter:        orgEnvAdapter,
+		variableAdapter:      variableAdapter,
+		secureStorageAdapter: secureStorageAdapter,
+		msgAdapter:           msgAdapter,
+	}
+}
+
+type orgEnvAppService struct {
+	permission           app.ResourcePermissionAppService
+	repoAdapter          spacerepo.SpaceRepositoryAdapter
+	repo                 repository.Repository
+	member               orgrepo.OrgMember
+	orgEnvAdapter        spacerepo.OrgEnvRepositoryAdapter
+	variableAdapter      spacerepo.SpaceVariableRepositoryAdapter
+	secureStorageAdapter securestorage.SpaceSecureManager
+	msgAdapter           message.SpaceMessage
+}
+
+func (s *orgEnvAppService) setAppRestarting(ctx context.Context, spaceId primitive.Identity) error {
+	app, err := s.repo.FindBySpaceId(ctx, spaceId)
+	if err != nil {
+		return nil
+	}
+	if app.Status.IsPaused() || app.Status.IsResuming() || app.Status.IsResumeFailed() {
+		return nil
+	}
+	app.Status = appprimitive.AppStatusRestarted
+	return s.repo.Save(&app)
+}
+
+// checkMember checks whether the user is a member of the organization, admin is required if onlyAdmin is true.
+func (s *orgEnvAppService) checkMember(
+	ctx context.Context, user, org primitive.Account, onlyAdmin bool,
+) error {
+	if user == nil {
+		return allerror.NewNoPermission("no permission", xerrors.New("anonymous user"))
+	}
+
+	m, err := s.member.GetByOrgAndUser(ctx, org.Account(), user.Account())
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = allerror.NewNoPermission("no permission",
+				xerrors.Errorf("%s is not a member of %s", user.Account(), org.Account()))
+		}
+
+		return err
+	}
+
+	if onlyAdmin && (m.Role == nil || m.Role.Role() != primitive.NewAdminRole().Role()) {
+		return allerror.NewNoPermission("no permission",
+			xerrors.Errorf("%s is not the admin of %s", user.Account(), org.Account()))
+	}
+
+	return nil
+}
+
+func (s *orgEnvAppService) findOrgEnv(
+	ctx context.Context, user primitive.Account, id primitive.Identity, onlyAdmin bool,
+) (domain.OrgEnv, error) {
+	e, err := s.orgEnvAdapter.FindOrgEnvById(id)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newOrgEnvNotFound(err)
+		}
+
+		return e, err
+	}
+
+	if err = s.checkMember(ctx, user, e.Owner, onlyAdmin); err != nil {
+		// don't let the outsider know the org env exists
+		return e, newOrgEnvNotFound(err)
+	}
+
+	return e, nil
+}
+
+// CreateOrgEnv creates a variable or secret of the organization, only the admin can do it.
+func (s *orgEnvAppService) CreateOrgEnv(
+	ctx context.Context, user primitive.Account, cmd *CmdToCreateOrgEnv,
+) (dto OrgEnvDTO, action string, err error) {
+	action = fmt.Sprintf(
+		"add org %s of %s/%s", cmd.Type, cmd.Owner.Account(), cmd.Name.ENVName(),
+	)
+
+	if err = s.checkMember(ctx, user, cmd.Owner, true); err != nil {
+		return
+	}
+
+	if _, err = s.orgEnvAdapter.FindOrgEnvByName(cmd.Owner, cmd.Type, cmd.Name); err == nil {
+		err = allerror.New(allerror.ErrorCodeOrgEnvExists, "org env exists",
+			xerrors.Errorf("%s of %s exists", cmd.Name.ENVName(), cmd.Owner.Account()))
+
+		return
+	}
+
+	if !commonrepo.IsErrorResourceNotExists(err) {
+		return
+	}
+
+	now := utils.Now()
+	e := domain.OrgEnv{
+		Owner:     cmd.Owner,
+		Type:      cmd.Type,
+		Name:      cmd.Name,
+		Desc:      cmd.Desc,
+		Value:     cmd.Value,
+		CreatedBy: user,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err = s.secureStorageAdapter.SaveSpaceEnvSecret(domain.NewOrgEnvVault(&e)); err != nil {
+		err = allerror.NewCommonRespError("failed to create org env",
+			xerrors.Errorf("org env name:%s, err: %w", e.Name.ENVName(), err))
+
+		return
+	}
+
+	if err = s.orgEnvAdapter.AddOrgEnv(&e); err != nil {
+		err = allerror.NewCommonRespError("failed to create org env db",
+			xerrors.Errorf("org env name:%s, err: %w", e.Name.ENVName(), err))
+
+		return
+	}
+
+	dto = toOrgEnvDTO(&e)
+
+	return
+}
+
+// UpdateOrgEnv updates the org env and lists the spaces depending on it.
+// The dependent spaces are restarted only if it is asked to.
+func (s *orgEnvAppService) UpdateOrgEnv(
+	ctx context.Context, user primitive.Account, id primitive.Identity, cmd *CmdToUpdateOrgEnv,
+) (dto OrgEnvUpdatedDTO, action string, err error) {
+	e, err := s.findOrgEnv(ctx, user, id, true)
+	if err != nil {
+		return
+	}
+
+	action = fmt.Sprintf(
+		"update org %s of %s/%s, restart dependents: %t",
+		e.Type, e.Owner.Account(), e.Name.ENVName(), cmd.RestartDependents,
+	)
+
+	changed, valueChanged := cmd.toOrgEnv(&e)
+	if changed {
+		if valueChanged {
+			if err = s.secureStorageAdapter.SaveSpaceEnvSecret(domain.NewOrgEnvVault(&e)); err != nil {
+				err = allerror.NewCommonRespError("failed to update org env",
+					xerrors.Errorf("org env name:%s, err: %w", e.Name.ENVName(), err))
+
+				return
+			}
+		}
+
+		if err = s.orgEnvAdapter.SaveOrgEnv(&e); err != nil {
+			err = allerror.NewCommonRespError("failed to update org env db",
+				xerrors.Errorf("org env name:%s, err: %w", e.Name.ENVName(), err))
+
+			return
+		}
+
+		e.Version++
+	}
+
+	dto.OrgEnv = toOrgEnvDTO(&e)
+
+	if dto.Dependents, err = s.listDependents(&e); err != nil || !valueChanged {
+		return
+	}
+
+	for i := range dto.Dependents {
+		item := &dto.Dependents[i]
+
+		item.Restarted = s.notifyDependent(ctx, user, item.SpaceId, cmd.RestartDependents)
+	}
+
+	return
+}
+
+// notifyDependent tells the dependent space that the org env is changed,
+// it returns true if the space app is set to restart.
+func (s *orgEnvAppService) notifyDependent(
+	ctx context.Context, user primitive.Account, id string, restart bool,
+) bool {
+	spaceId, err := primitive.NewIdentity(id)
+	if err != nil {
+		return false
+	}
+
+	space := domain.Space{}
+	space.Id = spaceId
+
+	e := domain.NewSpaceEnvChangedEvent(user, &space)
+	if err = s.msgAdapter.SendSpaceEnvChangedEvent(&e); err != nil {
+		logrus.Errorf("failed to send env changed event of space %s, err:%s", id, err)
+	}
+
+	if !restart {
+		return false
+	}
+
+	if err = s.setAppRestarting(ctx, spaceId); err != nil {
+		logrus.Errorf("failed to restart space app of %s, err:%s", id, err)
+
+		return false
+	}
+
+	return true
+}
+
+// DeleteOrgEnv deletes the org env which is not referenced by any space.
+func (s *orgEnvAppService) DeleteOrgEnv(
+	ctx context.Context, user primitive.Account, id primitive.Identity,
+) (action string, err error) {
+	e, err := s.findOrgEnv(ctx, user, id, true)
+	if err != nil {
+		return
+	}
+
+	action = fmt.Sprintf("delete org %s of %s/%s", e.Type, e.Owner.Account(), e.Name.ENVName())
+
+	refs, err := s.orgEnvAdapter.ListReferencesByOrgEnv(&e)
+	if err != nil {
+		return
+	}
+
+	if len(refs) > 0 {
+		err = allerror.New(allerror.ErrorCodeOrgEnvInUse, "org env is referenced by spaces",
+			xerrors.Errorf("%s is referenced by %d spaces", e.Name.ENVName(), len(refs)))
+
+		return
+	}
+
+	err = s.secureStorageAdapter.DeleteSpaceEnvSecret(e.GetOrgEnvPath(), e.Name.ENVName())
+	if err != nil {
+		err = allerror.NewCommonRespError("failed to delete org env",
+			xerrors.Errorf("org env name:%s, err: %w", e.Name.ENVName(), err))
+
+		return
+	}
+
+	if err = s.orgEnvAdapter.DeleteOrgEnv(e.Id); err != nil {
+		err = allerror.NewCommonRespError("failed to delete org env db",
+			xerrors.Errorf("org env name:%s, err: %w", e.Name.ENVName(), err))
+	}
+
+	return
+}
+
+// ListOrgEnv lists the org envs for the members, the values of secrets are never returned.
+func (s *orgEnvAppService) ListOrgEnv(
+	ctx context.Context, user, org primitive.Account,
+) ([]OrgEnvDTO, error) {
+	if err := s.checkMember(ctx, user, org, false); err != nil {
+		return nil, err
+	}
+
+	v, err := s.orgEnvAdapter.ListOrgEnv(org)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]OrgEnvDTO, len(v))
+	for i := range v {
+		dtos[i] = toOrgEnvDTO(&v[i])
+	}
+
+	return dtos, nil
+}
+
+// ListDependents lists the spaces which reference the org env.
+func (s *orgEnvAppService) ListDependents(
+	ctx context.Context, user primitive.Account, id primitive.Identity,
+) ([]SpaceEnvDependentDTO, error) {
+	e, err := s.findOrgEnv(ctx, user, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.listDependents(&e)
+}
+
+func (s *orgEnvAppService) listDependents(e *domain.OrgEnv) ([]SpaceEnvDependentDTO, error) {
+	refs, err := s.orgEnvAdapter.ListReferencesByOrgEnv(e)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]SpaceEnvDependentDTO, 0, len(refs))
+
+	for i := range refs {
+		space, err := s.repoAdapter.FindById(refs[i].SpaceId)
+		if err != nil {
+			if commonrepo.IsErrorResourceNotExists(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		dtos = append(dtos, SpaceEnvDependentDTO{
+			SpaceId:     space.Id.Identity(),
+			Owner:       space.Owner.Account(),
+			Name:        space.Name.MSDName(),
+			ReferenceId: refs[i].Id.Identity(),
+		})
+	}
+
+	return dtos, nil
+}
+
+// AddReference adds a reference from the space to the org env of the space owner.
+func (s *orgEnvAppService) AddReference(
+	ctx context.Context, user primitive.Account, spaceId primitive.Identity, cmd *CmdToAddSpaceEnvReference,
+) (dto SpaceEnvReferenceDTO, action string, err error) {
+	space, err := s.repoAdapter.FindById(spaceId)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceNotFound(err)
+		}
+
+		return
+	}
+
+	action = fmt.Sprintf(
+		"add reference of space %s:%s/%s to org %s %s",
+		spaceId.Identity(), space.Owner.Account(), space.Name.MSDName(), cmd.Type, cmd.Name.ENVName(),
+	)
+
+	notFound, err := app.CanUpdateOrNotFound(ctx, user, &space, s.permission)
+	if err != nil {
+		return
+	}
+	if notFound {
+		err = newSpaceNotFound(xerrors.Errorf("%s not found", spaceId.Identity()))
+
+		return
+	}
+
+	ref, err := domain.NewSpaceEnvReference(&space, cmd.Type, cmd.Name, user, utils.Now())
+	if err != nil {
+		err = allerror.NewInvalidParam(err.Error(), err)
+
+		return
+	}
+
+	if _, err = s.orgEnvAdapter.FindOrgEnvByName(ref.Owner, ref.Type, ref.Name); err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newOrgEnvNotFound(err)
+		}
+
+		return
+	}
+
+	if err = s.checkReferenceName(&ref); err != nil {
+		return
+	}
+
+	if err = s.orgEnvAdapter.AddReference(&ref); err != nil {
+		err = allerror.NewCommonRespError("failed to add reference db",
+			xerrors.Errorf("space id:%s, err: %w", spaceId.Identity(), err))
+
+		return
+	}
+
+	dto = toSpaceEnvReferenceDTO(&ref, true)
+
+	err = s.envChanged(ctx, user, &space)
+
+	return
+}
+
+// checkReferenceName checks the name is not used by the env of space or other references.
+func (s *orgEnvAppService) checkReferenceName(ref *domain.SpaceEnvReference) error {
+	name := ref.Name.ENVName()
+
+	items, err := s.variableAdapter.ListVariableSecret(ref.SpaceId.Identity())
+	if err != nil {
+		return err
+	}
+
+	for i := range items {
+		if items[i].Name == name {
+			return allerror.NewInvalidParam(
+				fmt.Sprintf("%s is the name of a space %s", name, items[i].Type),
+				xerrors.Errorf("conflict with space env %s", name),
+			)
+		}
+	}
+
+	refs, err := s.orgEnvAdapter.ListReferencesBySpaceId(ref.SpaceId)
+	if err != nil {
+		return err
+	}
+
+	for i := range refs {
+		if refs[i].Name.ENVName() == name {
+			return allerror.NewInvalidParam(
+				fmt.Sprintf("%s has been referenced", name),
+				xerrors.Errorf("conflict with reference %s", name),
+			)
+		}
+	}
+
+	return nil
+}
+
+// referencedNames returns the names of the org envs referenced by the space.
+func referencedNames(adapter spacerepo.OrgEnvRepositoryAdapter, spaceId primitive.Identity) (sets.Set[string], error) {
+	refs, err := adapter.ListReferencesBySpaceId(spaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	names := sets.New[string]()
+	for i := range refs {
+		names.Insert(refs[i].Name.ENVName())
+	}
+
+	return names, nil
+}
+
+// checkNotReferenced checks the name of space env is not used by the org envs referenced by the space.
+func checkNotReferenced(
+	adapter spacerepo.OrgEnvRepositoryAdapter, spaceId primitive.Identity, name spaceprimitive.ENVName,
+) error {
+	names, err := referencedNames(adapter, spaceId)
+	if err != nil {
+		return err
+	}
+
+	if v := name.ENVName(); names.Has(v) {
+		return allerror.NewInvalidParam(
+			fmt.Sprintf("%s is the name of an org env referenced by the space", v),
+			xerrors.Errorf("conflict with reference %s", v),
+		)
+	}
+
+	return nil
+}
+
+func (s *orgEnvAppService) envChanged(ctx context.Context, user primitive.Account, space *domain.Space) error {
+	e := domain.NewSpaceEnvChangedEvent(user, space)
+	if err := s.msgAdapter.SendSpaceEnvChangedEvent(&e); err != nil {
+		return allerror.NewCommonRespError("failed to send space env reference event",
+			xerrors.Errorf("space id:%s, err: %w", space.Id.Identity(), err))
+	}
+
+	if err := s.setAppRestarting(ctx, space.Id); err != nil {
+		return allerror.NewCommonRespError("failed to restart space app",
+			xerrors.Errorf("space id:%s, err: %w", space.Id.Identity(), err))
+	}
+
+	return nil
+}
+
+// DeleteReference deletes the reference from the space to the org env.
+func (s *orgEnvAppService) DeleteReference(
+	ctx context.Context, user primitive.Account, spaceId, refId primitive.Identity,
+) (action string, err error) {
+	space, err := s.repoAdapter.FindById(spaceId)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceNotFound(err)
+		}
+
+		return
+	}
+
+	ref, err := s.orgEnvAdapter.FindReferenceById(refId)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceEnvReferenceNotFound(err)
+		}
+
+		return
+	}
+
+	if ref.SpaceId != space.Id {
+		err = newSpaceEnvReferenceNotFound(
+			xerrors.Errorf("reference %s is not in space %s", refId.Identity(), spaceId.Identity()),
+		)
+
+		return
+	}
+
+	action = fmt.Sprintf(
+		"delete reference of space %s:%s/%s to org %s %s",
+		spaceId.Identity(), space.Owner.Account(), space.Name.MSDName(), ref.Type, ref.Name.ENVName(),
+	)
+
+	notFound, err := app.CanUpdateOrNotFound(ctx, user, &space, s.permission)
+	if err != nil {
+		return
+	}
+	if notFound {
+		err = newSpaceNotFound(xerrors.Errorf("%s not found", spaceId.Identity()))
+
+		return
+	}
+
+	if err = s.orgEnvAdapter.DeleteReference(ref.Id); err != nil {
+		err = allerror.NewCommonRespError("failed to delete reference db",
+			xerrors.Errorf("space id:%s, err: %w", spaceId.Identity(), err))
+
+		return
+	}
+
+	err = s.envChanged(ctx, user, &space)
+
+	return
+}
+
+// ListReferences lists the references of the space and whether they can be resolved.
+func (s *orgEnvAppService) ListReferences(
+	ctx context.Context, user primitive.Account, spaceId primitive.Identity,
+) ([]SpaceEnvReferenceDTO, error) {
+	space, err := s.repoAdapter.FindById(spaceId)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceNotFound(err)
+		}
+
+		return nil, err
+	}
+
+	if err = s.permission.CanRead(ctx, user, &space); err != nil {
+		if allerror.IsNoPermission(err) {
+			err = newSpaceNotFound(err)
+		}
+
+		return nil, err
+	}
+
+	refs, err := s.orgEnvAdapter.ListReferencesBySpaceId(spaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]SpaceEnvReferenceDTO, len(refs))
+	for i := range refs {
+		_, err := s.orgEnvAdapter.FindOrgEnvByName(refs[i].Owner, refs[i].Type, refs[i].Name)
+		if err != nil && !commonrepo.IsErrorResourceNotExists(err) {
+			return nil, err
+		}
+
+		dtos[i] = toSpaceEnvReferenceDTO(&refs[i], err == nil)
+	}
+
+	return dtos, nil
+}
+
+// ResolveReferences resolves the references of the space to the vault paths of org envs,
+// it is called when the space app is built so that the latest values are used.
+func (s *orgEnvAppService) ResolveReferences(spaceId primitive.Identity) ([]SpaceEnvResolvedDTO, error) {
+	refs, err := s.orgEnvAdapter.ListReferencesBySpaceId(spaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]SpaceEnvResolvedDTO, len(refs))
+	for i := range refs {
+		ref := &refs[i]
+
+		dto := SpaceEnvResolvedDTO{
+			Name: ref.Name.ENVName(),
+			Type: ref.Type,
+		}
+
+		e, err := s.orgEnvAdapter.FindOrgEnvByName(ref.Owner, ref.Type, ref.Name)
+		if err == nil {
+			dto.Resolved = true
+			dto.Path = e.GetOrgEnvPath()
+			dto.Key = e.Name.ENVName()
+		} else if !commonrepo.IsErrorResourceNotExists(err) {
+			return nil, err
+		} else {
+			logrus.Errorf("reference %s of space %s can't be resolved", dto.Name, spaceId.Identity())
+		}
+
+		dtos[i] = dto
+	}
+
+	return dtos, nil
+}
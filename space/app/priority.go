@@ -0,0 +1,105 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	orgrepo "github.com/openmerlin/merlin-server/organization/domain/repository"
+	spacerepo "github.com/openmerlin/merlin-server/space/domain/repository"
+)
+
+// SpacePriorityAppService is an interface for setting the priority of space,
+// the space app of higher priority can preempt the computility quota of the lower ones.
+type SpacePriorityAppService interface {
+	SetPriority(context.Context, primitive.Account, primitive.Identity, int) (string, error)
+}
+
+// NewSpacePriorityAppService creates a new instance of the space priority service.
+func NewSpacePriorityAppService(
+	repoAdapter spacerepo.SpaceRepositoryAdapter,
+	member orgrepo.OrgMember,
+) SpacePriorityAppService {
+	return &spacePriorityAppService{
+		repoAdapter: repoAdapter,
+		member:      member,
+	}
+}
+
+type spacePriorityAppService struct {
+	repoAdapter spacerepo.SpaceRepositoryAdapter
+	member      orgrepo.OrgMember
+}
+
+// SetPriority sets the priority of space, only the admin of the organization which owns the space can do it.
+func (s *spacePriorityAppService) SetPriority(
+	ctx context.Context, user primitive.Account, spaceId primitive.Identity, priority int,
+) (action string, err error) {
+	action = fmt.Sprintf("set priority of space %s to %d", spaceId.Identity(), priority)
+
+	space, err := s.repoAdapter.FindById(spaceId)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceNotFound(err)
+		}
+
+		return
+	}
+
+	action = fmt.Sprintf(
+		"set priority of space %s:%s/%s to %d",
+		spaceId.Identity(), space.Owner.Account(), space.Name.MSDName(), priority,
+	)
+
+	if err = s.checkOrgAdmin(ctx, user, space.Owner); err != nil {
+		return
+	}
+
+	if space.Priority == priority {
+		return
+	}
+
+	space.Priority = priority
+
+	if err = s.repoAdapter.Save(&space); err != nil {
+		return
+	}
+
+	logrus.Infof("priority of space %s is set to %d by %s", spaceId.Identity(), priority, user.Account())
+
+	return
+}
+
+// checkOrgAdmin checks whether the user is the admin of the organization,
+// the priority of personal space can't be set.
+func (s *spacePriorityAppService) checkOrgAdmin(ctx context.Context, user, org primitive.Account) error {
+	if user == nil {
+		return allerror.NewNoPermission("no permission", xerrors.New("anonymous user"))
+	}
+
+	m, err := s.member.GetByOrgAndUser(ctx, org.Account(), user.Account())
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = allerror.NewNoPermission("no permission",
+				xerrors.Errorf("%s is not the admin of the organization %s", user.Account(), org.Account()))
+		}
+
+		return err
+	}
+
+	if m.Role == nil || m.Role.Role() != primitive.NewAdminRole().Role() {
+		return allerror.NewNoPermission("no permission",
+			xerrors.Errorf("%s is not the admin of %s", user.Account(), org.Account()))
+	}
+
+	return nil
+}
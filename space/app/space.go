@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"golang.org/x/xerrors"
@@ -19,6 +20,9 @@ import (
 	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
 	computilityapp "github.com/openmerlin/merlin-server/computility/app"
 	computilitydomain "github.com/openmerlin/merlin-server/computility/domain"
+	moderationapp "github.com/openmerlin/merlin-server/moderation/app"
+	moderationdomain "github.com/openmerlin/merlin-server/moderation/domain"
+	moderationprimitive "github.com/openmerlin/merlin-server/moderation/domain/primitive"
 	orgapp "github.com/openmerlin/merlin-server/organization/app"
 	orgrepo "github.com/openmerlin/merlin-server/organization/domain/repository"
 	"github.com/openmerlin/merlin-server/space/domain"
@@ -50,6 +54,21 @@ func newSpaceSecretNotFound(err error) error {
 	return allerror.NewNotFound(allerror.ErrorCodeSpaceSecretNotFound, "not found", err)
 }
 
+func newSpaceSecretVersionNotFound(err error) error {
+	return allerror.NewNotFound(allerror.ErrorCodeSpaceSecretVersionNotFound, "not found", err)
+}
+
+func newInvalidDotEnv(errs []domain.DotEnvLineError) error {
+	msgs := make([]string, len(errs))
+	for i := range errs {
+		msgs[i] = errs[i].Error()
+	}
+
+	msg := strings.Join(msgs, "; ")
+
+	return allerror.NewInvalidParam(msg, xerrors.New(msg))
+}
+
 func newSpaceSecretCountExceeded(err error) error {
 	return allerror.NewCountExceeded("space secret count exceed", err)
 }
@@ -102,6 +121,11 @@ func NewSpaceAppService(
 	user userapp.UserService,
 	obs obs.ObsService,
 	email email.Email,
+	customDomainAdapter repository.SpaceCustomDomainRepositoryAdapter,
+	orgEnvAdapter repository.OrgEnvRepositoryAdapter,
+	baseImageAdapter repository.BaseImageRepositoryAdapter,
+	report moderationapp.ReportAppService,
+	queueAdapter spaceappRepository.SpaceAppQueueAdapter,
 ) SpaceAppService {
 	return &spaceAppService{
 		permission:           permission,
@@ -120,6 +144,11 @@ func NewSpaceAppService(
 		user:                 user,
 		obs:                  obs,
 		email:                email,
+		customDomainAdapter:  customDomainAdapter,
+		orgEnvAdapter:        orgEnvAdapter,
+		baseImageAdapter:     baseImageAdapter,
+		report:               report,
+		queueAdapter:         queueAdapter,
 	}
 }
 
@@ -140,6 +169,11 @@ type spaceAppService struct {
 	user                 userapp.UserService
 	obs                  obs.ObsService
 	email                email.Email
+	customDomainAdapter  repository.SpaceCustomDomainRepositoryAdapter
+	orgEnvAdapter        repository.OrgEnvRepositoryAdapter
+	baseImageAdapter     repository.BaseImageRepositoryAdapter
+	report               moderationapp.ReportAppService
+	queueAdapter         spaceappRepository.SpaceAppQueueAdapter
 }
 
 // Create creates a new space with the given command and returns the ID of the created space.
@@ -149,6 +183,10 @@ func (s *spaceAppService) Create(ctx context.Context, user primitive.Account, cm
 		return "", xerrors.Errorf("failed to create space: %w", err)
 	}
 
+	if err = checkBaseImageOfSpace(s.baseImageAdapter, cmd); err != nil {
+		return "", err
+	}
+
 	now := utils.Now()
 	space := cmd.toSpace()
 
@@ -186,12 +224,8 @@ func (s *spaceAppService) Create(ctx context.Context, user primitive.Account, cm
 	space.NoApplicationFile = true
 	space.Exception = primitive.ExceptionNoApplicationFile
 
-	if cmd.Hardware.IsNpu() {
-		space.CompPowerAllocated = true
-		space.Labels.HardwareType = "npu"
-	} else {
-		space.Labels.HardwareType = "cpu"
-	}
+	space.CompPowerAllocated = space.ConsumeComputility()
+	space.Labels.HardwareType = space.GetHardwareType()
 
 	if err = s.repoAdapter.Add(&space); err != nil {
 		err = xerrors.Errorf("space create failed | release user:%s quota | err: %w", user, err)
@@ -285,11 +319,26 @@ func (s *spaceAppService) Delete(
 		return
 	}
 
+	// del space app waiting for quota
+	if err = s.queueAdapter.DeleteBySpaceId(space.Id); err != nil {
+		return
+	}
+
 	// del space variable secret
 	if err = s.delSpaceVariableSecret(space.Id); err != nil {
 		return
 	}
 
+	// del space custom domain
+	if err = s.customDomainAdapter.DeleteCustomDomainBySpaceId(space.Id); err != nil {
+		return
+	}
+
+	// del references to org env
+	if err = s.orgEnvAdapter.DeleteReferencesBySpaceId(space.Id); err != nil {
+		return
+	}
+
 	if err = s.repoAdapter.Delete(space.Id); err != nil {
 		return
 	}
@@ -299,7 +348,7 @@ func (s *spaceAppService) Delete(
 		logrus.Errorf("failed to send space deleted event, space id:%s", spaceId.Identity())
 	}
 
-	if space.Hardware.IsNpu() && space.CompPowerAllocated {
+	if space.Hardware.IsAccelerator() && space.CompPowerAllocated {
 		logrus.Infof("release quota after user:%s npu space:%s delete", user, spaceId.Identity())
 
 		c := computilityapp.CmdToUserQuotaUpdate{
@@ -365,6 +414,10 @@ func (s *spaceAppService) delSpaceVariableSecret(spaceId primitive.Identity) err
 				logrus.Errorf("failed to delete secret db, err:%s", err)
 				continue
 			}
+			if err = deleteSpaceSecretVersions(s.secretAdapter, s.secureStorageAdapter, &secret); err != nil {
+				logrus.Errorf("failed to delete secret versions, err:%s", err)
+				continue
+			}
 		}
 	}
 	return nil
@@ -414,7 +467,22 @@ func (s *spaceAppService) Update(
 	}
 
 	b1 := cmd.toSpace(&space)
-	if !b && !b1 {
+
+	// the new docker option takes effect when the app is built next time
+	b2 := false
+	if cmd.AppPort != nil || cmd.RunAsUser != nil {
+		if b2, err = space.UpdateDockerOption(cmd.AppPort, cmd.RunAsUser); err != nil {
+			err = allerror.NewInvalidParam(err.Error(), err)
+
+			return
+		}
+
+		if b2 {
+			space.UpdatedAt = utils.Now()
+		}
+	}
+
+	if !b && !b1 && !b2 {
 		return
 	}
 
@@ -486,7 +554,13 @@ func (s *spaceAppService) Disable(
 		}
 	}
 
-	if space.Hardware.IsNpu() && space.CompPowerAllocated {
+	// del space app waiting for quota
+	if err = s.queueAdapter.DeleteBySpaceId(space.Id); err != nil {
+		logrus.Errorf("delete queued space app by id %v failed, err:%v", space.Id, err)
+		return
+	}
+
+	if space.Hardware.IsAccelerator() && space.CompPowerAllocated {
 		logrus.Infof("release quota after npu space:%s delete", spaceId.Identity())
 
 		c := computilityapp.CmdToUserQuotaUpdate{
@@ -569,7 +643,15 @@ func (s *spaceAppService) GetByName(
 		return dto, err
 	}
 
-	return toSpaceDTO(&space), nil
+	dto = toSpaceDTO(&space)
+
+	if v, err := s.localRunInstructions(&space); err != nil {
+		logrus.Errorf("failed to generate local run instructions of space:%s, err:%s", space.Id.Identity(), err)
+	} else {
+		dto.LocalRun = &v
+	}
+
+	return dto, nil
 }
 
 // List retrieves a list of spaces based on the provided command parameters and returns the corresponding SpacesDTO.
@@ -789,6 +871,20 @@ func (s *spaceAppService) SendSpaceReportEmail(user primitive.Account, cmd *CmdT
 		err := allerror.NewNoPermission(e.Error(), e)
 		return err
 	}
+	err = s.report.Report(&moderationapp.CmdToReport{
+		Reporter: user,
+		Target: moderationdomain.ReportTarget{
+			Type:         moderationprimitive.ReportTargetSpace,
+			Id:           data.Id.Integer(),
+			ResourceId:   data.Id,
+			ResourceType: primitive.ObjTypeSpace,
+		},
+		Reason: cmd.Msg,
+	})
+	if err != nil {
+		return err
+	}
+
 	safeMsg := utils.XSSEscapeString(cmd.Msg)
 	url := fmt.Sprintf("%s/spaces/%s/%s", s.email.GetRootUrl(), data.Owner.Account(), data.Name)
 	if err := s.email.Send(cmd.SpaceName.MSDName(), safeMsg, user.Account(), url); err != nil {
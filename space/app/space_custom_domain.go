@@ -0,0 +1,329 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+
+	"github.com/openmerlin/merlin-server/common/app"
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	"github.com/openmerlin/merlin-server/space/domain"
+	"github.com/openmerlin/merlin-server/space/domain/dnsresolver"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+	spacerepo "github.com/openmerlin/merlin-server/space/domain/repository"
+	"github.com/openmerlin/merlin-server/spaceapp/domain/repository"
+	"github.com/openmerlin/merlin-server/utils"
+)
+
+func newSpaceCustomDomainNotFound(err error) error {
+	return allerror.NewNotFound(allerror.ErrorCodeSpaceCustomDomainNotFound, "not found", err)
+}
+
+// SpaceCustomDomainService is an interface for the space custom domain service.
+type SpaceCustomDomainService interface {
+	AddCustomDomain(context.Context, primitive.Account, primitive.Identity, *CmdToAddSpaceCustomDomain) (
+		SpaceCustomDomainDTO, string, error)
+	VerifyCustomDomain(context.Context, primitive.Account, primitive.Identity, primitive.Identity) (
+		SpaceCustomDomainDTO, string, error)
+	DeleteCustomDomain(context.Context, primitive.Account, primitive.Identity, primitive.Identity) (string, error)
+	ListCustomDomains(context.Context, primitive.Account, primitive.Identity) ([]SpaceCustomDomainDTO, error)
+	ListVerifiedCustomDomains(context.Context) ([]SpaceCustomDomainMappingDTO, error)
+}
+
+// NewSpaceCustomDomainService creates a new instance of the space custom domain service.
+func NewSpaceCustomDomainService(
+	permission app.ResourcePermissionAppService,
+	repoAdapter spacerepo.SpaceRepositoryAdapter,
+	repo repository.Repository,
+	domainAdapter spacerepo.SpaceCustomDomainRepositoryAdapter,
+	resolver dnsresolver.Resolver,
+) SpaceCustomDomainService {
+	return &spaceCustomDomainService{
+		permission:    permission,
+		repoAdapter:   repoAdapter,
+		repo:          repo,
+		domainAdapter: domainAdapter,
+		resolver:      resolver,
+	}
+}
+
+type spaceCustomDomainService struct {
+	permission    app.ResourcePermissionAppService
+	repoAdapter   spacerepo.SpaceRepositoryAdapter
+	repo          repository.Repository
+	domainAdapter spacerepo.SpaceCustomDomainRepositoryAdapter
+	resolver      dnsresolver.Resolver
+}
+
+// AddCustomDomain registers a custom domain for a public space and returns the TXT challenge.
+func (s *spaceCustomDomainService) AddCustomDomain(
+	ctx context.Context, user primitive.Account, spaceId primitive.Identity, cmd *CmdToAddSpaceCustomDomain,
+) (dto SpaceCustomDomainDTO, action string, err error) {
+	space, err := s.findUpdatableSpace(ctx, user, spaceId)
+	if err != nil {
+		return
+	}
+
+	action = fmt.Sprintf(
+		"add custom domain of %s:%s/%s:%s",
+		spaceId.Identity(), space.Owner.Account(), space.Name.MSDName(), cmd.Domain.CustomDomain(),
+	)
+
+	if !space.IsPublic() {
+		err = allerror.NewInvalidParam("custom domain is only available for public space",
+			xerrors.Errorf("space:%s is not public", spaceId.Identity()))
+
+		return
+	}
+
+	// the pending claims of other spaces don't block the domain, the one verified first owns it
+	if err = s.checkCustomDomainVerified(cmd.Domain); err != nil {
+		return
+	}
+
+	if err = s.checkCustomDomainClaimed(spaceId, cmd.Domain); err != nil {
+		return
+	}
+
+	d, err := domain.NewSpaceCustomDomain(&space, cmd.Domain, user, utils.Now())
+	if err != nil {
+		return
+	}
+
+	if err = s.domainAdapter.AddCustomDomain(&d); err != nil {
+		err = allerror.NewCommonRespError("failed to add custom domain",
+			xerrors.Errorf("domain:%s, err: %w", cmd.Domain.CustomDomain(), err))
+
+		return
+	}
+
+	dto = toSpaceCustomDomainDTO(&d)
+
+	return
+}
+
+// VerifyCustomDomain checks the TXT record of the domain and marks it as verified if matched.
+func (s *spaceCustomDomainService) VerifyCustomDomain(
+	ctx context.Context, user primitive.Account, spaceId, domainId primitive.Identity,
+) (dto SpaceCustomDomainDTO, action string, err error) {
+	space, err := s.findUpdatableSpace(ctx, user, spaceId)
+	if err != nil {
+		return
+	}
+
+	d, err := s.findCustomDomain(spaceId, domainId)
+	if err != nil {
+		return
+	}
+
+	action = fmt.Sprintf(
+		"verify custom domain of %s:%s/%s:%s",
+		spaceId.Identity(), space.Owner.Account(), space.Name.MSDName(), d.Domain.CustomDomain(),
+	)
+
+	if d.IsVerified() {
+		dto = toSpaceCustomDomainDTO(&d)
+
+		return
+	}
+
+	if err = s.checkCustomDomainVerified(d.Domain); err != nil {
+		return
+	}
+
+	records, err := s.resolver.LookupTXT(ctx, d.ChallengeHost())
+	if err != nil {
+		logrus.Errorf("failed to lookup txt of %s, err:%s", d.ChallengeHost(), err)
+	}
+
+	if !d.Verify(records, utils.Now()) {
+		err = allerror.New(allerror.ErrorCodeSpaceCustomDomainUnverified, "txt record is not matched",
+			xerrors.Errorf("txt record of %s is not matched", d.ChallengeHost()))
+
+		return
+	}
+
+	if err = s.domainAdapter.SaveCustomDomain(&d); err != nil {
+		return
+	}
+
+	// the pending claims of other spaces can't be verified any more
+	if err := s.domainAdapter.DeletePendingCustomDomain(d.Domain); err != nil {
+		logrus.Errorf("failed to delete pending claims of %s, err:%s", d.Domain.CustomDomain(), err)
+	}
+
+	dto = toSpaceCustomDomainDTO(&d)
+
+	return
+}
+
+// DeleteCustomDomain deletes the custom domain of space.
+func (s *spaceCustomDomainService) DeleteCustomDomain(
+	ctx context.Context, user primitive.Account, spaceId, domainId primitive.Identity,
+) (action string, err error) {
+	space, err := s.findUpdatableSpace(ctx, user, spaceId)
+	if err != nil {
+		return
+	}
+
+	d, err := s.findCustomDomain(spaceId, domainId)
+	if err != nil {
+		return
+	}
+
+	action = fmt.Sprintf(
+		"delete custom domain of %s:%s/%s:%s",
+		spaceId.Identity(), space.Owner.Account(), space.Name.MSDName(), d.Domain.CustomDomain(),
+	)
+
+	err = s.domainAdapter.DeleteCustomDomain(d.Id)
+
+	return
+}
+
+// ListCustomDomains lists the custom domains of space.
+func (s *spaceCustomDomainService) ListCustomDomains(
+	ctx context.Context, user primitive.Account, spaceId primitive.Identity,
+) ([]SpaceCustomDomainDTO, error) {
+	if _, err := s.findUpdatableSpace(ctx, user, spaceId); err != nil {
+		return nil, err
+	}
+
+	v, err := s.domainAdapter.ListCustomDomainBySpaceId(spaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]SpaceCustomDomainDTO, len(v))
+	for i := range v {
+		dtos[i] = toSpaceCustomDomainDTO(&v[i])
+	}
+
+	return dtos, nil
+}
+
+// ListVerifiedCustomDomains lists the verified custom domains of the public spaces which are serving.
+func (s *spaceCustomDomainService) ListVerifiedCustomDomains(ctx context.Context) (
+	[]SpaceCustomDomainMappingDTO, error,
+) {
+	v, err := s.domainAdapter.ListVerifiedCustomDomain()
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]SpaceCustomDomainMappingDTO, 0, len(v))
+
+	for i := range v {
+		space, err := s.repoAdapter.FindById(v[i].SpaceId)
+		if err != nil {
+			logrus.Errorf("failed to find space:%s of domain, err:%s", v[i].SpaceId.Identity(), err)
+
+			continue
+		}
+
+		if !space.IsPublic() || space.IsDisable() {
+			continue
+		}
+
+		spaceApp, err := s.repo.FindBySpaceId(ctx, space.Id)
+		if err != nil || spaceApp.AppURL == nil || spaceApp.AppURL.AppURL() == "" {
+			continue
+		}
+
+		dtos = append(dtos, SpaceCustomDomainMappingDTO{
+			Domain:  v[i].Domain.CustomDomain(),
+			SpaceId: space.Id.Identity(),
+			Owner:   space.Owner.Account(),
+			Name:    space.Name.MSDName(),
+			AppURL:  spaceApp.AppURL.AppURL(),
+		})
+	}
+
+	return dtos, nil
+}
+
+// checkCustomDomainVerified returns error if the domain has been verified by a space.
+func (s *spaceCustomDomainService) checkCustomDomainVerified(d spaceprimitive.CustomDomain) error {
+	_, err := s.domainAdapter.FindVerifiedCustomDomain(d)
+	if err == nil {
+		return allerror.New(allerror.ErrorCodeSpaceCustomDomainExists, "domain has been registered",
+			xerrors.Errorf("domain:%s exists", d.CustomDomain()))
+	}
+
+	if commonrepo.IsErrorResourceNotExists(err) {
+		return nil
+	}
+
+	return err
+}
+
+// checkCustomDomainClaimed returns error if the domain has been claimed by the space.
+func (s *spaceCustomDomainService) checkCustomDomainClaimed(spaceId primitive.Identity, d spaceprimitive.CustomDomain,
+) error {
+	v, err := s.domainAdapter.ListCustomDomainBySpaceId(spaceId)
+	if err != nil {
+		return err
+	}
+
+	for i := range v {
+		if v[i].Domain.CustomDomain() == d.CustomDomain() {
+			return allerror.New(allerror.ErrorCodeSpaceCustomDomainExists, "domain has been registered",
+				xerrors.Errorf("domain:%s exists in space:%s", d.CustomDomain(), spaceId.Identity()))
+		}
+	}
+
+	return nil
+}
+
+func (s *spaceCustomDomainService) findUpdatableSpace(
+	ctx context.Context, user primitive.Account, spaceId primitive.Identity,
+) (space domain.Space, err error) {
+	space, err = s.repoAdapter.FindById(spaceId)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceNotFound(err)
+		}
+
+		return
+	}
+
+	notFound, err := app.CanUpdateOrNotFound(ctx, user, &space, s.permission)
+	if err != nil {
+		return
+	}
+
+	if notFound {
+		err = newSpaceNotFound(xerrors.Errorf("%s not found", spaceId.Identity()))
+	}
+
+	return
+}
+
+func (s *spaceCustomDomainService) findCustomDomain(spaceId, domainId primitive.Identity) (
+	domain.SpaceCustomDomain, error,
+) {
+	d, err := s.domainAdapter.FindCustomDomainById(domainId)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceCustomDomainNotFound(err)
+		}
+
+		return d, err
+	}
+
+	if d.SpaceId.Identity() != spaceId.Identity() {
+		return d, newSpaceCustomDomainNotFound(
+			xerrors.Errorf("domain:%s not belong to space:%s", domainId.Identity(), spaceId.Identity()),
+		)
+	}
+
+	return d, nil
+}
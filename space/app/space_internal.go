@@ -238,7 +238,7 @@ func (s *spaceInternalAppService) NotifyUpdateCodes(spaceId primitive.Identity,
 	}
 
 	space.SetSpaceCommitId(cmd.CommitId)
-	space.SetNoApplicationFile(cmd.HasHtml, cmd.HasApp)
+	space.SetNoApplicationFile(cmd.HasHtml, cmd.HasApp, cmd.HasDockerfile)
 	err = s.repoAdapter.Save(&space)
 
 	if err != nil {
@@ -16,6 +16,7 @@ import (
 	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
 	"github.com/openmerlin/merlin-server/space/domain"
 	"github.com/openmerlin/merlin-server/space/domain/message"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
 	spacerepo "github.com/openmerlin/merlin-server/space/domain/repository"
 	"github.com/openmerlin/merlin-server/space/domain/securestorage"
 	appprimitive "github.com/openmerlin/merlin-server/spaceapp/domain/primitive"
@@ -32,6 +33,12 @@ type SpaceSecretService interface {
 	UpdateSecret(
 		context.Context, primitive.Account, primitive.Identity,
 		primitive.Identity, *CmdToUpdateSpaceSecret) (string, error)
+	RollbackSecret(
+		context.Context, primitive.Account, primitive.Identity,
+		primitive.Identity, *CmdToRollbackSpaceSecret) (string, error)
+	ListSecretVersions(
+		context.Context, primitive.Account, primitive.Identity, primitive.Identity,
+	) ([]SpaceSecretVersionDTO, error)
 }
 
 // NewSpaceSecretService creates a new instance of the space secret service.
@@ -42,6 +49,7 @@ func NewSpaceSecretService(
 	secretAdapter spacerepo.SpaceSecretRepositoryAdapter,
 	secureStorageAdapter securestorage.SpaceSecureManager,
 	msgAdapter message.SpaceMessage,
+	orgEnvAdapter spacerepo.OrgEnvRepositoryAdapter,
 ) SpaceSecretService {
 	return &spaceSecretService{
 		permission:           permission,
@@ -50,6 +58,7 @@ func NewSpaceSecretService(
 		secretAdapter:        secretAdapter,
 		secureStorageAdapter: secureStorageAdapter,
 		msgAdapter:           msgAdapter,
+		orgEnvAdapter:        orgEnvAdapter,
 	}
 }
 
@@ -60,6 +69,7 @@ type spaceSecretService struct {
 	secretAdapter        spacerepo.SpaceSecretRepositoryAdapter
 	secureStorageAdapter securestorage.SpaceSecureManager
 	msgAdapter           message.SpaceMessage
+	orgEnvAdapter        spacerepo.OrgEnvRepositoryAdapter
 }
 
 func (s *spaceSecretService) setAppRestarting(ctx context.Context, spaceId primitive.Identity) error {
@@ -104,14 +114,20 @@ func (s *spaceSecretService) CreateSecret(
 		return "", action, err
 	}
 
+	if err = checkNotReferenced(s.orgEnvAdapter, space.Id, cmd.Name); err != nil {
+		return "", action, err
+	}
+
 	now := utils.Now()
 	secret := &domain.SpaceSecret{
-		SpaceId:   space.Id,
-		Desc:      cmd.Desc,
-		Name:      cmd.Name,
-		Value:     cmd.Value,
-		CreatedAt: now,
-		UpdatedAt: now,
+		SpaceId:     space.Id,
+		Desc:        cmd.Desc,
+		Name:        cmd.Name,
+		Value:       cmd.Value,
+		Version:     1,
+		AutoRestart: cmd.AutoRestart,
+		CreatedAt:   now,
+		UpdatedAt:   now,
 	}
 	es := domain.NewSpaceSecretVault(secret)
 	err = s.secureStorageAdapter.SaveSpaceEnvSecret(es)
@@ -127,6 +143,10 @@ func (s *spaceSecretService) CreateSecret(
 		return "", action, err
 	}
 
+	if err = s.addSecretVersion(user, secret, domain.SecretVersionActionCreate, 0); err != nil {
+		return "", action, err
+	}
+
 	e := domain.NewSpaceEnvChangedEvent(user, &space)
 	if err = s.msgAdapter.SendSpaceEnvChangedEvent(&e); err != nil {
 		err = allerror.NewCommonRespError("failed to send create space secret event",
@@ -205,6 +225,12 @@ func (s *spaceSecretService) DeleteSecret(
 		return
 	}
 
+	if err = deleteSpaceSecretVersions(s.secretAdapter, s.secureStorageAdapter, &secret); err != nil {
+		err = allerror.NewCommonRespError("failed to delete secret versions",
+			xerrors.Errorf("space secret name:%s, err: %w", secret.Name.ENVName(), err))
+		return
+	}
+
 	e := domain.NewSpaceEnvChangedEvent(user, &space)
 	if err = s.msgAdapter.SendSpaceEnvChangedEvent(&e); err != nil {
 		err = allerror.NewCommonRespError("failed to send delete space secret event",
@@ -223,25 +249,64 @@ func (s *spaceSecretService) UpdateSecret(
 	ctx context.Context, user primitive.Account, spaceId primitive.Identity,
 	secretId primitive.Identity, cmd *CmdToUpdateSpaceSecret,
 ) (action string, err error) {
-	space, err := s.repoAdapter.FindById(spaceId)
+	space, secret, err := s.findSecret(spaceId, secretId)
 	if err != nil {
-		if commonrepo.IsErrorResourceNotExists(err) {
-			err = newSpaceNotFound(err)
-		}
 		return
 	}
 
-	secret, err := s.secretAdapter.FindSecretById(secretId)
+	action = fmt.Sprintf(
+		"update space secret of %s:%s/%s",
+		spaceId.Identity(), space.Owner.Account(), secret.Name.ENVName(),
+	)
+
+	notFound, err := app.CanUpdateOrNotFound(ctx, user, &space, s.permission)
 	if err != nil {
-		if commonrepo.IsErrorResourceNotExists(err) {
-			err = newSpaceSecretNotFound(err)
+		return
+	}
+	if notFound {
+		err = newSpaceNotFound(xerrors.Errorf("%s not found", spaceId.Identity()))
+		return
+	}
+
+	unversioned := secret
+
+	b, rotated := cmd.toSpaceSecret(&secret)
+	if !b {
+		return
+	}
+
+	if !rotated {
+		if err = s.secretAdapter.SaveSecret(&secret); err != nil {
+			err = allerror.NewCommonRespError("failed to update secret db",
+				xerrors.Errorf("space secret name:%s, err: %w", secret.Name.ENVName(), err))
 		}
+
+		return
+	}
+
+	if err = s.keepUnversionedValue(user, &unversioned); err != nil {
+		return
+	}
+
+	err = s.rotateSecret(ctx, user, &space, &secret, domain.SecretVersionActionUpdate, 0)
+
+	return
+}
+
+// RollbackSecret sets the value of an old version as the current value of the space secret.
+// It creates a new version instead of rewriting the history.
+func (s *spaceSecretService) RollbackSecret(
+	ctx context.Context, user primitive.Account, spaceId primitive.Identity,
+	secretId primitive.Identity, cmd *CmdToRollbackSpaceSecret,
+) (action string, err error) {
+	space, secret, err := s.findSecret(spaceId, secretId)
+	if err != nil {
 		return
 	}
 
 	action = fmt.Sprintf(
-		"update space secret of %s:%s/%s",
-		spaceId.Identity(), space.Owner.Account(), secret.Name.ENVName(),
+		"rollback space secret of %s:%s/%s to version %d",
+		spaceId.Identity(), space.Owner.Account(), secret.Name.ENVName(), cmd.Version,
 	)
 
 	notFound, err := app.CanUpdateOrNotFound(ctx, user, &space, s.permission)
@@ -253,35 +318,189 @@ func (s *spaceSecretService) UpdateSecret(
 		return
 	}
 
-	b := cmd.toSpaceSecret(&secret)
-	if !b {
+	if cmd.Version == secret.Version {
+		err = allerror.NewInvalidParam(
+			"rollback to the current version",
+			xerrors.Errorf("version %d is the current version", cmd.Version),
+		)
 		return
 	}
 
-	es := domain.NewSpaceSecretVault(&secret)
-	err = s.secureStorageAdapter.SaveSpaceEnvSecret(es)
+	if _, err = s.secretAdapter.FindSecretVersion(secret.Id, cmd.Version); err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceSecretVersionNotFound(err)
+		}
+		return
+	}
+
+	value, err := s.secureStorageAdapter.GetAllSpaceEnvSecret(securestorage.SpaceEnvSecret{
+		Path: secret.GetSecretVersionPath(),
+		Name: domain.SecretVersionName(cmd.Version),
+	})
+	if err == nil && value == "" {
+		err = xerrors.Errorf("value of version %d is missing", cmd.Version)
+	}
 	if err != nil {
-		err = allerror.NewCommonRespError("failed to update secret",
+		err = allerror.NewCommonRespError("failed to get secret version",
 			xerrors.Errorf("space secret name:%s, err: %w", secret.Name.ENVName(), err))
 		return
 	}
 
-	err = s.secretAdapter.SaveSecret(&secret)
+	secret.Rotate(spaceprimitive.CreateENVValue(value), utils.Now())
+
+	err = s.rotateSecret(ctx, user, &space, &secret, domain.SecretVersionActionRollback, cmd.Version)
+
+	return
+}
+
+// ListSecretVersions lists all the versions of the space secret without the values.
+func (s *spaceSecretService) ListSecretVersions(
+	ctx context.Context, user primitive.Account, spaceId primitive.Identity, secretId primitive.Identity,
+) ([]SpaceSecretVersionDTO, error) {
+	space, secret, err := s.findSecret(spaceId, secretId)
+	if err != nil {
+		return nil, err
+	}
+
+	notFound, err := app.CanUpdateOrNotFound(ctx, user, &space, s.permission)
 	if err != nil {
-		err = allerror.NewCommonRespError("failed to update secret db",
-			xerrors.Errorf("space secret name:%s, err: %w", secret.Name.ENVName(), err))
+		return nil, err
+	}
+	if notFound {
+		return nil, newSpaceNotFound(xerrors.Errorf("%s not found", spaceId.Identity()))
+	}
+
+	versions, err := s.secretAdapter.ListSecretVersions(secret.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]SpaceSecretVersionDTO, len(versions))
+	for i := range versions {
+		dtos[i] = toSpaceSecretVersionDTO(&versions[i], secret.Version)
+	}
+
+	return dtos, nil
+}
+
+func (s *spaceSecretService) findSecret(spaceId, secretId primitive.Identity) (
+	space domain.Space, secret domain.SpaceSecret, err error,
+) {
+	if space, err = s.repoAdapter.FindById(spaceId); err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceNotFound(err)
+		}
 		return
 	}
 
-	e := domain.NewSpaceEnvChangedEvent(user, &space)
-	if err = s.msgAdapter.SendSpaceEnvChangedEvent(&e); err != nil {
-		err = allerror.NewCommonRespError("failed to send update space secret event",
-			xerrors.Errorf("space id:%s, err: %w", spaceId.Identity(), err))
+	if secret, err = s.secretAdapter.FindSecretById(secretId); err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceSecretNotFound(err)
+		}
 		return
 	}
-	if err = s.setAppRestarting(ctx, space.Id); err != nil {
-		err = allerror.NewCommonRespError("failed to restart space app",
-			xerrors.Errorf("space id:%s, err: %w", spaceId.Identity(), err))
+
+	if secret.SpaceId != space.Id {
+		err = newSpaceSecretNotFound(
+			xerrors.Errorf("secret %s is not in space %s", secretId.Identity(), spaceId.Identity()),
+		)
 	}
+
 	return
 }
+
+// rotateSecret saves the new value of the space secret as a new version,
+// the space app will be restarted only if the owner opted in.
+func (s *spaceSecretService) rotateSecret(
+	ctx context.Context, user primitive.Account, space *domain.Space,
+	secret *domain.SpaceSecret, action string, rollbackFrom int,
+) error {
+	es := domain.NewSpaceSecretVault(secret)
+	if err := s.secureStorageAdapter.SaveSpaceEnvSecret(es); err != nil {
+		return allerror.NewCommonRespError("failed to update secret",
+			xerrors.Errorf("space secret name:%s, err: %w", secret.Name.ENVName(), err))
+	}
+
+	if err := s.secretAdapter.SaveSecret(secret); err != nil {
+		return allerror.NewCommonRespError("failed to update secret db",
+			xerrors.Errorf("space secret name:%s, err: %w", secret.Name.ENVName(), err))
+	}
+
+	if err := s.addSecretVersion(user, secret, action, rollbackFrom); err != nil {
+		return err
+	}
+
+	e := domain.NewSpaceSecretRotatedEvent(user, space, secret)
+	if err := s.msgAdapter.SendSpaceEnvChangedEvent(&e); err != nil {
+		return allerror.NewCommonRespError("failed to send update space secret event",
+			xerrors.Errorf("space id:%s, err: %w", space.Id.Identity(), err))
+	}
+
+	if !secret.AutoRestart {
+		return nil
+	}
+
+	if err := s.setAppRestarting(ctx, space.Id); err != nil {
+		return allerror.NewCommonRespError("failed to restart space app",
+			xerrors.Errorf("space id:%s, err: %w", space.Id.Identity(), err))
+	}
+
+	return nil
+}
+
+// keepUnversionedValue records the value of the secret created before the versioning as version 0,
+// so that the secret can be rolled back to it after the first rotation.
+func (s *spaceSecretService) keepUnversionedValue(user primitive.Account, secret *domain.SpaceSecret) error {
+	if secret.Version != 0 {
+		return nil
+	}
+
+	value, err := s.secureStorageAdapter.GetAllSpaceEnvSecret(securestorage.SpaceEnvSecret{
+		Path: secret.GetSecretPath(),
+		Name: secret.Name.ENVName(),
+	})
+	if err != nil {
+		return allerror.NewCommonRespError("failed to get secret",
+			xerrors.Errorf("space secret name:%s, err: %w", secret.Name.ENVName(), err))
+	}
+
+	if value == "" {
+		return nil
+	}
+
+	secret.Value = spaceprimitive.CreateENVValue(value)
+
+	return s.addSecretVersion(user, secret, domain.SecretVersionActionUnversioned, 0)
+}
+
+func (s *spaceSecretService) addSecretVersion(
+	user primitive.Account, secret *domain.SpaceSecret, action string, rollbackFrom int,
+) error {
+	err := s.secureStorageAdapter.SaveSpaceEnvSecret(domain.NewSpaceSecretVersionVault(secret))
+	if err != nil {
+		return allerror.NewCommonRespError("failed to save secret version",
+			xerrors.Errorf("space secret name:%s, err: %w", secret.Name.ENVName(), err))
+	}
+
+	v := domain.NewSpaceSecretVersion(secret, action, user)
+	v.RollbackFrom = rollbackFrom
+
+	if err = s.secretAdapter.AddSecretVersion(&v); err != nil {
+		return allerror.NewCommonRespError("failed to save secret version db",
+			xerrors.Errorf("space secret name:%s, err: %w", secret.Name.ENVName(), err))
+	}
+
+	return nil
+}
+
+func deleteSpaceSecretVersions(
+	adapter spacerepo.SpaceSecretRepositoryAdapter,
+	storage securestorage.SpaceSecureManager,
+	secret *domain.SpaceSecret,
+) error {
+	if err := storage.DeleteSpaceEnvSecretPath(secret.GetSecretVersionPath()); err != nil {
+		return err
+	}
+
+	return adapter.DeleteSecretVersions(secret.Id)
+}
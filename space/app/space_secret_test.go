@@ -0,0 +1,260 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	commonapp "github.com/openmerlin/merlin-server/common/app"
+	"github.com/openmerlin/merlin-server/common/domain"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	spacedomain "github.com/openmerlin/merlin-server/space/domain"
+	"github.com/openmerlin/merlin-server/space/domain/message"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+	spacerepo "github.com/openmerlin/merlin-server/space/domain/repository"
+	"github.com/openmerlin/merlin-server/space/domain/securestorage"
+	appdomain "github.com/openmerlin/merlin-server/spaceapp/domain"
+	appprimitive "github.com/openmerlin/merlin-server/spaceapp/domain/primitive"
+	"github.com/openmerlin/merlin-server/spaceapp/domain/repository"
+)
+
+var (
+	testSpaceId  = primitive.CreateIdentity(1)
+	testSecretId = primitive.CreateIdentity(2)
+	testUser     = primitive.CreateAccount("owner")
+)
+
+type stubSpaceAdapter struct {
+	spacerepo.SpaceRepositoryAdapter
+}
+
+func (a stubSpaceAdapter) FindById(primitive.Identity) (spacedomain.Space, error) {
+	space := spacedomain.Space{}
+	space.Id = testSpaceId
+	space.Owner = testUser
+
+	return space, nil
+}
+
+type stubPermission struct {
+	commonapp.ResourcePermissionAppService
+}
+
+func (p stubPermission) CanUpdate(context.Context, primitive.Account, domain.Resource) error {
+	return nil
+}
+
+func (p stubPermission) CanCreate(context.Context, primitive.Account, primitive.Account, primitive.ObjType) error {
+	return nil
+}
+
+type stubAppRepo struct {
+	repository.Repository
+	saved []appdomain.SpaceApp
+}
+
+func (r *stubAppRepo) FindBySpaceId(context.Context, primitive.Identity) (appdomain.SpaceApp, error) {
+	return appdomain.SpaceApp{Status: appprimitive.AppStatusServing}, nil
+}
+
+func (r *stubAppRepo) Save(app *appdomain.SpaceApp) error {
+	r.saved = append(r.saved, *app)
+
+	return nil
+}
+
+type stubSecretAdapter struct {
+	spacerepo.SpaceSecretRepositoryAdapter
+	secret   spacedomain.SpaceSecret
+	versions []spacedomain.SpaceSecretVersion
+}
+
+func (a *stubSecretAdapter) FindSecretById(primitive.Identity) (spacedomain.SpaceSecret, error) {
+	return a.secret, nil
+}
+
+func (a *stubSecretAdapter) SaveSecret(secret *spacedomain.SpaceSecret) error {
+	// the value is kept in vault only
+	a.secret = *secret
+	a.secret.Value = nil
+
+	return nil
+}
+
+func (a *stubSecretAdapter) CountSecret(primitive.Identity) (int, error) {
+	return 1, nil
+}
+
+func (a *stubSecretAdapter) AddSecretVersion(v *spacedomain.SpaceSecretVersion) error {
+	a.versions = append(a.versions, *v)
+
+	return nil
+}
+
+func (a *stubSecretAdapter) FindSecretVersion(_ primitive.Identity, version int) (
+	spacedomain.SpaceSecretVersion, error,
+) {
+	for _, v := range a.versions {
+		if v.Version == version {
+			return v, nil
+		}
+	}
+
+	return spacedomain.SpaceSecretVersion{}, commonrepo.NewErrorResourceNotExists(errors.New("not found"))
+}
+
+func (a *stubSecretAdapter) ListSecretVersions(primitive.Identity) ([]spacedomain.SpaceSecretVersion, error) {
+	return a.versions, nil
+}
+
+type stubVault struct {
+	securestorage.SpaceSecureManager
+	values map[string]string
+}
+
+func (v *stubVault) SaveSpaceEnvSecret(s securestorage.SpaceEnvSecret) error {
+	v.values[s.Path+"/"+s.Name] = s.Value
+
+	return nil
+}
+
+func (v *stubVault) GetAllSpaceEnvSecret(s securestorage.SpaceEnvSecret) (string, error) {
+	return v.values[s.Path+"/"+s.Name], nil
+}
+
+type stubSpaceMessage struct {
+	message.SpaceMessage
+}
+
+func (m stubSpaceMessage) SendSpaceEnvChangedEvent(message.EventMessage) error {
+	return nil
+}
+
+type stubOrgEnvAdapter struct {
+	spacerepo.OrgEnvRepositoryAdapter
+	referenced []string
+}
+
+func (a stubOrgEnvAdapter) ListReferencesBySpaceId(primitive.Identity) ([]spacedomain.SpaceEnvReference, error) {
+	refs := make([]spacedomain.SpaceEnvReference, len(a.referenced))
+	for i, name := range a.referenced {
+		refs[i].Name = spaceprimitive.CreateENVName(name)
+	}
+
+	return refs, nil
+}
+
+// newTestSecretService creates the service with a secret set before the versioning.
+func newTestSecretService(autoRestart bool) (SpaceSecretService, *stubSecretAdapter, *stubVault, *stubAppRepo) {
+	secret := spacedomain.SpaceSecret{
+		Id:          testSecretId,
+		SpaceId:     testSpaceId,
+		Name:        spaceprimitive.CreateENVName("TOKEN"),
+		AutoRestart: autoRestart,
+	}
+
+	secrets := &stubSecretAdapter{secret: secret}
+	vault := &stubVault{values: map[string]string{secret.GetSecretPath() + "/TOKEN": "old"}}
+	apps := &stubAppRepo{}
+
+	s := NewSpaceSecretService(
+		stubPermission{}, stubSpaceAdapter{}, apps, secrets, vault, stubSpaceMessage{}, stubOrgEnvAdapter{},
+	)
+
+	return s, secrets, vault, apps
+}
+
+// TestUpdateSecretKeepsUnversionedValue tests that the first rotation of a secret created
+// before the versioning records the old value as version 0, so it can be rolled back to.
+func TestUpdateSecretKeepsUnversionedValue(t *testing.T) {
+	s, secrets, vault, _ := newTestSecretService(true)
+
+	_, err := s.UpdateSecret(
+		context.Background(), testUser, testSpaceId, testSecretId,
+		&CmdToUpdateSpaceSecret{Value: spaceprimitive.CreateENVValue("new")},
+	)
+	if err != nil {
+		t.Fatalf("update secret failed, %v", err)
+	}
+
+	if len(secrets.versions) != 2 {
+		t.Fatalf("expected versions 0 and 1, got %v", secrets.versions)
+	}
+
+	if v := secrets.versions[0]; v.Version != 0 || v.Action != spacedomain.SecretVersionActionUnversioned {
+		t.Fatalf("unexpected version 0: %v", v)
+	}
+
+	if v := secrets.versions[1]; v.Version != 1 || v.Action != spacedomain.SecretVersionActionUpdate {
+		t.Fatalf("unexpected version 1: %v", v)
+	}
+
+	path := secrets.secret.GetSecretVersionPath() + "/"
+	if v := vault.values[path+spacedomain.SecretVersionName(0)]; v != "old" {
+		t.Fatalf("expected the old value kept as version 0, got %q", v)
+	}
+
+	_, err = s.RollbackSecret(
+		context.Background(), testUser, testSpaceId, testSecretId, &CmdToRollbackSpaceSecret{Version: 0},
+	)
+	if err != nil {
+		t.Fatalf("rollback secret failed, %v", err)
+	}
+
+	if secrets.secret.Version != 2 {
+		t.Fatalf("expected the rollback to create version 2, got %d", secrets.secret.Version)
+	}
+
+	if v := secrets.versions[2]; v.Action != spacedomain.SecretVersionActionRollback || v.RollbackFrom != 0 {
+		t.Fatalf("unexpected version 2: %v", v)
+	}
+
+	if v := vault.values[secrets.secret.GetSecretPath()+"/TOKEN"]; v != "old" {
+		t.Fatalf("expected the old value restored, got %q", v)
+	}
+}
+
+// TestUpdateSecretRestartsAppOnlyIfOptedIn tests that the space app is restarted on rotation
+// only when the auto restart of the secret is enabled.
+func TestUpdateSecretRestartsAppOnlyIfOptedIn(t *testing.T) {
+	for _, autoRestart := range []bool{true, false} {
+		s, _, _, apps := newTestSecretService(autoRestart)
+
+		_, err := s.UpdateSecret(
+			context.Background(), testUser, testSpaceId, testSecretId,
+			&CmdToUpdateSpaceSecret{Value: spaceprimitive.CreateENVValue("new")},
+		)
+		if err != nil {
+			t.Fatalf("update secret failed, %v", err)
+		}
+
+		restarted := len(apps.saved) == 1 && apps.saved[0].Status == appprimitive.AppStatusRestarted
+		if restarted != autoRestart {
+			t.Fatalf("auto restart is %v but the app restarted is %v", autoRestart, restarted)
+		}
+	}
+}
+
+// TestCreateSecretConflictsWithReference tests that the secret can't be created with the name
+// of an org env referenced by the space.
+func TestCreateSecretConflictsWithReference(t *testing.T) {
+	vault := &stubVault{values: map[string]string{}}
+
+	s := NewSpaceSecretService(
+		stubPermission{}, stubSpaceAdapter{}, &stubAppRepo{}, &stubSecretAdapter{}, vault, stubSpaceMessage{},
+		stubOrgEnvAdapter{referenced: []string{"HF_TOKEN"}},
+	)
+
+	_, _, err := s.CreateSecret(context.Background(), testUser, testSpaceId, &CmdToCreateSpaceSecret{
+		Name:  spaceprimitive.CreateENVName("HF_TOKEN"),
+		Value: spaceprimitive.CreateENVValue("value"),
+	})
+	if err == nil {
+		t.Fatal("the secret with the name of a referenced org env should be rejected")
+	}
+
+	if len(vault.values) != 0 {
+		t.Fatalf("the rejected secret should not be saved in vault, got %v", vault.values)
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/sirupsen/logrus"
 	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/openmerlin/merlin-server/common/app"
 	"github.com/openmerlin/merlin-server/common/domain/allerror"
@@ -16,6 +18,7 @@ import (
 	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
 	"github.com/openmerlin/merlin-server/space/domain"
 	"github.com/openmerlin/merlin-server/space/domain/message"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
 	spacerepo "github.com/openmerlin/merlin-server/space/domain/repository"
 	"github.com/openmerlin/merlin-server/space/domain/securestorage"
 	appprimitive "github.com/openmerlin/merlin-server/spaceapp/domain/primitive"
@@ -31,6 +34,10 @@ type SpaceVariableService interface {
 	UpdateVariable(context.Context, primitive.Account,
 		primitive.Identity, primitive.Identity, *CmdToUpdateSpaceVariable) (string, error)
 	ListVariableSecret(string) (SpaceVariableSecretDTO, error)
+	ImportVariables(
+		context.Context, primitive.Account, primitive.Identity, *CmdToImportSpaceVariables,
+	) (SpaceVariableImportDTO, string, error)
+	ExportVariables(context.Context, primitive.Account, primitive.Identity) (SpaceVariableExportDTO, error)
 }
 
 // NewSpaceVariableService creates a new instance of the space secret variable.
@@ -41,6 +48,7 @@ func NewSpaceVariableService(
 	variableAdapter spacerepo.SpaceVariableRepositoryAdapter,
 	secureStorageAdapter securestorage.SpaceSecureManager,
 	msgAdapter message.SpaceMessage,
+	orgEnvAdapter spacerepo.OrgEnvRepositoryAdapter,
 ) SpaceVariableService {
 	return &spaceVariableService{
 		permission:           permission,
@@ -49,6 +57,7 @@ func NewSpaceVariableService(
 		variableAdapter:      variableAdapter,
 		secureStorageAdapter: secureStorageAdapter,
 		msgAdapter:           msgAdapter,
+		orgEnvAdapter:        orgEnvAdapter,
 	}
 }
 
@@ -59,6 +68,7 @@ type spaceVariableService struct {
 	variableAdapter      spacerepo.SpaceVariableRepositoryAdapter
 	secureStorageAdapter securestorage.SpaceSecureManager
 	msgAdapter           message.SpaceMessage
+	orgEnvAdapter        spacerepo.OrgEnvRepositoryAdapter
 }
 
 func (s *spaceVariableService) setAppRestarting(ctx context.Context, spaceId primitive.Identity) error {
@@ -103,6 +113,10 @@ func (s *spaceVariableService) CreateVariable(
 		return "", action, err
 	}
 
+	if err = checkNotReferenced(s.orgEnvAdapter, space.Id, cmd.Name); err != nil {
+		return "", action, err
+	}
+
 	now := utils.Now()
 	variable := &domain.SpaceVariable{
 		SpaceId:   space.Id,
@@ -299,3 +313,220 @@ func (s *spaceVariableService) ListVariableSecret(spaceId string) (
 		SpaceVariableSecret: variableSecretList,
 	}, err
 }
+
+// ImportVariables adds or updates the space variables by the content of dotenv file.
+// Nothing is saved when it is a dry run, otherwise all the changes are applied or none of them.
+func (s *spaceVariableService) ImportVariables(
+	ctx context.Context, user primitive.Account, spaceId primitive.Identity, cmd *CmdToImportSpaceVariables,
+) (dto SpaceVariableImportDTO, action string, err error) {
+	space, err := s.repoAdapter.FindById(spaceId)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceNotFound(err)
+		}
+		return
+	}
+
+	action = fmt.Sprintf(
+		"import space variables of %s:%s/%s, dry run: %t",
+		spaceId.Identity(), space.Owner.Account(), space.Name.MSDName(), cmd.DryRun,
+	)
+
+	notFound, err := app.CanUpdateOrNotFound(ctx, user, &space, s.permission)
+	if err != nil {
+		return
+	}
+	if notFound {
+		err = newSpaceNotFound(xerrors.Errorf("%s not found", spaceId.Identity()))
+		return
+	}
+
+	entries, lineErrs := domain.ParseDotEnv(cmd.Content)
+	if len(lineErrs) > 0 {
+		err = newInvalidDotEnv(lineErrs)
+		return
+	}
+
+	variables, secrets, err := s.listVariables(spaceId)
+	if err != nil {
+		return
+	}
+
+	referenced, err := referencedNames(s.orgEnvAdapter, spaceId)
+	if err != nil {
+		return
+	}
+
+	conflicts := []domain.DotEnvLineError{}
+
+	for i := range entries {
+		name := entries[i].Name.ENVName()
+
+		if secrets.Has(name) {
+			conflicts = append(conflicts, domain.DotEnvLineError{
+				Line: entries[i].Line, Reason: fmt.Sprintf("%s is the name of a space secret", name),
+			})
+		} else if referenced.Has(name) {
+			conflicts = append(conflicts, domain.DotEnvLineError{
+				Line: entries[i].Line, Reason: fmt.Sprintf("%s is the name of an org env referenced by the space", name),
+			})
+		}
+	}
+
+	if len(conflicts) > 0 {
+		err = newInvalidDotEnv(conflicts)
+		return
+	}
+
+	imp := domain.NewSpaceVariableImport(space.Id, variables, entries, utils.Now())
+
+	if total := len(variables) + len(imp.Added); total > config.MaxCountSpaceVariable {
+		err = newSpaceVariableCountExceeded(
+			fmt.Errorf("space varibale count(import:%d max:%d) exceed", total, config.MaxCountSpaceVariable),
+		)
+		return
+	}
+
+	dto = toSpaceVariableImportDTO(&imp, cmd.DryRun)
+
+	if cmd.DryRun || !imp.HasChanges() {
+		return
+	}
+
+	if err = s.applyImport(&space, &imp); err != nil {
+		return
+	}
+
+	e := domain.NewSpaceEnvChangedEvent(user, &space)
+	if err = s.msgAdapter.SendSpaceEnvChangedEvent(&e); err != nil {
+		err = allerror.NewCommonRespError("failed to send import space variables event",
+			xerrors.Errorf("space id:%s, err: %w", spaceId.Identity(), err))
+		return
+	}
+	if err = s.setAppRestarting(ctx, space.Id); err != nil {
+		err = allerror.NewCommonRespError("failed to restart space app",
+			xerrors.Errorf("space id:%s, err: %w", spaceId.Identity(), err))
+	}
+
+	return
+}
+
+// applyImport writes all the values to vault in one write and then saves the variables in one transaction.
+// The values in vault are restored if the transaction fails.
+func (s *spaceVariableService) applyImport(space *domain.Space, imp *domain.SpaceVariableImport) error {
+	path := (&domain.SpaceVariable{SpaceId: space.Id}).GetVariablePath()
+
+	values := make(map[string]string, len(imp.Added)+len(imp.Updated))
+	for _, items := range [][]domain.SpaceVariable{imp.Added, imp.Updated} {
+		for i := range items {
+			values[items[i].Name.ENVName()] = items[i].Value.ENVValue()
+		}
+	}
+
+	if err := s.secureStorageAdapter.SaveSpaceEnvSecrets(path, values); err != nil {
+		return allerror.NewCommonRespError("failed to import space variables",
+			xerrors.Errorf("space id:%s, err: %w", space.Id.Identity(), err))
+	}
+
+	err := s.variableAdapter.ImportVariables(imp.Added, imp.Updated)
+	if err == nil {
+		return nil
+	}
+
+	s.restoreImport(path, imp)
+
+	return allerror.NewCommonRespError("failed to import space variables db",
+		xerrors.Errorf("space id:%s, err: %w", space.Id.Identity(), err))
+}
+
+func (s *spaceVariableService) restoreImport(path string, imp *domain.SpaceVariableImport) {
+	if len(imp.Previous) > 0 {
+		values := make(map[string]string, len(imp.Previous))
+		for k, v := range imp.Previous {
+			values[k] = v.ENVValue()
+		}
+
+		if err := s.secureStorageAdapter.SaveSpaceEnvSecrets(path, values); err != nil {
+			logrus.Errorf("failed to restore space variables, path:%s, err:%s", path, err)
+		}
+	}
+
+	for i := range imp.Added {
+		name := imp.Added[i].Name.ENVName()
+
+		if err := s.secureStorageAdapter.DeleteSpaceEnvSecret(path, name); err != nil {
+			logrus.Errorf("failed to remove imported space variable %s, path:%s, err:%s", name, path, err)
+		}
+	}
+}
+
+// ExportVariables exports the space variables as the content of dotenv file, secrets are never exported.
+func (s *spaceVariableService) ExportVariables(
+	ctx context.Context, user primitive.Account, spaceId primitive.Identity,
+) (dto SpaceVariableExportDTO, err error) {
+	space, err := s.repoAdapter.FindById(spaceId)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceNotFound(err)
+		}
+		return
+	}
+
+	notFound, err := app.CanUpdateOrNotFound(ctx, user, &space, s.permission)
+	if err != nil {
+		return
+	}
+	if notFound {
+		err = newSpaceNotFound(xerrors.Errorf("%s not found", spaceId.Identity()))
+		return
+	}
+
+	variables, _, err := s.listVariables(spaceId)
+	if err != nil {
+		return
+	}
+
+	dto.Content = domain.FormatDotEnv(variables)
+	dto.Total = len(variables)
+
+	return
+}
+
+// listVariables returns the variables of the space and the names of secrets of the space.
+func (s *spaceVariableService) listVariables(spaceId primitive.Identity) (
+	[]domain.SpaceVariable, sets.Set[string], error,
+) {
+	items, err := s.variableAdapter.ListVariableSecret(spaceId.Identity())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	secrets := sets.New[string]()
+	variables := make([]domain.SpaceVariable, 0, len(items))
+
+	for i := range items {
+		item := &items[i]
+
+		if item.Type == secretTypeName {
+			secrets.Insert(item.Name)
+
+			continue
+		}
+
+		id, err := primitive.NewIdentity(item.Id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		variables = append(variables, domain.SpaceVariable{
+			Id:        id,
+			SpaceId:   spaceId,
+			Name:      spaceprimitive.CreateENVName(item.Name),
+			Desc:      primitive.CreateMSDDesc(item.Desc),
+			Value:     spaceprimitive.CreateENVValue(item.Value),
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+
+	return variables, secrets, nil
+}
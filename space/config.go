@@ -9,17 +9,19 @@ import (
 	"github.com/openmerlin/merlin-server/space/app"
 	"github.com/openmerlin/merlin-server/space/controller"
 	"github.com/openmerlin/merlin-server/space/domain/primitive"
+	"github.com/openmerlin/merlin-server/space/infrastructure/dnsresolveradapter"
 	"github.com/openmerlin/merlin-server/space/infrastructure/messageadapter"
 	"github.com/openmerlin/merlin-server/space/infrastructure/spacerepositoryadapter"
 )
 
 // Config is a struct that represents the overall configuration for the application.
 type Config struct {
-	App        app.Config                    `json:"app"`
-	Tables     spacerepositoryadapter.Tables `json:"tables"`
-	Topics     messageadapter.Topics         `json:"topics"`
-	Primitive  primitive.Config              `json:"primitive"`
-	Controller controller.Config             `json:"controller"`
+	App         app.Config                    `json:"app"`
+	Tables      spacerepositoryadapter.Tables `json:"tables"`
+	Topics      messageadapter.Topics         `json:"topics"`
+	Primitive   primitive.Config              `json:"primitive"`
+	Controller  controller.Config             `json:"controller"`
+	DNSResolver dnsresolveradapter.Config     `json:"dns_resolver"`
 }
 
 // ConfigItems returns a slice of interface{} containing pointers to the configuration items in the Config struct.
@@ -30,6 +32,7 @@ func (cfg *Config) ConfigItems() []interface{} {
 		&cfg.Topics,
 		&cfg.Primitive,
 		&cfg.Controller,
+		&cfg.DNSResolver,
 	}
 }
 
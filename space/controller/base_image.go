@@ -0,0 +1,190 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	commonctl "github.com/openmerlin/merlin-server/common/controller"
+	"github.com/openmerlin/merlin-server/common/controller/middleware"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	userctl "github.com/openmerlin/merlin-server/user/controller"
+)
+
+func addRouteForBaseImageController(
+	r *gin.RouterGroup,
+	ctl *SpaceController,
+	l middleware.OperationLog,
+	sl middleware.SecurityLog,
+	rl middleware.RateLimiter,
+) {
+	m := ctl.userMiddleWare
+
+	r.POST(`/v1/base-image`, m.Write,
+		userctl.CheckMail(ctl.userMiddleWare, ctl.user, sl), l.Write, rl.CheckLimit, ctl.CreateBaseImage)
+	r.PUT(`/v1/base-image/:id`, m.Write,
+		userctl.CheckMail(ctl.userMiddleWare, ctl.user, sl), l.Write, rl.CheckLimit, ctl.UpdateBaseImage)
+	r.DELETE(`/v1/base-image/:id`, m.Write,
+		userctl.CheckMail(ctl.userMiddleWare, ctl.user, sl), l.Write, rl.CheckLimit, ctl.DeleteBaseImage)
+	r.GET(`/v1/base-image`, m.Optional, rl.CheckLimit, ctl.ListBaseImages)
+	r.GET(`/v1/base-image/deprecated/space`, m.Read, rl.CheckLimit, ctl.ListDeprecatedBaseImageSpaces)
+}
+
+// @Summary  CreateBaseImage
+// @Description  add base image to the catalog, only the administrator can do it
+// @Tags     Space
+// @Param    body  body  reqToCreateBaseImage  true  "body of creating base image"
+// @Accept   json
+// @Security Bearer
+// @Success  201   {object}  commonctl.ResponseData{data=app.BaseImageDTO,msg=string,code=string}
+// @Router   /v1/base-image [post]
+func (ctl *SpaceController) CreateBaseImage(ctx *gin.Context) {
+	req := reqToCreateBaseImage{}
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+		return
+	}
+
+	cmd, err := req.toCmd()
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	v, action, err := ctl.baseImageService.Create(ctx.Request.Context(), user, &cmd)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, v)
+	}
+}
+
+// @Summary  UpdateBaseImage
+// @Description  update the compatibility, default flag or deprecation state of base image
+// @Tags     Space
+// @Param    id    path  string                true  "id of base image" MaxLength(20)
+// @Param    body  body  reqToUpdateBaseImage  true  "body of updating base image"
+// @Accept   json
+// @Security Bearer
+// @Success  202   {object}  commonctl.ResponseData{data=app.BaseImageDTO,msg=string,code=string}
+// @Router   /v1/base-image/{id} [put]
+func (ctl *SpaceController) UpdateBaseImage(ctx *gin.Context) {
+	req := reqToUpdateBaseImage{}
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+		return
+	}
+
+	cmd, err := req.toCmd()
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	id, err := primitive.NewIdentity(ctx.Param("id"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	v, action, err := ctl.baseImageService.Update(ctx.Request.Context(), user, id, &cmd)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, v)
+	}
+}
+
+// @Summary  DeleteBaseImage
+// @Description  delete base image which is not used by any space
+// @Tags     Space
+// @Param    id  path  string  true  "id of base image" MaxLength(20)
+// @Accept   json
+// @Security Bearer
+// @Success  204
+// @Router   /v1/base-image/{id} [delete]
+func (ctl *SpaceController) DeleteBaseImage(ctx *gin.Context) {
+	id, err := primitive.NewIdentity(ctx.Param("id"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	action, err := ctl.baseImageService.Delete(ctx.Request.Context(), user, id)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfDelete(ctx)
+	}
+}
+
+// @Summary  ListBaseImages
+// @Description  list base images of the catalog
+// @Tags     Space
+// @Param    sdk                 query  string  false  "sdk supported by the base image"
+// @Param    hardware            query  string  false  "hardware supported by the base image"
+// @Param    exclude_deprecated  query  bool    false  "exclude the deprecated base images"
+// @Accept   json
+// @Success  200  {object}  commonctl.ResponseData{data=[]app.BaseImageDTO,msg=string,code=string}
+// @Router   /v1/base-image [get]
+func (ctl *SpaceController) ListBaseImages(ctx *gin.Context) {
+	req := reqToListBaseImages{}
+	if err := ctx.BindQuery(&req); err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	cmd := req.toCmd()
+
+	if v, err := ctl.baseImageService.List(&cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary  ListDeprecatedBaseImageSpaces
+// @Description  list spaces using deprecated base images, only the administrator can list all of them
+// @Tags     Space
+// @Param    owner           query  string  false  "owner of space" MaxLength(40)
+// @Param    count_per_page  query  int     false  "count per page"
+// @Param    page_num        query  int     false  "page num which starts from 1"
+// @Accept   json
+// @Security Bearer
+// @Success  200  {object}  commonctl.ResponseData{data=app.DeprecatedBaseImageSpacesDTO,msg=string,code=string}
+// @Router   /v1/base-image/deprecated/space [get]
+func (ctl *SpaceController) ListDeprecatedBaseImageSpaces(ctx *gin.Context) {
+	req := reqToListDeprecatedBaseImageSpaces{}
+	if err := ctx.BindQuery(&req); err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	cmd, err := req.toCmd()
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	if v, err := ctl.baseImageService.ListDeprecatedSpaces(ctx.Request.Context(), user, &cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, v)
+	}
+}
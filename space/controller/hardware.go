@@ -0,0 +1,22 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	commonctl "github.com/openmerlin/merlin-server/common/controller"
+	"github.com/openmerlin/merlin-server/space/app"
+)
+
+// @Summary  ListHardwareFlavors
+// @Description  list the flavors of hardware in the catalog with their quota weight and capacity
+// @Tags     Space
+// @Accept   json
+// @Success  200  {object}  commonctl.ResponseData{data=[]app.HardwareFlavorDTO,msg=string,code=string}
+// @Router   /v1/hardware-flavor [get]
+func (ctl *SpaceController) ListHardwareFlavors(ctx *gin.Context) {
+	commonctl.SendRespOfGet(ctx, app.ListHardwareFlavors())
+}
@@ -0,0 +1,292 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	commonctl "github.com/openmerlin/merlin-server/common/controller"
+	"github.com/openmerlin/merlin-server/common/controller/middleware"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	userctl "github.com/openmerlin/merlin-server/user/controller"
+	"github.com/openmerlin/merlin-server/utils"
+)
+
+func addRouteForOrgEnvController(
+	r *gin.RouterGroup,
+	ctl *SpaceController,
+	l middleware.OperationLog,
+	sl middleware.SecurityLog,
+	rl middleware.RateLimiter,
+) {
+	m := ctl.userMiddleWare
+
+	r.POST(`/v1/org-env`, m.Write,
+		userctl.CheckMail(ctl.userMiddleWare, ctl.user, sl), l.Write, rl.CheckLimit, ctl.CreateOrgEnv)
+	r.PUT(`/v1/org-env/:id`, m.Write,
+		userctl.CheckMail(ctl.userMiddleWare, ctl.user, sl), l.Write, rl.CheckLimit, ctl.UpdateOrgEnv)
+	r.DELETE(`/v1/org-env/:id`, m.Write,
+		userctl.CheckMail(ctl.userMiddleWare, ctl.user, sl), l.Write, rl.CheckLimit, ctl.DeleteOrgEnv)
+	r.GET(`/v1/org-env`, m.Read, rl.CheckLimit, ctl.ListOrgEnv)
+	r.GET(`/v1/org-env/:id/dependent`, m.Read, rl.CheckLimit, ctl.ListOrgEnvDependents)
+
+	r.POST(`/v1/space/:id/env-reference`, m.Write,
+		userctl.CheckMail(ctl.userMiddleWare, ctl.user, sl), l.Write, rl.CheckLimit, ctl.AddEnvReference)
+	r.DELETE(`/v1/space/:id/env-reference/:rid`, m.Write,
+		userctl.CheckMail(ctl.userMiddleWare, ctl.user, sl), l.Write, rl.CheckLimit, ctl.DeleteEnvReference)
+	r.GET(`/v1/space/:owner/:name/env-reference`, m.Read, rl.CheckLimit, ctl.ListEnvReferences)
+}
+
+// @Summary  CreateOrgEnv
+// @Description  create variable or secret of organization which can be referenced by its spaces
+// @Tags     Space
+// @Param    body  body  reqToCreateOrgEnv  true  "body of creating org env"
+// @Accept   json
+// @Security Bearer
+// @Success  201   {object}  commonctl.ResponseData{data=app.OrgEnvDTO,msg=string,code=string}
+// @Router   /v1/org-env [post]
+func (ctl *SpaceController) CreateOrgEnv(ctx *gin.Context) {
+	req := reqToCreateOrgEnv{}
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+		return
+	}
+
+	defer utils.ClearStringMemory(req.Value)
+
+	cmd, err := req.toCmd()
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	v, action, err := ctl.orgEnvService.CreateOrgEnv(ctx.Request.Context(), user, &cmd)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, v)
+	}
+}
+
+// @Summary  UpdateOrgEnv
+// @Description  update org env, the dependent spaces are listed and restarted if it is asked to
+// @Tags     Space
+// @Param    id    path  string             true  "id of org env" MaxLength(20)
+// @Param    body  body  reqToUpdateOrgEnv  true  "body of updating org env"
+// @Accept   json
+// @Security Bearer
+// @Success  202   {object}  commonctl.ResponseData{data=app.OrgEnvUpdatedDTO,msg=string,code=string}
+// @Router   /v1/org-env/{id} [put]
+func (ctl *SpaceController) UpdateOrgEnv(ctx *gin.Context) {
+	req := reqToUpdateOrgEnv{}
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+		return
+	}
+
+	if req.Value != nil {
+		defer utils.ClearStringMemory(*req.Value)
+	}
+
+	cmd, err := req.toCmd()
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	id, err := primitive.NewIdentity(ctx.Param("id"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	v, action, err := ctl.orgEnvService.UpdateOrgEnv(ctx.Request.Context(), user, id, &cmd)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, v)
+	}
+}
+
+// @Summary  DeleteOrgEnv
+// @Description  delete org env which is not referenced by any space
+// @Tags     Space
+// @Param    id  path  string  true  "id of org env" MaxLength(20)
+// @Accept   json
+// @Security Bearer
+// @Success  204
+// @Router   /v1/org-env/{id} [delete]
+func (ctl *SpaceController) DeleteOrgEnv(ctx *gin.Context) {
+	id, err := primitive.NewIdentity(ctx.Param("id"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	action, err := ctl.orgEnvService.DeleteOrgEnv(ctx.Request.Context(), user, id)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfDelete(ctx)
+	}
+}
+
+// @Summary  ListOrgEnv
+// @Description  list org env, the values of secrets are not returned
+// @Tags     Space
+// @Param    owner  query  string  true  "name of organization" MaxLength(40)
+// @Accept   json
+// @Security Bearer
+// @Success  200  {object}  commonctl.ResponseData{data=[]app.OrgEnvDTO,msg=string,code=string}
+// @Router   /v1/org-env [get]
+func (ctl *SpaceController) ListOrgEnv(ctx *gin.Context) {
+	owner, err := primitive.NewAccount(ctx.Query("owner"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	if v, err := ctl.orgEnvService.ListOrgEnv(ctx.Request.Context(), user, owner); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary  ListOrgEnvDependents
+// @Description  list spaces which reference the org env
+// @Tags     Space
+// @Param    id  path  string  true  "id of org env" MaxLength(20)
+// @Accept   json
+// @Security Bearer
+// @Success  200  {object}  commonctl.ResponseData{data=[]app.SpaceEnvDependentDTO,msg=string,code=string}
+// @Router   /v1/org-env/{id}/dependent [get]
+func (ctl *SpaceController) ListOrgEnvDependents(ctx *gin.Context) {
+	id, err := primitive.NewIdentity(ctx.Param("id"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	if v, err := ctl.orgEnvService.ListDependents(ctx.Request.Context(), user, id); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary  AddEnvReference
+// @Description  reference an org env from the space, it is resolved when the space app is built
+// @Tags     Space
+// @Param    id    path  string                     true  "id of space" MaxLength(20)
+// @Param    body  body  reqToAddSpaceEnvReference  true  "body of adding reference"
+// @Accept   json
+// @Security Bearer
+// @Success  201   {object}  commonctl.ResponseData{data=app.SpaceEnvReferenceDTO,msg=string,code=string}
+// @Router   /v1/space/{id}/env-reference [post]
+func (ctl *SpaceController) AddEnvReference(ctx *gin.Context) {
+	req := reqToAddSpaceEnvReference{}
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+		return
+	}
+
+	cmd, err := req.toCmd()
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	spaceId, err := primitive.NewIdentity(ctx.Param("id"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	v, action, err := ctl.orgEnvService.AddReference(ctx.Request.Context(), user, spaceId, &cmd)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, v)
+	}
+}
+
+// @Summary  DeleteEnvReference
+// @Description  delete reference to org env from the space
+// @Tags     Space
+// @Param    id   path  string  true  "id of space" MaxLength(20)
+// @Param    rid  path  string  true  "id of reference" MaxLength(20)
+// @Accept   json
+// @Security Bearer
+// @Success  204
+// @Router   /v1/space/{id}/env-reference/{rid} [delete]
+func (ctl *SpaceController) DeleteEnvReference(ctx *gin.Context) {
+	spaceId, err := primitive.NewIdentity(ctx.Param("id"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	refId, err := primitive.NewIdentity(ctx.Param("rid"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	action, err := ctl.orgEnvService.DeleteReference(ctx.Request.Context(), user, spaceId, refId)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfDelete(ctx)
+	}
+}
+
+// @Summary  ListEnvReferences
+// @Description  list references to org env of the space
+// @Tags     Space
+// @Param    owner  path  string  true  "owner of space" MaxLength(40)
+// @Param    name   path  string  true  "name of space" MaxLength(100)
+// @Accept   json
+// @Security Bearer
+// @Success  200  {object}  commonctl.ResponseData{data=[]app.SpaceEnvReferenceDTO,msg=string,code=string}
+// @Router   /v1/space/{owner}/{name}/env-reference [get]
+func (ctl *SpaceController) ListEnvReferences(ctx *gin.Context) {
+	spaceId, err := ctl.parseSpaceId(ctx)
+	if err != nil {
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	if v, err := ctl.orgEnvService.ListReferences(ctx.Request.Context(), user, spaceId); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, v)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	commonctl "github.com/openmerlin/merlin-server/common/controller"
+	"github.com/openmerlin/merlin-server/common/controller/middleware"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+)
+
+func addRouteForSpacePriorityController(
+	r *gin.RouterGroup,
+	ctl *SpaceController,
+	l middleware.OperationLog,
+	rl middleware.RateLimiter,
+) {
+	r.PUT(`/v1/space/:id/priority`, ctl.userMiddleWare.Write, l.Write, rl.CheckLimit, ctl.SetPriority)
+}
+
+// @Summary  SetPriority
+// @Description  set priority of space, only the admin of organization can do it
+// @Tags     Space
+// @Param    id    path  string                 true  "id of space" MaxLength(20)
+// @Param    body  body  reqToSetSpacePriority  true  "body of setting priority"
+// @Accept   json
+// @Security Bearer
+// @Success  202   {object}  commonctl.ResponseData{data=nil,msg=string,code=string}
+// @Router   /v1/space/{id}/priority [put]
+func (ctl *SpaceController) SetPriority(ctx *gin.Context) {
+	middleware.SetAction(ctx, fmt.Sprintf("set priority of space %s", ctx.Param("id")))
+
+	req := reqToSetSpacePriority{}
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	priority, err := req.toPriority()
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	spaceId, err := primitive.NewIdentity(ctx.Param("id"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	action, err := ctl.priorityService.SetPriority(
+		ctx.Request.Context(), ctl.userMiddleWare.GetUser(ctx), spaceId, priority,
+	)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, nil)
+	}
+}
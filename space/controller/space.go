@@ -43,6 +43,10 @@ type SpaceController struct {
 	appService          app.SpaceAppService
 	variableService     app.SpaceVariableService
 	secretService       app.SpaceSecretService
+	customDomainService app.SpaceCustomDomainService
+	orgEnvService       app.OrgEnvAppService
+	baseImageService    app.BaseImageAppService
+	priorityService     app.SpacePriorityAppService
 	userMiddleWare      middleware.UserMiddleWare
 	user                userapp.UserService
 	rateLimitMiddleWare middleware.RateLimiter
@@ -180,6 +184,30 @@ func (ctl *SpaceController) parseIndex(ctx *gin.Context) (index domain.SpaceInde
 	return
 }
 
+// parseSpaceId finds the id of space by the owner and name in the path,
+// the response has been sent if it fails.
+func (ctl *SpaceController) parseSpaceId(ctx *gin.Context) (spaceId primitive.Identity, err error) {
+	index, err := ctl.parseIndex(ctx)
+	if err != nil {
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	space, err := ctl.appService.GetByName(ctx.Request.Context(), user, &index)
+	if err != nil {
+		commonctl.SendError(ctx, err)
+
+		return
+	}
+
+	if spaceId, err = primitive.NewIdentity(space.Id); err != nil {
+		commonctl.SendError(ctx, err)
+	}
+
+	return
+}
+
 // @Summary  Disable space
 // @Description  disable space
 // @Tags     Space
@@ -0,0 +1,162 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	commonctl "github.com/openmerlin/merlin-server/common/controller"
+	"github.com/openmerlin/merlin-server/common/controller/middleware"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	userctl "github.com/openmerlin/merlin-server/user/controller"
+)
+
+func addRouteForSpaceCustomDomainController(
+	r *gin.RouterGroup,
+	ctl *SpaceController,
+	l middleware.OperationLog,
+	sl middleware.SecurityLog,
+	rl middleware.RateLimiter,
+) {
+	m := ctl.userMiddleWare
+
+	r.POST(`/v1/space/:id/custom-domain`, m.Write,
+		userctl.CheckMail(ctl.userMiddleWare, ctl.user, sl), l.Write, rl.CheckLimit, ctl.AddCustomDomain)
+	r.PUT(`/v1/space/:id/custom-domain/:did/verify`, m.Write,
+		userctl.CheckMail(ctl.userMiddleWare, ctl.user, sl), l.Write, rl.CheckLimit, ctl.VerifyCustomDomain)
+	r.DELETE(`/v1/space/:id/custom-domain/:did`, m.Write,
+		userctl.CheckMail(ctl.userMiddleWare, ctl.user, sl), l.Write, rl.CheckLimit, ctl.DeleteCustomDomain)
+	r.GET(`/v1/space/:owner/:name/custom-domain`, m.Read, rl.CheckLimit, ctl.ListCustomDomains)
+}
+
+// @Summary  AddCustomDomain
+// @Description  add custom domain to space
+// @Tags     Space
+// @Param    id    path  string                     true  "id of space" MaxLength(20)
+// @Param    body  body  reqToAddSpaceCustomDomain  true  "body of adding custom domain"
+// @Accept   json
+// @Security Bearer
+// @Success  201   {object}  commonctl.ResponseData{data=app.SpaceCustomDomainDTO,msg=string,code=string}
+// @Router   /v1/space/{id}/custom-domain [post]
+func (ctl *SpaceController) AddCustomDomain(ctx *gin.Context) {
+	req := reqToAddSpaceCustomDomain{}
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+		return
+	}
+
+	cmd, err := req.toCmd()
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	spaceId, err := primitive.NewIdentity(ctx.Param("id"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	v, action, err := ctl.customDomainService.AddCustomDomain(ctx.Request.Context(), user, spaceId, &cmd)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, &v)
+	}
+}
+
+// @Summary  VerifyCustomDomain
+// @Description  verify the ownership of custom domain by the TXT record
+// @Tags     Space
+// @Param    id    path  string  true  "id of space" MaxLength(20)
+// @Param    did   path  string  true  "id of custom domain" MaxLength(20)
+// @Accept   json
+// @Security Bearer
+// @Success  202   {object}  commonctl.ResponseData{data=app.SpaceCustomDomainDTO,msg=string,code=string}
+// @Router   /v1/space/{id}/custom-domain/{did}/verify [put]
+func (ctl *SpaceController) VerifyCustomDomain(ctx *gin.Context) {
+	spaceId, domainId, err := parseCustomDomainParam(ctx)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	v, action, err := ctl.customDomainService.VerifyCustomDomain(ctx.Request.Context(), user, spaceId, domainId)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, &v)
+	}
+}
+
+// @Summary  DeleteCustomDomain
+// @Description  delete custom domain of space
+// @Tags     Space
+// @Param    id    path  string  true  "id of space" MaxLength(20)
+// @Param    did   path  string  true  "id of custom domain" MaxLength(20)
+// @Accept   json
+// @Security Bearer
+// @Success  204
+// @Router   /v1/space/{id}/custom-domain/{did} [delete]
+func (ctl *SpaceController) DeleteCustomDomain(ctx *gin.Context) {
+	spaceId, domainId, err := parseCustomDomainParam(ctx)
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	action, err := ctl.customDomainService.DeleteCustomDomain(ctx.Request.Context(), user, spaceId, domainId)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfDelete(ctx)
+	}
+}
+
+// @Summary  ListCustomDomains
+// @Description  list custom domains of space
+// @Tags     Space
+// @Param    owner  path  string  true  "owner of space" MaxLength(40)
+// @Param    name   path  string  true  "name of space" MaxLength(100)
+// @Accept   json
+// @Security Bearer
+// @Success  200   {object}  commonctl.ResponseData{data=[]app.SpaceCustomDomainDTO,msg=string,code=string}
+// @Router   /v1/space/{owner}/{name}/custom-domain [get]
+func (ctl *SpaceController) ListCustomDomains(ctx *gin.Context) {
+	spaceId, err := ctl.parseSpaceId(ctx)
+	if err != nil {
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	if v, err := ctl.customDomainService.ListCustomDomains(ctx.Request.Context(), user, spaceId); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, v)
+	}
+}
+
+func parseCustomDomainParam(ctx *gin.Context) (spaceId, domainId primitive.Identity, err error) {
+	if spaceId, err = primitive.NewIdentity(ctx.Param("id")); err != nil {
+		return
+	}
+
+	domainId, err = primitive.NewIdentity(ctx.Param("did"))
+
+	return
+}
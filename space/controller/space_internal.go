@@ -19,11 +19,15 @@ func AddRouterForSpaceInternalController(
 	r *gin.RouterGroup,
 	s app.SpaceInternalAppService,
 	ms app.ModelSpaceAppService,
+	cd app.SpaceCustomDomainService,
+	oe app.OrgEnvAppService,
 	m middleware.UserMiddleWare,
 ) {
 	ctl := SpaceInternalController{
-		appService:        s,
-		modelSpaceService: ms,
+		appService:          s,
+		modelSpaceService:   ms,
+		customDomainService: cd,
+		orgEnvService:       oe,
 	}
 
 	r.GET("/v1/space/:id", m.Write, ctl.Get)
@@ -33,12 +37,16 @@ func AddRouterForSpaceInternalController(
 	r.PUT("/v1/space/:id/disable", m.Write, ctl.Disable)
 	r.PUT("/v1/space/:id/label", m.Write, ctl.ResetLabel)
 	r.PUT("/v1/space/:id/notify_update_code", m.Write, ctl.NotifyUpdateCode)
+	r.GET("/v1/space/custom-domain", m.Write, ctl.ListVerifiedCustomDomains)
+	r.GET("/v1/space/:id/env-reference", m.Write, ctl.ResolveEnvReferences)
 }
 
 // SpaceInternalController is a struct that holds the necessary dependencies for handling space-related operations.
 type SpaceInternalController struct {
-	appService        app.SpaceInternalAppService
-	modelSpaceService app.ModelSpaceAppService
+	appService          app.SpaceInternalAppService
+	modelSpaceService   app.ModelSpaceAppService
+	customDomainService app.SpaceCustomDomainService
+	orgEnvService       app.OrgEnvAppService
 }
 
 // @Summary  Get
@@ -266,3 +274,40 @@ func (ctl *SpaceInternalController) NotifyUpdateCode(ctx *gin.Context) {
 		commonctl.SendRespOfPut(ctx, nil)
 	}
 }
+
+// @Summary  ListVerifiedCustomDomains
+// @Description  list the verified custom domains and the app urls they are mapped to
+// @Tags     SpaceInternal
+// @Accept   json
+// @Security Internal
+// @Success  200  {object}  commonctl.ResponseData{data=[]app.SpaceCustomDomainMappingDTO,msg=string,code=string}
+// @Router   /v1/space/custom-domain [get]
+func (ctl *SpaceInternalController) ListVerifiedCustomDomains(ctx *gin.Context) {
+	if v, err := ctl.customDomainService.ListVerifiedCustomDomains(ctx.Request.Context()); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary  ResolveEnvReferences
+// @Description  resolve the references to org env of the space when the space app is built
+// @Tags     SpaceInternal
+// @Param    id  path  string  true  "id of space" MaxLength(20)
+// @Accept   json
+// @Security Internal
+// @Success  200  {object}  commonctl.ResponseData{data=[]app.SpaceEnvResolvedDTO,msg=string,code=string}
+// @Router   /v1/space/{id}/env-reference [get]
+func (ctl *SpaceInternalController) ResolveEnvReferences(ctx *gin.Context) {
+	spaceId, err := primitive.NewIdentity(ctx.Param("id"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	if v, err := ctl.orgEnvService.ResolveReferences(spaceId); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, v)
+	}
+}
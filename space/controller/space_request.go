@@ -22,6 +22,7 @@ import (
 	"github.com/openmerlin/merlin-server/common/domain/primitive"
 	"github.com/openmerlin/merlin-server/models/domain"
 	"github.com/openmerlin/merlin-server/space/app"
+	spacedomain "github.com/openmerlin/merlin-server/space/domain"
 	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
 	"github.com/openmerlin/merlin-server/space/domain/repository"
 	"github.com/openmerlin/merlin-server/utils"
@@ -31,6 +32,8 @@ const (
 	firstPage          = 1
 	labelSpliter       = ","
 	repoNameSplitedLen = 2
+
+	maxDotEnvContentSize = 64 * 1024
 )
 
 type reqToCreateSpace struct {
@@ -40,10 +43,12 @@ type reqToCreateSpace struct {
 	Owner      string `json:"owner"      required:"true"`
 	License    string `json:"license"    required:"true"`
 	Hardware   string `json:"hardware"   required:"true"`
-	BaseImage  string `json:"base_image" required:"true"`
+	BaseImage  string `json:"base_image"`
 	Fullname   string `json:"fullname"`
 	Visibility string `json:"visibility" required:"true"`
 	AvatarId   string `json:"space_avatar_id"`
+	AppPort    int    `json:"app_port"`
+	RunAsUser  int    `json:"run_as_user"`
 }
 
 func (req *reqToCreateSpace) action() string {
@@ -96,8 +101,21 @@ func (req *reqToCreateSpace) toCmd() (cmd app.CmdToCreateSpace, err error) {
 		return
 	}
 
-	if cmd.BaseImage, err = spaceprimitive.NewBaseImage(req.BaseImage, req.Hardware); err != nil {
-		err = xerrors.Errorf("invalid base image: %w", err)
+	// the default base image of the catalog is used if it is empty
+	if req.BaseImage != "" {
+		if cmd.BaseImage, err = spaceprimitive.NewBaseImageName(req.BaseImage); err != nil {
+			err = xerrors.Errorf("invalid base image: %w", err)
+			return
+		}
+	}
+
+	if cmd.SDK.IsDocker() {
+		if cmd.Docker, err = spaceprimitive.NewDockerOption(req.AppPort, req.RunAsUser); err != nil {
+			err = xerrors.Errorf("invalid docker option: %w", err)
+			return
+		}
+	} else if req.AppPort != 0 || req.RunAsUser != 0 {
+		err = errors.New("app port and user are only for the space of docker sdk")
 		return
 	}
 
@@ -115,6 +133,8 @@ type reqToUpdateSpace struct {
 	Fullname   *string `json:"fullname"`
 	Hardware   *string `json:"hardware"`
 	Visibility *string `json:"visibility"`
+	AppPort    *int    `json:"app_port"`
+	RunAsUser  *int    `json:"run_as_user"`
 }
 
 func (p *reqToUpdateSpace) action() (str string) {
@@ -150,6 +170,9 @@ func (p *reqToUpdateSpace) toCmd() (cmd app.CmdToUpdateSpace, err error) {
 		}
 	}
 
+	cmd.AppPort = p.AppPort
+	cmd.RunAsUser = p.RunAsUser
+
 	return
 }
 
@@ -368,6 +391,25 @@ func (req *ModeIds) toCmd() []*domain.ModelIndex {
 	return modelsIndex
 }
 
+// reqToImportSpaceVariables
+type reqToImportSpaceVariables struct {
+	Content string `json:"content" binding:"required"`
+	DryRun  bool   `json:"dry_run"`
+}
+
+func (p *reqToImportSpaceVariables) toCmd() (cmd app.CmdToImportSpaceVariables, err error) {
+	if len(p.Content) > maxDotEnvContentSize {
+		err = xerrors.Errorf("content is too large, should be no more than %d bytes", maxDotEnvContentSize)
+
+		return
+	}
+
+	cmd.Content = p.Content
+	cmd.DryRun = p.DryRun
+
+	return
+}
+
 type reqToCreateSpaceVariable struct {
 	Name  *string `json:"name"       required:"true"`
 	Desc  *string `json:"desc"`
@@ -434,12 +476,15 @@ func (p *reqToUpdateSpaceVariable) toCmd() (cmd app.CmdToUpdateSpaceVariable, er
 }
 
 type reqToCreateSpaceSecret struct {
-	Name  *string `json:"name"       required:"true"`
-	Desc  *string `json:"desc"`
-	Value *string `json:"value"`
+	Name        *string `json:"name"       required:"true"`
+	Desc        *string `json:"desc"`
+	Value       *string `json:"value"`
+	AutoRestart bool    `json:"auto_restart"`
 }
 
 func (p *reqToCreateSpaceSecret) toCmd() (cmd app.CmdToCreateSpaceSecret, err error) {
+	cmd.AutoRestart = p.AutoRestart
+
 	if p.Name != nil {
 		if cmd.Name, err = spaceprimitive.NewENVName(*p.Name); err != nil {
 			err = xerrors.Errorf("failed to create env name, err:%w", err)
@@ -466,11 +511,14 @@ func (p *reqToCreateSpaceSecret) toCmd() (cmd app.CmdToCreateSpaceSecret, err er
 
 // reqToUpdateSpaceSecret
 type reqToUpdateSpaceSecret struct {
-	Value *string `json:"value"`
-	Desc  *string `json:"desc"`
+	Value       *string `json:"value"`
+	Desc        *string `json:"desc"`
+	AutoRestart *bool   `json:"auto_restart"`
 }
 
 func (p *reqToUpdateSpaceSecret) toCmd() (cmd app.CmdToUpdateSpaceSecret, err error) {
+	cmd.AutoRestart = p.AutoRestart
+
 	if p.Desc != nil {
 		if cmd.Desc, err = primitive.NewMSDDesc(*p.Desc); err != nil {
 			return
@@ -486,6 +534,193 @@ func (p *reqToUpdateSpaceSecret) toCmd() (cmd app.CmdToUpdateSpaceSecret, err er
 	return
 }
 
+// reqToRollbackSpaceSecret
+type reqToRollbackSpaceSecret struct {
+	Version int `json:"version" binding:"required"`
+}
+
+func (p *reqToRollbackSpaceSecret) toCmd() (cmd app.CmdToRollbackSpaceSecret, err error) {
+	if p.Version <= 0 {
+		err = errors.New("invalid version")
+
+		return
+	}
+
+	cmd.Version = p.Version
+
+	return
+}
+
+// reqToCreateOrgEnv
+type reqToCreateOrgEnv struct {
+	Owner string `json:"owner" binding:"required"`
+	Type  string `json:"type"  binding:"required"`
+	Name  string `json:"name"  binding:"required"`
+	Desc  string `json:"desc"`
+	Value string `json:"value"`
+}
+
+func (p *reqToCreateOrgEnv) toCmd() (cmd app.CmdToCreateOrgEnv, err error) {
+	if cmd.Owner, err = primitive.NewAccount(p.Owner); err != nil {
+		return
+	}
+
+	if cmd.Type, err = spacedomain.NewOrgEnvType(p.Type); err != nil {
+		return
+	}
+
+	if cmd.Name, err = spaceprimitive.NewENVName(p.Name); err != nil {
+		err = xerrors.Errorf("failed to create env name, err:%w", err)
+		return
+	}
+
+	if cmd.Desc, err = primitive.NewMSDDesc(p.Desc); err != nil {
+		err = xerrors.Errorf("failed to create env desc, err:%w", err)
+		return
+	}
+
+	if cmd.Value, err = spaceprimitive.NewENVValue(p.Value); err != nil {
+		err = xerrors.Errorf("failed to create env value, err:%w", err)
+	}
+
+	return
+}
+
+// reqToUpdateOrgEnv
+type reqToUpdateOrgEnv struct {
+	Desc              *string `json:"desc"`
+	Value             *string `json:"value"`
+	RestartDependents bool    `json:"restart_dependents"`
+}
+
+func (p *reqToUpdateOrgEnv) toCmd() (cmd app.CmdToUpdateOrgEnv, err error) {
+	cmd.RestartDependents = p.RestartDependents
+
+	if p.Desc != nil {
+		if cmd.Desc, err = primitive.NewMSDDesc(*p.Desc); err != nil {
+			return
+		}
+	}
+
+	if p.Value != nil {
+		cmd.Value, err = spaceprimitive.NewENVValue(*p.Value)
+	}
+
+	return
+}
+
+// reqToAddSpaceEnvReference
+type reqToAddSpaceEnvReference struct {
+	Type string `json:"type" binding:"required"`
+	Name string `json:"name" binding:"required"`
+}
+
+func (p *reqToAddSpaceEnvReference) toCmd() (cmd app.CmdToAddSpaceEnvReference, err error) {
+	if cmd.Type, err = spacedomain.NewOrgEnvType(p.Type); err != nil {
+		return
+	}
+
+	cmd.Name, err = spaceprimitive.NewENVName(p.Name)
+
+	return
+}
+
+// reqToCreateBaseImage
+type reqToCreateBaseImage struct {
+	Name      string   `json:"name"     binding:"required"`
+	SDKs      []string `json:"sdks"     binding:"required"`
+	Hardware  []string `json:"hardware" binding:"required"`
+	IsDefault bool     `json:"is_default"`
+}
+
+func (p *reqToCreateBaseImage) toCmd() (cmd app.CmdToCreateBaseImage, err error) {
+	if cmd.Name, err = spaceprimitive.NewBaseImageName(p.Name); err != nil {
+		return
+	}
+
+	cmd.SDKs = p.SDKs
+	cmd.Hardware = p.Hardware
+	cmd.IsDefault = p.IsDefault
+
+	return
+}
+
+// reqToUpdateBaseImage
+type reqToUpdateBaseImage struct {
+	SDKs        []string `json:"sdks"`
+	Hardware    []string `json:"hardware"`
+	IsDefault   *bool    `json:"is_default"`
+	Deprecated  *bool    `json:"deprecated"`
+	Replacement string   `json:"replacement"`
+}
+
+func (p *reqToUpdateBaseImage) toCmd() (cmd app.CmdToUpdateBaseImage, err error) {
+	cmd.SDKs = p.SDKs
+	cmd.Hardware = p.Hardware
+	cmd.IsDefault = p.IsDefault
+	cmd.Deprecated = p.Deprecated
+
+	if p.Replacement != "" {
+		if p.Deprecated == nil || !*p.Deprecated {
+			err = errors.New("replacement is only allowed when deprecating")
+
+			return
+		}
+
+		cmd.Replacement, err = spaceprimitive.NewBaseImageName(p.Replacement)
+	}
+
+	return
+}
+
+// reqToListBaseImages
+type reqToListBaseImages struct {
+	SDK               string `form:"sdk"`
+	Hardware          string `form:"hardware"`
+	ExcludeDeprecated bool   `form:"exclude_deprecated"`
+}
+
+func (req *reqToListBaseImages) toCmd() (cmd app.CmdToListBaseImages) {
+	cmd.SDK = strings.ToLower(strings.TrimSpace(req.SDK))
+	cmd.Hardware = strings.ToLower(strings.TrimSpace(req.Hardware))
+	cmd.ExcludeDeprecated = req.ExcludeDeprecated
+
+	return
+}
+
+// reqToListDeprecatedBaseImageSpaces
+type reqToListDeprecatedBaseImageSpaces struct {
+	Owner string `form:"owner"`
+	controller.CommonListRequest
+}
+
+func (req *reqToListDeprecatedBaseImageSpaces) toCmd() (cmd app.CmdToListDeprecatedBaseImageSpaces, err error) {
+	if req.Owner != "" {
+		if cmd.Owner, err = primitive.NewAccount(req.Owner); err != nil {
+			return
+		}
+	}
+
+	if v := req.CountPerPage; v <= 0 || v > config.MaxCountPerPage {
+		cmd.CountPerPage = config.MaxCountPerPage
+	} else {
+		cmd.CountPerPage = v
+	}
+
+	if v := req.PageNum; v <= 0 {
+		cmd.PageNum = firstPage
+	} else {
+		if v > (math.MaxInt / cmd.CountPerPage) {
+			err = errors.New("invalid page num")
+
+			return
+		}
+		cmd.PageNum = v
+	}
+
+	return
+}
+
 type localCMD space.LocalCMD
 
 func (req *localCMD) toCmd() string {
@@ -545,8 +780,13 @@ func (req *reqToNotifyUpdateCode) toCmd() (cmd app.CmdToNotifyUpdateCode, err er
 		cmd.HasApp = true
 		return
 	}
+	if req.SdkType == spaceprimitive.DockerSdk.SDK() {
+		cmd.HasDockerfile = true
+		return
+	}
 	cmd.HasHtml = true
 	cmd.HasApp = true
+	cmd.HasDockerfile = true
 	return
 }
 
@@ -589,3 +829,25 @@ func toUploadCoverCmd(ctx *gin.Context, user primitive.Account) (app.CmdToUpload
 
 	return cmd, nil
 }
+
+// reqToAddSpaceCustomDomain
+type reqToAddSpaceCustomDomain struct {
+	Domain string `json:"domain" required:"true"`
+}
+
+func (p *reqToAddSpaceCustomDomain) toCmd() (cmd app.CmdToAddSpaceCustomDomain, err error) {
+	if cmd.Domain, err = spaceprimitive.NewCustomDomain(p.Domain); err != nil {
+		err = xerrors.Errorf("failed to create custom domain, err:%w", err)
+	}
+
+	return
+}
+
+// reqToSetSpacePriority
+type reqToSetSpacePriority struct {
+	Priority *int `json:"priority" binding:"required"`
+}
+
+func (p *reqToSetSpacePriority) toPriority() (int, error) {
+	return spacedomain.NewSpacePriority(*p.Priority)
+}
@@ -29,6 +29,9 @@ func addRouteForSpaceSecretController(
 		userctl.CheckMail(ctl.userMiddleWare, ctl.user, sl), l.Write, rl.CheckLimit, ctl.DeleteSecret)
 	r.PUT("/v1/space/:id/secret/:sid", m.Write,
 		userctl.CheckMail(ctl.userMiddleWare, ctl.user, sl), l.Write, rl.CheckLimit, ctl.UpdateSecret)
+	r.GET("/v1/space/:owner/:name/secret/:sid/version", m.Read, rl.CheckLimit, ctl.ListSecretVersions)
+	r.POST("/v1/space/:id/secret/:sid/rollback", m.Write,
+		userctl.CheckMail(ctl.userMiddleWare, ctl.user, sl), l.Write, rl.CheckLimit, ctl.RollbackSecret)
 }
 
 // @Summary  CreateSecret
@@ -124,7 +127,9 @@ func (ctl *SpaceController) UpdateSecret(ctx *gin.Context) {
 		return
 	}
 
-	defer utils.ClearStringMemory(*req.Value)
+	if req.Value != nil {
+		defer utils.ClearStringMemory(*req.Value)
+	}
 
 	cmd, err := req.toCmd()
 	if err != nil {
@@ -159,3 +164,81 @@ func (ctl *SpaceController) UpdateSecret(ctx *gin.Context) {
 		commonctl.SendRespOfPut(ctx, nil)
 	}
 }
+
+// @Summary  RollbackSecret
+// @Description  roll back space secret to an old version, a new version will be created
+// @Tags     Space
+// @Param    id    path  string                    true  "id of space" MaxLength(20)
+// @Param    sid   path  string                    true  "id of secret" MaxLength(20)
+// @Param    body  body  reqToRollbackSpaceSecret  true  "body of rolling back space secret"
+// @Accept   json
+// @Security Bearer
+// @Success  201   {object}  commonctl.ResponseData{data=nil,msg=string,code=string}
+// @Router   /v1/space/{id}/secret/{sid}/rollback [post]
+func (ctl *SpaceController) RollbackSecret(ctx *gin.Context) {
+	req := reqToRollbackSpaceSecret{}
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+		return
+	}
+
+	cmd, err := req.toCmd()
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	spaceId, err := primitive.NewIdentity(ctx.Param("id"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	secretId, err := primitive.NewIdentity(ctx.Param("sid"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	action, err := ctl.secretService.RollbackSecret(ctx.Request.Context(), user, spaceId, secretId, &cmd)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, nil)
+	}
+}
+
+// @Summary  ListSecretVersions
+// @Description  list versions of space secret, the values are not returned
+// @Tags     Space
+// @Param    owner  path  string  true  "owner of space" MaxLength(40)
+// @Param    name   path  string  true  "name of space" MaxLength(100)
+// @Param    sid    path  string  true  "id of secret" MaxLength(20)
+// @Accept   json
+// @Security Bearer
+// @Success  200  {object}  commonctl.ResponseData{data=[]app.SpaceSecretVersionDTO,msg=string,code=string}
+// @Router   /v1/space/{owner}/{name}/secret/{sid}/version [get]
+func (ctl *SpaceController) ListSecretVersions(ctx *gin.Context) {
+	secretId, err := primitive.NewIdentity(ctx.Param("sid"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	spaceId, err := ctl.parseSpaceId(ctx)
+	if err != nil {
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	if v, err := ctl.secretService.ListSecretVersions(ctx.Request.Context(), user, spaceId, secretId); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, v)
+	}
+}
@@ -30,6 +30,9 @@ func addRouteForSpaceVariableController(
 		userctl.CheckMail(ctl.userMiddleWare, ctl.user, sl), l.Write, rl.CheckLimit, ctl.DeleteVariable)
 	r.PUT("/v1/space/:id/variable/:vid", m.Write,
 		userctl.CheckMail(ctl.userMiddleWare, ctl.user, sl), l.Write, rl.CheckLimit, ctl.UpdateVariable)
+	r.POST(`/v1/space/:id/variable/import`, m.Write,
+		userctl.CheckMail(ctl.userMiddleWare, ctl.user, sl), l.Write, rl.CheckLimit, ctl.ImportVariables)
+	r.GET("/v1/space/:owner/:name/variable/export", m.Read, rl.CheckLimit, ctl.ExportVariables)
 	r.GET("/v1/space/:owner/:name/variable-secret", m.Read,
 		userctl.CheckMail(ctl.userMiddleWare, ctl.user, sl), l.Write, rl.CheckLimit, ctl.GetVariableSecret)
 }
@@ -183,3 +186,67 @@ func (ctl *SpaceController) GetVariableSecret(ctx *gin.Context) {
 		commonctl.SendRespOfGet(ctx, &dto)
 	}
 }
+
+// @Summary  ImportVariables
+// @Description  add or update space variables by the content of dotenv file, set dry_run to get the diff only
+// @Tags     Space
+// @Param    id    path  string                     true  "id of space" MaxLength(20)
+// @Param    body  body  reqToImportSpaceVariables  true  "body of importing space variables"
+// @Accept   json
+// @Security Bearer
+// @Success  201   {object}  commonctl.ResponseData{data=app.SpaceVariableImportDTO,msg=string,code=string}
+// @Router   /v1/space/{id}/variable/import [post]
+func (ctl *SpaceController) ImportVariables(ctx *gin.Context) {
+	req := reqToImportSpaceVariables{}
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+		return
+	}
+
+	cmd, err := req.toCmd()
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	spaceId, err := primitive.NewIdentity(ctx.Param("id"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+	v, action, err := ctl.variableService.ImportVariables(ctx.Request.Context(), user, spaceId, &cmd)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, v)
+	}
+}
+
+// @Summary  ExportVariables
+// @Description  export space variables in the format of dotenv file, secrets are not included
+// @Tags     Space
+// @Param    owner  path  string  true  "owner of space" MaxLength(40)
+// @Param    name   path  string  true  "name of space" MaxLength(100)
+// @Accept   json
+// @Security Bearer
+// @Success  200   {object}  commonctl.ResponseData{data=app.SpaceVariableExportDTO,msg=string,code=string}
+// @Router   /v1/space/{owner}/{name}/variable/export [get]
+func (ctl *SpaceController) ExportVariables(ctx *gin.Context) {
+	spaceId, err := ctl.parseSpaceId(ctx)
+	if err != nil {
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	if v, err := ctl.variableService.ExportVariables(ctx.Request.Context(), user, spaceId); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, &v)
+	}
+}
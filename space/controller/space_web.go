@@ -24,6 +24,10 @@ func AddRouteForSpaceWebController(
 	ms app.ModelSpaceAppService,
 	sv app.SpaceVariableService,
 	ss app.SpaceSecretService,
+	cd app.SpaceCustomDomainService,
+	oe app.OrgEnvAppService,
+	bi app.BaseImageAppService,
+	sp app.SpacePriorityAppService,
 	m middleware.UserMiddleWare,
 	l middleware.OperationLog,
 	sl middleware.SecurityLog,
@@ -37,6 +41,10 @@ func AddRouteForSpaceWebController(
 			appService:          s,
 			variableService:     sv,
 			secretService:       ss,
+			customDomainService: cd,
+			orgEnvService:       oe,
+			baseImageService:    bi,
+			priorityService:     sp,
 			userMiddleWare:      m,
 			rateLimitMiddleWare: rl,
 			user:                u,
@@ -51,10 +59,19 @@ func AddRouteForSpaceWebController(
 
 	addRouteForSpaceSecretController(r, &ctl.SpaceController, l, sl, rl)
 
+	addRouteForSpaceCustomDomainController(r, &ctl.SpaceController, l, sl, rl)
+
+	addRouteForOrgEnvController(r, &ctl.SpaceController, l, sl, rl)
+
+	addRouteForBaseImageController(r, &ctl.SpaceController, l, sl, rl)
+
+	addRouteForSpacePriorityController(r, &ctl.SpaceController, l, rl)
+
 	r.GET("/v1/space/:owner/:name", p.CheckOwner, m.Optional, rl.CheckLimit, ctl.Get)
 	r.GET("/v1/space/:owner", p.CheckOwner, m.Optional, rl.CheckLimit, ctl.List)
 	r.GET("/v1/space", m.Optional, rl.CheckLimit, ctl.ListGlobal)
 	r.GET("/v1/space/relation/:id/model", m.Optional, rl.CheckLimit, ctl.GetModelsBySpaceId)
+	r.GET("/v1/hardware-flavor", m.Optional, rl.CheckLimit, ctl.ListHardwareFlavors)
 
 	r.PUT("/v1/space/:id/disable", ctl.SpaceController.userMiddleWare.Write, l.Write, rl.CheckLimit, ctl.Disable)
 	r.POST("/v1/space/web/report", rl.CheckLimit, l.Write, m.Write, ctl.SpaceReport)
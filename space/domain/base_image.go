@@ -0,0 +1,111 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"errors"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+)
+
+// BaseImageEntry is an entry of the base image catalog managed by the administrator.
+type BaseImageEntry struct {
+	Id           primitive.Identity
+	Name         spaceprimitive.BaseImage
+	SDKs         []string
+	Hardware     []string
+	Deprecated   bool
+	DeprecatedAt int64
+	Replacement  string
+	IsDefault    bool
+	CreatedBy    primitive.Account
+	CreatedAt    int64
+	UpdatedAt    int64
+	Version      int
+}
+
+// NewBaseImageCompatibility checks the sdks and hardware the base image is compatible with.
+func NewBaseImageCompatibility(sdks, hardware []string) ([]string, []string, error) {
+	if len(sdks) == 0 || len(hardware) == 0 {
+		return nil, nil, errors.New("sdk and hardware can't be empty")
+	}
+
+	sdkSet := sets.New[string]()
+	for _, v := range sdks {
+		sdk, err := spaceprimitive.NewSDK(v)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		sdkSet.Insert(sdk.SDK())
+	}
+
+	hardwareSet := sets.New[string]()
+	for _, v := range hardware {
+		found := false
+		for _, sdk := range sdkSet.UnsortedList() {
+			if h, err := spaceprimitive.NewHardware(v, sdk); err == nil {
+				hardwareSet.Insert(h.Hardware())
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return nil, nil, errors.New("unsupported hardware: " + v)
+		}
+	}
+
+	return sets.List(sdkSet), sets.List(hardwareSet), nil
+}
+
+// Support returns true if the base image can be used by the space of the sdk and hardware.
+func (e *BaseImageEntry) Support(sdk, hardware string) bool {
+	return sets.New(e.SDKs...).Has(sdk) && sets.New(e.Hardware...).Has(hardware)
+}
+
+// Deprecate marks the base image deprecated, it can't be used by new spaces any more
+// and the default flag is cleared.
+func (e *BaseImageEntry) Deprecate(replacement string, now int64) {
+	if !e.Deprecated {
+		e.DeprecatedAt = now
+	}
+
+	e.Deprecated = true
+	e.Replacement = replacement
+	e.IsDefault = false
+}
+
+// Undeprecate makes the base image available again.
+func (e *BaseImageEntry) Undeprecate() {
+	e.Deprecated = false
+	e.DeprecatedAt = 0
+	e.Replacement = ""
+}
+
+// SetDefault makes the base image default for the hardware it supports.
+func (e *BaseImageEntry) SetDefault() error {
+	if e.Deprecated {
+		return errors.New("deprecated base image can't be default")
+	}
+
+	e.IsDefault = true
+
+	return nil
+}
+
+// OverlapDefault returns true if the other entry is the default base image of
+// one of the hardware supported by e, only one default image is kept per hardware.
+func (e *BaseImageEntry) OverlapDefault(other *BaseImageEntry) bool {
+	if !other.IsDefault || other.Name.BaseImage() == e.Name.BaseImage() {
+		return false
+	}
+
+	return sets.New(e.Hardware...).HasAny(other.Hardware...)
+}
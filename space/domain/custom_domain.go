@@ -0,0 +1,86 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+)
+
+const (
+	customDomainChallengeHost   = "_merlin-challenge."
+	customDomainChallengePrefix = "merlin-verification="
+)
+
+// SpaceCustomDomain represents a custom domain registered for a space.
+type SpaceCustomDomain struct {
+	Id        primitive.Identity
+	SpaceId   primitive.Identity
+	Domain    spaceprimitive.CustomDomain
+	Token     string
+	Status    spaceprimitive.DomainStatus
+	CreatedBy primitive.Account
+
+	CreatedAt  int64
+	UpdatedAt  int64
+	VerifiedAt int64
+	Version    int
+}
+
+// NewSpaceCustomDomain creates a pending custom domain of the space with a new challenge token.
+func NewSpaceCustomDomain(
+	space *Space, d spaceprimitive.CustomDomain, user primitive.Account, now int64,
+) (SpaceCustomDomain, error) {
+	token, err := primitive.NewRandomId()
+	if err != nil {
+		return SpaceCustomDomain{}, xerrors.Errorf("failed to gen challenge token, err: %w", err)
+	}
+
+	return SpaceCustomDomain{
+		SpaceId:   space.Id,
+		Domain:    d,
+		Token:     token.RandomId(),
+		Status:    spaceprimitive.DomainStatusPending,
+		CreatedBy: user,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// ChallengeHost returns the host on which the TXT record should be set.
+func (d *SpaceCustomDomain) ChallengeHost() string {
+	return customDomainChallengeHost + d.Domain.CustomDomain()
+}
+
+// ChallengeValue returns the expected value of the TXT record.
+func (d *SpaceCustomDomain) ChallengeValue() string {
+	return customDomainChallengePrefix + d.Token
+}
+
+// IsVerified checks if the ownership of the domain has been verified.
+func (d *SpaceCustomDomain) IsVerified() bool {
+	return d.Status != nil && d.Status.IsVerified()
+}
+
+// Verify marks the domain as verified if one of the TXT records matches the challenge.
+func (d *SpaceCustomDomain) Verify(records []string, now int64) bool {
+	expected := d.ChallengeValue()
+
+	for _, v := range records {
+		if strings.TrimSpace(v) == expected {
+			d.Status = spaceprimitive.DomainStatusVerified
+			d.VerifiedAt = now
+			d.UpdatedAt = now
+
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,67 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+)
+
+// stubResolver is a local resolver which returns the TXT records configured in memory.
+type stubResolver map[string][]string
+
+func (r stubResolver) LookupTXT(_ context.Context, host string) ([]string, error) {
+	return r[host], nil
+}
+
+// TestSpaceCustomDomainVerify tests that the custom domain is verified by the matched TXT record only.
+func TestSpaceCustomDomainVerify(t *testing.T) {
+	d, err := spaceprimitive.NewCustomDomain("Demo.Example.com.")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if d.CustomDomain() != "demo.example.com" {
+		t.Fatalf("unexpected domain: %s", d.CustomDomain())
+	}
+
+	space := Space{}
+	space.Id = primitive.CreateIdentity(1)
+
+	cd, err := NewSpaceCustomDomain(&space, d, primitive.CreateAccount("test"), 1)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if cd.ChallengeHost() != "_merlin-challenge.demo.example.com" {
+		t.Fatalf("unexpected challenge host: %s", cd.ChallengeHost())
+	}
+
+	resolver := stubResolver{cd.ChallengeHost(): {"other"}}
+
+	records, _ := resolver.LookupTXT(context.Background(), cd.ChallengeHost())
+	if cd.Verify(records, 2) || cd.IsVerified() {
+		t.Fatal("domain should not be verified by unmatched record")
+	}
+
+	resolver[cd.ChallengeHost()] = append(resolver[cd.ChallengeHost()], cd.ChallengeValue())
+
+	records, _ = resolver.LookupTXT(context.Background(), cd.ChallengeHost())
+	if !cd.Verify(records, 3) || !cd.IsVerified() || cd.VerifiedAt != 3 {
+		t.Fatal("domain should be verified by matched record")
+	}
+}
+
+// TestNewCustomDomain tests that the invalid domain names are rejected.
+func TestNewCustomDomain(t *testing.T) {
+	for _, v := range []string{"", "localhost", "-a.example.com", "a_b.example.com", "a..com"} {
+		if _, err := spaceprimitive.NewCustomDomain(v); err == nil {
+			t.Errorf("%q should be invalid", v)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+// Package dnsresolver provides an interface for looking up the dns records of custom domains.
+package dnsresolver
+
+import "context"
+
+// Resolver is an interface for looking up the TXT records of a host.
+type Resolver interface {
+	LookupTXT(ctx context.Context, host string) ([]string, error)
+}
@@ -0,0 +1,257 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+)
+
+const (
+	dotEnvExportPrefix = "export "
+	dotEnvComment      = "#"
+	dotEnvSeparator    = "="
+	dotEnvDoubleQuote  = '"'
+	dotEnvSingleQuote  = '\''
+)
+
+// DotEnvEntry is a variable parsed from the content of dotenv file.
+type DotEnvEntry struct {
+	Line  int
+	Name  spaceprimitive.ENVName
+	Value spaceprimitive.ENVValue
+}
+
+// DotEnvLineError describes why a line of dotenv file is invalid.
+type DotEnvLineError struct {
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
+// Error returns the description of the line error.
+func (e DotEnvLineError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Reason)
+}
+
+// ParseDotEnv parses the content of dotenv file. Blank lines and comments are skipped,
+// names and values are validated by the rules of space env.
+// All the invalid lines are returned so that they can be fixed at once.
+func ParseDotEnv(content string) ([]DotEnvEntry, []DotEnvLineError) {
+	var (
+		entries []DotEnvEntry
+		errs    []DotEnvLineError
+		lines   = map[string]int{}
+	)
+
+	for i, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		no := i + 1
+
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, dotEnvComment) {
+			continue
+		}
+
+		entry, err := parseDotEnvLine(line)
+		if err != nil {
+			errs = append(errs, DotEnvLineError{Line: no, Reason: err.Error()})
+
+			continue
+		}
+
+		name := entry.Name.ENVName()
+		if v, ok := lines[name]; ok {
+			errs = append(errs, DotEnvLineError{
+				Line: no, Reason: fmt.Sprintf("duplicate name %s, first defined at line %d", name, v),
+			})
+
+			continue
+		}
+
+		lines[name] = no
+		entry.Line = no
+		entries = append(entries, entry)
+	}
+
+	return entries, errs
+}
+
+func parseDotEnvLine(line string) (entry DotEnvEntry, err error) {
+	line = strings.TrimSpace(strings.TrimPrefix(line, dotEnvExportPrefix))
+
+	name, value, ok := strings.Cut(line, dotEnvSeparator)
+	if !ok {
+		err = fmt.Errorf("missing %s", dotEnvSeparator)
+
+		return
+	}
+
+	if entry.Name, err = spaceprimitive.NewENVName(strings.TrimSpace(name)); err != nil {
+		return
+	}
+
+	v, err := unquoteDotEnvValue(strings.TrimSpace(value))
+	if err != nil {
+		return
+	}
+
+	entry.Value, err = spaceprimitive.NewENVValue(v)
+
+	return
+}
+
+func unquoteDotEnvValue(v string) (string, error) {
+	if v == "" {
+		return v, nil
+	}
+
+	switch q := v[0]; q {
+	case dotEnvSingleQuote:
+		end := strings.IndexByte(v[1:], q)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated quoted value")
+		}
+
+		return v[1 : end+1], checkDotEnvTrailing(v[end+2:])
+
+	case dotEnvDoubleQuote:
+		b := strings.Builder{}
+
+		for i := 1; i < len(v); i++ {
+			c := v[i]
+
+			if c == dotEnvDoubleQuote {
+				return b.String(), checkDotEnvTrailing(v[i+1:])
+			}
+
+			if c == '\\' && i+1 < len(v) {
+				i++
+
+				switch v[i] {
+				case 'n':
+					c = '\n'
+				case 'r':
+					c = '\r'
+				case 't':
+					c = '\t'
+				default:
+					c = v[i]
+				}
+			}
+
+			b.WriteByte(c)
+		}
+
+		return "", fmt.Errorf("unterminated quoted value")
+
+	default:
+		// an unquoted value ends at the inline comment
+		if i := strings.Index(v, " "+dotEnvComment); i >= 0 {
+			v = v[:i]
+		}
+
+		return strings.TrimSpace(v), nil
+	}
+}
+
+func checkDotEnvTrailing(s string) error {
+	s = strings.TrimSpace(s)
+	if s != "" && !strings.HasPrefix(s, dotEnvComment) {
+		return fmt.Errorf("unexpected characters after quoted value")
+	}
+
+	return nil
+}
+
+// FormatDotEnv formats the variables as the content of dotenv file sorted by name.
+// The values are quoted only when needed, so the content can be parsed by ParseDotEnv.
+func FormatDotEnv(variables []SpaceVariable) string {
+	items := make([]string, len(variables))
+	for i := range variables {
+		items[i] = variables[i].Name.ENVName() + dotEnvSeparator + quoteDotEnvValue(variables[i].Value.ENVValue())
+	}
+
+	sort.Strings(items)
+
+	b := strings.Builder{}
+	for _, item := range items {
+		b.WriteString(item)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func quoteDotEnvValue(v string) string {
+	if !strings.ContainsAny(v, " \t\r\n\"'\\#") {
+		return v
+	}
+
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+	return string(dotEnvDoubleQuote) + r.Replace(v) + string(dotEnvDoubleQuote)
+}
+
+// SpaceVariableImport is the result of comparing the dotenv entries with the existing variables.
+// Variables which are not in the dotenv file are kept untouched.
+type SpaceVariableImport struct {
+	Added     []SpaceVariable
+	Updated   []SpaceVariable
+	Previous  map[string]spaceprimitive.ENVValue
+	Unchanged []string
+}
+
+// HasChanges returns true if any variable will be added or updated.
+func (imp *SpaceVariableImport) HasChanges() bool {
+	return len(imp.Added) > 0 || len(imp.Updated) > 0
+}
+
+// NewSpaceVariableImport compares the entries with the existing variables of the space.
+func NewSpaceVariableImport(
+	spaceId primitive.Identity, existing []SpaceVariable, entries []DotEnvEntry, now int64,
+) SpaceVariableImport {
+	m := make(map[string]*SpaceVariable, len(existing))
+	for i := range existing {
+		m[existing[i].Name.ENVName()] = &existing[i]
+	}
+
+	imp := SpaceVariableImport{Previous: map[string]spaceprimitive.ENVValue{}}
+
+	for i := range entries {
+		e := &entries[i]
+
+		old, ok := m[e.Name.ENVName()]
+		if !ok {
+			imp.Added = append(imp.Added, SpaceVariable{
+				SpaceId:   spaceId,
+				Name:      e.Name,
+				Desc:      primitive.CreateMSDDesc(""),
+				Value:     e.Value,
+				CreatedAt: now,
+				UpdatedAt: now,
+			})
+
+			continue
+		}
+
+		if old.Value != nil && old.Value.ENVValue() == e.Value.ENVValue() {
+			imp.Unchanged = append(imp.Unchanged, e.Name.ENVName())
+
+			continue
+		}
+
+		v := *old
+		v.Value = e.Value
+		v.UpdatedAt = now
+
+		imp.Previous[e.Name.ENVName()] = old.Value
+		imp.Updated = append(imp.Updated, v)
+	}
+
+	return imp
+}
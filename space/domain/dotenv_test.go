@@ -0,0 +1,108 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+)
+
+func initENVConfig(t *testing.T) {
+	cfg := spaceprimitive.Config{
+		ENVConfig: spaceprimitive.ENVConfig{
+			MinValueLength: 1,
+			MaxValueLength: 100,
+			NameRegexp:     "^[a-zA-Z_][a-zA-Z0-9_]*$",
+		},
+	}
+
+	if err := cfg.ENVConfig.Validate(); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	spaceprimitive.Init(&cfg)
+}
+
+// TestParseDotEnv tests that the valid entries are parsed and the invalid or duplicate lines are reported.
+func TestParseDotEnv(t *testing.T) {
+	initENVConfig(t)
+
+	content := "# comment\n" +
+		"export A=1\n" +
+		"B = \"x y\\n\" # quoted\n" +
+		"C='#literal' \n" +
+		"D=plain # inline\n" +
+		"\n" +
+		"1E=bad\n" +
+		"A=2\n" +
+		"F\n"
+
+	entries, errs := ParseDotEnv(content)
+
+	want := map[string]string{"A": "1", "B": "x y\n", "C": "#literal", "D": "plain"}
+	if len(entries) != len(want) {
+		t.Fatalf("unexpected entries: %v", entries)
+	}
+
+	for _, e := range entries {
+		if want[e.Name.ENVName()] != e.Value.ENVValue() {
+			t.Fatalf("unexpected value of %s: %q", e.Name.ENVName(), e.Value.ENVValue())
+		}
+	}
+
+	if len(errs) != 3 || errs[0].Line != 7 || errs[1].Line != 8 || errs[2].Line != 9 {
+		t.Fatalf("unexpected errs: %v", errs)
+	}
+}
+
+// TestFormatDotEnv tests that the formatted variables are sorted, quoted and parsed back.
+func TestFormatDotEnv(t *testing.T) {
+	initENVConfig(t)
+
+	variables := []SpaceVariable{
+		{Name: spaceprimitive.CreateENVName("B"), Value: spaceprimitive.CreateENVValue("a \"b\"\n")},
+		{Name: spaceprimitive.CreateENVName("A"), Value: spaceprimitive.CreateENVValue("1")},
+	}
+
+	content := FormatDotEnv(variables)
+	if content != "A=1\nB=\"a \\\"b\\\"\\n\"\n" {
+		t.Fatalf("unexpected content: %q", content)
+	}
+
+	entries, errs := ParseDotEnv(content)
+	if len(errs) != 0 || len(entries) != 2 || entries[1].Value.ENVValue() != "a \"b\"\n" {
+		t.Fatalf("unexpected result: %v %v", entries, errs)
+	}
+}
+
+// TestNewSpaceVariableImport tests that the imported entries are classified as added, updated or unchanged.
+func TestNewSpaceVariableImport(t *testing.T) {
+	initENVConfig(t)
+
+	existing := []SpaceVariable{
+		{Name: spaceprimitive.CreateENVName("A"), Value: spaceprimitive.CreateENVValue("1")},
+		{Name: spaceprimitive.CreateENVName("B"), Value: spaceprimitive.CreateENVValue("2")},
+		{Name: spaceprimitive.CreateENVName("C"), Value: spaceprimitive.CreateENVValue("3")},
+	}
+
+	entries, _ := ParseDotEnv("A=1\nB=20\nD=4\n")
+
+	imp := NewSpaceVariableImport(primitive.CreateIdentity(1), existing, entries, 1)
+
+	if len(imp.Added) != 1 || imp.Added[0].Name.ENVName() != "D" {
+		t.Fatalf("unexpected added: %v", imp.Added)
+	}
+
+	if len(imp.Updated) != 1 || imp.Updated[0].Value.ENVValue() != "20" ||
+		imp.Previous["B"].ENVValue() != "2" {
+		t.Fatalf("unexpected updated: %v", imp.Updated)
+	}
+
+	if len(imp.Unchanged) != 1 || imp.Unchanged[0] != "A" || !imp.HasChanges() {
+		t.Fatalf("unexpected unchanged: %v", imp.Unchanged)
+	}
+}
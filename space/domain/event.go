@@ -105,8 +105,11 @@ func NewSpaceUpdatedEvent(updateParam SpaceUpdateEventParam) spaceUpdatedEvent {
 
 // spaceEnvChangedEvent
 type spaceEnvChangedEvent struct {
-	SpaceId   string `json:"space_id"`
-	ChangedBy string `json:"changed_by"`
+	SpaceId       string `json:"space_id"`
+	ChangedBy     string `json:"changed_by"`
+	SecretName    string `json:"secret_name,omitempty"`
+	SecretVersion int    `json:"secret_version,omitempty"`
+	AutoRestart   bool   `json:"auto_restart,omitempty"`
 }
 
 // Message serializes the spaceEnvChangedEvent into a JSON byte array.
@@ -122,6 +125,17 @@ func NewSpaceEnvChangedEvent(user primitive.Account, space *Space) spaceEnvChang
 	}
 }
 
+// NewSpaceSecretRotatedEvent creates a spaceEnvChangedEvent instance when the secret is rotated.
+func NewSpaceSecretRotatedEvent(user primitive.Account, space *Space, secret *SpaceSecret) spaceEnvChangedEvent {
+	return spaceEnvChangedEvent{
+		SpaceId:       space.Id.Identity(),
+		ChangedBy:     user.Account(),
+		SecretName:    secret.Name.ENVName(),
+		SecretVersion: secret.Version,
+		AutoRestart:   secret.AutoRestart,
+	}
+}
+
 // spaceDisableEvent
 type spaceDisableEvent struct {
 	Time      int64  `json:"time"`
@@ -0,0 +1,61 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"testing"
+
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+)
+
+// TestSpaceQuotaOfHardwareFlavor tests the quota and compute type of the flavors in the catalog and the legacy ones.
+func TestSpaceQuotaOfHardwareFlavor(t *testing.T) {
+	cfg := spaceprimitive.Config{
+		Catalog: []spaceprimitive.HardwareFlavor{
+			{Name: "NPU 2x", ComputeType: "npu", QuotaWeight: 2, Capacity: 2, Aliases: []string{"npu basic"}},
+			{Name: "GPU 1x", ComputeType: "gpu", QuotaWeight: 1, Capacity: 1},
+			{Name: "CPU basic", ComputeType: "cpu"},
+		},
+	}
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	spaceprimitive.Init(&cfg)
+	defer spaceprimitive.Init(&spaceprimitive.Config{})
+
+	space := Space{SDK: spaceprimitive.CreateSDK("gradio"), Hardware: spaceprimitive.CreateHardware("npu 2x")}
+	if space.GetQuotaCount() != 2 || space.GetComputeType().ComputilityType() != "npu" || !space.ConsumeComputility() {
+		t.Fatalf("unexpected quota of npu flavor: %d", space.GetQuotaCount())
+	}
+
+	space.Hardware = spaceprimitive.CreateHardware("gpu 1x")
+	if space.GetQuotaCount() != 1 || space.GetHardwareType() != "gpu" || space.Hardware.IsNpu() {
+		t.Fatalf("unexpected quota of gpu flavor: %d", space.GetQuotaCount())
+	}
+
+	space.Hardware = spaceprimitive.CreateHardware("cpu basic")
+	if space.GetQuotaCount() != 0 || space.ConsumeComputility() {
+		t.Fatal("cpu flavor should not consume quota")
+	}
+
+	// the hardware which is not in the catalog is recognized by its name
+	space.Hardware = spaceprimitive.CreateHardware("npu legacy")
+	if space.GetQuotaCount() != 1 || !space.Hardware.IsNpu() {
+		t.Fatal("legacy npu hardware should consume 1 quota")
+	}
+
+	if spaceprimitive.HardwareAliases()["npu basic"] != "npu 2x" {
+		t.Fatal("legacy hardware should be migrated to the flavor")
+	}
+
+	cfg.Catalog = append(cfg.Catalog, spaceprimitive.HardwareFlavor{
+		Name: "cpu upgrade", ComputeType: "cpu", QuotaWeight: 1,
+	})
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("cpu flavor consuming quota should be rejected")
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	localRunAppPort    = 7860
+	localRunStaticPort = 8000
+	localRunDir        = "app"
+
+	localRunGitUser  = "<username>"
+	localRunGitToken = "<access_token>"
+)
+
+// npu devices and driver which must be mounted into the container to use the npu of host.
+var localRunNpuOptions = []string{
+	"--device /dev/davinci0",
+	"--device /dev/davinci_manager",
+	"--device /dev/devmm_svm",
+	"--device /dev/hisi_hdc",
+	"-v /usr/local/Ascend/driver:/usr/local/Ascend/driver",
+}
+
+// packages which the app of sdk depends on besides the requirements.txt of repo.
+var localRunSDKPackages = map[string][]string{
+	"gradio":    {"gradio"},
+	"streamlit": {"streamlit"},
+}
+
+// LocalRunEnv is an env which the app needs to run locally,
+// the value of secret or org env is a placeholder which must be filled by the user.
+type LocalRunEnv struct {
+	Name   string
+	Value  string
+	Secret bool
+}
+
+// NewLocalRunEnv creates a local run env, the placeholder is used as the value of secret.
+func NewLocalRunEnv(name, value string, secret bool) LocalRunEnv {
+	if secret {
+		value = "<" + name + ">"
+	}
+
+	return LocalRunEnv{Name: name, Value: value, Secret: secret}
+}
+
+// NewLocalRunEnvOfOrg creates a local run env referring to the org env, the placeholder is used as the value
+// since the org envs are only visible to the members of org.
+func NewLocalRunEnvOfOrg(name string, secret bool) LocalRunEnv {
+	return LocalRunEnv{Name: name, Value: "<" + name + ">", Secret: secret}
+}
+
+// LocalRunOption is the option of the platform to generate the local run instructions.
+type LocalRunOption struct {
+	// GitURL is the base url of code repo which can be cloned by the user.
+	GitURL string
+	// ImageRegistry is the registry where the base images are pulled from.
+	ImageRegistry string
+}
+
+// LocalRunInstructions tells how to run the space app locally.
+type LocalRunInstructions struct {
+	Image        string
+	Port         int
+	Envs         []LocalRunEnv
+	GitClone     string
+	PipInstall   string
+	Requirements []string
+	DockerBuild  string
+	DockerRun    string
+	Run          string
+}
+
+// NewLocalRunInstructions generates the instructions from the sdk, base image, hardware and envs of space.
+func NewLocalRunInstructions(space *Space, envs []LocalRunEnv, opt *LocalRunOption) LocalRunInstructions {
+	r := LocalRunInstructions{
+		Envs:     envs,
+		GitClone: fmt.Sprintf("git clone %s %s", localRunCloneURL(space, opt.GitURL), localRunDir),
+	}
+
+	switch {
+	case space.SDK.IsStatic():
+		r.Port = localRunStaticPort
+		r.Run = fmt.Sprintf("cd %s && python -m http.server %d", localRunDir, r.Port)
+
+	case space.SDK.IsDocker():
+		r.Port = localRunAppPort
+		if space.Docker != nil {
+			r.Port = space.Docker.AppPort()
+		}
+
+		r.Image = strings.ToLower(space.Owner.Account() + "/" + space.Name.MSDName())
+		r.DockerBuild = fmt.Sprintf("cd %s && docker build -t %s .", localRunDir, r.Image)
+
+		opts := localRunDockerOptions(space, &r)
+		if space.Docker != nil {
+			opts = append(opts, fmt.Sprintf("--user %d", space.Docker.RunAsUser()))
+		}
+
+		r.DockerRun = fmt.Sprintf("docker run %s %s", strings.Join(opts, " "), r.Image)
+
+	default:
+		r.Port = localRunAppPort
+		r.Requirements = localRunSDKPackages[space.SDK.SDK()]
+		r.PipInstall = strings.TrimSpace("pip install -r requirements.txt " + strings.Join(r.Requirements, " "))
+		r.Run = localRunAppCmd(space.SDK.SDK(), r.Port)
+
+		if space.BaseImage != nil && space.BaseImage.BaseImage() != "" {
+			r.Image = space.BaseImage.BaseImage()
+			if opt.ImageRegistry != "" {
+				r.Image = strings.TrimSuffix(opt.ImageRegistry, "/") + "/" + r.Image
+			}
+
+			script := strings.Join(
+				[]string{r.GitClone, "cd " + localRunDir, r.PipInstall, r.Run}, " && ",
+			)
+
+			r.DockerRun = fmt.Sprintf(
+				"docker run %s %s bash -c %s",
+				strings.Join(localRunDockerOptions(space, &r), " "), r.Image, shellQuote(script),
+			)
+		}
+	}
+
+	return r
+}
+
+func localRunCloneURL(space *Space, gitURL string) string {
+	scheme, host, found := strings.Cut(strings.TrimSuffix(gitURL, "/"), "://")
+	if !found {
+		scheme, host = "https", scheme
+	}
+
+	return fmt.Sprintf(
+		"%s://%s:%s@%s/%s/%s.git",
+		scheme, localRunGitUser, localRunGitToken, host, space.Owner.Account(), space.Name.MSDName(),
+	)
+}
+
+func localRunDockerOptions(space *Space, r *LocalRunInstructions) []string {
+	opts := []string{"-it", fmt.Sprintf("-p %d:%d", r.Port, r.Port)}
+
+	if space.Hardware != nil && space.Hardware.IsNpu() {
+		opts = append(opts, localRunNpuOptions...)
+	}
+
+	for _, e := range r.Envs {
+		opts = append(opts, "-e "+e.Name+"="+shellQuote(e.Value))
+	}
+
+	return opts
+}
+
+func localRunAppCmd(sdk string, port int) string {
+	if sdk == "streamlit" {
+		return fmt.Sprintf("streamlit run app.py --server.address 0.0.0.0 --server.port %d", port)
+	}
+
+	return fmt.Sprintf("GRADIO_SERVER_NAME=0.0.0.0 GRADIO_SERVER_PORT=%d python app.py", port)
+}
+
+func shellQuote(v string) string {
+	return "'" + strings.ReplaceAll(v, "'", `'\''`) + "'"
+}
@@ -0,0 +1,84 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+)
+
+// TestNewLocalRunInstructions tests the instructions of running the gradio space locally without leaking secrets.
+func TestNewLocalRunInstructions(t *testing.T) {
+	space := Space{
+		SDK:       spaceprimitive.CreateSDK("gradio"),
+		Hardware:  spaceprimitive.CreateHardware("npu basic"),
+		BaseImage: spaceprimitive.CreateBaseImage("python3.8-pytorch2.1"),
+	}
+	space.Owner = primitive.CreateAccount("alice")
+	space.Name = primitive.CreateMSDName("demo")
+
+	envs := []LocalRunEnv{
+		NewLocalRunEnv("MODE", "it's", false),
+		NewLocalRunEnv("API_KEY", "real-secret", true),
+		NewLocalRunEnvOfOrg("ORG_MODE", false),
+	}
+
+	r := NewLocalRunInstructions(&space, envs, &LocalRunOption{
+		GitURL:        "https://git.example.com/",
+		ImageRegistry: "registry.example.com/space",
+	})
+
+	if r.GitClone != "git clone https://<username>:<access_token>@git.example.com/alice/demo.git app" {
+		t.Fatalf("unexpected git clone: %s", r.GitClone)
+	}
+
+	if r.Image != "registry.example.com/space/python3.8-pytorch2.1" {
+		t.Fatalf("unexpected image: %s", r.Image)
+	}
+
+	for _, v := range []string{
+		"--device /dev/davinci0", `-e MODE='it'\''s'`, "-e API_KEY='<API_KEY>'", "-e ORG_MODE='<ORG_MODE>'",
+	} {
+		if !strings.Contains(r.DockerRun, v) {
+			t.Fatalf("docker run misses %s: %s", v, r.DockerRun)
+		}
+	}
+
+	if strings.Contains(r.DockerRun, "real-secret") {
+		t.Fatal("secret is leaked")
+	}
+
+	if r.PipInstall != "pip install -r requirements.txt gradio" {
+		t.Fatalf("unexpected pip install: %s", r.PipInstall)
+	}
+}
+
+// TestNewLocalRunInstructionsOfDocker tests the instructions of building and running the docker space locally.
+func TestNewLocalRunInstructionsOfDocker(t *testing.T) {
+	space := Space{
+		SDK:      spaceprimitive.CreateSDK("docker"),
+		Hardware: spaceprimitive.CreateHardware("cpu basic"),
+		Docker:   spaceprimitive.CreateDockerOption(8080, 1000),
+	}
+	space.Owner = primitive.CreateAccount("Alice")
+	space.Name = primitive.CreateMSDName("Demo")
+
+	r := NewLocalRunInstructions(&space, nil, &LocalRunOption{GitURL: "git.example.com"})
+
+	if r.DockerBuild != "cd app && docker build -t alice/demo ." {
+		t.Fatalf("unexpected docker build: %s", r.DockerBuild)
+	}
+
+	if r.DockerRun != "docker run -it -p 8080:8080 --user 1000 alice/demo" {
+		t.Fatalf("unexpected docker run: %s", r.DockerRun)
+	}
+
+	if r.PipInstall != "" {
+		t.Fatalf("docker space needs no pip install: %s", r.PipInstall)
+	}
+}
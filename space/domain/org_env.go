@@ -0,0 +1,109 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"errors"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+	"github.com/openmerlin/merlin-server/space/domain/securestorage"
+)
+
+const (
+	orgEnvPath = "org/"
+
+	// OrgEnvTypeVariable is the type of org env whose value can be read by the members.
+	OrgEnvTypeVariable = "variable"
+	// OrgEnvTypeSecret is the type of org env whose value is kept in vault only.
+	OrgEnvTypeSecret = "secret"
+)
+
+// NewOrgEnvType checks the type of org env.
+func NewOrgEnvType(v string) (string, error) {
+	if v != OrgEnvTypeVariable && v != OrgEnvTypeSecret {
+		return "", errors.New("invalid type of org env")
+	}
+
+	return v, nil
+}
+
+// OrgEnv represents a variable or secret shared by all the spaces of the organization.
+type OrgEnv struct {
+	Id        primitive.Identity
+	Owner     primitive.Account
+	Type      string
+	Name      spaceprimitive.ENVName
+	Desc      primitive.MSDDesc
+	Value     spaceprimitive.ENVValue
+	CreatedBy primitive.Account
+	CreatedAt int64
+	UpdatedAt int64
+	Version   int
+}
+
+// IsSecret returns true if the value of org env is a secret.
+func (e *OrgEnv) IsSecret() bool {
+	return e.Type == OrgEnvTypeSecret
+}
+
+// GetOrgEnvPath return vault path of org env, it follows the path scheme of space env.
+func (e *OrgEnv) GetOrgEnvPath() string {
+	return OrgEnvPath(e.Owner, e.Type)
+}
+
+// OrgEnvPath return vault path of org env of the type.
+func OrgEnvPath(owner primitive.Account, t string) string {
+	p := variablePath
+	if t == OrgEnvTypeSecret {
+		p = secretePath
+	}
+
+	return p + orgEnvPath + owner.Account()
+}
+
+// NewOrgEnvVault return a space env secret vault by org env
+func NewOrgEnvVault(e *OrgEnv) securestorage.SpaceEnvSecret {
+	return securestorage.SpaceEnvSecret{
+		Path:  e.GetOrgEnvPath(),
+		Name:  e.Name.ENVName(),
+		Value: e.Value.ENVValue(),
+	}
+}
+
+// SpaceEnvReference is a reference from the space to an org env by name,
+// it is resolved when the space app is built.
+type SpaceEnvReference struct {
+	Id        primitive.Identity
+	SpaceId   primitive.Identity
+	Owner     primitive.Account
+	Type      string
+	Name      spaceprimitive.ENVName
+	CreatedBy primitive.Account
+	CreatedAt int64
+}
+
+// NewSpaceEnvReference creates a reference to the org env of the space owner.
+func NewSpaceEnvReference(
+	space *Space, t string, name spaceprimitive.ENVName, user primitive.Account, now int64,
+) (SpaceEnvReference, error) {
+	if space.OwnedByPerson() {
+		return SpaceEnvReference{}, errors.New("only the space of organization can reference org env")
+	}
+
+	return SpaceEnvReference{
+		SpaceId:   space.Id,
+		Owner:     space.Owner,
+		Type:      t,
+		Name:      name,
+		CreatedBy: user,
+		CreatedAt: now,
+	}, nil
+}
+
+// Refer returns true if the reference points to the org env.
+func (r *SpaceEnvReference) Refer(e *OrgEnv) bool {
+	return r.Owner == e.Owner && r.Type == e.Type && r.Name.ENVName() == e.Name.ENVName()
+}
@@ -13,6 +13,8 @@ import (
 const (
 	PyTorch   = "pytorch"
 	MindSpore = "mindspore"
+
+	baseImageNameMaxLength = 200
 )
 
 // Hardware is an interface that defines hardware-related operations.
@@ -23,17 +25,13 @@ type BaseImage interface {
 	Type() string
 }
 
-// NewHardware creates a new Hardware instance decided by sdk based on the given string.
-func NewBaseImage(v string, hardware string) (BaseImage, error) {
+// NewBaseImageName creates a base image by its name only, the compatibility
+// with the hardware is checked against the base image catalog.
+func NewBaseImageName(v string) (BaseImage, error) {
 	v = strings.ToLower(strings.TrimSpace(v))
-	hardware = strings.ToLower(strings.TrimSpace(hardware))
-
-	if _, ok := baseImages[hardware]; hardware == "" || !ok {
-		return nil, xerrors.Errorf("unsupported hardware: %s", hardware)
-	}
 
-	if v == "" || !baseImages[hardware].Has(v) {
-		return nil, xerrors.Errorf("%s unsupported base image: %s", hardware, v)
+	if v == "" || len(v) > baseImageNameMaxLength || strings.ContainsAny(v, " \t\r\n") {
+		return nil, xerrors.Errorf("invalid base image: %s", v)
 	}
 
 	return baseImage(v), nil
@@ -6,6 +6,8 @@ Copyright (c) Huawei Technologies Co., Ltd. 2023. All rights reserved
 package primitive
 
 import (
+	"errors"
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -13,27 +15,57 @@ import (
 )
 
 var (
-	envConfig  ENVConfig
-	sdkObjects map[string]sets.Set[string]
-	baseImages map[string]sets.Set[string]
-	tasks      sets.Set[string]
+	envConfig    ENVConfig
+	dockerConfig DockerConfig
+	sdkObjects   map[string]sets.Set[string]
+	baseImages   map[string]sets.Set[string]
+	tasks        sets.Set[string]
 )
 
 // Config represents the configuration structure for initialization.
 type Config struct {
-	SDKObjects []SDKObject     `json:"sdk"`
-	ENVConfig  ENVConfig       `json:"env"`
-	Tasks      []string        `json:"tasks"      required:"true"`
-	BaseImages []baseImageConf `json:"base_image"   required:"true"`
+	SDKObjects []SDKObject      `json:"sdk"`
+	ENVConfig  ENVConfig        `json:"env"`
+	Tasks      []string         `json:"tasks"      required:"true"`
+	BaseImages []baseImageConf  `json:"base_image"   required:"true"`
+	Docker     DockerConfig     `json:"docker"`
+	Catalog    []HardwareFlavor `json:"hardware_catalog"`
 }
 
 // ConfigItems returns a slice of interface{} containing pointers to the configuration items.
 func (cfg *Config) ConfigItems() []interface{} {
 	return []interface{}{
 		&cfg.ENVConfig,
+		&cfg.Docker,
 	}
 }
 
+// Validate checks the hardware catalog, all the hardware of sdk must be in the catalog if it is configured.
+func (cfg *Config) Validate() error {
+	if len(cfg.Catalog) == 0 {
+		return nil
+	}
+
+	if err := validateHardwareCatalog(cfg.Catalog); err != nil {
+		return err
+	}
+
+	names := sets.New[string]()
+	for i := range cfg.Catalog {
+		names.Insert(strings.ToLower(strings.TrimSpace(cfg.Catalog[i].Name)))
+	}
+
+	for _, obj := range cfg.SDKObjects {
+		for _, h := range obj.Hardware {
+			if !names.Has(strings.ToLower(h)) {
+				return fmt.Errorf("hardware: %s of sdk: %s is not in the hardware catalog", h, obj.SdkType)
+			}
+		}
+	}
+
+	return nil
+}
+
 // ENVConfig represents the configuration for env.
 type ENVConfig struct {
 	MinValueLength int    `json:"env_value_min_length"      required:"true"`
@@ -49,6 +81,52 @@ func (cfg *ENVConfig) Validate() (err error) {
 	return
 }
 
+// DockerConfig represents the limits of the space of docker sdk.
+type DockerConfig struct {
+	DefaultAppPort   int `json:"default_app_port"`
+	MinAppPort       int `json:"min_app_port"`
+	MaxAppPort       int `json:"max_app_port"`
+	DefaultRunAsUser int `json:"default_run_as_user"`
+	MinRunAsUser     int `json:"min_run_as_user"`
+}
+
+// SetDefault sets default values for DockerConfig if they are not provided.
+func (cfg *DockerConfig) SetDefault() {
+	if cfg.MinAppPort <= 0 {
+		cfg.MinAppPort = 1024
+	}
+
+	if cfg.MaxAppPort <= 0 {
+		cfg.MaxAppPort = 65535
+	}
+
+	if cfg.DefaultAppPort <= 0 {
+		cfg.DefaultAppPort = 7860
+	}
+
+	if cfg.MinRunAsUser <= 0 {
+		cfg.MinRunAsUser = 1000
+	}
+
+	if cfg.DefaultRunAsUser <= 0 {
+		cfg.DefaultRunAsUser = 1000
+	}
+}
+
+// Validate check values for DockerConfig whether they are valid.
+func (cfg *DockerConfig) Validate() error {
+	if cfg.MinAppPort > cfg.MaxAppPort ||
+		cfg.DefaultAppPort < cfg.MinAppPort || cfg.DefaultAppPort > cfg.MaxAppPort {
+		return errors.New("invalid app port of docker config")
+	}
+
+	if cfg.DefaultRunAsUser < cfg.MinRunAsUser {
+		return errors.New("invalid run as user of docker config")
+	}
+
+	return nil
+}
+
 type SDKObject struct {
 	SdkType  string   `json:"type"        required:"true"`
 	Hardware []string `json:"hardware"    required:"true"`
@@ -59,6 +137,46 @@ type baseImageConf struct {
 	BaseImage    []string `json:"base_image"    required:"true"`
 }
 
+// ConfiguredBaseImages returns the base images in the configuration and the hardware of them,
+// they are migrated to the base image catalog which is the only source of base images.
+func ConfiguredBaseImages() map[string][]string {
+	v := map[string]sets.Set[string]{}
+
+	for hardware, images := range baseImages {
+		if name, ok := hardwareAliases[hardware]; ok {
+			hardware = name
+		}
+
+		for _, img := range images.UnsortedList() {
+			if v[img] == nil {
+				v[img] = sets.New[string]()
+			}
+
+			v[img].Insert(hardware)
+		}
+	}
+
+	r := make(map[string][]string, len(v))
+	for img, hardware := range v {
+		r[img] = sets.List(hardware)
+	}
+
+	return r
+}
+
+// SDKsOfHardware returns the configured sdks which run on the hardware and need a base image.
+func SDKsOfHardware(hardware string) []string {
+	v := sets.New[string]()
+
+	for sdk, items := range sdkObjects {
+		if sdk != static && sdk != docker && items.Has(hardware) {
+			v.Insert(sdk)
+		}
+	}
+
+	return sets.List(v)
+}
+
 // Init initializes the system with the provided configuration.
 func Init(cfg *Config) {
 	if cfg == nil {
@@ -76,6 +194,7 @@ func Init(cfg *Config) {
 	}
 
 	envConfig = cfg.ENVConfig
+	dockerConfig = cfg.Docker
 	// init base image
 	baseImages = make(map[string]sets.Set[string])
 	for _, img := range cfg.BaseImages {
@@ -91,6 +210,8 @@ func Init(cfg *Config) {
 	for _, task := range cfg.Tasks {
 		tasks.Insert(task)
 	}
+
+	initHardwareCatalog(cfg.Catalog)
 }
 
 // SetDefault sets default values for PasswordConfig if they are not provided.
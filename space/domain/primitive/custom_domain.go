@@ -0,0 +1,80 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package primitive
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	customDomainMaxLength = 253
+
+	// DomainStatusPending means the ownership of domain has not been verified.
+	DomainStatusPending = domainStatus("pending")
+	// DomainStatusVerified means the ownership of domain has been verified.
+	DomainStatusVerified = domainStatus("verified")
+)
+
+var customDomainRegexp = regexp.MustCompile(
+	`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]([a-z0-9-]{0,61}[a-z0-9])?$`,
+)
+
+// CustomDomain is an interface that defines the method to get the custom domain.
+type CustomDomain interface {
+	CustomDomain() string
+}
+
+// NewCustomDomain creates a new custom domain instance from a host name.
+func NewCustomDomain(v string) (CustomDomain, error) {
+	v = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(v), "."))
+
+	if v == "" || len(v) > customDomainMaxLength {
+		return nil, xerrors.Errorf("invalid domain length, should between 1 and %d", customDomainMaxLength)
+	}
+
+	if !customDomainRegexp.MatchString(v) {
+		return nil, xerrors.New("invalid domain")
+	}
+
+	return customDomain(v), nil
+}
+
+// CreateCustomDomain creates a new custom domain instance without validation.
+func CreateCustomDomain(v string) CustomDomain {
+	return customDomain(v)
+}
+
+type customDomain string
+
+// CustomDomain returns the string representation of the custom domain.
+func (r customDomain) CustomDomain() string {
+	return string(r)
+}
+
+// DomainStatus is an interface that defines the verification status of custom domain.
+type DomainStatus interface {
+	DomainStatus() string
+	IsVerified() bool
+}
+
+// CreateDomainStatus creates a new domain status instance from a string.
+func CreateDomainStatus(v string) DomainStatus {
+	return domainStatus(v)
+}
+
+type domainStatus string
+
+// DomainStatus returns the string representation of the domain status.
+func (r domainStatus) DomainStatus() string {
+	return string(r)
+}
+
+// IsVerified checks if the domain has been verified.
+func (r domainStatus) IsVerified() bool {
+	return r == DomainStatusVerified
+}
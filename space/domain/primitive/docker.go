@@ -0,0 +1,59 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package primitive
+
+import (
+	"fmt"
+)
+
+// DockerOption is an interface that defines how the space of docker sdk is run.
+type DockerOption interface {
+	AppPort() int
+	RunAsUser() int
+}
+
+// NewDockerOption creates a docker option, the default value of config is used if it is zero.
+func NewDockerOption(port, user int) (DockerOption, error) {
+	if port == 0 {
+		port = dockerConfig.DefaultAppPort
+	}
+
+	if port < dockerConfig.MinAppPort || port > dockerConfig.MaxAppPort {
+		return nil, fmt.Errorf(
+			"invalid app port, it should be in [%d, %d]", dockerConfig.MinAppPort, dockerConfig.MaxAppPort,
+		)
+	}
+
+	if user == 0 {
+		user = dockerConfig.DefaultRunAsUser
+	}
+
+	// the app is not allowed to run as root or system users
+	if user < dockerConfig.MinRunAsUser {
+		return nil, fmt.Errorf("invalid user, it should not be less than %d", dockerConfig.MinRunAsUser)
+	}
+
+	return dockerOption{port: port, user: user}, nil
+}
+
+// CreateDockerOption creates a docker option without validation.
+func CreateDockerOption(port, user int) DockerOption {
+	return dockerOption{port: port, user: user}
+}
+
+type dockerOption struct {
+	port int
+	user int
+}
+
+// AppPort returns the port exposed by the docker image.
+func (r dockerOption) AppPort() int {
+	return r.port
+}
+
+// RunAsUser returns the uid which the app runs as.
+func (r dockerOption) RunAsUser() int {
+	return r.user
+}
@@ -14,6 +14,9 @@ type Hardware interface {
 	Hardware() string
 	IsNpu() bool
 	IsCpu() bool
+	IsAccelerator() bool
+	ComputeType() string
+	QuotaWeight() int
 }
 
 // NewHardware creates a new Hardware instance decided by sdk based on the given string.
@@ -21,17 +24,45 @@ func NewHardware(v string, sdk string) (Hardware, error) {
 	v = strings.ToLower(strings.TrimSpace(v))
 	sdk = strings.ToLower(strings.TrimSpace(sdk))
 
-	if _, ok := sdkObjects[sdk]; sdk == "" || !ok {
-		return nil, errors.New("unsupported sdk")
-	}
+	switch sdk {
+	case static:
+		// static files are served without runtime, so it can't occupy the accelerator.
+		if !hardware(v).IsCpu() || !isHardwareOfFirstClassSDK(sdk, v) {
+			return nil, errors.New("unsupported hardware, static sdk supports cpu only")
+		}
+
+	case docker:
+		if !isHardwareOfFirstClassSDK(sdk, v) {
+			return nil, errors.New("unsupported hardware")
+		}
 
-	if v == "" || !sdkObjects[sdk].Has(v) {
-		return nil, errors.New("unsupported hardware")
+	default:
+		if _, ok := sdkObjects[sdk]; sdk == "" || !ok {
+			return nil, errors.New("unsupported sdk")
+		}
+
+		if v == "" || !sdkObjects[sdk].Has(v) {
+			return nil, errors.New("unsupported hardware")
+		}
 	}
 
 	return hardware(v), nil
 }
 
+// isHardwareOfFirstClassSDK checks the hardware of static or docker sdk, it must be
+// the configured one of sdk, or any valid hardware if the sdk is not configured.
+func isHardwareOfFirstClassSDK(sdk, v string) bool {
+	if v == "" {
+		return false
+	}
+
+	if hardwares, ok := sdkObjects[sdk]; ok {
+		return hardwares.Has(v)
+	}
+
+	return IsValidHardware(v)
+}
+
 func IsValidHardware(h string) bool {
 	for _, sdk := range sdkObjects {
 		if sdk.Has(h) {
@@ -54,10 +85,49 @@ func (r hardware) Hardware() string {
 	return string(r)
 }
 
+// ComputeType returns the compute type of the flavor in the hardware catalog.
+// The hardware which is not in the catalog is recognized by its name.
+func (r hardware) ComputeType() string {
+	if f, ok := LookupHardwareFlavor(string(r)); ok {
+		return f.ComputeType
+	}
+
+	v := strings.ToLower(string(r))
+
+	switch {
+	case strings.Contains(v, computeTypeNpu):
+		return computeTypeNpu
+	case strings.Contains(v, computeTypeCpu):
+		return computeTypeCpu
+	}
+
+	return ""
+}
+
+// QuotaWeight returns the computility quota which the hardware consumes.
+func (r hardware) QuotaWeight() int {
+	if f, ok := LookupHardwareFlavor(string(r)); ok {
+		return f.QuotaWeight
+	}
+
+	if r.IsNpu() {
+		return 1
+	}
+
+	return 0
+}
+
+// IsAccelerator checks if the hardware occupies an accelerator which is limited by the computility quota.
+func (r hardware) IsAccelerator() bool {
+	v := r.ComputeType()
+
+	return v != "" && v != computeTypeCpu
+}
+
 func (r hardware) IsNpu() bool {
-	return strings.Contains(strings.ToLower(string(r)), "npu")
+	return r.ComputeType() == computeTypeNpu
 }
 
 func (r hardware) IsCpu() bool {
-	return strings.Contains(strings.ToLower(string(r)), "cpu")
+	return r.ComputeType() == computeTypeCpu
 }
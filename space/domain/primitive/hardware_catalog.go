@@ -0,0 +1,116 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package primitive
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	computeTypeNpu = "npu"
+	computeTypeCpu = "cpu"
+	computeTypeGpu = "gpu"
+)
+
+var (
+	hardwareCatalog []HardwareFlavor
+	hardwareFlavors map[string]HardwareFlavor
+	hardwareAliases map[string]string
+)
+
+// HardwareFlavor is a flavor of hardware in the catalog.
+// QuotaWeight is the computility quota consumed by the space running on the flavor,
+// Capacity is the number of accelerators of the flavor and
+// Aliases are the legacy names of hardware which are migrated to the flavor.
+type HardwareFlavor struct {
+	Name        string   `json:"name"         required:"true"`
+	ComputeType string   `json:"compute_type" required:"true"`
+	QuotaWeight int      `json:"quota_weight"`
+	Capacity    int      `json:"capacity"`
+	Aliases     []string `json:"aliases"`
+}
+
+// validateHardwareCatalog checks the flavors in the catalog, the name and alias must be unique.
+func validateHardwareCatalog(flavors []HardwareFlavor) error {
+	names := sets.New[string]()
+
+	for i := range flavors {
+		f := &flavors[i]
+
+		name := strings.ToLower(strings.TrimSpace(f.Name))
+		if name == "" || names.Has(name) {
+			return fmt.Errorf("invalid or duplicate hardware flavor: %s", f.Name)
+		}
+
+		names.Insert(name)
+
+		switch strings.ToLower(f.ComputeType) {
+		case computeTypeNpu, computeTypeGpu:
+		case computeTypeCpu:
+			if f.QuotaWeight != 0 {
+				return fmt.Errorf("cpu hardware flavor: %s can't consume quota", f.Name)
+			}
+		default:
+			return fmt.Errorf("unknown compute type of hardware flavor: %s", f.Name)
+		}
+
+		if f.QuotaWeight < 0 || f.Capacity < 0 {
+			return fmt.Errorf("quota weight and capacity of hardware flavor: %s can't be negative", f.Name)
+		}
+	}
+
+	for i := range flavors {
+		for _, alias := range flavors[i].Aliases {
+			alias = strings.ToLower(strings.TrimSpace(alias))
+			if alias == "" || names.Has(alias) {
+				return fmt.Errorf("invalid or duplicate alias of hardware flavor: %s", flavors[i].Name)
+			}
+
+			names.Insert(alias)
+		}
+	}
+
+	return nil
+}
+
+func initHardwareCatalog(flavors []HardwareFlavor) {
+	hardwareCatalog = make([]HardwareFlavor, len(flavors))
+	hardwareFlavors = make(map[string]HardwareFlavor, len(flavors))
+	hardwareAliases = make(map[string]string)
+
+	for i := range flavors {
+		f := flavors[i]
+		f.Name = strings.ToLower(strings.TrimSpace(f.Name))
+		f.ComputeType = strings.ToLower(f.ComputeType)
+
+		for j := range f.Aliases {
+			f.Aliases[j] = strings.ToLower(strings.TrimSpace(f.Aliases[j]))
+			hardwareAliases[f.Aliases[j]] = f.Name
+		}
+
+		hardwareCatalog[i] = f
+		hardwareFlavors[f.Name] = f
+	}
+}
+
+// HardwareCatalog returns all the flavors in the catalog.
+func HardwareCatalog() []HardwareFlavor {
+	return hardwareCatalog
+}
+
+// LookupHardwareFlavor finds the flavor of hardware in the catalog.
+func LookupHardwareFlavor(v string) (HardwareFlavor, bool) {
+	f, ok := hardwareFlavors[strings.ToLower(v)]
+
+	return f, ok
+}
+
+// HardwareAliases returns the legacy names of hardware and the flavors they are migrated to.
+func HardwareAliases() map[string]string {
+	return hardwareAliases
+}
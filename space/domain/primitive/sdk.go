@@ -12,12 +12,19 @@ import (
 // SDK is an interface that defines the method to get the SDK name.
 type SDK interface {
 	SDK() string
+	IsStatic() bool
+	IsDocker() bool
 }
 
 // NewSDK creates a new SDK instance based on the given version string.
+// The static and docker sdk are always supported even if they are not configured.
 func NewSDK(v string) (SDK, error) {
 	v = strings.ToLower(strings.TrimSpace(v))
 
+	if v == static || v == docker {
+		return sdk(v), nil
+	}
+
 	if _, ok := sdkObjects[v]; v == "" || !ok {
 		return nil, errors.New("unsupported sdk")
 	}
@@ -37,9 +44,20 @@ func (r sdk) SDK() string {
 	return string(r)
 }
 
+// IsStatic returns true if the space serves static files without runtime.
+func (r sdk) IsStatic() bool {
+	return string(r) == static
+}
+
+// IsDocker returns true if the space is built from the Dockerfile of its repo.
+func (r sdk) IsDocker() bool {
+	return string(r) == docker
+}
+
 const (
 	static = "static"
 	gradio = "gradio"
+	docker = "docker"
 )
 
 var (
@@ -47,4 +65,6 @@ var (
 	StaticSdk = sdk(static)
 	// GradioSdk represents gradio sdk.
 	GradioSdk = sdk(gradio)
-)
\ No newline at end of file
+	// DockerSdk represents docker sdk.
+	DockerSdk = sdk(docker)
+)
@@ -0,0 +1,31 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"errors"
+)
+
+const (
+	// MinSpacePriority is the default priority of space which can't preempt the others.
+	MinSpacePriority = 0
+	// MaxSpacePriority is the highest priority of space.
+	MaxSpacePriority = 100
+)
+
+// NewSpacePriority checks the priority of space, the space of higher priority can
+// preempt the computility quota used by the apps of lower priority.
+func NewSpacePriority(v int) (int, error) {
+	if v < MinSpacePriority || v > MaxSpacePriority {
+		return 0, errors.New("invalid priority of space")
+	}
+
+	return v, nil
+}
+
+// CanPreempt checks if the space is privileged to preempt the other space apps.
+func (m *Space) CanPreempt() bool {
+	return m.Priority > MinSpacePriority
+}
@@ -64,6 +64,9 @@ type ListOption struct {
 	// Hardware is an interface that defines hardware-related operations.
 	Hardware spaceprimitive.Hardware
 
+	// list space which uses one of the base images
+	BaseImages []string
+
 	// sort
 	SortType primitive.SortType
 
@@ -120,6 +123,7 @@ type SpaceVariableRepositoryAdapter interface {
 	SaveVariable(*domain.SpaceVariable) error
 	CountVariable(primitive.Identity) (int, error)
 	ListVariableSecret(string) ([]SpaceVariableSecretSummary, error)
+	ImportVariables(added, updated []domain.SpaceVariable) error
 }
 
 // SpaceSecretRepositoryAdapter is an interface for interacting with space secret repositories.
@@ -129,4 +133,57 @@ type SpaceSecretRepositoryAdapter interface {
 	DeleteSecret(primitive.Identity) error
 	SaveSecret(*domain.SpaceSecret) error
 	CountSecret(primitive.Identity) (int, error)
+	AddSecretVersion(*domain.SpaceSecretVersion) error
+	FindSecretVersion(primitive.Identity, int) (domain.SpaceSecretVersion, error)
+	ListSecretVersions(primitive.Identity) ([]domain.SpaceSecretVersion, error)
+	DeleteSecretVersions(primitive.Identity) error
+}
+
+// SpaceCustomDomainRepositoryAdapter is an interface for interacting with space custom domain repositories.
+type SpaceCustomDomainRepositoryAdapter interface {
+	AddCustomDomain(*domain.SpaceCustomDomain) error
+	FindCustomDomainById(primitive.Identity) (domain.SpaceCustomDomain, error)
+	FindVerifiedCustomDomain(spaceprimitive.CustomDomain) (domain.SpaceCustomDomain, error)
+	SaveCustomDomain(*domain.SpaceCustomDomain) error
+	DeleteCustomDomain(primitive.Identity) error
+	DeletePendingCustomDomain(spaceprimitive.CustomDomain) error
+	DeleteCustomDomainBySpaceId(primitive.Identity) error
+	ListCustomDomainBySpaceId(primitive.Identity) ([]domain.SpaceCustomDomain, error)
+	ListVerifiedCustomDomain() ([]domain.SpaceCustomDomain, error)
+}
+
+// OrgEnvRepositoryAdapter is an interface for interacting with org env and the references of space.
+type OrgEnvRepositoryAdapter interface {
+	AddOrgEnv(*domain.OrgEnv) error
+	FindOrgEnvById(primitive.Identity) (domain.OrgEnv, error)
+	FindOrgEnvByName(primitive.Account, string, spaceprimitive.ENVName) (domain.OrgEnv, error)
+	SaveOrgEnv(*domain.OrgEnv) error
+	DeleteOrgEnv(primitive.Identity) error
+	ListOrgEnv(primitive.Account) ([]domain.OrgEnv, error)
+
+	AddReference(*domain.SpaceEnvReference) error
+	FindReferenceById(primitive.Identity) (domain.SpaceEnvReference, error)
+	DeleteReference(primitive.Identity) error
+	DeleteReferencesBySpaceId(primitive.Identity) error
+	ListReferencesBySpaceId(primitive.Identity) ([]domain.SpaceEnvReference, error)
+	ListReferencesByOrgEnv(*domain.OrgEnv) ([]domain.SpaceEnvReference, error)
+}
+
+// BaseImageListOption contains options for listing base images of the catalog.
+type BaseImageListOption struct {
+	SDK      string
+	Hardware string
+
+	ExcludeDeprecated bool
+	OnlyDeprecated    bool
+}
+
+// BaseImageRepositoryAdapter is an interface for interacting with the base image catalog.
+type BaseImageRepositoryAdapter interface {
+	AddBaseImage(*domain.BaseImageEntry) error
+	FindBaseImageById(primitive.Identity) (domain.BaseImageEntry, error)
+	FindBaseImageByName(spaceprimitive.BaseImage) (domain.BaseImageEntry, error)
+	SaveBaseImage(*domain.BaseImageEntry) error
+	DeleteBaseImage(primitive.Identity) error
+	ListBaseImages(*BaseImageListOption) ([]domain.BaseImageEntry, error)
 }
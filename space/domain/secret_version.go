@@ -0,0 +1,43 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import "github.com/openmerlin/merlin-server/common/domain/primitive"
+
+const (
+	// SecretVersionActionCreate means the version is created along with the secret.
+	SecretVersionActionCreate = "create"
+	// SecretVersionActionUpdate means the version is created by updating the value.
+	SecretVersionActionUpdate = "update"
+	// SecretVersionActionRollback means the version is created by rolling back to an old version.
+	SecretVersionActionRollback = "rollback"
+	// SecretVersionActionUnversioned means the version keeps the value set before the versioning,
+	// it is recorded when the value is rotated for the first time.
+	SecretVersionActionUnversioned = "unversioned"
+)
+
+// SpaceSecretVersion records who changed the value of the space secret and when, the value is kept in vault.
+type SpaceSecretVersion struct {
+	Id           primitive.Identity
+	SecretId     primitive.Identity
+	SpaceId      primitive.Identity
+	Version      int
+	Action       string
+	RollbackFrom int
+	CreatedBy    primitive.Account
+	CreatedAt    int64
+}
+
+// NewSpaceSecretVersion creates a version record of the current value of the space secret.
+func NewSpaceSecretVersion(secret *SpaceSecret, action string, user primitive.Account) SpaceSecretVersion {
+	return SpaceSecretVersion{
+		SecretId:  secret.Id,
+		SpaceId:   secret.SpaceId,
+		Version:   secret.Version,
+		Action:    action,
+		CreatedBy: user,
+		CreatedAt: secret.UpdatedAt,
+	}
+}
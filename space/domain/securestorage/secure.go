@@ -17,4 +17,6 @@ type SpaceSecureManager interface {
 	SaveSpaceEnvSecret(SpaceEnvSecret) error
 	DeleteSpaceEnvSecret(string, string) error
 	GetAllSpaceEnvSecret(SpaceEnvSecret) (string, error)
+	DeleteSpaceEnvSecretPath(string) error
+	SaveSpaceEnvSecrets(string, map[string]string) error
 }
@@ -5,6 +5,7 @@ Copyright (c) Huawei Technologies Co., Ltd. 2023. All rights reserved
 package domain
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -18,11 +19,14 @@ import (
 )
 
 const (
-	variablePath = "variable/"
-	secretePath  = "secret/"
+	variablePath      = "variable/"
+	secretePath       = "secret/"
+	secretVersionPath = "secret_version/"
+	secretVersionName = "v"
 
 	computilityTypeNpu = "npu"
 	computilityTypeCpu = "cpu"
+	computilityTypeGpu = "gpu"
 )
 
 // Space represents a space with its associated properties and methods.
@@ -36,6 +40,7 @@ type Space struct {
 	Hardware      spaceprimitive.Hardware
 	AvatarId      primitive.Avatar
 	BaseImage     spaceprimitive.BaseImage
+	Docker        spaceprimitive.DockerOption
 	LocalCmd      string
 	LocalEnvInfo  string
 	Version       int
@@ -53,6 +58,7 @@ type Space struct {
 	NoApplicationFile    bool
 	CommitId             string
 	IsDiscussionDisabled bool
+	Priority             int
 }
 
 // ResourceType returns the type of the model resource.
@@ -124,7 +130,7 @@ func (m *Space) SetSpaceCommitId(commitId string) {
 }
 
 // SetNoApplicationFile for set NoApplicationFile and Exception.
-func (m *Space) SetNoApplicationFile(hasHtml, hasApp bool) {
+func (m *Space) SetNoApplicationFile(hasHtml, hasApp, hasDockerfile bool) {
 	m.NoApplicationFile = true
 	if (m.SDK == spaceprimitive.StaticSdk) && hasHtml {
 		m.NoApplicationFile = false
@@ -132,6 +138,9 @@ func (m *Space) SetNoApplicationFile(hasHtml, hasApp bool) {
 	if (m.SDK == spaceprimitive.GradioSdk) && hasApp {
 		m.NoApplicationFile = false
 	}
+	if (m.SDK == spaceprimitive.DockerSdk) && hasDockerfile {
+		m.NoApplicationFile = false
+	}
 	if m.NoApplicationFile {
 		m.Exception = primitive.CreateException(primitive.NoApplicationFile)
 		return
@@ -141,6 +150,45 @@ func (m *Space) SetNoApplicationFile(hasHtml, hasApp bool) {
 	}
 }
 
+// UpdateDockerOption updates the app port or the user of docker space, nil means unchanged.
+func (m *Space) UpdateDockerOption(port, user *int) (bool, error) {
+	if !m.SDK.IsDocker() {
+		return false, errors.New("docker option is only for the space of docker sdk")
+	}
+
+	p, u := 0, 0
+	if m.Docker != nil {
+		p, u = m.Docker.AppPort(), m.Docker.RunAsUser()
+	}
+
+	if port != nil {
+		p = *port
+	}
+
+	if user != nil {
+		u = *user
+	}
+
+	v, err := spaceprimitive.NewDockerOption(p, u)
+	if err != nil {
+		return false, err
+	}
+
+	if m.Docker != nil && v.AppPort() == m.Docker.AppPort() && v.RunAsUser() == m.Docker.RunAsUser() {
+		return false, nil
+	}
+
+	m.Docker = v
+
+	return true, nil
+}
+
+// ConsumeComputility returns true if the app of space occupies the computility quota,
+// the static space serves files without runtime and consumes nothing.
+func (m *Space) ConsumeComputility() bool {
+	return !m.SDK.IsStatic() && m.Hardware.IsAccelerator()
+}
+
 // SpaceLabels represents labels associated with a space.
 type SpaceLabels struct {
 	Task         spaceprimitive.Task // task label
@@ -186,6 +234,11 @@ type SpaceSecret struct {
 	Desc    primitive.MSDDesc
 	Value   spaceprimitive.ENVValue
 
+	// Version is the current version of the secret value, it increases on each rotation.
+	Version int
+	// AutoRestart restarts the space app automatically when the secret is rotated.
+	AutoRestart bool
+
 	CreatedAt int64
 	UpdatedAt int64
 }
@@ -204,40 +257,63 @@ func (secret *SpaceSecret) GetSecretPath() string {
 	return secretePath + secret.SpaceId.Identity()
 }
 
-// GetComputeType returns the compute type of the Space.
+// GetSecretVersionPath return vault path where all the versions of the secret are stored
+func (secret *SpaceSecret) GetSecretVersionPath() string {
+	return secretVersionPath + secret.SpaceId.Identity() + "/" + secret.Id.Identity()
+}
+
+// SecretVersionName return the key of the version in vault
+func SecretVersionName(version int) string {
+	return fmt.Sprintf("%s%d", secretVersionName, version)
+}
+
+// NewSpaceSecretVersionVault return a space env secret vault of the current version of space secret
+func NewSpaceSecretVersionVault(secret *SpaceSecret) securestorage.SpaceEnvSecret {
+	return securestorage.SpaceEnvSecret{
+		Path:  secret.GetSecretVersionPath(),
+		Name:  SecretVersionName(secret.Version),
+		Value: secret.Value.ENVValue(),
+	}
+}
+
+// Rotate sets a new value of the secret and increases the version.
+func (secret *SpaceSecret) Rotate(v spaceprimitive.ENVValue, now int64) {
+	secret.Value = v
+	secret.Version++
+	secret.UpdatedAt = now
+}
+
+// GetComputeType returns the compute type of the hardware flavor of the Space.
 func (s *Space) GetComputeType() primitive.ComputilityType {
-	if s.Hardware.IsNpu() {
-		return primitive.CreateComputilityType(computilityTypeNpu)
-	} else if s.Hardware.IsCpu() {
-		return primitive.CreateComputilityType(computilityTypeCpu)
+	if v := s.Hardware.ComputeType(); v != "" {
+		return primitive.CreateComputilityType(v)
 	}
 
 	return nil
 }
 
-// GetQuotaCount returns the quota count of the Space.
+// GetQuotaCount returns the quota count of the Space, it is the quota weight of the hardware flavor.
 func (s *Space) GetQuotaCount() int {
-	if s.Hardware.IsNpu() {
-		return 1
-	} else if s.Hardware.IsCpu() {
+	if s.SDK.IsStatic() || !s.Hardware.IsAccelerator() {
 		return 0
 	}
 
-	return 0
+	return s.Hardware.QuotaWeight()
 }
 
+// GetHardwareType returns the compute type of the hardware flavor which is used as the label of Space.
 func (s *Space) GetHardwareType() string {
-	if s.Hardware.IsNpu() {
-		return "npu"
-	} else if s.Hardware.IsCpu() {
-		return "cpu"
-	}
-	return ""
+	return s.Hardware.ComputeType()
 }
 
 // IsValidHardwareType checks if the provided hardware type string is a valid hardware type.
 func IsValidHardwareType(h string) bool {
-	return strings.ToLower(h) == computilityTypeNpu || strings.ToLower(h) == computilityTypeCpu
+	switch strings.ToLower(h) {
+	case computilityTypeNpu, computilityTypeCpu, computilityTypeGpu:
+		return true
+	}
+
+	return false
 }
 
 type CoverInfo struct {
@@ -0,0 +1,61 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+// Package dnsresolveradapter provides an adapter for looking up the dns records of custom domains.
+package dnsresolveradapter
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+const defaultTimeout = 5 * time.Second
+
+// Config is a struct that holds the configuration of the dns resolver.
+type Config struct {
+	// Server is the address(ip:port) of the name server, the system resolver is used if empty.
+	// It can point to a local dns server when testing.
+	Server  string `json:"server"`
+	Timeout int    `json:"timeout"`
+}
+
+// SetDefault sets the default values for the Config struct.
+func (cfg *Config) SetDefault() {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = int(defaultTimeout / time.Second)
+	}
+}
+
+// NewResolver creates a new dns resolver with the given configuration.
+func NewResolver(cfg *Config) *resolverImpl {
+	r := &net.Resolver{PreferGo: true}
+
+	if cfg.Server != "" {
+		server := cfg.Server
+		r.Dial = func(ctx context.Context, network, _ string) (net.Conn, error) {
+			d := net.Dialer{}
+
+			return d.DialContext(ctx, network, server)
+		}
+	}
+
+	return &resolverImpl{
+		cli:     r,
+		timeout: time.Duration(cfg.Timeout) * time.Second,
+	}
+}
+
+type resolverImpl struct {
+	cli     *net.Resolver
+	timeout time.Duration
+}
+
+// LookupTXT returns the TXT records of the host.
+func (impl *resolverImpl) LookupTXT(ctx context.Context, host string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, impl.timeout)
+	defer cancel()
+
+	return impl.cli.LookupTXT(ctx, host)
+}
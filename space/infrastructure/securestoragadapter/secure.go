@@ -51,6 +51,25 @@ func (v vaultAdapter) SaveSpaceEnvSecret(es securestorage.SpaceEnvSecret) error
 	return nil
 }
 
+// SaveSpaceEnvSecrets saves several keys of the path in one write.
+func (v vaultAdapter) SaveSpaceEnvSecrets(path string, values map[string]string) error {
+	storageValueList, err := v.client.KVv2(v.basePath).Get(context.Background(), path)
+	if err != nil && !errors.Is(err, api.ErrSecretNotFound) {
+		logrus.Errorf("get storage value failed: %v", err)
+		return err
+	}
+
+	storageData := map[string]interface{}{}
+	if storageValueList != nil && storageValueList.Data != nil {
+		storageData = storageValueList.Data
+	}
+	for key, value := range values {
+		storageData[key] = value
+	}
+
+	return v.setSpaceEnvSecret(securestorage.SpaceEnvSecret{Path: path}, storageData)
+}
+
 func (v vaultAdapter) setSpaceEnvSecret(es securestorage.SpaceEnvSecret, storageData map[string]interface{}) error {
 	_, err := v.client.KVv2(v.basePath).Put(context.Background(), es.Path, storageData)
 	if err != nil && !errors.Is(err, api.ErrSecretNotFound) {
@@ -94,3 +113,13 @@ func (v vaultAdapter) GetAllSpaceEnvSecret(es securestorage.SpaceEnvSecret) (str
 	}
 	return value, nil
 }
+
+// DeleteSpaceEnvSecretPath deletes the path with all the keys and their history.
+func (v vaultAdapter) DeleteSpaceEnvSecretPath(path string) error {
+	err := v.client.KVv2(v.basePath).DeleteMetadata(context.Background(), path)
+	if err != nil && !errors.Is(err, api.ErrSecretNotFound) {
+		logrus.Errorf("unable to delete storage path: %v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package spacerepositoryadapter
+
+import (
+	"errors"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	"github.com/openmerlin/merlin-server/space/domain"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+	"github.com/openmerlin/merlin-server/space/domain/repository"
+)
+
+type baseImageAdapter struct {
+	daoImpl
+}
+
+// AddBaseImage adds a new base image to the catalog.
+func (adapter *baseImageAdapter) AddBaseImage(e *domain.BaseImageEntry) error {
+	do := toBaseImageDO(e)
+
+	if err := adapter.db().Create(&do).Error; err != nil {
+		return err
+	}
+
+	e.Id = primitive.CreateIdentity(do.Id)
+
+	return nil
+}
+
+// FindBaseImageById finds a base image of the catalog by its ID.
+func (adapter *baseImageAdapter) FindBaseImageById(id primitive.Identity) (domain.BaseImageEntry, error) {
+	do := baseImageDO{Id: id.Integer()}
+
+	if err := adapter.GetByPrimaryKey(&do); err != nil {
+		return domain.BaseImageEntry{}, err
+	}
+
+	return do.toBaseImageEntry(), nil
+}
+
+// FindBaseImageByName finds a base image of the catalog by its name.
+func (adapter *baseImageAdapter) FindBaseImageByName(name spaceprimitive.BaseImage) (
+	domain.BaseImageEntry, error,
+) {
+	filter := baseImageDO{Name: name.BaseImage()}
+
+	result := baseImageDO{}
+	if err := adapter.GetRecord(&filter, &result); err != nil {
+		return domain.BaseImageEntry{}, err
+	}
+
+	return result.toBaseImageEntry(), nil
+}
+
+// SaveBaseImage updates a base image of the catalog.
+func (adapter *baseImageAdapter) SaveBaseImage(e *domain.BaseImageEntry) error {
+	do := toBaseImageDO(e)
+	do.Version += 1
+
+	v := adapter.db().Model(
+		&baseImageDO{Id: do.Id},
+	).Where(
+		equalQuery(fieldVersion), e.Version,
+	).Select(`*`).Updates(&do)
+
+	if v.Error != nil {
+		return v.Error
+	}
+
+	if v.RowsAffected == 0 {
+		return commonrepo.NewErrorConcurrentUpdating(
+			errors.New("concurrent updating"),
+		)
+	}
+
+	return nil
+}
+
+// DeleteBaseImage deletes a base image of the catalog by its ID.
+func (adapter *baseImageAdapter) DeleteBaseImage(id primitive.Identity) error {
+	return adapter.DeleteByPrimaryKey(
+		&baseImageDO{Id: id.Integer()},
+	)
+}
+
+// ListBaseImages lists the base images of the catalog.
+func (adapter *baseImageAdapter) ListBaseImages(opt *repository.BaseImageListOption) (
+	[]domain.BaseImageEntry, error,
+) {
+	db := adapter.db()
+
+	if opt.SDK != "" {
+		query, arg := intersectionFilter(fieldSDKs, []string{opt.SDK})
+		db = db.Where(query, arg)
+	}
+
+	if opt.Hardware != "" {
+		query, arg := intersectionFilter(fieldHardware, []string{opt.Hardware})
+		db = db.Where(query, arg)
+	}
+
+	if opt.ExcludeDeprecated {
+		db = db.Where(equalQuery(fieldDeprecated), false)
+	}
+
+	if opt.OnlyDeprecated {
+		db = db.Where(equalQuery(fieldDeprecated), true)
+	}
+
+	var dos []baseImageDO
+
+	if err := db.Order(fieldName).Find(&dos).Error; err != nil {
+		return nil, err
+	}
+
+	r := make([]domain.BaseImageEntry, len(dos))
+	for i := range dos {
+		r[i] = dos[i].toBaseImageEntry()
+	}
+
+	return r, nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package spacerepositoryadapter
+
+import (
+	"github.com/lib/pq"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/space/domain"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+)
+
+const (
+	fieldSDKs       = "sdks"
+	fieldDeprecated = "deprecated"
+	fieldCreatedBy  = "created_by"
+
+	// baseImageMigrator is the creator of the base images migrated from the configuration
+	baseImageMigrator = "config"
+)
+
+var (
+	baseImageTableName = ""
+)
+
+func toBaseImageDO(e *domain.BaseImageEntry) baseImageDO {
+	do := baseImageDO{
+		Name:         e.Name.BaseImage(),
+		SDKs:         e.SDKs,
+		Hardware:     e.Hardware,
+		Deprecated:   e.Deprecated,
+		DeprecatedAt: e.DeprecatedAt,
+		Replacement:  e.Replacement,
+		IsDefault:    e.IsDefault,
+		CreatedBy:    e.CreatedBy.Account(),
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
+		Version:      e.Version,
+	}
+
+	if e.Id != nil {
+		do.Id = e.Id.Integer()
+	}
+
+	return do
+}
+
+type baseImageDO struct {
+	Id           int64          `gorm:"primaryKey;autoIncrement"`
+	Name         string         `gorm:"column:name;uniqueIndex"`
+	SDKs         pq.StringArray `gorm:"column:sdks;type:text[];default:'{}'"`
+	Hardware     pq.StringArray `gorm:"column:hardware;type:text[];default:'{}'"`
+	Deprecated   bool           `gorm:"column:deprecated"`
+	DeprecatedAt int64          `gorm:"column:deprecated_at"`
+	Replacement  string         `gorm:"column:replacement"`
+	IsDefault    bool           `gorm:"column:is_default"`
+	CreatedBy    string         `gorm:"column:created_by"`
+	CreatedAt    int64          `gorm:"column:created_at"`
+	UpdatedAt    int64          `gorm:"column:updated_at"`
+	Version      int            `gorm:"column:version"`
+}
+
+// TableName returns the table name of baseImageDO.
+func (do *baseImageDO) TableName() string {
+	return baseImageTableName
+}
+
+func (do *baseImageDO) toBaseImageEntry() domain.BaseImageEntry {
+	return domain.BaseImageEntry{
+		Id:           primitive.CreateIdentity(do.Id),
+		Name:         spaceprimitive.CreateBaseImage(do.Name),
+		SDKs:         do.SDKs,
+		Hardware:     do.Hardware,
+		Deprecated:   do.Deprecated,
+		DeprecatedAt: do.DeprecatedAt,
+		Replacement:  do.Replacement,
+		IsDefault:    do.IsDefault,
+		CreatedBy:    primitive.CreateAccount(do.CreatedBy),
+		CreatedAt:    do.CreatedAt,
+		UpdatedAt:    do.UpdatedAt,
+		Version:      do.Version,
+	}
+}
@@ -7,7 +7,15 @@ package spacerepositoryadapter
 
 // Tables is a struct that represents a table with a space.
 type Tables struct {
-	Space          string `json:"space" required:"true"`
-	SpaceModel     string `json:"space_model" required:"true"`
-	SpaceEnvSecret string `json:"space_env_secret" required:"true"`
+	Space             string `json:"space" required:"true"`
+	SpaceModel        string `json:"space_model" required:"true"`
+	SpaceEnvSecret    string `json:"space_env_secret" required:"true"`
+	SpaceCustomDomain string `json:"space_custom_domain" required:"true"`
+
+	SpaceSecretVersion string `json:"space_secret_version" required:"true"`
+
+	OrgEnv            string `json:"org_env" required:"true"`
+	SpaceEnvReference string `json:"space_env_reference" required:"true"`
+
+	BaseImage string `json:"base_image" required:"true"`
 }
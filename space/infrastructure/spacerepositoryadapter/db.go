@@ -4,7 +4,14 @@ Copyright (c) Huawei Technologies Co., Ltd. 2023. All rights reserved
 
 package spacerepositoryadapter
 
-import "gorm.io/gorm"
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+	"github.com/openmerlin/merlin-server/utils"
+)
 
 var (
 	spaceAdapterInstance         *spaceAdapter
@@ -12,6 +19,10 @@ var (
 	spaceModelInstance           *modelSpaceRelationAdapter
 	spaceVariableAdapterInstance *spaceVariableAdapter
 	spaceSecretAdapterInstance   *spaceSecretAdapter
+
+	spaceCustomDomainAdapterInstance *spaceCustomDomainAdapter
+	orgEnvAdapterInstance            *orgEnvAdapter
+	baseImageAdapterInstance         *baseImageAdapter
 )
 
 // Init initializes the database and sets up the necessary adapters.
@@ -20,6 +31,11 @@ func Init(db *gorm.DB, tables *Tables) error {
 	spaceTableName = tables.Space
 	spaceModelRelationTableName = tables.SpaceModel
 	spaceEnvSecretTableName = tables.SpaceEnvSecret
+	spaceCustomDomainTableName = tables.SpaceCustomDomain
+	spaceSecretVersionTableName = tables.SpaceSecretVersion
+	orgEnvTableName = tables.OrgEnv
+	spaceEnvReferenceTableName = tables.SpaceEnvReference
+	baseImageTableName = tables.BaseImage
 
 	if err := db.AutoMigrate(&spaceDO{}); err != nil {
 		return err
@@ -33,17 +49,63 @@ func Init(db *gorm.DB, tables *Tables) error {
 		return err
 	}
 
+	if err := migrateCustomDomainIndex(db); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&spaceCustomDomainDO{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&spaceSecretVersionDO{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&orgEnvDO{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&spaceEnvReferenceDO{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&baseImageDO{}); err != nil {
+		return err
+	}
+
+	if err := migrateHardwareToCatalog(db, tables); err != nil {
+		return err
+	}
+
+	if err := migrateSecretAutoRestart(db, tables); err != nil {
+		return err
+	}
+
+	if err := migrateBaseImagesToCatalog(db, tables); err != nil {
+		return err
+	}
+
 	dbInstance = db
 
 	spaceDao := daoImpl{table: tables.Space}
 	spaceModelDao := daoImpl{table: tables.SpaceModel}
 	spaceEnvSecretDao := daoImpl{table: tables.SpaceEnvSecret}
+	spaceCustomDomainDao := daoImpl{table: tables.SpaceCustomDomain}
 
 	spaceAdapterInstance = &spaceAdapter{daoImpl: spaceDao}
 	spaceLabelsAdapterInstance = &spaceLabelsAdapter{daoImpl: spaceDao}
 	spaceModelInstance = &modelSpaceRelationAdapter{daoImpl: spaceModelDao}
 	spaceVariableAdapterInstance = &spaceVariableAdapter{daoImpl: spaceEnvSecretDao}
-	spaceSecretAdapterInstance = &spaceSecretAdapter{daoImpl: spaceEnvSecretDao}
+	spaceSecretAdapterInstance = &spaceSecretAdapter{
+		daoImpl:    spaceEnvSecretDao,
+		versionDao: daoImpl{table: tables.SpaceSecretVersion},
+	}
+	spaceCustomDomainAdapterInstance = &spaceCustomDomainAdapter{daoImpl: spaceCustomDomainDao}
+	orgEnvAdapterInstance = &orgEnvAdapter{
+		daoImpl:      daoImpl{table: tables.OrgEnv},
+		referenceDao: daoImpl{table: tables.SpaceEnvReference},
+	}
+	baseImageAdapterInstance = &baseImageAdapter{daoImpl: daoImpl{table: tables.BaseImage}}
 
 	return nil
 }
@@ -72,3 +134,111 @@ func SpaceVariableAdapter() *spaceVariableAdapter {
 func SpaceSecretAdapter() *spaceSecretAdapter {
 	return spaceSecretAdapterInstance
 }
+
+// SpaceCustomDomainAdapter returns the instance of the space custom domain adapter.
+func SpaceCustomDomainAdapter() *spaceCustomDomainAdapter {
+	return spaceCustomDomainAdapterInstance
+}
+
+// OrgEnvAdapter returns the instance of the org env adapter.
+func OrgEnvAdapter() *orgEnvAdapter {
+	return orgEnvAdapterInstance
+}
+
+// BaseImageAdapter returns the instance of the base image catalog adapter.
+func BaseImageAdapter() *baseImageAdapter {
+	return baseImageAdapterInstance
+}
+
+// migrateSecretAutoRestart keeps restarting the space app on rotation for the secrets created
+// before it was optional, it does nothing once all are migrated.
+// The column has no default so that the false value of new secrets is not replaced by gorm.
+func migrateSecretAutoRestart(db *gorm.DB, tables *Tables) error {
+	return db.Table(tables.SpaceEnvSecret).
+		Where(equalQuery(fieldType), secretTypeName).
+		Where(fieldAutoRestart+" is null").
+		Update(fieldAutoRestart, true).Error
+}
+
+// migrateHardwareToCatalog renames the legacy hardware of spaces and base images
+// to the flavors in the hardware catalog, it does nothing once all are migrated.
+func migrateHardwareToCatalog(db *gorm.DB, tables *Tables) error {
+	for alias, name := range spaceprimitive.HardwareAliases() {
+		f, _ := spaceprimitive.LookupHardwareFlavor(name)
+
+		err := db.Table(tables.Space).Where("lower("+fieldHardware+") = ?", alias).Updates(map[string]interface{}{
+			fieldHardware:     name,
+			fieldHardwareType: f.ComputeType,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		err = db.Table(tables.BaseImage).Where("? = ANY("+fieldHardware+")", alias).Update(
+			fieldHardware, gorm.Expr("array_replace("+fieldHardware+", ?, ?)", alias, name),
+		).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateCustomDomainIndex drops the unique index of domain which also covered the pending claims,
+// it is replaced by the one covering the verified domains only.
+func migrateCustomDomainIndex(db *gorm.DB) error {
+	m := db.Migrator()
+
+	if !m.HasIndex(&spaceCustomDomainDO{}, legacyCustomDomainIndex) {
+		return nil
+	}
+
+	return m.DropIndex(&spaceCustomDomainDO{}, legacyCustomDomainIndex)
+}
+
+// migrateBaseImagesToCatalog adds the base images in the configuration to the catalog once,
+// the ones in the catalog are not changed and the ones deleted by the administrator are not
+// added again because it does nothing once any base image has been migrated.
+func migrateBaseImagesToCatalog(db *gorm.DB, tables *Tables) error {
+	var count int64
+
+	err := db.Table(tables.BaseImage).Where(equalQuery(fieldCreatedBy), baseImageMigrator).Count(&count).Error
+	if err != nil || count > 0 {
+		return err
+	}
+
+	images := spaceprimitive.ConfiguredBaseImages()
+	if len(images) == 0 {
+		return nil
+	}
+
+	now := utils.Now()
+	dos := make([]baseImageDO, 0, len(images))
+
+	for name, hardware := range images {
+		sdks := sets.New[string]()
+		for _, h := range hardware {
+			sdks.Insert(spaceprimitive.SDKsOfHardware(h)...)
+		}
+
+		if sdks.Len() == 0 {
+			continue
+		}
+
+		dos = append(dos, baseImageDO{
+			Name:      name,
+			SDKs:      sets.List(sdks),
+			Hardware:  hardware,
+			CreatedBy: baseImageMigrator,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+
+	if len(dos) == 0 {
+		return nil
+	}
+
+	return db.Table(tables.BaseImage).Clauses(clause.OnConflict{DoNothing: true}).Create(&dos).Error
+}
@@ -0,0 +1,189 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package spacerepositoryadapter
+
+import (
+	"errors"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	"github.com/openmerlin/merlin-server/space/domain"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+)
+
+type orgEnvAdapter struct {
+	daoImpl
+
+	referenceDao daoImpl
+}
+
+// AddOrgEnv adds a new org env to the database.
+func (adapter *orgEnvAdapter) AddOrgEnv(e *domain.OrgEnv) error {
+	do := toOrgEnvDO(e)
+
+	if err := adapter.db().Create(&do).Error; err != nil {
+		return err
+	}
+
+	e.Id = primitive.CreateIdentity(do.Id)
+
+	return nil
+}
+
+// FindOrgEnvById finds an org env by its ID.
+func (adapter *orgEnvAdapter) FindOrgEnvById(id primitive.Identity) (domain.OrgEnv, error) {
+	do := orgEnvDO{Id: id.Integer()}
+
+	if err := adapter.GetByPrimaryKey(&do); err != nil {
+		return domain.OrgEnv{}, err
+	}
+
+	return do.toOrgEnv(), nil
+}
+
+// FindOrgEnvByName finds an org env by the owner, type and name.
+func (adapter *orgEnvAdapter) FindOrgEnvByName(owner primitive.Account, t string, name spaceprimitive.ENVName) (
+	domain.OrgEnv, error,
+) {
+	filter := orgEnvDO{Owner: owner.Account(), Type: t, Name: name.ENVName()}
+
+	result := orgEnvDO{}
+	if err := adapter.GetRecord(&filter, &result); err != nil {
+		return domain.OrgEnv{}, err
+	}
+
+	return result.toOrgEnv(), nil
+}
+
+// SaveOrgEnv updates an org env in the database.
+func (adapter *orgEnvAdapter) SaveOrgEnv(e *domain.OrgEnv) error {
+	do := toOrgEnvDO(e)
+	do.Version += 1
+
+	v := adapter.db().Model(
+		&orgEnvDO{Id: do.Id},
+	).Where(
+		equalQuery(fieldVersion), e.Version,
+	).Select(`*`).Updates(&do)
+
+	if v.Error != nil {
+		return v.Error
+	}
+
+	if v.RowsAffected == 0 {
+		return commonrepo.NewErrorConcurrentUpdating(
+			errors.New("concurrent updating"),
+		)
+	}
+
+	return nil
+}
+
+// DeleteOrgEnv deletes an org env by its ID.
+func (adapter *orgEnvAdapter) DeleteOrgEnv(id primitive.Identity) error {
+	return adapter.DeleteByPrimaryKey(
+		&orgEnvDO{Id: id.Integer()},
+	)
+}
+
+// ListOrgEnv lists all the org envs of the organization.
+func (adapter *orgEnvAdapter) ListOrgEnv(owner primitive.Account) ([]domain.OrgEnv, error) {
+	var dos []orgEnvDO
+
+	err := adapter.db().Where(
+		equalQuery(fieldOwner), owner.Account(),
+	).Order(fieldName).Find(&dos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]domain.OrgEnv, len(dos))
+	for i := range dos {
+		r[i] = dos[i].toOrgEnv()
+	}
+
+	return r, nil
+}
+
+// AddReference adds a reference from the space to an org env.
+func (adapter *orgEnvAdapter) AddReference(r *domain.SpaceEnvReference) error {
+	do := toSpaceEnvReferenceDO(r)
+
+	if err := adapter.referenceDao.db().Create(&do).Error; err != nil {
+		return err
+	}
+
+	r.Id = primitive.CreateIdentity(do.Id)
+
+	return nil
+}
+
+// FindReferenceById finds a reference by its ID.
+func (adapter *orgEnvAdapter) FindReferenceById(id primitive.Identity) (domain.SpaceEnvReference, error) {
+	do := spaceEnvReferenceDO{Id: id.Integer()}
+
+	if err := adapter.referenceDao.GetByPrimaryKey(&do); err != nil {
+		return domain.SpaceEnvReference{}, err
+	}
+
+	return do.toSpaceEnvReference(), nil
+}
+
+// DeleteReference deletes a reference by its ID.
+func (adapter *orgEnvAdapter) DeleteReference(id primitive.Identity) error {
+	return adapter.referenceDao.DeleteByPrimaryKey(
+		&spaceEnvReferenceDO{Id: id.Integer()},
+	)
+}
+
+// DeleteReferencesBySpaceId deletes all the references of the space.
+func (adapter *orgEnvAdapter) DeleteReferencesBySpaceId(spaceId primitive.Identity) error {
+	return adapter.referenceDao.db().Where(
+		equalQuery(filedSpaceId), spaceId.Integer(),
+	).Delete(&spaceEnvReferenceDO{}).Error
+}
+
+// ListReferencesBySpaceId lists all the references of the space.
+func (adapter *orgEnvAdapter) ListReferencesBySpaceId(spaceId primitive.Identity) (
+	[]domain.SpaceEnvReference, error,
+) {
+	var dos []spaceEnvReferenceDO
+
+	err := adapter.referenceDao.db().Where(
+		equalQuery(filedSpaceId), spaceId.Integer(),
+	).Order(fieldName).Find(&dos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toSpaceEnvReferences(dos), nil
+}
+
+// ListReferencesByOrgEnv lists all the references to the org env.
+func (adapter *orgEnvAdapter) ListReferencesByOrgEnv(e *domain.OrgEnv) ([]domain.SpaceEnvReference, error) {
+	var dos []spaceEnvReferenceDO
+
+	err := adapter.referenceDao.db().Where(
+		equalQuery(fieldOwner), e.Owner.Account(),
+	).Where(
+		equalQuery(fieldType), e.Type,
+	).Where(
+		equalQuery(fieldName), e.Name.ENVName(),
+	).Order(filedSpaceId).Find(&dos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toSpaceEnvReferences(dos), nil
+}
+
+func toSpaceEnvReferences(dos []spaceEnvReferenceDO) []domain.SpaceEnvReference {
+	r := make([]domain.SpaceEnvReference, len(dos))
+	for i := range dos {
+		r[i] = dos[i].toSpaceEnvReference()
+	}
+
+	return r
+}
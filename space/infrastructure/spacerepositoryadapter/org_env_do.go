@@ -0,0 +1,114 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package spacerepositoryadapter
+
+import (
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/space/domain"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+)
+
+var (
+	orgEnvTableName            = ""
+	spaceEnvReferenceTableName = ""
+)
+
+func toOrgEnvDO(e *domain.OrgEnv) orgEnvDO {
+	do := orgEnvDO{
+		Owner:     e.Owner.Account(),
+		Type:      e.Type,
+		Name:      e.Name.ENVName(),
+		CreatedBy: e.CreatedBy.Account(),
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+		Version:   e.Version,
+	}
+
+	if e.Id != nil {
+		do.Id = e.Id.Integer()
+	}
+
+	if e.Desc != nil {
+		do.Desc = e.Desc.MSDDesc()
+	}
+
+	// the value of secret is kept in vault only
+	if !e.IsSecret() && e.Value != nil {
+		do.Value = e.Value.ENVValue()
+	}
+
+	return do
+}
+
+type orgEnvDO struct {
+	Id        int64  `gorm:"primaryKey;autoIncrement"`
+	Owner     string `gorm:"column:owner;index:org_env_index,unique,priority:1"`
+	Type      string `gorm:"column:type;index:org_env_index,unique,priority:2"`
+	Name      string `gorm:"column:name;index:org_env_index,unique,priority:3"`
+	Desc      string `gorm:"column:desc"`
+	Value     string `gorm:"column:value"`
+	CreatedBy string `gorm:"column:created_by"`
+	CreatedAt int64  `gorm:"column:created_at"`
+	UpdatedAt int64  `gorm:"column:updated_at"`
+	Version   int    `gorm:"column:version"`
+}
+
+// TableName returns the table name of orgEnvDO.
+func (do *orgEnvDO) TableName() string {
+	return orgEnvTableName
+}
+
+func (do *orgEnvDO) toOrgEnv() domain.OrgEnv {
+	return domain.OrgEnv{
+		Id:        primitive.CreateIdentity(do.Id),
+		Owner:     primitive.CreateAccount(do.Owner),
+		Type:      do.Type,
+		Name:      spaceprimitive.CreateENVName(do.Name),
+		Desc:      primitive.CreateMSDDesc(do.Desc),
+		Value:     spaceprimitive.CreateENVValue(do.Value),
+		CreatedBy: primitive.CreateAccount(do.CreatedBy),
+		CreatedAt: do.CreatedAt,
+		UpdatedAt: do.UpdatedAt,
+		Version:   do.Version,
+	}
+}
+
+func toSpaceEnvReferenceDO(r *domain.SpaceEnvReference) spaceEnvReferenceDO {
+	return spaceEnvReferenceDO{
+		SpaceId:   r.SpaceId.Integer(),
+		Owner:     r.Owner.Account(),
+		Type:      r.Type,
+		Name:      r.Name.ENVName(),
+		CreatedBy: r.CreatedBy.Account(),
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+type spaceEnvReferenceDO struct {
+	Id        int64  `gorm:"primaryKey;autoIncrement"`
+	SpaceId   int64  `gorm:"column:space_id;index:space_env_reference_index,unique,priority:1"`
+	Name      string `gorm:"column:name;index:space_env_reference_index,unique,priority:2"`
+	Owner     string `gorm:"column:owner;index:space_env_reference_org_index,priority:1"`
+	Type      string `gorm:"column:type;index:space_env_reference_org_index,priority:2"`
+	CreatedBy string `gorm:"column:created_by"`
+	CreatedAt int64  `gorm:"column:created_at"`
+}
+
+// TableName returns the table name of spaceEnvReferenceDO.
+func (do *spaceEnvReferenceDO) TableName() string {
+	return spaceEnvReferenceTableName
+}
+
+func (do *spaceEnvReferenceDO) toSpaceEnvReference() domain.SpaceEnvReference {
+	return domain.SpaceEnvReference{
+		Id:        primitive.CreateIdentity(do.Id),
+		SpaceId:   primitive.CreateIdentity(do.SpaceId),
+		Owner:     primitive.CreateAccount(do.Owner),
+		Type:      do.Type,
+		Name:      spaceprimitive.CreateENVName(do.Name),
+		CreatedBy: primitive.CreateAccount(do.CreatedBy),
+		CreatedAt: do.CreatedAt,
+	}
+}
@@ -239,6 +239,10 @@ func (adapter *spaceAdapter) toQuery(opt *repository.ListOption) *gorm.DB {
 		db = db.Where(db.Where(query1, arg1))
 	}
 
+	if len(opt.BaseImages) > 0 {
+		db = db.Where(fieldBaseImage+" IN ?", opt.BaseImages)
+	}
+
 	if opt.HasAppFile {
 		db = db.Where(notEqualQuery(fieldNoApplicationFile), opt.HasAppFile)
 	}
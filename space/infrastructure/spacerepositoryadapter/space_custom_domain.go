@@ -0,0 +1,147 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package spacerepositoryadapter
+
+import (
+	"errors"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	"github.com/openmerlin/merlin-server/space/domain"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+)
+
+type spaceCustomDomainAdapter struct {
+	daoImpl
+}
+
+// AddCustomDomain adds a new space custom domain to the database and sets the id of it.
+func (adapter *spaceCustomDomainAdapter) AddCustomDomain(d *domain.SpaceCustomDomain) error {
+	do := toSpaceCustomDomainDO(d)
+
+	if err := adapter.db().Create(&do).Error; err != nil {
+		return err
+	}
+
+	d.Id = primitive.CreateIdentity(do.Id)
+
+	return nil
+}
+
+// FindCustomDomainById finds a space custom domain by its ID.
+func (adapter *spaceCustomDomainAdapter) FindCustomDomainById(id primitive.Identity) (
+	domain.SpaceCustomDomain, error,
+) {
+	do := spaceCustomDomainDO{Id: id.Integer()}
+
+	if err := adapter.GetByPrimaryKey(&do); err != nil {
+		return domain.SpaceCustomDomain{}, err
+	}
+
+	return do.toSpaceCustomDomain(), nil
+}
+
+// FindVerifiedCustomDomain finds the verified space custom domain by the domain name,
+// the domain can be claimed by many spaces but verified by one only.
+func (adapter *spaceCustomDomainAdapter) FindVerifiedCustomDomain(d spaceprimitive.CustomDomain) (
+	domain.SpaceCustomDomain, error,
+) {
+	do := spaceCustomDomainDO{
+		Domain: d.CustomDomain(),
+		Status: spaceprimitive.DomainStatusVerified.DomainStatus(),
+	}
+
+	if err := adapter.GetRecord(&do, &do); err != nil {
+		return domain.SpaceCustomDomain{}, err
+	}
+
+	return do.toSpaceCustomDomain(), nil
+}
+
+// SaveCustomDomain updates a space custom domain in the database.
+func (adapter *spaceCustomDomainAdapter) SaveCustomDomain(d *domain.SpaceCustomDomain) error {
+	do := toSpaceCustomDomainDO(d)
+	do.Version += 1
+
+	v := adapter.db().Model(
+		&spaceCustomDomainDO{Id: do.Id},
+	).Where(
+		equalQuery(fieldVersion), d.Version,
+	).Select(`*`).Updates(&do)
+
+	if v.Error != nil {
+		return v.Error
+	}
+
+	if v.RowsAffected == 0 {
+		return commonrepo.NewErrorConcurrentUpdating(
+			errors.New("concurrent updating"),
+		)
+	}
+
+	return nil
+}
+
+// DeleteCustomDomain deletes a space custom domain by its ID.
+func (adapter *spaceCustomDomainAdapter) DeleteCustomDomain(id primitive.Identity) error {
+	return adapter.DeleteByPrimaryKey(
+		&spaceCustomDomainDO{Id: id.Integer()},
+	)
+}
+
+// DeletePendingCustomDomain deletes all the pending claims of the domain.
+func (adapter *spaceCustomDomainAdapter) DeletePendingCustomDomain(d spaceprimitive.CustomDomain) error {
+	return adapter.db().Where(
+		equalQuery(fieldDomain), d.CustomDomain(),
+	).Where(
+		notEqualQuery(fieldDomainStatus), spaceprimitive.DomainStatusVerified.DomainStatus(),
+	).Delete(&spaceCustomDomainDO{}).Error
+}
+
+// DeleteCustomDomainBySpaceId deletes all the custom domains of the space.
+func (adapter *spaceCustomDomainAdapter) DeleteCustomDomainBySpaceId(spaceId primitive.Identity) error {
+	return adapter.db().Where(
+		equalQuery(filedSpaceId), spaceId.Integer(),
+	).Delete(&spaceCustomDomainDO{}).Error
+}
+
+// ListCustomDomainBySpaceId lists all the custom domains of the space.
+func (adapter *spaceCustomDomainAdapter) ListCustomDomainBySpaceId(spaceId primitive.Identity) (
+	[]domain.SpaceCustomDomain, error,
+) {
+	var dos []spaceCustomDomainDO
+
+	err := adapter.db().Where(
+		equalQuery(filedSpaceId), spaceId.Integer(),
+	).Order(fieldCreatedAt).Find(&dos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toSpaceCustomDomains(dos), nil
+}
+
+// ListVerifiedCustomDomain lists all the verified custom domains.
+func (adapter *spaceCustomDomainAdapter) ListVerifiedCustomDomain() ([]domain.SpaceCustomDomain, error) {
+	var dos []spaceCustomDomainDO
+
+	err := adapter.db().Where(
+		equalQuery(fieldDomainStatus), spaceprimitive.DomainStatusVerified.DomainStatus(),
+	).Order(fieldDomain).Find(&dos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toSpaceCustomDomains(dos), nil
+}
+
+func toSpaceCustomDomains(dos []spaceCustomDomainDO) []domain.SpaceCustomDomain {
+	r := make([]domain.SpaceCustomDomain, len(dos))
+	for i := range dos {
+		r[i] = dos[i].toSpaceCustomDomain()
+	}
+
+	return r
+}
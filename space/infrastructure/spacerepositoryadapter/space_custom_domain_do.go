@@ -0,0 +1,75 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package spacerepositoryadapter
+
+import (
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/space/domain"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+)
+
+var (
+	spaceCustomDomainTableName = ""
+)
+
+const (
+	fieldDomain       = "domain"
+	fieldDomainStatus = "status"
+
+	legacyCustomDomainIndex = "space_custom_domain_index"
+)
+
+func toSpaceCustomDomainDO(d *domain.SpaceCustomDomain) spaceCustomDomainDO {
+	do := spaceCustomDomainDO{
+		SpaceId:    d.SpaceId.Integer(),
+		Domain:     d.Domain.CustomDomain(),
+		Token:      d.Token,
+		Status:     d.Status.DomainStatus(),
+		CreatedBy:  d.CreatedBy.Account(),
+		CreatedAt:  d.CreatedAt,
+		UpdatedAt:  d.UpdatedAt,
+		VerifiedAt: d.VerifiedAt,
+		Version:    d.Version,
+	}
+
+	if d.Id != nil {
+		do.Id = d.Id.Integer()
+	}
+
+	return do
+}
+
+type spaceCustomDomainDO struct {
+	Id         int64  `gorm:"primaryKey;autoIncrement"`
+	SpaceId    int64  `gorm:"column:space_id;index:space_custom_domain_space_index"`
+	Domain     string `gorm:"column:domain;uniqueIndex:space_custom_domain_verified_index,where:status = 'verified'"`
+	Token      string `gorm:"column:token"`
+	Status     string `gorm:"column:status"`
+	CreatedBy  string `gorm:"column:created_by"`
+	CreatedAt  int64  `gorm:"column:created_at"`
+	UpdatedAt  int64  `gorm:"column:updated_at"`
+	VerifiedAt int64  `gorm:"column:verified_at"`
+	Version    int    `gorm:"column:version"`
+}
+
+// TableName returns the table name of spaceCustomDomainDO.
+func (do *spaceCustomDomainDO) TableName() string {
+	return spaceCustomDomainTableName
+}
+
+func (do *spaceCustomDomainDO) toSpaceCustomDomain() domain.SpaceCustomDomain {
+	return domain.SpaceCustomDomain{
+		Id:         primitive.CreateIdentity(do.Id),
+		SpaceId:    primitive.CreateIdentity(do.SpaceId),
+		Domain:     spaceprimitive.CreateCustomDomain(do.Domain),
+		Token:      do.Token,
+		Status:     spaceprimitive.CreateDomainStatus(do.Status),
+		CreatedBy:  primitive.CreateAccount(do.CreatedBy),
+		CreatedAt:  do.CreatedAt,
+		UpdatedAt:  do.UpdatedAt,
+		VerifiedAt: do.VerifiedAt,
+		Version:    do.Version,
+	}
+}
@@ -69,6 +69,7 @@ func toSpaceDO(m *domain.Space) spaceDO {
 		NoApplicationFile:    m.NoApplicationFile,
 		CommitId:             m.CommitId,
 		IsDiscussionDisabled: m.IsDiscussionDisabled,
+		Priority:             m.Priority,
 	}
 
 	if m.DisableReason != nil {
@@ -87,6 +88,11 @@ func toSpaceDO(m *domain.Space) spaceDO {
 		do.License = m.Labels.Licenses.License()
 	}
 
+	if m.Docker != nil {
+		do.AppPort = m.Docker.AppPort()
+		do.RunAsUser = m.Docker.RunAsUser()
+	}
+
 	return do
 }
 
@@ -147,6 +153,13 @@ type spaceDO struct {
 	CommitId string `gorm:"column:commit_id"`
 
 	IsDiscussionDisabled bool `gorm:"column:is_discussion_disabled"`
+
+	// priority of preempting the computility quota
+	Priority int `gorm:"column:priority;not null;default:0"`
+
+	// docker option
+	AppPort   int `gorm:"column:app_port"`
+	RunAsUser int `gorm:"column:run_as_user"`
 }
 
 // TableName returns the table name of spaceDO.
@@ -155,7 +168,7 @@ func (do *spaceDO) TableName() string {
 }
 
 func (do *spaceDO) toSpace() domain.Space {
-	return domain.Space{
+	space := domain.Space{
 		CodeRepo: coderepo.CodeRepo{
 			Id:         primitive.CreateIdentity(do.Id),
 			Name:       primitive.CreateMSDName(do.Name),
@@ -191,7 +204,14 @@ func (do *spaceDO) toSpace() domain.Space {
 		NoApplicationFile:    do.NoApplicationFile,
 		CommitId:             do.CommitId,
 		IsDiscussionDisabled: do.IsDiscussionDisabled,
+		Priority:             do.Priority,
 	}
+
+	if space.SDK.IsDocker() {
+		space.Docker = spaceprimitive.CreateDockerOption(do.AppPort, do.RunAsUser)
+	}
+
+	return space
 }
 
 func (do *spaceDO) toSpaceSummary() repository.SpaceSummary {
@@ -19,8 +19,9 @@ const (
 	variableTypeName = "variable"
 	secretTypeName   = "secret"
 
-	fieldType    = "type"
-	filedSpaceId = "space_id"
+	fieldType        = "type"
+	fieldAutoRestart = "auto_restart"
+	filedSpaceId     = "space_id"
 )
 
 func toSpaceVariableDO(m *domain.SpaceVariable) spaceEnvSecretDO {
@@ -44,11 +45,13 @@ func toSpaceSecretDO(m *domain.SpaceSecret) spaceEnvSecretDO {
 		resDesc = m.Desc.MSDDesc()
 	}
 	return spaceEnvSecretDO{
-		SpaceId:   m.SpaceId.Integer(),
-		Desc:      resDesc,
-		Name:      m.Name.ENVName(),
-		Type:      secretTypeName,
-		UpdatedAt: m.UpdatedAt,
+		SpaceId:       m.SpaceId.Integer(),
+		Desc:          resDesc,
+		Name:          m.Name.ENVName(),
+		Type:          secretTypeName,
+		SecretVersion: m.Version,
+		AutoRestart:   m.AutoRestart,
+		UpdatedAt:     m.UpdatedAt,
 	}
 }
 
@@ -60,6 +63,9 @@ type spaceEnvSecretDO struct {
 	Value     string `gorm:"column:value"`
 	Type      string `gorm:"column:type"`
 	UpdatedAt int64  `gorm:"column:updated_at"`
+
+	SecretVersion int  `gorm:"column:secret_version;default:0"`
+	AutoRestart   bool `gorm:"column:auto_restart"`
 }
 
 // TableName returns the table name of spaceDO.
@@ -80,12 +86,14 @@ func (do *spaceEnvSecretDO) toSpaceVariable() domain.SpaceVariable {
 
 func (do *spaceEnvSecretDO) toSpaceSecret() domain.SpaceSecret {
 	return domain.SpaceSecret{
-		Id:        primitive.CreateIdentity(do.Id),
-		SpaceId:   primitive.CreateIdentity(do.SpaceId),
-		Name:      spaceprimitive.CreateENVName(do.Name),
-		Desc:      primitive.CreateMSDDesc(do.Desc),
-		Value:     spaceprimitive.CreateENVValue(do.Value),
-		UpdatedAt: do.UpdatedAt,
+		Id:          primitive.CreateIdentity(do.Id),
+		SpaceId:     primitive.CreateIdentity(do.SpaceId),
+		Name:        spaceprimitive.CreateENVName(do.Name),
+		Desc:        primitive.CreateMSDDesc(do.Desc),
+		Value:       spaceprimitive.CreateENVValue(do.Value),
+		Version:     do.SecretVersion,
+		AutoRestart: do.AutoRestart,
+		UpdatedAt:   do.UpdatedAt,
 	}
 }
 
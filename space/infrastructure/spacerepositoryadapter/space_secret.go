@@ -14,15 +14,21 @@ import (
 
 type spaceSecretAdapter struct {
 	daoImpl
+
+	versionDao daoImpl
 }
 
 // Add adds a new space secret to the database and returns an error if any occurs.
 func (adapter *spaceSecretAdapter) AddSecret(secret *domain.SpaceSecret) error {
 	do := toSpaceSecretDO(secret)
 
-	v := adapter.db().Create(&do)
+	if err := adapter.db().Create(&do).Error; err != nil {
+		return err
+	}
+
+	secret.Id = primitive.CreateIdentity(do.Id)
 
-	return v.Error
+	return nil
 }
 
 // FindById finds a space secret by its ID and returns it along with an error if any occurs.
@@ -0,0 +1,58 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package spacerepositoryadapter
+
+import (
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/space/domain"
+)
+
+// AddSecretVersion adds a version record of the space secret.
+func (adapter *spaceSecretAdapter) AddSecretVersion(v *domain.SpaceSecretVersion) error {
+	do := toSpaceSecretVersionDO(v)
+
+	return adapter.versionDao.db().Create(&do).Error
+}
+
+// FindSecretVersion finds the version record of the space secret.
+func (adapter *spaceSecretAdapter) FindSecretVersion(secretId primitive.Identity, version int) (
+	domain.SpaceSecretVersion, error,
+) {
+	do := spaceSecretVersionDO{SecretId: secretId.Integer(), Version: version}
+
+	if err := adapter.versionDao.GetRecord(&do, &do); err != nil {
+		return domain.SpaceSecretVersion{}, err
+	}
+
+	return do.toSpaceSecretVersion(), nil
+}
+
+// ListSecretVersions lists all the version records of the space secret, the latest is the first.
+func (adapter *spaceSecretAdapter) ListSecretVersions(secretId primitive.Identity) (
+	[]domain.SpaceSecretVersion, error,
+) {
+	var dos []spaceSecretVersionDO
+
+	err := adapter.versionDao.db().Where(
+		equalQuery(fieldSecretId), secretId.Integer(),
+	).Order(orderByDesc(fieldSecretVersion)).Find(&dos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]domain.SpaceSecretVersion, len(dos))
+	for i := range dos {
+		r[i] = dos[i].toSpaceSecretVersion()
+	}
+
+	return r, nil
+}
+
+// DeleteSecretVersions deletes all the version records of the space secret.
+func (adapter *spaceSecretAdapter) DeleteSecretVersions(secretId primitive.Identity) error {
+	return adapter.versionDao.db().Where(
+		equalQuery(fieldSecretId), secretId.Integer(),
+	).Delete(&spaceSecretVersionDO{}).Error
+}
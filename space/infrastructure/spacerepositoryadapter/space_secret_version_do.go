@@ -0,0 +1,60 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package spacerepositoryadapter
+
+import (
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/space/domain"
+)
+
+var (
+	spaceSecretVersionTableName = ""
+)
+
+const (
+	fieldSecretId      = "secret_id"
+	fieldSecretVersion = "version"
+)
+
+func toSpaceSecretVersionDO(v *domain.SpaceSecretVersion) spaceSecretVersionDO {
+	return spaceSecretVersionDO{
+		SecretId:     v.SecretId.Integer(),
+		SpaceId:      v.SpaceId.Integer(),
+		Version:      v.Version,
+		Action:       v.Action,
+		RollbackFrom: v.RollbackFrom,
+		CreatedBy:    v.CreatedBy.Account(),
+		CreatedAt:    v.CreatedAt,
+	}
+}
+
+type spaceSecretVersionDO struct {
+	Id           int64  `gorm:"primaryKey;autoIncrement"`
+	SecretId     int64  `gorm:"column:secret_id;index:space_secret_version_index,unique,priority:1"`
+	Version      int    `gorm:"column:version;index:space_secret_version_index,unique,priority:2"`
+	SpaceId      int64  `gorm:"column:space_id"`
+	Action       string `gorm:"column:action"`
+	RollbackFrom int    `gorm:"column:rollback_from"`
+	CreatedBy    string `gorm:"column:created_by"`
+	CreatedAt    int64  `gorm:"column:created_at"`
+}
+
+// TableName returns the table name of spaceSecretVersionDO.
+func (do *spaceSecretVersionDO) TableName() string {
+	return spaceSecretVersionTableName
+}
+
+func (do *spaceSecretVersionDO) toSpaceSecretVersion() domain.SpaceSecretVersion {
+	return domain.SpaceSecretVersion{
+		Id:           primitive.CreateIdentity(do.Id),
+		SecretId:     primitive.CreateIdentity(do.SecretId),
+		SpaceId:      primitive.CreateIdentity(do.SpaceId),
+		Version:      do.Version,
+		Action:       do.Action,
+		RollbackFrom: do.RollbackFrom,
+		CreatedBy:    primitive.CreateAccount(do.CreatedBy),
+		CreatedAt:    do.CreatedAt,
+	}
+}
@@ -7,6 +7,8 @@ package spacerepositoryadapter
 import (
 	"errors"
 
+	"gorm.io/gorm"
+
 	"github.com/openmerlin/merlin-server/common/domain/primitive"
 	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
 	"github.com/openmerlin/merlin-server/space/domain"
@@ -98,3 +100,35 @@ func (adapter *spaceVariableAdapter) ListVariableSecret(spaceId string) (
 
 	return r, nil
 }
+
+// ImportVariables adds and updates the space variables in one transaction,
+// none of them will be saved if any error occurs.
+func (adapter *spaceVariableAdapter) ImportVariables(added, updated []domain.SpaceVariable) error {
+	return adapter.db().Transaction(func(tx *gorm.DB) error {
+		for i := range added {
+			do := toSpaceVariableDO(&added[i])
+
+			if err := tx.Create(&do).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range updated {
+			do := toSpaceVariableDO(&updated[i])
+			do.Id = updated[i].Id.Integer()
+
+			v := tx.Model(&spaceEnvSecretDO{Id: do.Id}).Select(`*`).Updates(&do)
+			if v.Error != nil {
+				return v.Error
+			}
+
+			if v.RowsAffected == 0 {
+				return commonrepo.NewErrorConcurrentUpdating(
+					errors.New("concurrent updating"),
+				)
+			}
+		}
+
+		return nil
+	})
+}
@@ -37,7 +37,7 @@ type SpaceappAppService interface {
 	RestartSpaceApp(context.Context, primitive.Account, *spacedomain.SpaceIndex) error
 	PauseSpaceApp(context.Context, primitive.Account, *spacedomain.SpaceIndex) error
 	ResumeSpaceApp(context.Context, primitive.Account, *spacedomain.SpaceIndex) error
-	CheckPermissionRead(context.Context, primitive.Account, *spacedomain.SpaceIndex) error
+	CheckPermissionRead(context.Context, *CmdToCheckPermissionRead) error
 	GetSpaceIdByName(index *spacedomain.SpaceIndex) (spacedomain.Space, error)
 	WakeupSpaceApp(context.Context, primitive.Account, *spacedomain.SpaceIndex) (domain.SpaceApp, error)
 	WakeupSpaceAppWithMsg(context.Context, primitive.Account, *spacedomain.SpaceIndex) error
@@ -61,6 +61,7 @@ func NewSpaceappAppService(
 	repoAdapterModelSpace spacerepo.ModelSpaceRepositoryAdapter,
 	modelRepoAdapter modelrepo.ModelRepositoryAdapter,
 	buildLogAdapter repository.SpaceAppBuildLogAdapter,
+	embedTokenAdapter repository.SpaceEmbedTokenAdapter,
 ) *spaceappAppService {
 	return &spaceappAppService{
 		msg:                   msg,
@@ -72,6 +73,7 @@ func NewSpaceappAppService(
 		repoAdapterModelSpace: repoAdapterModelSpace,
 		modelRepoAdapter:      modelRepoAdapter,
 		buildLogAdapter:       buildLogAdapter,
+		embedTokenAdapter:     embedTokenAdapter,
 	}
 }
 
@@ -86,6 +88,7 @@ type spaceappAppService struct {
 	repoAdapterModelSpace spacerepo.ModelSpaceRepositoryAdapter
 	modelRepoAdapter      modelrepo.ModelRepositoryAdapter
 	buildLogAdapter       repository.SpaceAppBuildLogAdapter
+	embedTokenAdapter     repository.SpaceEmbedTokenAdapter
 }
 
 func (s *spaceappAppService) canHandleNotDisable(space *spacedomain.Space) error {
@@ -168,7 +171,7 @@ func (s *spaceappAppService) GetByName(
 		return toSpaceAppDTO(&app), nil
 	}
 
-	if space.Hardware.IsNpu() && !space.CompPowerAllocated {
+	if space.Hardware.IsAccelerator() && !space.CompPowerAllocated {
 		return toSpaceNoCompQuotaDTO(&space), nil
 	}
 
@@ -299,7 +302,31 @@ func (s *spaceappAppService) RestartSpaceApp(
 		return err
 	}
 
+	// the quota may have been released, e.g. the lease expired, so it must be taken again.
+	spaceCompCmd := spaceUserComputilityService{
+		userName:    user,
+		space:       space,
+		spaceRepo:   s.spaceRepo,
+		computility: s.computility,
+	}
+
+	bound := false
+	if space.ConsumeComputility() && !space.CompPowerAllocated {
+		if err := spaceCompCmd.bindSpaceCompQuota(); err != nil {
+			return allerror.New(allerror.ErrorCodeInsufficientQuota,
+				"restart space failed", xerrors.Errorf("bind space comp quota failed, err:%w", err))
+		}
+
+		bound = true
+	}
+
 	if err := s.repo.Save(&app); err != nil {
+		if bound {
+			if e := spaceCompCmd.unbindSpaceCompQuota(); e != nil {
+				logrus.Errorf("spaceId:%s release space comp quota failed, err:%s", space.Id.Identity(), e)
+			}
+		}
+
 		return err
 	}
 
@@ -312,15 +339,20 @@ func (s *spaceappAppService) RestartSpaceApp(
 }
 
 // CheckPermissionRead  check user permission for read space app.
-func (s *spaceappAppService) CheckPermissionRead(
-	ctx context.Context, user primitive.Account, index *spacedomain.SpaceIndex) error {
-	space, err := s.spaceRepo.FindByName(index)
+// The embed token is used in place of the user session if it is provided.
+func (s *spaceappAppService) CheckPermissionRead(ctx context.Context, cmd *CmdToCheckPermissionRead) error {
+	space, err := s.spaceRepo.FindByName(&cmd.Index)
 	if err != nil {
 		err = newSpaceNotFound(err)
 		return err
 	}
 
-	if err := s.permission.CanRead(ctx, user, &space); err != nil {
+	if cmd.EmbedToken != "" {
+		err = s.checkEmbedToken(ctx, &space, cmd)
+	} else {
+		err = s.permission.CanRead(ctx, cmd.User, &space)
+	}
+	if err != nil {
 		return err
 	}
 
@@ -422,7 +454,7 @@ func (sc *spaceUserComputilityService) unbindSpaceCompQuota() error {
 }
 
 func (sc *spaceUserComputilityService) bindSpaceCompQuota() error {
-	if !sc.space.Hardware.IsNpu() {
+	if !sc.space.ConsumeComputility() {
 		logrus.Info("no allow consume type")
 		return nil
 	}
@@ -497,7 +529,7 @@ func (s *spaceappAppService) ResumeSpaceApp(
 		if commonrepo.IsErrorResourceNotExists(err) {
 			err = newSpaceAppNotFound(err)
 		}
-		if !space.Hardware.IsNpu() {
+		if !space.Hardware.IsAccelerator() {
 			return err
 		}
 		app, err = s.reCreateApp(ctx, space)
@@ -538,13 +570,14 @@ func (s *spaceappAppService) ResumeSpaceApp(
 }
 
 func (s *spaceappAppService) reCreateApp(ctx context.Context, space spacedomain.Space) (domain.SpaceApp, error) {
-	if err := s.repo.Add(&domain.SpaceApp{
-		Status: appprimitive.AppStatusPaused,
-		SpaceAppIndex: domain.SpaceAppIndex{
+	v := domain.NewSpaceApp(
+		domain.SpaceAppIndex{
 			SpaceId:  space.Id,
 			CommitId: space.CommitId,
 		},
-	}); err != nil {
+		space.SDK, space.Docker, appprimitive.AppStatusPaused,
+	)
+	if err := s.repo.Add(&v); err != nil {
 		return domain.SpaceApp{}, err
 	}
 	return s.repo.FindBySpaceId(ctx, space.Id)
@@ -44,9 +44,13 @@ type SpaceappInternalAppService interface {
 	NotifyIsResumeFailed(ctx context.Context, cmd *CmdToNotifyFailedStatus) error
 
 	ForcePauseSpaceApp(context.Context, primitive.Identity) error
+	PreemptSpaceApp(context.Context, primitive.Identity, primitive.Account) error
 	PauseSpaceApp(context.Context, primitive.Identity) error
 
 	SleepSpaceApp(context.Context, *CmdToSleepSpaceApp) error
+
+	RenewLease(context.Context, *CmdToRenewLease) error
+	ExpireLeases(context.Context) ([]string, error)
 }
 
 // NewSpaceappInternalAppService creates a new instance of spaceappInternalAppService
@@ -95,7 +99,7 @@ func (s *spaceappInternalAppService) Create(ctx context.Context, cmd *CmdToCreat
 		return err
 	}
 
-	if space.Hardware.IsNpu() && !space.CompPowerAllocated {
+	if space.ConsumeComputility() && !space.CompPowerAllocated {
 		e := xerrors.Errorf("failed to create space failed, "+
 			"spaceId:%s is npu but not allocate computility", space.Id.Identity())
 		err = allerror.New(allerror.ErrorCodeSpaceAppCreateFailed, e.Error(), e)
@@ -132,10 +136,7 @@ func (s *spaceappInternalAppService) Create(ctx context.Context, cmd *CmdToCreat
 		return err
 	}
 
-	v := domain.SpaceApp{
-		Status:        appprimitive.AppStatusInit,
-		SpaceAppIndex: *cmd,
-	}
+	v := domain.NewSpaceApp(*cmd, space.SDK, space.Docker, appprimitive.AppStatusInit)
 	if err := s.repo.Add(&v); err != nil {
 		logrus.Errorf("spaceId:%s create space app db failed, err:%s", space.Id.Identity(), err)
 		return err
@@ -366,6 +367,21 @@ func (s *spaceappInternalAppService) NotifyIsResumeFailed(ctx context.Context, c
 
 // PauseSpaceApp pause a SpaceApp in the spaceappAppService.
 func (s *spaceappInternalAppService) ForcePauseSpaceApp(ctx context.Context, spaceId primitive.Identity) error {
+	return s.forcePauseSpaceApp(ctx, spaceId, nil)
+}
+
+// PreemptSpaceApp pauses the space app and releases the quota consumed from the account of quotaOwner,
+// which is not the creator of space if the app was resumed by another member of the org.
+func (s *spaceappInternalAppService) PreemptSpaceApp(
+	ctx context.Context, spaceId primitive.Identity, quotaOwner primitive.Account,
+) error {
+	return s.forcePauseSpaceApp(ctx, spaceId, quotaOwner)
+}
+
+// forcePauseSpaceApp pauses the space app, the quota is released from the creator of space if quotaOwner is nil.
+func (s *spaceappInternalAppService) forcePauseSpaceApp(
+	ctx context.Context, spaceId primitive.Identity, quotaOwner primitive.Account,
+) error {
 	space, err := s.spaceRepo.FindById(spaceId)
 	if err != nil {
 		if commonrepo.IsErrorResourceNotExists(err) {
@@ -375,8 +391,11 @@ func (s *spaceappInternalAppService) ForcePauseSpaceApp(ctx context.Context, spa
 
 		return err
 	}
+	if quotaOwner == nil {
+		quotaOwner = space.CreatedBy
+	}
 	spaceCompCmd := spaceUserComputilityService{
-		userName:    space.CreatedBy,
+		userName:    quotaOwner,
 		space:       space,
 		spaceRepo:   s.spaceRepo,
 		computility: s.computility,
@@ -116,4 +116,141 @@ type BuildLogsDTO struct {
 // CmdToSleepSpaceApp is a command to sleep space app
 type CmdToSleepSpaceApp struct {
 	domain.SpaceAppIndex
-}
\ No newline at end of file
+}
+
+// CmdToCheckPermissionRead is a command to check the read permission of space app,
+// EmbedToken is used in place of the User if it is not empty.
+type CmdToCheckPermissionRead struct {
+	User       primitive.Account
+	Index      spacedomain.SpaceIndex
+	EmbedToken string
+	Origin     string
+	IP         string
+}
+
+// CmdToCreateEmbedToken is a command to create embed token of space.
+type CmdToCreateEmbedToken struct {
+	Name      string
+	Scope     appprimitive.EmbedScope
+	Origins   []appprimitive.EmbedOrigin
+	ExpiredAt int64
+}
+
+// EmbedTokenDTO is a struct that represents a data transfer object for embed token.
+type EmbedTokenDTO struct {
+	Id        string   `json:"id"`
+	Name      string   `json:"name"`
+	Scope     string   `json:"scope"`
+	Origins   []string `json:"origins"`
+	CreatedBy string   `json:"created_by"`
+	CreatedAt int64    `json:"created_at"`
+	ExpiredAt int64    `json:"expired_at"`
+	RevokedAt int64    `json:"revoked_at"`
+}
+
+func toEmbedTokenDTO(t *domain.SpaceEmbedToken) EmbedTokenDTO {
+	origins := make([]string, len(t.Origins))
+	for i := range t.Origins {
+		origins[i] = t.Origins[i].EmbedOrigin()
+	}
+
+	return EmbedTokenDTO{
+		Id:        t.Id.Identity(),
+		Name:      t.Name,
+		Scope:     t.Scope.EmbedScope(),
+		Origins:   origins,
+		CreatedBy: t.CreatedBy.Account(),
+		CreatedAt: t.CreatedAt,
+		ExpiredAt: t.ExpiredAt,
+		RevokedAt: t.RevokedAt,
+	}
+}
+
+// EmbedTokenCreatedDTO is the created embed token, the plain token is only returned here.
+type EmbedTokenCreatedDTO struct {
+	EmbedTokenDTO
+
+	Token string `json:"token"`
+}
+
+// EmbedTokenUsageDTO is a struct that represents the uses of embed token in a day,
+// Origin, IP and UsedAt are of the latest use.
+type EmbedTokenUsageDTO struct {
+	Day    string `json:"day"`
+	Count  int64  `json:"count"`
+	Origin string `json:"origin"`
+	IP     string `json:"ip"`
+	UsedAt int64  `json:"used_at"`
+}
+
+// CmdToIngestMetric is a command to ingest the runtime metric of space app.
+type CmdToIngestMetric = domain.SpaceAppMetric
+
+// CmdToGetMetrics is a command to get the runtime metrics of space app in the window.
+type CmdToGetMetrics struct {
+	// Window is the length of time series in seconds which ends at now.
+	Window int64
+}
+
+// MetricPointDTO is a point of the time series of space app runtime metrics.
+type MetricPointDTO struct {
+	Timestamp    int64   `json:"timestamp"`
+	CPUUsage     float64 `json:"cpu_usage"`
+	MemoryUsage  float64 `json:"memory_usage"`
+	NPUUsage     float64 `json:"npu_usage"`
+	RequestCount int64   `json:"request_count"`
+}
+
+// MetricsDTO is the time series of space app runtime metrics.
+type MetricsDTO struct {
+	Granularity string           `json:"granularity"`
+	Interval    int64            `json:"interval"`
+	Since       int64            `json:"since"`
+	Points      []MetricPointDTO `json:"points"`
+}
+
+func toMetricPointDTO(m *domain.SpaceAppMetric) MetricPointDTO {
+	return MetricPointDTO{
+		Timestamp:    m.Timestamp,
+		CPUUsage:     m.CPUUsage,
+		MemoryUsage:  m.MemoryUsage,
+		NPUUsage:     m.NPUUsage,
+		RequestCount: m.RequestCount,
+	}
+}
+
+// CmdToRenewLease is a command to renew the lease of computility quota consumed by the space app.
+type CmdToRenewLease = domain.SpaceAppIndex
+
+// QueueEntryDTO is the space app waiting for the computility quota, it is not queued if it starts at once.
+type QueueEntryDTO struct {
+	Queued      bool   `json:"queued"`
+	Action      string `json:"action,omitempty"`
+	ComputeType string `json:"compute_type,omitempty"`
+	Position    int    `json:"position,omitempty"`
+	CreatedAt   int64  `json:"created_at,omitempty"`
+}
+
+func toQueueEntryDTO(e *domain.SpaceAppQueueEntry, ahead int) QueueEntryDTO {
+	return QueueEntryDTO{
+		Queued:      true,
+		Action:      e.Action,
+		ComputeType: e.ComputeType.ComputilityType(),
+		Position:    ahead + 1,
+		CreatedAt:   e.CreatedAt,
+	}
+}
+
+// QueueDispatchDTO is the result of dispatching the queued space apps.
+type QueueDispatchDTO struct {
+	Started []string `json:"started"`
+	Expired []string `json:"expired"`
+}
+
+// PreemptionDTO is the result of preempting the quota for the space app,
+// Preempted are the apps paused and Failed are the apps failed to be paused.
+type PreemptionDTO struct {
+	Started   bool     `json:"started"`
+	Preempted []string `json:"preempted"`
+	Failed    []string `json:"failed"`
+}
@@ -0,0 +1,269 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+
+	commonapp "github.com/openmerlin/merlin-server/common/app"
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	spacedomain "github.com/openmerlin/merlin-server/space/domain"
+	"github.com/openmerlin/merlin-server/spaceapp/domain"
+	appprimitive "github.com/openmerlin/merlin-server/spaceapp/domain/primitive"
+	"github.com/openmerlin/merlin-server/spaceapp/domain/repository"
+	"github.com/openmerlin/merlin-server/utils"
+)
+
+const (
+	embedTokenUsageLimit     = 100
+	embedTokenUsageDayLayout = "2006-01-02"
+)
+
+func newEmbedTokenNotFound(err error) error {
+	return allerror.NewNotFound(allerror.ErrorCodeTokenNotFound, "embed token not found", err)
+}
+
+// SpaceEmbedTokenAppService is the interface for managing the embed tokens of space.
+type SpaceEmbedTokenAppService interface {
+	Create(context.Context, primitive.Account, *spacedomain.SpaceIndex, *CmdToCreateEmbedToken) (
+		EmbedTokenCreatedDTO, string, error)
+	List(context.Context, primitive.Account, *spacedomain.SpaceIndex) ([]EmbedTokenDTO, error)
+	Revoke(context.Context, primitive.Account, *spacedomain.SpaceIndex, primitive.Identity) (string, error)
+	ListUsage(context.Context, primitive.Account, *spacedomain.SpaceIndex, primitive.Identity) (
+		[]EmbedTokenUsageDTO, error)
+}
+
+// NewSpaceEmbedTokenAppService creates a new instance of the space embed token service.
+func NewSpaceEmbedTokenAppService(
+	spaceRepo spaceRepository,
+	permission commonapp.ResourcePermissionAppService,
+	embedTokenAdapter repository.SpaceEmbedTokenAdapter,
+) *spaceEmbedTokenAppService {
+	return &spaceEmbedTokenAppService{
+		spaceRepo:         spaceRepo,
+		permission:        permission,
+		embedTokenAdapter: embedTokenAdapter,
+	}
+}
+
+type spaceEmbedTokenAppService struct {
+	spaceRepo         spaceRepository
+	permission        commonapp.ResourcePermissionAppService
+	embedTokenAdapter repository.SpaceEmbedTokenAdapter
+}
+
+// Create mints an embed token of the space which is bound to the allowed origins.
+func (s *spaceEmbedTokenAppService) Create(
+	ctx context.Context, user primitive.Account, index *spacedomain.SpaceIndex, cmd *CmdToCreateEmbedToken,
+) (dto EmbedTokenCreatedDTO, action string, err error) {
+	space, err := s.findUpdatableSpace(ctx, user, index)
+	if err != nil {
+		return
+	}
+
+	action = fmt.Sprintf(
+		"create embed token of %s:%s/%s:%s",
+		space.Id.Identity(), index.Owner.Account(), index.Name.MSDName(), cmd.Name,
+	)
+
+	t := domain.SpaceEmbedToken{
+		SpaceId:   space.Id,
+		Name:      cmd.Name,
+		Scope:     cmd.Scope,
+		Origins:   cmd.Origins,
+		CreatedBy: user,
+		ExpiredAt: cmd.ExpiredAt,
+	}
+
+	token, err := domain.NewSpaceEmbedToken(&t, utils.Now())
+	if err != nil {
+		err = allerror.NewInvalidParam(err.Error(), err)
+
+		return
+	}
+
+	if err = s.embedTokenAdapter.Add(&t); err != nil {
+		return
+	}
+
+	dto = EmbedTokenCreatedDTO{
+		EmbedTokenDTO: toEmbedTokenDTO(&t),
+		Token:         token,
+	}
+
+	return
+}
+
+// List lists the embed tokens of the space.
+func (s *spaceEmbedTokenAppService) List(
+	ctx context.Context, user primitive.Account, index *spacedomain.SpaceIndex,
+) ([]EmbedTokenDTO, error) {
+	space, err := s.findUpdatableSpace(ctx, user, index)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := s.embedTokenAdapter.ListBySpaceId(ctx, space.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]EmbedTokenDTO, len(v))
+	for i := range v {
+		dtos[i] = toEmbedTokenDTO(&v[i])
+	}
+
+	return dtos, nil
+}
+
+// Revoke revokes the embed token of the space.
+func (s *spaceEmbedTokenAppService) Revoke(
+	ctx context.Context, user primitive.Account, index *spacedomain.SpaceIndex, tokenId primitive.Identity,
+) (action string, err error) {
+	space, err := s.findUpdatableSpace(ctx, user, index)
+	if err != nil {
+		return
+	}
+
+	t, err := s.findEmbedToken(ctx, &space, tokenId)
+	if err != nil {
+		return
+	}
+
+	action = fmt.Sprintf(
+		"revoke embed token of %s:%s/%s:%s",
+		space.Id.Identity(), index.Owner.Account(), index.Name.MSDName(), t.Name,
+	)
+
+	if t.IsRevoked() {
+		return
+	}
+
+	t.Revoke(utils.Now())
+
+	err = s.embedTokenAdapter.Save(&t)
+
+	return
+}
+
+// ListUsage lists the daily uses of the embed token in the latest days.
+func (s *spaceEmbedTokenAppService) ListUsage(
+	ctx context.Context, user primitive.Account, index *spacedomain.SpaceIndex, tokenId primitive.Identity,
+) ([]EmbedTokenUsageDTO, error) {
+	space, err := s.findUpdatableSpace(ctx, user, index)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = s.findEmbedToken(ctx, &space, tokenId); err != nil {
+		return nil, err
+	}
+
+	v, err := s.embedTokenAdapter.ListUsage(ctx, tokenId, embedTokenUsageLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]EmbedTokenUsageDTO, len(v))
+	for i := range v {
+		dtos[i] = EmbedTokenUsageDTO{
+			Day:    time.Unix(v[i].Day, 0).UTC().Format(embedTokenUsageDayLayout),
+			Count:  v[i].Count,
+			Origin: v[i].Origin,
+			IP:     v[i].IP,
+			UsedAt: v[i].UsedAt,
+		}
+	}
+
+	return dtos, nil
+}
+
+func (s *spaceEmbedTokenAppService) findUpdatableSpace(
+	ctx context.Context, user primitive.Account, index *spacedomain.SpaceIndex,
+) (space spacedomain.Space, err error) {
+	space, err = s.spaceRepo.FindByName(index)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceNotFound(err)
+		}
+
+		return
+	}
+
+	notFound, err := commonapp.CanUpdateOrNotFound(ctx, user, &space, s.permission)
+	if err != nil {
+		return
+	}
+
+	if notFound {
+		err = newSpaceNotFound(xerrors.Errorf("%s not found", space.Id.Identity()))
+	}
+
+	return
+}
+
+func (s *spaceEmbedTokenAppService) findEmbedToken(
+	ctx context.Context, space *spacedomain.Space, tokenId primitive.Identity,
+) (domain.SpaceEmbedToken, error) {
+	t, err := s.embedTokenAdapter.Find(ctx, tokenId)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newEmbedTokenNotFound(err)
+		}
+
+		return t, err
+	}
+
+	if t.SpaceId.Identity() != space.Id.Identity() {
+		return t, newEmbedTokenNotFound(
+			xerrors.Errorf("token:%s not belong to space:%s", tokenId.Identity(), space.Id.Identity()),
+		)
+	}
+
+	return t, nil
+}
+
+// checkEmbedToken checks the embed token in place of the user session and records the use of it.
+func (s *spaceappAppService) checkEmbedToken(
+	ctx context.Context, space *spacedomain.Space, cmd *CmdToCheckPermissionRead,
+) error {
+	if !domain.IsEmbedToken(cmd.EmbedToken) {
+		return allerror.NewNoPermission("invalid embed token", xerrors.New("malformed embed token"))
+	}
+
+	tokens, err := s.embedTokenAdapter.FindByLastEight(ctx, domain.EmbedTokenLastEight(cmd.EmbedToken))
+	if err != nil {
+		return err
+	}
+
+	now := utils.Now()
+
+	for i := range tokens {
+		t := &tokens[i]
+		if !t.Match(cmd.EmbedToken) {
+			continue
+		}
+
+		if err := t.CheckAccess(space.Id, cmd.Origin, appprimitive.EmbedScopeRead, now); err != nil {
+			return allerror.NewNoPermission("invalid embed token", err)
+		}
+
+		usage := domain.NewSpaceEmbedTokenUsage(t, cmd.Origin, cmd.IP, now)
+		if err := s.embedTokenAdapter.AddUsage(&usage); err != nil {
+			logrus.Errorf("failed to record use of embed token:%s, err:%s", t.Id.Identity(), err)
+		}
+
+		return nil
+	}
+
+	return allerror.NewNoPermission("invalid embed token", xerrors.New("embed token not found"))
+}
@@ -0,0 +1,55 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+)
+
+// RenewLease renews the lease of computility quota consumed by the space app which is still running.
+func (s *spaceappInternalAppService) RenewLease(ctx context.Context, cmd *CmdToRenewLease) error {
+	app, err := s.repo.Find(ctx, cmd)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceAppNotFound(err)
+		}
+
+		return err
+	}
+
+	if !app.CanRenewLease() {
+		e := fmt.Errorf("spaceId:%s, status:%s can't renew lease", cmd.SpaceId.Identity(), app.Status.AppStatus())
+
+		return allerror.New(allerror.ErrorCodeSpaceAppUnmatchedStatus, e.Error(), e)
+	}
+
+	return s.computility.RenewLease(cmd.SpaceId)
+}
+
+// ExpireLeases force pauses the space apps whose lease of computility quota is expired.
+func (s *spaceappInternalAppService) ExpireLeases(ctx context.Context) ([]string, error) {
+	records, err := s.computility.ExpireLeases(func(spaceId primitive.Identity) error {
+		return s.ForcePauseSpaceApp(ctx, spaceId)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(records))
+	for i := range records {
+		ids[i] = records[i].SpaceId
+	}
+
+	logrus.Infof("lease expired | %d space apps are paused", len(ids))
+
+	return ids, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package app
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+
+	commonapp "github.com/openmerlin/merlin-server/common/app"
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	spacedomain "github.com/openmerlin/merlin-server/space/domain"
+	"github.com/openmerlin/merlin-server/spaceapp/domain"
+	"github.com/openmerlin/merlin-server/spaceapp/domain/repository"
+	"github.com/openmerlin/merlin-server/utils"
+)
+
+// SpaceAppMetricAppService is the interface for ingesting and reading the runtime metrics of space app.
+type SpaceAppMetricAppService interface {
+	Ingest(context.Context, *CmdToIngestMetric) error
+	RemoveExpired(context.Context) error
+	Get(context.Context, primitive.Account, *spacedomain.SpaceIndex, *CmdToGetMetrics) (MetricsDTO, error)
+}
+
+// NewSpaceAppMetricAppService creates a new instance of the space app metric service.
+func NewSpaceAppMetricAppService(
+	repo repository.Repository,
+	spaceRepo spaceRepository,
+	permission commonapp.ResourcePermissionAppService,
+	metricAdapter repository.SpaceAppMetricAdapter,
+) *spaceAppMetricAppService {
+	return &spaceAppMetricAppService{
+		repo:          repo,
+		spaceRepo:     spaceRepo,
+		permission:    permission,
+		metricAdapter: metricAdapter,
+	}
+}
+
+type spaceAppMetricAppService struct {
+	repo          repository.Repository
+	spaceRepo     spaceRepository
+	permission    commonapp.ResourcePermissionAppService
+	metricAdapter repository.SpaceAppMetricAdapter
+}
+
+// Ingest saves the metric reported for the space app, the metric of the outdated app is rejected.
+func (s *spaceAppMetricAppService) Ingest(ctx context.Context, cmd *CmdToIngestMetric) error {
+	now := utils.Now()
+
+	if err := domain.NewSpaceAppMetric(cmd, now); err != nil {
+		return allerror.NewInvalidParam(err.Error(), err)
+	}
+
+	if _, err := s.repo.Find(ctx, &cmd.SpaceAppIndex); err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceAppNotFound(err)
+		}
+
+		return err
+	}
+
+	return s.metricAdapter.Add(cmd)
+}
+
+// RemoveExpired removes the metrics beyond the retention of their granularity,
+// it is called periodically instead of on every ingestion.
+func (s *spaceAppMetricAppService) RemoveExpired(ctx context.Context) error {
+	if err := s.metricAdapter.RemoveExpired(utils.Now()); err != nil {
+		logrus.Errorf("remove expired metrics failed, err:%s", err)
+
+		return err
+	}
+
+	return nil
+}
+
+// Get returns the time series of the metrics in the window, the granularity is chosen by the window.
+func (s *spaceAppMetricAppService) Get(
+	ctx context.Context, user primitive.Account, index *spacedomain.SpaceIndex, cmd *CmdToGetMetrics,
+) (dto MetricsDTO, err error) {
+	g, err := domain.MetricGranularityOfWindow(cmd.Window)
+	if err != nil {
+		err = allerror.NewInvalidParam(err.Error(), err)
+
+		return
+	}
+
+	space, err := s.spaceRepo.FindByName(index)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceNotFound(err)
+		}
+
+		return
+	}
+
+	notFound, err := commonapp.CanUpdateOrNotFound(ctx, user, &space, s.permission)
+	if err != nil {
+		return
+	}
+
+	if notFound {
+		err = newSpaceNotFound(xerrors.Errorf("%s not found", space.Id.Identity()))
+
+		return
+	}
+
+	since := g.Bucket(utils.Now() - cmd.Window)
+
+	v, err := s.metricAdapter.List(ctx, &repository.MetricListOption{
+		SpaceId:     space.Id,
+		Granularity: g.Name,
+		Since:       since,
+	})
+	if err != nil {
+		return
+	}
+
+	dto = MetricsDTO{
+		Granularity: g.Name,
+		Interval:    g.Interval,
+		Since:       since,
+		Points:      make([]MetricPointDTO, len(v)),
+	}
+
+	for i := range v {
+		dto.Points[i] = toMetricPointDTO(&v[i])
+	}
+
+	return
+}
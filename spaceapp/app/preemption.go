@@ -0,0 +1,271 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	commonapp "github.com/openmerlin/merlin-server/common/app"
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	computilityapp "github.com/openmerlin/merlin-server/computility/app"
+	computilitydomain "github.com/openmerlin/merlin-server/computility/domain"
+	spacedomain "github.com/openmerlin/merlin-server/space/domain"
+	"github.com/openmerlin/merlin-server/spaceapp/domain"
+	"github.com/openmerlin/merlin-server/spaceapp/domain/email"
+	"github.com/openmerlin/merlin-server/spaceapp/domain/message"
+	"github.com/openmerlin/merlin-server/spaceapp/domain/repository"
+	userapp "github.com/openmerlin/merlin-server/user/app"
+	"github.com/openmerlin/merlin-server/utils"
+)
+
+// idleWindow is the window of metrics used to find the last time the app served requests.
+const idleWindow = 24 * 60 * 60
+
+// SpaceAppPreemptionAppService is the interface for starting the space app of high priority
+// by pausing the apps of lower priority when the computility quota is exhausted.
+type SpaceAppPreemptionAppService interface {
+	Preempt(context.Context, primitive.Account, *spacedomain.SpaceIndex) (PreemptionDTO, string, error)
+}
+
+// NewSpaceAppPreemptionAppService creates a new instance of the space app preemption service.
+func NewSpaceAppPreemptionAppService(
+	appService SpaceappAppService,
+	internal SpaceappInternalAppService,
+	spaceRepo spaceRepository,
+	permission commonapp.ResourcePermissionAppService,
+	repo repository.Repository,
+	metricAdapter repository.SpaceAppMetricAdapter,
+	computility computilityapp.ComputilityInternalAppService,
+	msg message.SpaceAppMessage,
+	emailAdapter email.Email,
+	user userapp.UserService,
+) *spaceAppPreemptionAppService {
+	return &spaceAppPreemptionAppService{
+		appService:    appService,
+		internal:      internal,
+		spaceRepo:     spaceRepo,
+		permission:    permission,
+		repo:          repo,
+		metricAdapter: metricAdapter,
+		computility:   computility,
+		msg:           msg,
+		emailAdapter:  emailAdapter,
+		user:          user,
+	}
+}
+
+type spaceAppPreemptionAppService struct {
+	appService    SpaceappAppService
+	internal      SpaceappInternalAppService
+	spaceRepo     spaceRepository
+	permission    commonapp.ResourcePermissionAppService
+	repo          repository.Repository
+	metricAdapter repository.SpaceAppMetricAdapter
+	computility   computilityapp.ComputilityInternalAppService
+	msg           message.SpaceAppMessage
+	emailAdapter  email.Email
+	user          userapp.UserService
+}
+
+// Preempt resumes the space app, if the quota is exhausted the serving apps of lower priority
+// which consume the quota of the same account are paused, the lowest priority and the longest
+// idle first, and their owners are warned by event and email before they are paused.
+// The app failed to be paused is reported and the others are still paused.
+func (s *spaceAppPreemptionAppService) Preempt(
+	ctx context.Context, user primitive.Account, index *spacedomain.SpaceIndex,
+) (dto PreemptionDTO, action string, err error) {
+	action = fmt.Sprintf("preempt quota for space app of %s", index.Owner.Account()+"/"+index.Name.MSDName())
+
+	space, err := s.spaceRepo.FindByName(index)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceNotFound(err)
+		}
+
+		return
+	}
+
+	if err = s.permission.CanUpdate(ctx, user, &space); err != nil {
+		if allerror.IsNoPermission(err) {
+			err = newSpaceNotFound(err)
+		}
+
+		return
+	}
+
+	if !space.CanPreempt() || !space.ConsumeComputility() {
+		err = allerror.NewNoPermission("no permission",
+			xerrors.Errorf("space:%s has no priority to preempt quota", space.Id.Identity()))
+
+		return
+	}
+
+	dto.Preempted = []string{}
+	dto.Failed = []string{}
+
+	if err = s.appService.ResumeSpaceApp(ctx, user, index); err == nil || !allerror.IsInsufficientQuota(err) {
+		dto.Started = err == nil
+
+		return
+	}
+
+	victims, err := s.selectVictims(ctx, user, &space)
+	if err != nil {
+		return
+	}
+
+	for i := range victims {
+		v := &victims[i]
+
+		s.warn(ctx, user, v, &space)
+
+		if err := s.internal.PreemptSpaceApp(ctx, v.SpaceId, user); err != nil {
+			logrus.Errorf("preempt | pause spaceId:%s failed, err:%s", v.SpaceId.Identity(), err)
+
+			dto.Failed = append(dto.Failed, v.SpaceId.Identity())
+
+			continue
+		}
+
+		logrus.Infof("preempt | spaceId:%s is paused for spaceId:%s", v.SpaceId.Identity(), space.Id.Identity())
+
+		dto.Preempted = append(dto.Preempted, v.SpaceId.Identity())
+	}
+
+	if err = s.appService.ResumeSpaceApp(ctx, user, index); err == nil {
+		dto.Started = true
+
+		return
+	}
+
+	if len(dto.Preempted) > 0 {
+		// the paused apps must be reported even if the app can't be started
+		logrus.Errorf("preempt | resume spaceId:%s failed, err:%s", space.Id.Identity(), err)
+
+		err = nil
+	}
+
+	return
+}
+
+// selectVictims picks the serving apps to pause, all the apps consuming the quota of the user
+// are candidates whoever created them, because the quota is released from the account of user.
+func (s *spaceAppPreemptionAppService) selectVictims(
+	ctx context.Context, user primitive.Account, space *spacedomain.Space,
+) ([]domain.PreemptionCandidate, error) {
+	usage, err := s.computility.GetAccountUsage(computilitydomain.ComputilityAccountIndex{
+		UserName:    user,
+		ComputeType: space.GetComputeType(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]domain.PreemptionCandidate, 0, len(usage.Records))
+
+	for i := range usage.Records {
+		c, ok := s.toCandidate(ctx, space, &usage.Records[i])
+		if ok {
+			candidates = append(candidates, c)
+		}
+	}
+
+	victims, ok := domain.SelectPreemptionVictims(candidates, space.Priority, space.GetQuotaCount()-usage.Balance)
+	if !ok {
+		return nil, allerror.New(allerror.ErrorCodeInsufficientQuota, "no space app of lower priority to preempt",
+			xerrors.Errorf("quota of %s can't be freed for space:%s", user.Account(), space.Id.Identity()))
+	}
+
+	return victims, nil
+}
+
+func (s *spaceAppPreemptionAppService) toCandidate(
+	ctx context.Context, space *spacedomain.Space, record *computilityapp.AccountRecordlDTO,
+) (domain.PreemptionCandidate, bool) {
+	spaceId, err := primitive.NewIdentity(record.SpaceId)
+	if err != nil || spaceId.Identity() == space.Id.Identity() {
+		return domain.PreemptionCandidate{}, false
+	}
+
+	v, err := s.spaceRepo.FindById(spaceId)
+	if err != nil {
+		return domain.PreemptionCandidate{}, false
+	}
+
+	app, err := s.repo.FindBySpaceId(ctx, spaceId)
+	if err != nil || !app.Status.IsServing() {
+		return domain.PreemptionCandidate{}, false
+	}
+
+	metrics, err := s.metricAdapter.List(ctx, &repository.MetricListOption{
+		SpaceId:     spaceId,
+		Granularity: domain.MetricGranularityMinute,
+		Since:       utils.Now() - idleWindow,
+	})
+	if err != nil {
+		logrus.Errorf("preempt | list metrics of spaceId:%s failed, err:%s", spaceId.Identity(), err)
+	}
+
+	return domain.PreemptionCandidate{
+		SpaceId:      spaceId,
+		Owner:        v.Owner,
+		Name:         v.Name,
+		Priority:     v.Priority,
+		QuotaCount:   record.QuotaCount,
+		LastActiveAt: domain.LastActiveAt(&app, metrics),
+	}, true
+}
+
+// warn sends the warning event and email to the owners of the app before it is paused,
+// the failure of warning doesn't stop the preemption.
+func (s *spaceAppPreemptionAppService) warn(
+	ctx context.Context, creator primitive.Account, victim *domain.PreemptionCandidate, space *spacedomain.Space,
+) {
+	e := domain.NewSpaceAppPreemptWarningEvent(victim, space.Id, space.Priority, utils.Now())
+	if err := s.msg.SendSpaceAppPreemptWarningEvent(&e); err != nil {
+		logrus.Errorf("preempt | send warning event of spaceId:%s failed, err:%s", victim.SpaceId.Identity(), err)
+	}
+
+	receivers := s.emails(ctx, victim.Owner, creator)
+	if len(receivers) == 0 {
+		return
+	}
+
+	err := s.emailAdapter.SendPreemptionNotice(
+		receivers,
+		victim.Owner.Account()+"/"+victim.Name.MSDName(),
+		space.Owner.Account()+"/"+space.Name.MSDName(),
+	)
+	if err != nil {
+		logrus.Errorf("preempt | send warning email of spaceId:%s failed, err:%s", victim.SpaceId.Identity(), err)
+	}
+}
+
+// emails returns the emails of the accounts, the organization has no email.
+func (s *spaceAppPreemptionAppService) emails(ctx context.Context, accounts ...primitive.Account) []string {
+	emails := sets.New[string]()
+
+	for _, acc := range accounts {
+		u, err := s.user.GetByAccount(ctx, acc, acc)
+		if err != nil {
+			logrus.Errorf("preempt | get email of %s failed, err:%s", acc.Account(), err)
+
+			continue
+		}
+
+		if u.Email != nil && *u.Email != "" {
+			emails.Insert(*u.Email)
+		}
+	}
+
+	return sets.List(emails)
+}
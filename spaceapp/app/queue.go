@@ -0,0 +1,320 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+
+	commonapp "github.com/openmerlin/merlin-server/common/app"
+	"github.com/openmerlin/merlin-server/common/domain/allerror"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	computilityapp "github.com/openmerlin/merlin-server/computility/app"
+	spacedomain "github.com/openmerlin/merlin-server/space/domain"
+	"github.com/openmerlin/merlin-server/spaceapp/domain"
+	"github.com/openmerlin/merlin-server/spaceapp/domain/repository"
+	"github.com/openmerlin/merlin-server/utils"
+)
+
+// SpaceAppQueueAppService is the interface for queuing the space apps when the computility quota is exhausted.
+type SpaceAppQueueAppService interface {
+	Enqueue(context.Context, primitive.Account, *spacedomain.SpaceIndex, string) (QueueEntryDTO, string, error)
+	Get(context.Context, primitive.Account, *spacedomain.SpaceIndex) (QueueEntryDTO, error)
+	Cancel(context.Context, primitive.Account, *spacedomain.SpaceIndex) (string, error)
+	Dispatch(context.Context) (QueueDispatchDTO, error)
+}
+
+// NewSpaceAppQueueAppService creates a new instance of the space app queue service.
+func NewSpaceAppQueueAppService(
+	appService SpaceappAppService,
+	spaceRepo spaceRepository,
+	permission commonapp.ResourcePermissionAppService,
+	queueAdapter repository.SpaceAppQueueAdapter,
+) *spaceAppQueueAppService {
+	return &spaceAppQueueAppService{
+		appService:   appService,
+		spaceRepo:    spaceRepo,
+		permission:   permission,
+		queueAdapter: queueAdapter,
+	}
+}
+
+type spaceAppQueueAppService struct {
+	appService   SpaceappAppService
+	spaceRepo    spaceRepository
+	permission   commonapp.ResourcePermissionAppService
+	queueAdapter repository.SpaceAppQueueAdapter
+
+	// dispatching serializes the dispatching, pending is set when a dispatching is waiting for it.
+	dispatching sync.Mutex
+	pending     atomic.Bool
+}
+
+func newSpaceAppNotQueued(err error) error {
+	return allerror.NewNotFound(allerror.ErrorCodeSpaceAppNotQueued, "space app is not queued", err)
+}
+
+// Enqueue resumes or restarts the space app, it enters the queue if the computility quota is exhausted.
+func (s *spaceAppQueueAppService) Enqueue(
+	ctx context.Context, user primitive.Account, index *spacedomain.SpaceIndex, action string,
+) (dto QueueEntryDTO, op string, err error) {
+	op = fmt.Sprintf("%s space app of %s with queue", action, index.Owner.Account()+"/"+index.Name.MSDName())
+
+	err = s.start(ctx, user, index, action)
+	if err == nil || !allerror.IsInsufficientQuota(err) {
+		return
+	}
+
+	space, e := s.spaceRepo.FindByName(index)
+	if e != nil || !space.ConsumeComputility() {
+		return
+	}
+
+	entry, e := s.queueAdapter.FindBySpaceId(ctx, space.Id)
+	if e == nil {
+		if !entry.IsStale(&space) {
+			dto, err = s.toDTO(&entry)
+
+			return
+		}
+
+		if e = s.queueAdapter.Delete(entry.Id); e != nil {
+			err = e
+
+			return
+		}
+	} else if !commonrepo.IsErrorResourceNotExists(e) {
+		err = e
+
+		return
+	}
+
+	entry = domain.NewSpaceAppQueueEntry(&space, user, action, utils.Now())
+
+	if err = s.queueAdapter.Add(&entry); err != nil {
+		return
+	}
+
+	logrus.Infof("spaceId:%s is queued for %s quota of %s",
+		space.Id.Identity(), entry.ComputeType.ComputilityType(), user.Account())
+
+	dto, err = s.toDTO(&entry)
+
+	return
+}
+
+// Get returns the position of the space app in the queue.
+func (s *spaceAppQueueAppService) Get(
+	ctx context.Context, user primitive.Account, index *spacedomain.SpaceIndex,
+) (QueueEntryDTO, error) {
+	entry, err := s.getEntry(ctx, user, index)
+	if err != nil {
+		return QueueEntryDTO{}, err
+	}
+
+	return s.toDTO(&entry)
+}
+
+// Cancel removes the space app from the queue.
+func (s *spaceAppQueueAppService) Cancel(
+	ctx context.Context, user primitive.Account, index *spacedomain.SpaceIndex,
+) (string, error) {
+	action := fmt.Sprintf("cancel queuing space app of %s", index.Owner.Account()+"/"+index.Name.MSDName())
+
+	entry, err := s.getEntry(ctx, user, index)
+	if err != nil {
+		return action, err
+	}
+
+	return action, s.queueAdapter.Delete(entry.Id)
+}
+
+// Dispatch starts the queued space apps in the order of queuing when the quota frees up.
+// The later entries of the queue whose quota is still exhausted wait, and the entries of
+// the space which is deleted or changed expire.
+func (s *spaceAppQueueAppService) Dispatch(ctx context.Context) (QueueDispatchDTO, error) {
+	s.dispatching.Lock()
+	defer s.dispatching.Unlock()
+
+	return s.dispatch(ctx)
+}
+
+// DispatchOnRelease dispatches the queue in background after the quota is released.
+// The releases before the waiting dispatching starts are handled by it together.
+func (s *spaceAppQueueAppService) DispatchOnRelease() {
+	if s.pending.Swap(true) {
+		return
+	}
+
+	go func() {
+		s.dispatching.Lock()
+		defer s.dispatching.Unlock()
+
+		s.pending.Store(false)
+
+		if _, err := s.dispatch(context.Background()); err != nil {
+			logrus.Errorf("queue | dispatch after releasing quota failed, err:%s", err)
+		}
+	}()
+}
+
+func (s *spaceAppQueueAppService) dispatch(ctx context.Context) (QueueDispatchDTO, error) {
+	entries, err := s.queueAdapter.ListAll()
+	if err != nil {
+		return QueueDispatchDTO{}, err
+	}
+
+	dto := QueueDispatchDTO{
+		Started: []string{},
+		Expired: []string{},
+	}
+
+	blocked := map[string]bool{}
+
+	for i := range entries {
+		entry := &entries[i]
+
+		key := entry.QueueKey()
+		if blocked[key] {
+			continue
+		}
+
+		space, err := s.spaceRepo.FindById(entry.SpaceId)
+		if err != nil && !commonrepo.IsErrorResourceNotExists(err) {
+			logrus.Errorf("queue | find space:%s failed, err:%s", entry.SpaceId.Identity(), err)
+
+			continue
+		}
+
+		if err == nil && !entry.IsStale(&space) {
+			index := spacedomain.SpaceIndex{Owner: space.Owner, Name: space.Name}
+
+			err = s.start(ctx, entry.User, &index, entry.Action)
+			if err != nil && allerror.IsInsufficientQuota(err) {
+				blocked[key] = true
+
+				continue
+			}
+		} else {
+			err = xerrors.New("space is deleted or changed")
+		}
+
+		if e := s.queueAdapter.Delete(entry.Id); e != nil {
+			logrus.Errorf("queue | delete entry of space:%s failed, err:%s", entry.SpaceId.Identity(), e)
+
+			continue
+		}
+
+		if err != nil {
+			logrus.Infof("queue | entry of space:%s expired, %s", entry.SpaceId.Identity(), err)
+
+			dto.Expired = append(dto.Expired, entry.SpaceId.Identity())
+		} else {
+			dto.Started = append(dto.Started, entry.SpaceId.Identity())
+		}
+	}
+
+	logrus.Infof("queue | %d space apps are started, %d entries expired", len(dto.Started), len(dto.Expired))
+
+	return dto, nil
+}
+
+// NewQuotaReleaseNotifier wraps the computility service to call the listener after the quota
+// is released, so that the space apps waiting in the queue can take it.
+func NewQuotaReleaseNotifier(c computilityapp.ComputilityInternalAppService) *quotaReleaseNotifier {
+	return &quotaReleaseNotifier{ComputilityInternalAppService: c}
+}
+
+type quotaReleaseNotifier struct {
+	computilityapp.ComputilityInternalAppService
+
+	listener func()
+}
+
+// Listen sets the listener, it must be called before the service is used.
+func (n *quotaReleaseNotifier) Listen(listener func()) {
+	n.listener = listener
+}
+
+// UserQuotaRelease releases the quota and notifies the listener.
+func (n *quotaReleaseNotifier) UserQuotaRelease(cmd computilityapp.CmdToUserQuotaUpdate) error {
+	if err := n.ComputilityInternalAppService.UserQuotaRelease(cmd); err != nil {
+		return err
+	}
+
+	if n.listener != nil {
+		n.listener()
+	}
+
+	return nil
+}
+
+func (s *spaceAppQueueAppService) start(
+	ctx context.Context, user primitive.Account, index *spacedomain.SpaceIndex, action string,
+) error {
+	if action == domain.QueueActionRestart {
+		return s.appService.RestartSpaceApp(ctx, user, index)
+	}
+
+	return s.appService.ResumeSpaceApp(ctx, user, index)
+}
+
+// getEntry returns the entry of the space which the user can update, the stale entry is removed.
+func (s *spaceAppQueueAppService) getEntry(
+	ctx context.Context, user primitive.Account, index *spacedomain.SpaceIndex,
+) (domain.SpaceAppQueueEntry, error) {
+	space, err := s.spaceRepo.FindByName(index)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceNotFound(err)
+		}
+
+		return domain.SpaceAppQueueEntry{}, err
+	}
+
+	if err = s.permission.CanUpdate(ctx, user, &space); err != nil {
+		if allerror.IsNoPermission(err) {
+			err = newSpaceNotFound(err)
+		}
+
+		return domain.SpaceAppQueueEntry{}, err
+	}
+
+	entry, err := s.queueAdapter.FindBySpaceId(ctx, space.Id)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			err = newSpaceAppNotQueued(err)
+		}
+
+		return domain.SpaceAppQueueEntry{}, err
+	}
+
+	if entry.IsStale(&space) {
+		if err := s.queueAdapter.Delete(entry.Id); err != nil {
+			return domain.SpaceAppQueueEntry{}, err
+		}
+
+		return domain.SpaceAppQueueEntry{}, newSpaceAppNotQueued(
+			xerrors.Errorf("space:%s has changed since queued", space.Id.Identity()),
+		)
+	}
+
+	return entry, nil
+}
+
+func (s *spaceAppQueueAppService) toDTO(entry *domain.SpaceAppQueueEntry) (QueueEntryDTO, error) {
+	ahead, err := s.queueAdapter.CountAhead(entry)
+	if err != nil {
+		return QueueEntryDTO{}, err
+	}
+
+	return toQueueEntryDTO(entry, ahead), nil
+}
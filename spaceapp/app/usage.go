@@ -0,0 +1,130 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package app
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	computilityapp "github.com/openmerlin/merlin-server/computility/app"
+	"github.com/openmerlin/merlin-server/spaceapp/domain"
+	"github.com/openmerlin/merlin-server/spaceapp/domain/repository"
+)
+
+// NewMeteredRepository wraps the repository of space app to record the computility usage
+// whenever the app enters or leaves the status which consumes the quota.
+// The failure of metering is logged and doesn't fail the status transition.
+func NewMeteredRepository(
+	repo repository.Repository,
+	spaceRepo spaceRepository,
+	computility computilityapp.ComputilityInternalAppService,
+) repository.Repository {
+	return &meteredRepository{
+		Repository:  repo,
+		spaceRepo:   spaceRepo,
+		computility: computility,
+	}
+}
+
+type meteredRepository struct {
+	repository.Repository
+
+	spaceRepo   spaceRepository
+	computility computilityapp.ComputilityInternalAppService
+}
+
+// Add adds the app and starts the usage if the app consumes the quota.
+func (r *meteredRepository) Add(app *domain.SpaceApp) error {
+	if err := r.Repository.Add(app); err != nil {
+		return err
+	}
+
+	r.meter(app)
+
+	return nil
+}
+
+// Save saves the app and starts or stops the usage according to the status of app.
+func (r *meteredRepository) Save(app *domain.SpaceApp) error {
+	if err := r.Repository.Save(app); err != nil {
+		return err
+	}
+
+	r.meter(app)
+
+	return nil
+}
+
+// SaveWithBuildLog saves the app with build log and starts or stops the usage according to the status of app.
+func (r *meteredRepository) SaveWithBuildLog(app *domain.SpaceApp, log *domain.SpaceAppBuildLog) error {
+	if err := r.Repository.SaveWithBuildLog(app, log); err != nil {
+		return err
+	}
+
+	r.meter(app)
+
+	return nil
+}
+
+// Remove removes the app of space and stops the usage.
+func (r *meteredRepository) Remove(spaceId primitive.Identity) error {
+	if err := r.Repository.Remove(spaceId); err != nil {
+		return err
+	}
+
+	r.stop(spaceId)
+
+	return nil
+}
+
+// DeleteBySpaceId deletes the app of space and stops the usage.
+func (r *meteredRepository) DeleteBySpaceId(spaceId primitive.Identity) error {
+	if err := r.Repository.DeleteBySpaceId(spaceId); err != nil {
+		return err
+	}
+
+	r.stop(spaceId)
+
+	return nil
+}
+
+func (r *meteredRepository) meter(app *domain.SpaceApp) {
+	if !app.CanRenewLease() {
+		r.stop(app.SpaceId)
+
+		return
+	}
+
+	space, err := r.spaceRepo.FindById(app.SpaceId)
+	if err != nil {
+		logrus.Errorf("computility usage | find space:%s failed, %s", app.SpaceId.Identity(), err)
+
+		return
+	}
+
+	if !space.ConsumeComputility() {
+		r.stop(app.SpaceId)
+
+		return
+	}
+
+	cmd := computilityapp.CmdToStartUsage{
+		SpaceId:     space.Id,
+		UserName:    space.CreatedBy,
+		OrgName:     space.Owner,
+		ComputeType: space.GetComputeType(),
+		QuotaCount:  space.GetQuotaCount(),
+	}
+
+	if err := r.computility.StartUsage(&cmd); err != nil {
+		logrus.Errorf("computility usage | start usage of space:%s failed, %s", app.SpaceId.Identity(), err)
+	}
+}
+
+func (r *meteredRepository) stop(spaceId primitive.Identity) {
+	if err := r.computility.StopUsage(spaceId); err != nil {
+		logrus.Errorf("computility usage | stop usage of space:%s failed, %s", spaceId.Identity(), err)
+	}
+}
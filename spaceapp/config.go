@@ -23,6 +23,7 @@ type Config struct {
 func (cfg *Config) ConfigItems() []interface{} {
 	return []interface{}{
 		&cfg.Controller,
+		&cfg.Domain,
 		&cfg.Tables,
 		&cfg.Topics,
 	}
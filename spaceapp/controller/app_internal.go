@@ -21,11 +21,15 @@ import (
 func AddRouteForSpaceappInternalController(
 	r *gin.RouterGroup,
 	s app.SpaceappInternalAppService,
+	mt app.SpaceAppMetricAppService,
+	q app.SpaceAppQueueAppService,
 	m middleware.UserMiddleWare,
 ) {
 
 	ctl := SpaceAppInternalController{
-		appService: s,
+		appService:    s,
+		metricService: mt,
+		queueService:  q,
 	}
 
 	r.POST(`/v1/space-app`, m.Write, ctl.Create)
@@ -37,12 +41,22 @@ func AddRouteForSpaceappInternalController(
 
 	r.POST(`/v1/space-app/pause`, m.Write, ctl.Pause)
 	r.POST(`/v1/space-app/sleep`, m.Write, ctl.Sleep)
+
+	r.POST(`/v1/space-app/metric`, m.Write, ctl.IngestMetric)
+	r.POST(`/v1/space-app/metric/expire`, m.Write, ctl.RemoveExpiredMetrics)
+
+	r.PUT(`/v1/space-app/lease`, m.Write, ctl.RenewLease)
+	r.POST(`/v1/space-app/lease/expire`, m.Write, ctl.ExpireLeases)
+
+	r.POST(`/v1/space-app/queue/dispatch`, m.Write, ctl.DispatchQueue)
 }
 
 // SpaceAppInternalController is a struct that holds the app service
 // and provides methods for handling requests related to space apps.
 type SpaceAppInternalController struct {
-	appService app.SpaceappInternalAppService
+	appService    app.SpaceappInternalAppService
+	metricService app.SpaceAppMetricAppService
+	queueService  app.SpaceAppQueueAppService
 }
 
 // @Summary  Create
@@ -289,3 +303,110 @@ func (ctl *SpaceAppInternalController) Sleep(ctx *gin.Context) {
 		commonctl.SendRespOfPost(ctx, "successfully")
 	}
 }
+
+// @Summary  IngestMetric
+// @Description  ingest the runtime metric of space app which is reported periodically
+// @Tags     SpaceApp
+// @Param    body  body  reqToIngestMetric  true  "body"
+// @Accept   json
+// @Success  201   {object}  commonctl.ResponseData{data=nil,msg=string,code=string}
+// @Security Internal
+// @Router   /v1/space-app/metric [post]
+func (ctl *SpaceAppInternalController) IngestMetric(ctx *gin.Context) {
+	req := reqToIngestMetric{}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	cmd, err := req.toCmd()
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if err := ctl.metricService.Ingest(ctx.Request.Context(), &cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, nil)
+	}
+}
+
+// @Summary  RemoveExpiredMetrics
+// @Description  remove the runtime metrics of space app which are beyond the retention
+// @Tags     SpaceApp
+// @Accept   json
+// @Success  201   {object}  commonctl.ResponseData{data=nil,msg=string,code=string}
+// @Security Internal
+// @Router   /v1/space-app/metric/expire [post]
+func (ctl *SpaceAppInternalController) RemoveExpiredMetrics(ctx *gin.Context) {
+	if err := ctl.metricService.RemoveExpired(ctx.Request.Context()); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, nil)
+	}
+}
+
+// @Summary  RenewLease
+// @Description  renew the lease of computility quota consumed by the running space app
+// @Tags     SpaceApp
+// @Param    body  body  reqToCreateSpaceApp  true  "body"
+// @Accept   json
+// @Success  202   {object}  commonctl.ResponseData{data=nil,msg=string,code=string}
+// @Security Internal
+// @Router   /v1/space-app/lease [put]
+func (ctl *SpaceAppInternalController) RenewLease(ctx *gin.Context) {
+	req := reqToCreateSpaceApp{}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	cmd, err := req.toCmd()
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if err := ctl.appService.RenewLease(ctx.Request.Context(), &cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPut(ctx, nil)
+	}
+}
+
+// @Summary  ExpireLeases
+// @Description  force pause the space apps whose lease of computility quota is expired
+// @Tags     SpaceApp
+// @Accept   json
+// @Success  201   {object}  commonctl.ResponseData{data=[]string,msg=string,code=string}
+// @Security Internal
+// @Router   /v1/space-app/lease/expire [post]
+func (ctl *SpaceAppInternalController) ExpireLeases(ctx *gin.Context) {
+	if v, err := ctl.appService.ExpireLeases(ctx.Request.Context()); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, v)
+	}
+}
+
+// @Summary  DispatchQueue
+// @Description  start the space apps waiting in the queue when the computility quota frees up
+// @Tags     SpaceApp
+// @Accept   json
+// @Success  201   {object}  commonctl.ResponseData{data=app.QueueDispatchDTO,msg=string,code=string}
+// @Security Internal
+// @Router   /v1/space-app/queue/dispatch [post]
+func (ctl *SpaceAppInternalController) DispatchQueue(ctx *gin.Context) {
+	if v, err := ctl.queueService.Dispatch(ctx.Request.Context()); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, &v)
+	}
+}
@@ -23,6 +23,10 @@ import (
 func AddRouterForSpaceappWebController(
 	r *gin.RouterGroup,
 	s app.SpaceappAppService,
+	e app.SpaceEmbedTokenAppService,
+	mt app.SpaceAppMetricAppService,
+	q app.SpaceAppQueueAppService,
+	p app.SpaceAppPreemptionAppService,
 	m middleware.UserMiddleWare,
 	t middleware.TokenMiddleWare,
 	l middleware.RateLimiter,
@@ -34,20 +38,34 @@ func AddRouterForSpaceappWebController(
 			tokenMiddleWare:     t,
 			rateLimitMiddleWare: l,
 		},
+		embedTokenService: e,
+		metricService:     mt,
+		queueService:      q,
+		preemptionService: p,
 	}
 
 	addRouterForSpaceappController(r, &ctl.SpaceAppController, m, l)
 
+	addRouterForEmbedTokenController(r, &ctl, m, l)
+
+	addRouterForQueueController(r, &ctl, m, l)
+
 	r.GET("/v1/space-app/:owner/:name", m.Optional, l.CheckLimit, ctl.Get)
 	r.GET("/v1/space-app/:owner/:name/buildlog/realtime", m.Read, l.CheckLimit, ctl.GetRealTimeBuildLog)
 	r.GET("/v1/space-app/:owner/:name/spacelog/realtime", m.Read, l.CheckLimit, ctl.GetRealTimeSpaceLog)
-	r.GET("/v1/space-app/:owner/:name/read", t.CheckSession, l.CheckLimit, ctl.CanRead)
+	r.GET("/v1/space-app/:owner/:name/read", checkSessionOrEmbedToken(t), l.CheckLimit, ctl.CanRead)
 	r.GET("/v1/space-app/:owner/:name/buildlog/complete", m.Read, l.CheckLimit, ctl.GetBuildLogs)
+	r.GET("/v1/space-app/:owner/:name/metric", m.Read, l.CheckLimit, ctl.GetMetrics)
 }
 
 // SpaceAppWebController is a struct that represents the web controller for the space app.
 type SpaceAppWebController struct {
 	SpaceAppController
+
+	embedTokenService app.SpaceEmbedTokenAppService
+	metricService     app.SpaceAppMetricAppService
+	queueService      app.SpaceAppQueueAppService
+	preemptionService app.SpaceAppPreemptionAppService
 }
 
 // @Summary  Get
@@ -233,6 +251,7 @@ func (ctl *SpaceAppWebController) GetRealTimeSpaceLog(ctx *gin.Context) {
 // @Tags     SpaceAppWeb
 // @Param    owner  path  string  true  "owner of space" MaxLength(40)
 // @Param    name   path  string  true  "name of space" MaxLength(100)
+// @Param    embed_token  query  string  false  "embed token used in place of the session"
 // @Accept   json
 // @Success  200  {object}  commonctl.ResponseData
 // @x-example {"data": "successfully"}
@@ -243,11 +262,44 @@ func (ctl *SpaceAppWebController) CanRead(ctx *gin.Context) {
 		return
 	}
 
-	user := ctl.userMiddleWare.GetUser(ctx)
+	cmd := toCmdToCheckPermissionRead(ctx, ctl.userMiddleWare.GetUser(ctx))
+	cmd.Index = index
 
-	if err := ctl.appService.CheckPermissionRead(ctx.Request.Context(), user, &index); err != nil {
+	if err := ctl.appService.CheckPermissionRead(ctx.Request.Context(), &cmd); err != nil {
 		commonctl.SendError(ctx, err)
 	} else {
 		commonctl.SendRespOfGet(ctx, "successfully")
 	}
 }
+
+// @Summary  GetMetrics
+// @Description  get the runtime metrics of space app in the window which ends at now
+// @Tags     SpaceAppWeb
+// @Param    owner   path   string  true   "owner of space" MaxLength(40)
+// @Param    name    path   string  true   "name of space" MaxLength(100)
+// @Param    window  query  int     false  "length of the window in seconds, default is one hour"
+// @Accept   json
+// @Success  200  {object}  commonctl.ResponseData{data=app.MetricsDTO,msg=string,code=string}
+// @Router   /v1/space-app/{owner}/{name}/metric [get]
+func (ctl *SpaceAppWebController) GetMetrics(ctx *gin.Context) {
+	index, err := ctl.parseIndex(ctx)
+	if err != nil {
+		return
+	}
+
+	req := reqToGetMetrics{}
+	if err := ctx.BindQuery(&req); err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	cmd := req.toCmd()
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	if dto, err := ctl.metricService.Get(ctx.Request.Context(), user, &index, &cmd); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, &dto)
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package controller
+
+import (
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+
+	commonctl "github.com/openmerlin/merlin-server/common/controller"
+	"github.com/openmerlin/merlin-server/common/controller/middleware"
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/spaceapp/app"
+)
+
+const (
+	embedTokenHeader = "Embed-Token" // #nosec G101
+	embedTokenQuery  = "embed_token" // #nosec G101
+	headerOrigin     = "Origin"
+	headerReferer    = "Referer"
+)
+
+func addRouterForEmbedTokenController(
+	r *gin.RouterGroup,
+	ctl *SpaceAppWebController,
+	m middleware.UserMiddleWare,
+	l middleware.RateLimiter,
+) {
+	r.POST("/v1/space-app/:owner/:name/embed-token", m.Write, l.CheckLimit, ctl.CreateEmbedToken)
+	r.GET("/v1/space-app/:owner/:name/embed-token", m.Read, l.CheckLimit, ctl.ListEmbedTokens)
+	r.DELETE("/v1/space-app/:owner/:name/embed-token/:id", m.Write, l.CheckLimit, ctl.RevokeEmbedToken)
+	r.GET("/v1/space-app/:owner/:name/embed-token/:id/usage", m.Read, l.CheckLimit, ctl.ListEmbedTokenUsage)
+}
+
+// getEmbedToken returns the embed token from the header or the query.
+func getEmbedToken(ctx *gin.Context) string {
+	if v := ctx.GetHeader(embedTokenHeader); v != "" {
+		return v
+	}
+
+	return ctx.Query(embedTokenQuery)
+}
+
+// getOrigin returns the origin of request, it falls back to the origin of referer.
+func getOrigin(ctx *gin.Context) string {
+	if v := ctx.GetHeader(headerOrigin); v != "" {
+		return v
+	}
+
+	u, err := url.Parse(ctx.GetHeader(headerReferer))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return ""
+	}
+
+	return u.Scheme + "://" + u.Host
+}
+
+// checkSessionOrEmbedToken checks the session only when the embed token is absent.
+func checkSessionOrEmbedToken(t middleware.TokenMiddleWare) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if getEmbedToken(ctx) != "" {
+			ctx.Next()
+		} else {
+			t.CheckSession(ctx)
+		}
+	}
+}
+
+// @Summary  CreateEmbedToken
+// @Description  create embed token of space app
+// @Tags     SpaceAppWeb
+// @Param    owner  path  string                 true  "owner of space" MaxLength(40)
+// @Param    name   path  string                 true  "name of space" MaxLength(100)
+// @Param    body   body  reqToCreateEmbedToken  true  "body of creating embed token"
+// @Accept   json
+// @Success  201  {object}  commonctl.ResponseData{data=app.EmbedTokenCreatedDTO,msg=string,code=string}
+// @Router   /v1/space-app/{owner}/{name}/embed-token [post]
+func (ctl *SpaceAppWebController) CreateEmbedToken(ctx *gin.Context) {
+	index, err := ctl.parseIndex(ctx)
+	if err != nil {
+		return
+	}
+
+	req := reqToCreateEmbedToken{}
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	cmd, err := req.toCmd()
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	dto, action, err := ctl.embedTokenService.Create(ctx.Request.Context(), user, &index, &cmd)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, &dto)
+	}
+}
+
+// @Summary  ListEmbedTokens
+// @Description  list embed tokens of space app
+// @Tags     SpaceAppWeb
+// @Param    owner  path  string  true  "owner of space" MaxLength(40)
+// @Param    name   path  string  true  "name of space" MaxLength(100)
+// @Accept   json
+// @Success  200  {object}  commonctl.ResponseData{data=[]app.EmbedTokenDTO,msg=string,code=string}
+// @Router   /v1/space-app/{owner}/{name}/embed-token [get]
+func (ctl *SpaceAppWebController) ListEmbedTokens(ctx *gin.Context) {
+	index, err := ctl.parseIndex(ctx)
+	if err != nil {
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	if dtos, err := ctl.embedTokenService.List(ctx.Request.Context(), user, &index); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, dtos)
+	}
+}
+
+// @Summary  RevokeEmbedToken
+// @Description  revoke embed token of space app
+// @Tags     SpaceAppWeb
+// @Param    owner  path  string  true  "owner of space" MaxLength(40)
+// @Param    name   path  string  true  "name of space" MaxLength(100)
+// @Param    id     path  string  true  "id of embed token" MaxLength(20)
+// @Accept   json
+// @Success  204
+// @Router   /v1/space-app/{owner}/{name}/embed-token/{id} [delete]
+func (ctl *SpaceAppWebController) RevokeEmbedToken(ctx *gin.Context) {
+	index, err := ctl.parseIndex(ctx)
+	if err != nil {
+		return
+	}
+
+	tokenId, err := primitive.NewIdentity(ctx.Param("id"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	action, err := ctl.embedTokenService.Revoke(ctx.Request.Context(), user, &index, tokenId)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfDelete(ctx)
+	}
+}
+
+// @Summary  ListEmbedTokenUsage
+// @Description  list the latest uses of embed token
+// @Tags     SpaceAppWeb
+// @Param    owner  path  string  true  "owner of space" MaxLength(40)
+// @Param    name   path  string  true  "name of space" MaxLength(100)
+// @Param    id     path  string  true  "id of embed token" MaxLength(20)
+// @Accept   json
+// @Success  200  {object}  commonctl.ResponseData{data=[]app.EmbedTokenUsageDTO,msg=string,code=string}
+// @Router   /v1/space-app/{owner}/{name}/embed-token/{id}/usage [get]
+func (ctl *SpaceAppWebController) ListEmbedTokenUsage(ctx *gin.Context) {
+	index, err := ctl.parseIndex(ctx)
+	if err != nil {
+		return
+	}
+
+	tokenId, err := primitive.NewIdentity(ctx.Param("id"))
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	if dtos, err := ctl.embedTokenService.ListUsage(ctx.Request.Context(), user, &index, tokenId); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, dtos)
+	}
+}
+
+func toCmdToCheckPermissionRead(ctx *gin.Context, user primitive.Account) app.CmdToCheckPermissionRead {
+	cmd := app.CmdToCheckPermissionRead{
+		User:       user,
+		EmbedToken: getEmbedToken(ctx),
+	}
+
+	if cmd.EmbedToken != "" {
+		cmd.Origin = getOrigin(ctx)
+		cmd.IP, _ = commonctl.GetIp(ctx)
+	}
+
+	return cmd
+}
@@ -0,0 +1,149 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	commonctl "github.com/openmerlin/merlin-server/common/controller"
+	"github.com/openmerlin/merlin-server/common/controller/middleware"
+)
+
+func addRouterForQueueController(
+	r *gin.RouterGroup,
+	ctl *SpaceAppWebController,
+	m middleware.UserMiddleWare,
+	l middleware.RateLimiter,
+) {
+	r.POST("/v1/space-app/:owner/:name/queue", m.Write, l.CheckLimit, ctl.Enqueue)
+	r.GET("/v1/space-app/:owner/:name/queue", m.Read, l.CheckLimit, ctl.GetQueueEntry)
+	r.DELETE("/v1/space-app/:owner/:name/queue", m.Write, l.CheckLimit, ctl.CancelQueueEntry)
+	r.POST("/v1/space-app/:owner/:name/preempt", m.Write, l.CheckLimit, ctl.Preempt)
+}
+
+// @Summary  Enqueue
+// @Description  resume or restart space app, it waits in the queue if the computility quota is exhausted
+// @Tags     SpaceAppWeb
+// @Param    owner  path  string        true  "owner of space" MaxLength(40)
+// @Param    name   path  string        true  "name of space" MaxLength(100)
+// @Param    body   body  reqToEnqueue  true  "body of queuing space app"
+// @Accept   json
+// @Success  201  {object}  commonctl.ResponseData{data=app.QueueEntryDTO,msg=string,code=string}
+// @Router   /v1/space-app/{owner}/{name}/queue [post]
+func (ctl *SpaceAppWebController) Enqueue(ctx *gin.Context) {
+	index, err := ctl.parseIndex(ctx)
+	if err != nil {
+		return
+	}
+
+	req := reqToEnqueue{}
+	if err := ctx.BindJSON(&req); err != nil {
+		commonctl.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	action, err := req.toAction()
+	if err != nil {
+		commonctl.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUserAndExitIfFailed(ctx)
+	if user == nil {
+		return
+	}
+
+	dto, op, err := ctl.queueService.Enqueue(ctx.Request.Context(), user, &index, action)
+
+	middleware.SetAction(ctx, op)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, &dto)
+	}
+}
+
+// @Summary  GetQueueEntry
+// @Description  get the position of space app in the queue of computility quota
+// @Tags     SpaceAppWeb
+// @Param    owner  path  string  true  "owner of space" MaxLength(40)
+// @Param    name   path  string  true  "name of space" MaxLength(100)
+// @Accept   json
+// @Success  200  {object}  commonctl.ResponseData{data=app.QueueEntryDTO,msg=string,code=string}
+// @Router   /v1/space-app/{owner}/{name}/queue [get]
+func (ctl *SpaceAppWebController) GetQueueEntry(ctx *gin.Context) {
+	index, err := ctl.parseIndex(ctx)
+	if err != nil {
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	if dto, err := ctl.queueService.Get(ctx.Request.Context(), user, &index); err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfGet(ctx, &dto)
+	}
+}
+
+// @Summary  CancelQueueEntry
+// @Description  remove space app from the queue of computility quota
+// @Tags     SpaceAppWeb
+// @Param    owner  path  string  true  "owner of space" MaxLength(40)
+// @Param    name   path  string  true  "name of space" MaxLength(100)
+// @Accept   json
+// @Success  204
+// @Router   /v1/space-app/{owner}/{name}/queue [delete]
+func (ctl *SpaceAppWebController) CancelQueueEntry(ctx *gin.Context) {
+	index, err := ctl.parseIndex(ctx)
+	if err != nil {
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUser(ctx)
+
+	action, err := ctl.queueService.Cancel(ctx.Request.Context(), user, &index)
+
+	middleware.SetAction(ctx, action)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfDelete(ctx)
+	}
+}
+
+// @Summary  Preempt
+// @Description  resume space app of high priority, the apps of lower priority are paused if the quota is exhausted
+// @Tags     SpaceAppWeb
+// @Param    owner  path  string  true  "owner of space" MaxLength(40)
+// @Param    name   path  string  true  "name of space" MaxLength(100)
+// @Accept   json
+// @Success  201  {object}  commonctl.ResponseData{data=app.PreemptionDTO,msg=string,code=string}
+// @Router   /v1/space-app/{owner}/{name}/preempt [post]
+func (ctl *SpaceAppWebController) Preempt(ctx *gin.Context) {
+	index, err := ctl.parseIndex(ctx)
+	if err != nil {
+		return
+	}
+
+	user := ctl.userMiddleWare.GetUserAndExitIfFailed(ctx)
+	if user == nil {
+		return
+	}
+
+	dto, op, err := ctl.preemptionService.Preempt(ctx.Request.Context(), user, &index)
+
+	middleware.SetAction(ctx, op)
+
+	if err != nil {
+		commonctl.SendError(ctx, err)
+	} else {
+		commonctl.SendRespOfPost(ctx, &dto)
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package controller
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/openmerlin/merlin-server/spaceapp/app"
+	"github.com/openmerlin/merlin-server/spaceapp/domain"
+	appprimitive "github.com/openmerlin/merlin-server/spaceapp/domain/primitive"
+)
+
+const (
+	maxEmbedTokenNameLength = 50
+	defaultMetricWindow     = 60 * 60
+)
+
+// reqToCreateEmbedToken
+type reqToCreateEmbedToken struct {
+	Name      string   `json:"name"       required:"true"`
+	Scope     string   `json:"scope"`
+	Origins   []string `json:"origins"    required:"true"`
+	ExpiredAt int64    `json:"expired_at" required:"true"`
+}
+
+func (req *reqToCreateEmbedToken) toCmd() (cmd app.CmdToCreateEmbedToken, err error) {
+	if req.Name == "" || len(req.Name) > maxEmbedTokenNameLength {
+		err = xerrors.Errorf("invalid name length, should between 1 and %d", maxEmbedTokenNameLength)
+
+		return
+	}
+
+	if cmd.Scope, err = appprimitive.NewEmbedScope(req.Scope); err != nil {
+		return
+	}
+
+	cmd.Origins = make([]appprimitive.EmbedOrigin, len(req.Origins))
+	for i := range req.Origins {
+		if cmd.Origins[i], err = appprimitive.NewEmbedOrigin(req.Origins[i]); err != nil {
+			return
+		}
+	}
+
+	cmd.Name = req.Name
+	cmd.ExpiredAt = req.ExpiredAt
+
+	return
+}
+
+// reqToGetMetrics
+type reqToGetMetrics struct {
+	Window int64 `form:"window"`
+}
+
+func (req *reqToGetMetrics) toCmd() app.CmdToGetMetrics {
+	if req.Window == 0 {
+		req.Window = defaultMetricWindow
+	}
+
+	return app.CmdToGetMetrics{Window: req.Window}
+}
+
+// reqToEnqueue
+type reqToEnqueue struct {
+	Action string `json:"action" required:"true"`
+}
+
+func (req *reqToEnqueue) toAction() (string, error) {
+	return domain.NewQueueAction(req.Action)
+}
@@ -134,4 +134,29 @@ func (req *reqToSleepSpaceApp) toCmd() (cmd app.CmdToSleepSpaceApp, err error) {
 	cmd.CommitId = req.CommitId
 
 	return
-}
\ No newline at end of file
+}
+
+// reqToIngestMetric
+type reqToIngestMetric struct {
+	reqToCreateSpaceApp
+
+	Timestamp    int64   `json:"timestamp"`
+	CPUUsage     float64 `json:"cpu_usage"`
+	MemoryUsage  float64 `json:"memory_usage"`
+	NPUUsage     float64 `json:"npu_usage"`
+	RequestCount int64   `json:"request_count"`
+}
+
+func (req *reqToIngestMetric) toCmd() (cmd app.CmdToIngestMetric, err error) {
+	if cmd.SpaceAppIndex, err = req.reqToCreateSpaceApp.toCmd(); err != nil {
+		return
+	}
+
+	cmd.Timestamp = req.Timestamp
+	cmd.CPUUsage = req.CPUUsage
+	cmd.MemoryUsage = req.MemoryUsage
+	cmd.NPUUsage = req.NPUUsage
+	cmd.RequestCount = req.RequestCount
+
+	return
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/openmerlin/merlin-server/common/domain/allerror"
 	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
 	appprimitive "github.com/openmerlin/merlin-server/spaceapp/domain/primitive"
 	"github.com/openmerlin/merlin-server/utils"
 )
@@ -26,6 +27,10 @@ type SpaceApp struct {
 
 	SpaceAppIndex
 
+	// SDK decides the lifecycle of app, the static app has no building and runtime.
+	SDK    spaceprimitive.SDK
+	Docker spaceprimitive.DockerOption
+
 	Status appprimitive.AppStatus
 	Reason string
 
@@ -39,8 +44,35 @@ type SpaceApp struct {
 	Version int
 }
 
+// NewSpaceApp creates a space app of the sdk with the status.
+func NewSpaceApp(
+	index SpaceAppIndex, sdk spaceprimitive.SDK, docker spaceprimitive.DockerOption, status appprimitive.AppStatus,
+) SpaceApp {
+	return SpaceApp{
+		SpaceAppIndex: index,
+		SDK:           sdk,
+		Docker:        docker,
+		Status:        status,
+	}
+}
+
+// IsStatic returns true if the app serves static files without runtime.
+func (app *SpaceApp) IsStatic() bool {
+	return app.SDK != nil && app.SDK.IsStatic()
+}
+
+// IsDocker returns true if the app is built from the Dockerfile.
+func (app *SpaceApp) IsDocker() bool {
+	return app.SDK != nil && app.SDK.IsDocker()
+}
+
 // StartBuilding starts the building process for the space app and sets the build log URL.
 func (app *SpaceApp) StartBuilding(logURL primitive.URL) error {
+	if app.IsStatic() {
+		e := fmt.Errorf("spaceId:%s, static app has no building", app.SpaceId.Identity())
+		return allerror.New(allerror.ErrorCodeSpaceAppUnmatchedStatus, e.Error(), e)
+	}
+
 	if !app.Status.IsInit() {
 		e := fmt.Errorf("old status is %s, can not set", app.Status.AppStatus())
 		return allerror.New(allerror.ErrorCodeSpaceAppUnmatchedStatus, e.Error(), e)
@@ -65,8 +97,15 @@ func (app *SpaceApp) SetBuildFailed(status appprimitive.AppStatus, reason string
 	return nil
 }
 
-// SetStarting sets the starting status of the space app based on the success parameter.
+// SetStarting sets the starting status of the space app based on the success parameter,
+// the static app starts from init directly because it has no building.
 func (app *SpaceApp) SetStarting() error {
+	if app.IsStatic() && app.Status.IsInit() {
+		app.Status = appprimitive.AppStatusServeStarting
+
+		return nil
+	}
+
 	if !app.Status.IsBuilding() {
 		e := fmt.Errorf("old status is %s, can not set", app.Status.AppStatus())
 		return allerror.New(allerror.ErrorCodeSpaceAppUnmatchedStatus, e.Error(), e)
@@ -204,8 +243,14 @@ func (app *SpaceApp) ResumeService() error {
 	return allerror.New(allerror.ErrorCodeSpaceAppUnmatchedStatus, e.Error(), e)
 }
 
-// SleepService sleep the service for space app
+// SleepService sleep the service for space app, the static app never sleeps
+// because it has no runtime to be released.
 func (app *SpaceApp) SleepService() error {
+	if app.IsStatic() {
+		e := fmt.Errorf("spaceId:%s, static app can not sleep", app.SpaceId.Identity())
+		return allerror.New(allerror.ErrorCodeSpaceAppUnmatchedStatus, e.Error(), e)
+	}
+
 	if !app.Status.IsServing() {
 		e := fmt.Errorf("spaceId:%s, not serving", app.SpaceId.Identity())
 		return allerror.New(allerror.ErrorCodeSpaceAppUnmatchedStatus, e.Error(), e)
@@ -237,6 +282,14 @@ func (app *SpaceApp) IsAppNotAllowToInit() bool {
 	return false
 }
 
+// CanRenewLease checks if the app is running or being brought up, only such app
+// can renew the lease of computility quota.
+func (app *SpaceApp) CanRenewLease() bool {
+	s := app.Status
+
+	return s.IsInit() || s.IsBuilding() || s.IsStarting() || s.IsServing() || s.IsRestarting() || s.IsResuming()
+}
+
 // GetFailedReason app only return failed reason
 func (app *SpaceApp) GetFailedReason() string {
 	if !app.Status.IsUpdateStatusAccept() {
@@ -0,0 +1,51 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+	appprimitive "github.com/openmerlin/merlin-server/spaceapp/domain/primitive"
+)
+
+// TestStaticSpaceAppLifecycle tests that the static app starts without building and never sleeps.
+func TestStaticSpaceAppLifecycle(t *testing.T) {
+	index := SpaceAppIndex{SpaceId: primitive.CreateIdentity(1), CommitId: "c1"}
+
+	app := NewSpaceApp(index, spaceprimitive.StaticSdk, nil, appprimitive.AppStatusInit)
+
+	if err := app.StartBuilding(nil); err == nil {
+		t.Fatal("static app should not be built")
+	}
+
+	if err := app.SetStarting(); err != nil || !app.Status.IsStarting() {
+		t.Fatalf("static app should start from init, err: %v", err)
+	}
+
+	app.Status = appprimitive.AppStatusServing
+	if err := app.SleepService(); err == nil {
+		t.Fatal("static app should not sleep")
+	}
+
+	gradio := NewSpaceApp(index, spaceprimitive.GradioSdk, nil, appprimitive.AppStatusInit)
+	if err := gradio.SetStarting(); err == nil {
+		t.Fatal("gradio app should be built before starting")
+	}
+}
+
+// TestDockerSpaceAppCreatedEvent tests that the docker option of app is carried by the created event.
+func TestDockerSpaceAppCreatedEvent(t *testing.T) {
+	index := SpaceAppIndex{SpaceId: primitive.CreateIdentity(1), CommitId: "c1"}
+	docker := spaceprimitive.CreateDockerOption(8080, 1000)
+
+	app := NewSpaceApp(index, spaceprimitive.DockerSdk, docker, appprimitive.AppStatusInit)
+
+	e := NewSpaceAppCreatedEvent(&app)
+	if e.SDK != "docker" || e.AppPort != 8080 || e.RunAsUser != 1000 {
+		t.Fatalf("unexpected event: %v", e)
+	}
+}
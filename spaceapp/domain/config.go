@@ -9,6 +9,7 @@ package domain
 const (
 	overRestartTimePeriod = 60 * 60 * 2
 	overResumeTimePeriod  = 60 * 60 * 2
+	embedTokenMaxExpiry   = 60 * 60 * 24 * 30
 )
 
 // Init initializes the configuration with the given Config struct.
@@ -23,6 +24,9 @@ func Init(cfg *Config) {
 type Config struct {
 	RestartOverTime int64 `json:"restart_over_time"`
 	ResumeOverTime  int64 `json:"resume_over_time"`
+
+	// EmbedTokenMaxExpiry is the max lifetime of the embed token in seconds.
+	EmbedTokenMaxExpiry int64 `json:"embed_token_max_expiry"`
 }
 
 // SetDefault sets the default values for the Config struct.
@@ -33,4 +37,7 @@ func (cfg *Config) SetDefault() {
 	if cfg.ResumeOverTime <= 0 {
 		cfg.ResumeOverTime = overResumeTimePeriod
 	}
+	if cfg.EmbedTokenMaxExpiry <= 0 {
+		cfg.EmbedTokenMaxExpiry = embedTokenMaxExpiry
+	}
 }
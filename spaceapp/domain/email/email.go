@@ -0,0 +1,13 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+// Package email provides functionality for sending emails.
+package email
+
+// Email is an interface for notifying the owners of space apps by email.
+type Email interface {
+	// SendPreemptionNotice notifies the receiver that the app of space is paused
+	// to free the quota for the space of higher priority.
+	SendPreemptionNotice(receiver []string, space, preemptedBy string) error
+}
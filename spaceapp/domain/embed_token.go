@@ -0,0 +1,198 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/xerrors"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	appprimitive "github.com/openmerlin/merlin-server/spaceapp/domain/primitive"
+)
+
+const (
+	embedTokenPrefix    = "mse_"
+	embedTokenLength    = 32
+	embedTokenIter      = 10000
+	embedTokenKeyLen    = 32
+	embedTokenSuffixLen = 8
+
+	embedTokenRevoked       = "embed token revoked"
+	embedTokenExpired       = "embed token expired"
+	embedTokenOriginDenied  = "embed token origin denied"
+	embedTokenScopeDenied   = "embed token scope denied"
+	embedTokenSpaceMismatch = "embed token space mismatch"
+)
+
+// SpaceEmbedToken is a token which allows a private space app to be embedded in the allowed origins.
+type SpaceEmbedToken struct {
+	Id        primitive.Identity
+	SpaceId   primitive.Identity
+	Name      string
+	Scope     appprimitive.EmbedScope
+	Origins   []appprimitive.EmbedOrigin
+	Token     string
+	Salt      string
+	LastEight string
+	CreatedBy primitive.Account
+	CreatedAt int64
+	ExpiredAt int64
+	RevokedAt int64
+	Version   int
+}
+
+// NewSpaceEmbedToken creates an embed token and returns it with the plain token which is only visible once.
+func NewSpaceEmbedToken(t *SpaceEmbedToken, now int64) (plain string, err error) {
+	if t.ExpiredAt <= now {
+		return "", xerrors.New("expiry must be later than now")
+	}
+
+	if t.ExpiredAt-now > config.EmbedTokenMaxExpiry {
+		return "", xerrors.Errorf("expiry can't exceed %d seconds", config.EmbedTokenMaxExpiry)
+	}
+
+	if len(t.Origins) == 0 {
+		return "", xerrors.New("missing allowed origins")
+	}
+
+	b := make([]byte, embedTokenLength)
+	if _, err = rand.Read(b); err != nil {
+		return
+	}
+
+	plain = embedTokenPrefix + hex.EncodeToString(b)
+
+	salt := make([]byte, embedTokenKeyLen)
+	if _, err = rand.Read(salt); err != nil {
+		return
+	}
+
+	t.Salt = base64.RawStdEncoding.EncodeToString(salt)
+	t.Token = base64.RawStdEncoding.EncodeToString(
+		pbkdf2.Key([]byte(plain), salt, embedTokenIter, embedTokenKeyLen, sha256.New),
+	)
+	t.LastEight = EmbedTokenLastEight(plain)
+	t.CreatedAt = now
+
+	return
+}
+
+// EmbedTokenLastEight returns the last eight characters which is used to look up the token.
+func EmbedTokenLastEight(token string) string {
+	if len(token) < embedTokenSuffixLen {
+		return token
+	}
+
+	return token[len(token)-embedTokenSuffixLen:]
+}
+
+// IsEmbedToken checks whether the value looks like an embed token.
+func IsEmbedToken(v string) bool {
+	return strings.HasPrefix(v, embedTokenPrefix) && len(v) > len(embedTokenPrefix)+embedTokenSuffixLen
+}
+
+// IsRevoked checks if the token has been revoked.
+func (t *SpaceEmbedToken) IsRevoked() bool {
+	return t.RevokedAt > 0
+}
+
+// IsExpired checks if the token is expired.
+func (t *SpaceEmbedToken) IsExpired(now int64) bool {
+	return t.ExpiredAt <= now
+}
+
+// Revoke revokes the token.
+func (t *SpaceEmbedToken) Revoke(now int64) {
+	if !t.IsRevoked() {
+		t.RevokedAt = now
+	}
+}
+
+// Match checks if the given plain token matches the stored token.
+func (t *SpaceEmbedToken) Match(token string) bool {
+	salt, err := base64.RawStdEncoding.DecodeString(t.Salt)
+	if err != nil {
+		return false
+	}
+
+	src, err := base64.RawStdEncoding.DecodeString(t.Token)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(src, pbkdf2.Key([]byte(token), salt, embedTokenIter, embedTokenKeyLen, sha256.New))
+}
+
+// AllowOrigin checks if the origin is in the allowed origin list.
+func (t *SpaceEmbedToken) AllowOrigin(origin string) bool {
+	origin = strings.ToLower(strings.TrimSuffix(origin, "/"))
+
+	for _, v := range t.Origins {
+		if v.EmbedOrigin() == origin {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckAccess checks if the matched token can be used to access the space from the origin with the scope.
+func (t *SpaceEmbedToken) CheckAccess(
+	spaceId primitive.Identity, origin string, scope appprimitive.EmbedScope, now int64,
+) error {
+	if t.SpaceId.Identity() != spaceId.Identity() {
+		return xerrors.New(embedTokenSpaceMismatch)
+	}
+
+	if t.IsRevoked() {
+		return xerrors.New(embedTokenRevoked)
+	}
+
+	if t.IsExpired(now) {
+		return xerrors.New(embedTokenExpired)
+	}
+
+	if t.Scope.EmbedScope() != scope.EmbedScope() {
+		return xerrors.New(embedTokenScopeDenied)
+	}
+
+	if !t.AllowOrigin(origin) {
+		return xerrors.New(embedTokenOriginDenied)
+	}
+
+	return nil
+}
+
+// SpaceEmbedTokenUsage counts the uses of an embed token in a UTC day,
+// Origin, IP and UsedAt are of the latest use in that day.
+type SpaceEmbedTokenUsage struct {
+	TokenId primitive.Identity
+	SpaceId primitive.Identity
+	Day     int64
+	Count   int64
+	Origin  string
+	IP      string
+	UsedAt  int64
+}
+
+// NewSpaceEmbedTokenUsage creates the usage of a single use of the embed token at now.
+func NewSpaceEmbedTokenUsage(t *SpaceEmbedToken, origin, ip string, now int64) SpaceEmbedTokenUsage {
+	return SpaceEmbedTokenUsage{
+		TokenId: t.Id,
+		SpaceId: t.SpaceId,
+		Day:     now - now%secondsOfDay,
+		Count:   1,
+		Origin:  origin,
+		IP:      ip,
+		UsedAt:  now,
+	}
+}
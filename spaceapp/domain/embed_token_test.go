@@ -0,0 +1,89 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	appprimitive "github.com/openmerlin/merlin-server/spaceapp/domain/primitive"
+)
+
+// TestSpaceEmbedTokenVerify tests that the embed token is accepted only if it matches,
+// is not expired and is used for the space it is created for.
+func TestSpaceEmbedTokenVerify(t *testing.T) {
+	Init(&Config{EmbedTokenMaxExpiry: 3600})
+
+	now := int64(1700000000)
+	origin := "https://app.example.com"
+	spaceId := primitive.CreateIdentity(1)
+
+	token := SpaceEmbedToken{
+		SpaceId:   spaceId,
+		Scope:     appprimitive.EmbedScopeRead,
+		Origins:   []appprimitive.EmbedOrigin{appprimitive.CreateEmbedOrigin(origin)},
+		ExpiredAt: now + 60,
+	}
+
+	plain, err := NewSpaceEmbedToken(&token, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !IsEmbedToken(plain) || token.LastEight != EmbedTokenLastEight(plain) {
+		t.Fatalf("unexpected token: %s", plain)
+	}
+
+	if !token.Match(plain) {
+		t.Fatal("the plain token should match")
+	}
+
+	if err := token.CheckAccess(spaceId, origin+"/", appprimitive.EmbedScopeRead, now); err != nil {
+		t.Fatalf("the valid token should be accepted, %v", err)
+	}
+
+	// tamper with a character which is not in the last eight used to look up the token
+	i, c := len(embedTokenPrefix), "0"
+	if plain[i] == '0' {
+		c = "1"
+	}
+
+	tampered := plain[:i] + c + plain[i+1:]
+
+	if token.Match(tampered) {
+		t.Fatal("the tampered token should not match")
+	}
+
+	err = token.CheckAccess(spaceId, origin, appprimitive.EmbedScopeRead, token.ExpiredAt)
+	if err == nil || err.Error() != embedTokenExpired {
+		t.Fatalf("the expired token should be rejected, %v", err)
+	}
+
+	err = token.CheckAccess(primitive.CreateIdentity(2), origin, appprimitive.EmbedScopeRead, now)
+	if err == nil || err.Error() != embedTokenSpaceMismatch {
+		t.Fatalf("the token of another space should be rejected, %v", err)
+	}
+
+	if err := token.CheckAccess(spaceId, "https://evil.example.com", appprimitive.EmbedScopeRead, now); err == nil {
+		t.Fatal("the token used in a disallowed origin should be rejected")
+	}
+}
+
+// TestNewSpaceEmbedTokenUsage tests that a use of the embed token is counted in the UTC day it happens.
+func TestNewSpaceEmbedTokenUsage(t *testing.T) {
+	token := SpaceEmbedToken{
+		Id:      primitive.CreateIdentity(1),
+		SpaceId: primitive.CreateIdentity(2),
+	}
+
+	day := int64(1704067200)
+
+	for _, now := range []int64{day, day + secondsOfDay - 1} {
+		u := NewSpaceEmbedTokenUsage(&token, "https://app.example.com", "127.0.0.1", now)
+		if u.Day != day || u.Count != 1 || u.UsedAt != now || u.SpaceId != token.SpaceId {
+			t.Fatalf("unexpected usage: %+v", u)
+		}
+	}
+}
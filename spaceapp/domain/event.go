@@ -11,6 +11,11 @@ import "encoding/json"
 type spaceappCreatedEvent struct {
 	SpaceId  string `json:"space_id"`
 	CommitId string `json:"commit_id"`
+
+	// the builder uses the Dockerfile of repo, exposes the port and runs as the user for docker app
+	SDK       string `json:"sdk,omitempty"`
+	AppPort   int    `json:"app_port,omitempty"`
+	RunAsUser int    `json:"run_as_user,omitempty"`
 }
 
 // Message returns the JSON representation of the spaceappCreatedEvent.
@@ -20,10 +25,21 @@ func (e *spaceappCreatedEvent) Message() ([]byte, error) {
 
 // NewSpaceAppCreatedEvent creates a new spaceappCreatedEvent instance with the given SpaceApp.
 func NewSpaceAppCreatedEvent(app *SpaceApp) spaceappCreatedEvent {
-	return spaceappCreatedEvent{
+	e := spaceappCreatedEvent{
 		SpaceId:  app.SpaceId.Identity(),
 		CommitId: app.CommitId,
 	}
+
+	if app.SDK != nil {
+		e.SDK = app.SDK.SDK()
+	}
+
+	if app.IsDocker() && app.Docker != nil {
+		e.AppPort = app.Docker.AppPort()
+		e.RunAsUser = app.Docker.RunAsUser()
+	}
+
+	return e
 }
 
 // spaceappRestartEvent
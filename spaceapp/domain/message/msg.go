@@ -20,4 +20,5 @@ type SpaceAppMessage interface {
 	SendSpaceAppHeartbeatEvent(EventMessage) error
 	SendSpaceAppSleepEvent(EventMessage) error
 	SendSpaceAppWakeupEvent(EventMessage) error
+	SendSpaceAppPreemptWarningEvent(EventMessage) error
 }
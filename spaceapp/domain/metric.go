@@ -0,0 +1,101 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"golang.org/x/xerrors"
+)
+
+const (
+	// MetricGranularityRaw is the granularity of metrics as they are reported.
+	MetricGranularityRaw = "raw"
+	// MetricGranularityMinute is the granularity of metrics aggregated by minute.
+	MetricGranularityMinute = "minute"
+	// MetricGranularityHour is the granularity of metrics aggregated by hour.
+	MetricGranularityHour = "hour"
+
+	metricMaxUsage = 100
+
+	secondsOfMinute = 60
+	secondsOfHour   = 60 * secondsOfMinute
+	secondsOfDay    = 24 * secondsOfHour
+)
+
+// MetricGranularity describes how the metrics are bucketed and how long they are kept.
+type MetricGranularity struct {
+	Name      string
+	Interval  int64
+	Retention int64
+}
+
+// Bucket returns the start of the bucket which the timestamp belongs to.
+func (g *MetricGranularity) Bucket(t int64) int64 {
+	if g.Interval <= 1 {
+		return t
+	}
+
+	return t - t%g.Interval
+}
+
+// MetricGranularities lists the granularities from the finest to the coarsest.
+var MetricGranularities = []MetricGranularity{
+	{Name: MetricGranularityRaw, Interval: 1, Retention: secondsOfHour},
+	{Name: MetricGranularityMinute, Interval: secondsOfMinute, Retention: secondsOfDay},
+	{Name: MetricGranularityHour, Interval: secondsOfHour, Retention: 30 * secondsOfDay},
+}
+
+// MetricGranularityOfWindow returns the finest granularity which still covers the window.
+func MetricGranularityOfWindow(window int64) (MetricGranularity, error) {
+	if window <= 0 {
+		return MetricGranularity{}, xerrors.New("window must be positive")
+	}
+
+	for _, g := range MetricGranularities {
+		if window <= g.Retention {
+			return g, nil
+		}
+	}
+
+	return MetricGranularity{}, xerrors.Errorf("window can't exceed %d seconds", secondsOfDay*30)
+}
+
+// SpaceAppMetric is the runtime metric of space app, the usages are in percent
+// and the request count is the number of requests since the last report.
+// The usages of the aggregated metric are averaged over the samples of the bucket.
+type SpaceAppMetric struct {
+	SpaceAppIndex
+
+	Timestamp    int64
+	CPUUsage     float64
+	MemoryUsage  float64
+	NPUUsage     float64
+	RequestCount int64
+	Samples      int64
+}
+
+// NewSpaceAppMetric checks the metric reported at the timestamp.
+func NewSpaceAppMetric(m *SpaceAppMetric, now int64) error {
+	if m.Timestamp <= 0 || m.Timestamp > now {
+		return xerrors.New("invalid timestamp")
+	}
+
+	if now-m.Timestamp > secondsOfHour {
+		return xerrors.New("metric is too old")
+	}
+
+	for _, v := range []float64{m.CPUUsage, m.MemoryUsage, m.NPUUsage} {
+		if v < 0 || v > metricMaxUsage {
+			return xerrors.Errorf("usage must be between 0 and %d", metricMaxUsage)
+		}
+	}
+
+	if m.RequestCount < 0 {
+		return xerrors.New("request count can't be negative")
+	}
+
+	m.Samples = 1
+
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import "testing"
+
+// TestMetricGranularityOfWindow tests the granularity chosen for the window and the invalid windows.
+func TestMetricGranularityOfWindow(t *testing.T) {
+	cases := map[int64]string{
+		60:                MetricGranularityRaw,
+		secondsOfHour:     MetricGranularityRaw,
+		secondsOfHour + 1: MetricGranularityMinute,
+		secondsOfDay:      MetricGranularityMinute,
+		7 * secondsOfDay:  MetricGranularityHour,
+	}
+
+	for window, want := range cases {
+		g, err := MetricGranularityOfWindow(window)
+		if err != nil || g.Name != want {
+			t.Fatalf("window %d: got %s, err %v, want %s", window, g.Name, err, want)
+		}
+	}
+
+	for _, window := range []int64{0, 31 * secondsOfDay} {
+		if _, err := MetricGranularityOfWindow(window); err == nil {
+			t.Fatalf("window %d should be invalid", window)
+		}
+	}
+}
+
+// TestMetricGranularityBucket tests that the timestamp is truncated to the bucket of each granularity.
+func TestMetricGranularityBucket(t *testing.T) {
+	ts := int64(1700000123)
+
+	want := []int64{ts, 1700000100, 1699999200}
+	for i := range MetricGranularities {
+		if v := MetricGranularities[i].Bucket(ts); v != want[i] {
+			t.Fatalf("%s: got %d, want %d", MetricGranularities[i].Name, v, want[i])
+		}
+	}
+}
+
+// TestNewSpaceAppMetric tests the validation of the timestamp and usages of the reported metric.
+func TestNewSpaceAppMetric(t *testing.T) {
+	now := int64(1700000000)
+
+	m := SpaceAppMetric{Timestamp: now - 10, CPUUsage: 50, RequestCount: 3}
+	if err := NewSpaceAppMetric(&m, now); err != nil || m.Samples != 1 {
+		t.Fatalf("valid metric is rejected, err: %v", err)
+	}
+
+	invalid := []SpaceAppMetric{
+		{Timestamp: now + 1},
+		{Timestamp: now - secondsOfHour - 1},
+		{Timestamp: now, MemoryUsage: 101},
+		{Timestamp: now, RequestCount: -1},
+	}
+	for i := range invalid {
+		if err := NewSpaceAppMetric(&invalid[i], now); err == nil {
+			t.Fatalf("case %d should be invalid", i)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+)
+
+// PreemptionCandidate is a serving space app which consumes the quota and may be paused
+// to free the quota for the space app of higher priority.
+type PreemptionCandidate struct {
+	SpaceId      primitive.Identity
+	Owner        primitive.Account
+	Name         primitive.MSDName
+	Priority     int
+	QuotaCount   int
+	LastActiveAt int64
+}
+
+// LastActiveAt returns the last time the app served requests, the app which has not served
+// any requests since it was resumed or restarted is regarded active at that time.
+func LastActiveAt(app *SpaceApp, metrics []SpaceAppMetric) int64 {
+	t := max(app.ResumedAt, app.RestartedAt)
+
+	for i := range metrics {
+		if metrics[i].RequestCount > 0 {
+			t = max(t, metrics[i].Timestamp)
+		}
+	}
+
+	return t
+}
+
+// SelectPreemptionVictims picks the candidates of lower priority than the preempting space,
+// the lowest priority and the longest idle first, until the freed quota covers the shortage.
+// It returns false if all the candidates of lower priority can't cover the shortage.
+func SelectPreemptionVictims(
+	candidates []PreemptionCandidate, priority, shortage int,
+) ([]PreemptionCandidate, bool) {
+	eligible := make([]PreemptionCandidate, 0, len(candidates))
+
+	for i := range candidates {
+		if candidates[i].Priority < priority && candidates[i].QuotaCount > 0 {
+			eligible = append(eligible, candidates[i])
+		}
+	}
+
+	sort.SliceStable(eligible, func(i, j int) bool {
+		if eligible[i].Priority != eligible[j].Priority {
+			return eligible[i].Priority < eligible[j].Priority
+		}
+
+		return eligible[i].LastActiveAt < eligible[j].LastActiveAt
+	})
+
+	freed := 0
+
+	for i := range eligible {
+		if freed >= shortage {
+			return eligible[:i], true
+		}
+
+		freed += eligible[i].QuotaCount
+	}
+
+	return eligible, freed >= shortage
+}
+
+// spaceAppPreemptWarningEvent
+type spaceAppPreemptWarningEvent struct {
+	SpaceId     string `json:"space_id"`
+	PreemptedBy string `json:"preempted_by"`
+	Priority    int    `json:"priority"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
+// Message returns the JSON representation of the spaceAppPreemptWarningEvent.
+func (e *spaceAppPreemptWarningEvent) Message() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// NewSpaceAppPreemptWarningEvent creates the event warning that the app of space is going to be
+// paused for the space of higher priority.
+func NewSpaceAppPreemptWarningEvent(
+	victim *PreemptionCandidate, preemptedBy primitive.Identity, priority int, now int64,
+) spaceAppPreemptWarningEvent {
+	return spaceAppPreemptWarningEvent{
+		SpaceId:     victim.SpaceId.Identity(),
+		PreemptedBy: preemptedBy.Identity(),
+		Priority:    priority,
+		CreatedAt:   now,
+	}
+}
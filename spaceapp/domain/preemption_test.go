@@ -0,0 +1,52 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+)
+
+// TestSelectPreemptionVictims tests that the apps of lower priority and longer idle time are preempted first.
+func TestSelectPreemptionVictims(t *testing.T) {
+	candidates := []PreemptionCandidate{
+		{SpaceId: primitive.CreateIdentity(1), Priority: 10, QuotaCount: 1, LastActiveAt: 100},
+		{SpaceId: primitive.CreateIdentity(2), Priority: 0, QuotaCount: 1, LastActiveAt: 300},
+		{SpaceId: primitive.CreateIdentity(3), Priority: 0, QuotaCount: 1, LastActiveAt: 200},
+		{SpaceId: primitive.CreateIdentity(4), Priority: 50, QuotaCount: 4, LastActiveAt: 0},
+	}
+
+	victims, ok := SelectPreemptionVictims(candidates, 50, 2)
+	if !ok || len(victims) != 2 {
+		t.Fatalf("expect 2 victims, got %d", len(victims))
+	}
+
+	if victims[0].SpaceId.Integer() != 3 || victims[1].SpaceId.Integer() != 2 {
+		t.Fatal("the lowest priority and the longest idle app should be preempted first")
+	}
+
+	if _, ok := SelectPreemptionVictims(candidates, 50, 4); ok {
+		t.Fatal("app of the same priority should not be preempted")
+	}
+}
+
+// TestLastActiveAt tests that the last active time is taken from the metrics with requests or the resumed time.
+func TestLastActiveAt(t *testing.T) {
+	app := SpaceApp{ResumedAt: 100, RestartedAt: 50}
+
+	metrics := []SpaceAppMetric{
+		{Timestamp: 200, RequestCount: 3},
+		{Timestamp: 300},
+	}
+
+	if v := LastActiveAt(&app, metrics); v != 200 {
+		t.Fatalf("expect 200, got %d", v)
+	}
+
+	if v := LastActiveAt(&app, nil); v != 100 {
+		t.Fatalf("expect 100, got %d", v)
+	}
+}
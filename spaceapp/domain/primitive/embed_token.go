@@ -0,0 +1,86 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package primitive
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// EmbedScopeRead allows the holder to read the space app.
+	EmbedScopeRead = embedScope("read")
+
+	embedOriginMaxLength = 255
+)
+
+// EmbedScope is an interface for the scope of embed token.
+type EmbedScope interface {
+	EmbedScope() string
+}
+
+// NewEmbedScope creates a new embed scope instance with validation.
+func NewEmbedScope(v string) (EmbedScope, error) {
+	if v == "" {
+		return EmbedScopeRead, nil
+	}
+
+	if v != EmbedScopeRead.EmbedScope() {
+		return nil, xerrors.Errorf("unsupported scope: %s", v)
+	}
+
+	return embedScope(v), nil
+}
+
+// CreateEmbedScope creates a new embed scope instance without validation.
+func CreateEmbedScope(v string) EmbedScope {
+	return embedScope(v)
+}
+
+type embedScope string
+
+// EmbedScope returns the scope as a string.
+func (r embedScope) EmbedScope() string {
+	return string(r)
+}
+
+// EmbedOrigin is an interface for the origin which is allowed to embed the space app.
+type EmbedOrigin interface {
+	EmbedOrigin() string
+}
+
+// NewEmbedOrigin creates a new embed origin instance, only scheme and host are allowed.
+func NewEmbedOrigin(v string) (EmbedOrigin, error) {
+	v = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(v), "/"))
+	if v == "" || len(v) > embedOriginMaxLength {
+		return nil, xerrors.Errorf("invalid origin length, should between 1 and %d", embedOriginMaxLength)
+	}
+
+	u, err := url.Parse(v)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid origin, err:%w", err)
+	}
+
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" ||
+		u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return nil, xerrors.Errorf("invalid origin: %s", v)
+	}
+
+	return embedOrigin(v), nil
+}
+
+// CreateEmbedOrigin creates a new embed origin instance without validation.
+func CreateEmbedOrigin(v string) EmbedOrigin {
+	return embedOrigin(v)
+}
+
+type embedOrigin string
+
+// EmbedOrigin returns the origin as a string.
+func (r embedOrigin) EmbedOrigin() string {
+	return string(r)
+}
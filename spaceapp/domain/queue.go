@@ -0,0 +1,76 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"errors"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	spacedomain "github.com/openmerlin/merlin-server/space/domain"
+)
+
+const (
+	// QueueActionResume is the action to resume the paused space app.
+	QueueActionResume = "resume"
+	// QueueActionRestart is the action to restart the space app.
+	QueueActionRestart = "restart"
+)
+
+// NewQueueAction checks the action which is done when the queued space app gets the quota.
+func NewQueueAction(v string) (string, error) {
+	if v != QueueActionResume && v != QueueActionRestart {
+		return "", errors.New("unsupported queue action")
+	}
+
+	return v, nil
+}
+
+// SpaceAppQueueEntry is a space app waiting for the computility quota.
+// The entries are queued per compute type and owner of space in the order of creation.
+// The commit, hardware and sdk of space are recorded to detect the change of space.
+type SpaceAppQueueEntry struct {
+	Id          primitive.Identity
+	SpaceId     primitive.Identity
+	Owner       primitive.Account
+	User        primitive.Account
+	ComputeType primitive.ComputilityType
+	Action      string
+	CommitId    string
+	Hardware    string
+	SDK         string
+	CreatedAt   int64
+}
+
+// NewSpaceAppQueueEntry creates the entry of space which waits for the quota of the user.
+func NewSpaceAppQueueEntry(
+	space *spacedomain.Space, user primitive.Account, action string, now int64,
+) SpaceAppQueueEntry {
+	return SpaceAppQueueEntry{
+		SpaceId:     space.Id,
+		Owner:       space.Owner,
+		User:        user,
+		ComputeType: space.GetComputeType(),
+		Action:      action,
+		CommitId:    space.CommitId,
+		Hardware:    space.Hardware.Hardware(),
+		SDK:         space.SDK.SDK(),
+		CreatedAt:   now,
+	}
+}
+
+// QueueKey returns the key of the queue which the entry is in, the entries are queued
+// per owner of space and compute type.
+func (e *SpaceAppQueueEntry) QueueKey() string {
+	return e.Owner.Account() + "/" + e.ComputeType.ComputilityType()
+}
+
+// IsStale checks if the space has changed since the entry was queued,
+// the stale entry expires and must not be started.
+func (e *SpaceAppQueueEntry) IsStale(space *spacedomain.Space) bool {
+	return space.IsDisable() ||
+		space.CommitId != e.CommitId ||
+		space.Hardware.Hardware() != e.Hardware ||
+		space.SDK.SDK() != e.SDK
+}
@@ -0,0 +1,72 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	spacedomain "github.com/openmerlin/merlin-server/space/domain"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
+)
+
+// TestSpaceAppQueueEntryIsStale tests that the queue entry is stale after the code, hardware or status changed.
+func TestSpaceAppQueueEntryIsStale(t *testing.T) {
+	space := spacedomain.Space{
+		SDK:      spaceprimitive.CreateSDK("gradio"),
+		Hardware: spaceprimitive.CreateHardware("npu basic"),
+		CommitId: "c1",
+	}
+	space.Id = primitive.CreateIdentity(1)
+	space.Owner = primitive.CreateAccount("org")
+
+	entry := NewSpaceAppQueueEntry(&space, primitive.CreateAccount("alice"), QueueActionResume, 1)
+	if entry.IsStale(&space) {
+		t.Fatal("entry of the unchanged space should not be stale")
+	}
+
+	changed := space
+	changed.CommitId = "c2"
+	if !entry.IsStale(&changed) {
+		t.Fatal("entry should be stale after the code changed")
+	}
+
+	changed = space
+	changed.Hardware = spaceprimitive.CreateHardware("cpu basic")
+	if !entry.IsStale(&changed) {
+		t.Fatal("entry should be stale after the hardware changed")
+	}
+
+	changed = space
+	changed.Disable = true
+	if !entry.IsStale(&changed) {
+		t.Fatal("entry should be stale after the space is disabled")
+	}
+
+	if _, err := NewQueueAction("wakeup"); err == nil {
+		t.Fatal("unsupported action should be rejected")
+	}
+}
+
+// TestSpaceAppQueueEntryQueueKey tests that the entries of different users are in the same queue of the owner.
+func TestSpaceAppQueueEntryQueueKey(t *testing.T) {
+	entry := func(owner, user string) SpaceAppQueueEntry {
+		return SpaceAppQueueEntry{
+			Owner:       primitive.CreateAccount(owner),
+			User:        primitive.CreateAccount(user),
+			ComputeType: primitive.CreateComputilityType("npu"),
+		}
+	}
+
+	a, b, c := entry("org", "alice"), entry("org", "bob"), entry("other", "alice")
+
+	if a.QueueKey() != b.QueueKey() {
+		t.Fatal("entries of the same owner and compute type should be in the same queue")
+	}
+
+	if a.QueueKey() == c.QueueKey() {
+		t.Fatal("entries of different owners should be in different queues")
+	}
+}
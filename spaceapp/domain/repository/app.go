@@ -28,3 +28,42 @@ type SpaceAppBuildLogAdapter interface {
 	Find(context.Context, primitive.Identity) (domain.SpaceAppBuildLog, error)
 	Save(*domain.SpaceAppBuildLog) error
 }
+
+// SpaceEmbedTokenAdapter is an interface that defines methods for managing space embed tokens.
+type SpaceEmbedTokenAdapter interface {
+	Add(*domain.SpaceEmbedToken) error
+	Find(context.Context, primitive.Identity) (domain.SpaceEmbedToken, error)
+	FindByLastEight(context.Context, string) ([]domain.SpaceEmbedToken, error)
+	ListBySpaceId(context.Context, primitive.Identity) ([]domain.SpaceEmbedToken, error)
+	Save(*domain.SpaceEmbedToken) error
+	AddUsage(*domain.SpaceEmbedTokenUsage) error
+	ListUsage(context.Context, primitive.Identity, int) ([]domain.SpaceEmbedTokenUsage, error)
+}
+
+// MetricListOption is the option of listing the metrics of space app.
+type MetricListOption struct {
+	SpaceId     primitive.Identity
+	Granularity string
+	Since       int64
+}
+
+// SpaceAppMetricAdapter is an interface that defines methods for managing space app runtime metrics.
+type SpaceAppMetricAdapter interface {
+	// Add saves the raw metric and merges it into the aggregated buckets.
+	Add(*domain.SpaceAppMetric) error
+	RemoveExpired(now int64) error
+	List(context.Context, *MetricListOption) ([]domain.SpaceAppMetric, error)
+}
+
+// SpaceAppQueueAdapter is an interface that defines methods for managing the space apps waiting for quota.
+type SpaceAppQueueAdapter interface {
+	// Add adds the entry, there is at most one entry for each space.
+	Add(*domain.SpaceAppQueueEntry) error
+	FindBySpaceId(context.Context, primitive.Identity) (domain.SpaceAppQueueEntry, error)
+	Delete(primitive.Identity) error
+	DeleteBySpaceId(primitive.Identity) error
+	// CountAhead counts the entries which are queued before the entry in the same queue.
+	CountAhead(*domain.SpaceAppQueueEntry) (int, error)
+	// ListAll lists all the entries in the order of queuing.
+	ListAll() ([]domain.SpaceAppQueueEntry, error)
+}
@@ -0,0 +1,47 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+// Package emailadapter provides an adapter for sending the emails of space app.
+package emailadapter
+
+import (
+	"fmt"
+
+	"github.com/openmerlin/merlin-server/spaceapp/domain/email"
+)
+
+const (
+	preemptionSubject  = "Space app is paused"
+	preemptionTemplate = `<p>Hello,</p>
+<p>The app of your space <a href="%s">%s</a> has been paused to free the computility quota
+for the space %s of higher priority.</p>
+<p>You can resume it when the quota is available again.</p>`
+)
+
+// Email is an interface for sending emails.
+type Email interface {
+	Send(receiver []string, subject, content string) error
+}
+
+// NewEmailImpl creates a new email adapter of space app.
+func NewEmailImpl(e Email, rootUrl string) email.Email {
+	return &emailImpl{
+		instance: e,
+		rootUrl:  rootUrl,
+	}
+}
+
+type emailImpl struct {
+	instance Email
+	rootUrl  string
+}
+
+// SendPreemptionNotice sends the email of preemption to the receiver.
+func (impl *emailImpl) SendPreemptionNotice(receiver []string, space, preemptedBy string) error {
+	url := fmt.Sprintf("%s/spaces/%s", impl.rootUrl, space)
+
+	return impl.instance.Send(
+		receiver, preemptionSubject, fmt.Sprintf(preemptionTemplate, url, space, preemptedBy),
+	)
+}
@@ -15,4 +15,5 @@ type Topics struct {
 	SpaceAppSleep   	string `json:"space_app_sleep" required:"true"`
 	SpaceAppWakeup   	string `json:"space_app_wakeup" required:"true"`
 	SpaceForceEvent   	string `json:"space_force_event" required:"true"`
+	SpaceAppPreemptWarning string `json:"space_app_preempt_warning" required:"true"`
 }
@@ -61,6 +61,11 @@ func (p *messageAdapter) SendSpaceAppWakeupEvent(e message.EventMessage) error {
 	return send(p.topics.SpaceAppWakeup, e)
 }
 
+// SendSpaceAppPreemptWarningEvent sends a SpaceAppPreemptWarning event message to the corresponding topic.
+func (p *messageAdapter) SendSpaceAppPreemptWarningEvent(e message.EventMessage) error {
+	return send(p.topics.SpaceAppPreemptWarning, e)
+}
+
 func send(topic string, v message.EventMessage) error {
 	body, err := v.Message()
 	if err != nil {
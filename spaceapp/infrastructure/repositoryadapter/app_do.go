@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	spaceprimitive "github.com/openmerlin/merlin-server/space/domain/primitive"
 	"github.com/openmerlin/merlin-server/spaceapp/domain"
 	appprimitive "github.com/openmerlin/merlin-server/spaceapp/domain/primitive"
 )
@@ -51,6 +52,15 @@ func toSpaceAppDO(m *domain.SpaceApp) spaceappDO {
 		do.BuildLogURL = m.BuildLogURL.URL()
 	}
 
+	if m.SDK != nil {
+		do.SDK = m.SDK.SDK()
+	}
+
+	if m.Docker != nil {
+		do.AppPort = m.Docker.AppPort()
+		do.RunAsUser = m.Docker.RunAsUser()
+	}
+
 	return do
 }
 
@@ -60,6 +70,10 @@ type spaceappDO struct {
 	SpaceId  int64  `gorm:"column:space_id;index:,unique"`
 	CommitId string `gorm:"column:commit_id"`
 
+	SDK       string `gorm:"column:sdk"`
+	AppPort   int    `gorm:"column:app_port"`
+	RunAsUser int    `gorm:"column:run_as_user"`
+
 	Status string `gorm:"column:status"`
 	Reason string `gorm:"column:reason"`
 
@@ -106,6 +120,14 @@ func (do *spaceappDO) toSpaceApp() domain.SpaceApp {
 		v.BuildLogURL = primitive.CreateURL(do.BuildLogURL)
 	}
 
+	if do.SDK != "" {
+		v.SDK = spaceprimitive.CreateSDK(do.SDK)
+	}
+
+	if v.IsDocker() {
+		v.Docker = spaceprimitive.CreateDockerOption(do.AppPort, do.RunAsUser)
+	}
+
 	return v
 }
 
@@ -7,5 +7,9 @@ package repositoryadapter
 
 // Tables is a struct that represents table names for different entities.
 type Tables struct {
-	SpaceApp string `json:"space_app" required:"true"`
+	SpaceApp        string `json:"space_app" required:"true"`
+	EmbedToken      string `json:"embed_token" required:"true"`
+	EmbedTokenUsage string `json:"embed_token_usage" required:"true"`
+	Metric          string `json:"metric" required:"true"`
+	Queue           string `json:"queue" required:"true"`
 }
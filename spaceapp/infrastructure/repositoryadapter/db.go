@@ -6,6 +6,8 @@ Copyright (c) Huawei Technologies Co., Ltd. 2023. All rights reserved
 package repositoryadapter
 
 import (
+	"fmt"
+
 	"gorm.io/gorm"
 
 	"github.com/openmerlin/merlin-server/common/infrastructure/postgresql"
@@ -14,17 +16,44 @@ import (
 var (
 	buildLogAdapterInstance      *buildLogAdapterImpl
 	appRepositoryAdapterInstance *appRepositoryAdapter
+	embedTokenAdapterInstance    *embedTokenAdapter
+	metricAdapterInstance        *metricAdapter
+	queueAdapterInstance         *queueAdapter
 )
 
 // Init initializes the space app module by performing necessary setup and migrations.
 func Init(db *gorm.DB, tables *Tables) error {
 	// must set branchTableName before migrating
 	spaceappTableName = tables.SpaceApp
+	embedTokenTableName = tables.EmbedToken
+	embedTokenUsageTableName = tables.EmbedTokenUsage
+	metricTableName = tables.Metric
+	queueTableName = tables.Queue
 
 	if err := db.AutoMigrate(&spaceappDO{}); err != nil {
 		return err
 	}
 
+	if err := db.AutoMigrate(&embedTokenDO{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&embedTokenUsageDO{}); err != nil {
+		return err
+	}
+
+	if err := migrateEmbedTokenUsage(db); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&metricDO{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&queueEntryDO{}); err != nil {
+		return err
+	}
+
 	dao := postgresql.DAO(tables.SpaceApp)
 
 	appRepositoryAdapterInstance = &appRepositoryAdapter{
@@ -35,9 +64,43 @@ func Init(db *gorm.DB, tables *Tables) error {
 		dao: dao,
 	}
 
+	embedTokenAdapterInstance = &embedTokenAdapter{
+		dao:      postgresql.DAO(tables.EmbedToken),
+		usageDao: postgresql.DAO(tables.EmbedTokenUsage),
+	}
+
+	metricAdapterInstance = &metricAdapter{
+		dao: postgresql.DAO(tables.Metric),
+	}
+
+	queueAdapterInstance = &queueAdapter{
+		dao: postgresql.DAO(tables.Queue),
+	}
+
 	return nil
 }
 
+// migrateEmbedTokenUsage folds the usage recorded per use before into the daily usage,
+// it does nothing once all are migrated.
+func migrateEmbedTokenUsage(db *gorm.DB) error {
+	fold := fmt.Sprintf(`
+		insert into %[1]s (token_id, space_id, day, count, origin, ip, used_at)
+		select token_id, max(space_id), used_at - used_at %% %[2]d, count(*),
+			(array_agg(origin order by used_at desc))[1], (array_agg(ip order by used_at desc))[1], max(used_at)
+		from %[1]s where day is null group by token_id, used_at - used_at %% %[2]d
+		on conflict (token_id, day) do update set count = %[1]s.count + excluded.count`,
+		embedTokenUsageTableName, secondsOfDay,
+	)
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(fold).Error; err != nil {
+			return err
+		}
+
+		return tx.Where(fieldDay + " is null").Delete(&embedTokenUsageDO{}).Error
+	})
+}
+
 // AppRepositoryAdapter is an instance of the AppRepositoryAdapter.
 func AppRepositoryAdapter() *appRepositoryAdapter {
 	return appRepositoryAdapterInstance
@@ -47,3 +110,18 @@ func AppRepositoryAdapter() *appRepositoryAdapter {
 func BuildLogAdapter() *buildLogAdapterImpl {
 	return buildLogAdapterInstance
 }
+
+// EmbedTokenAdapter is an instance of the EmbedTokenAdapter.
+func EmbedTokenAdapter() *embedTokenAdapter {
+	return embedTokenAdapterInstance
+}
+
+// MetricAdapter is an instance of the MetricAdapter.
+func MetricAdapter() *metricAdapter {
+	return metricAdapterInstance
+}
+
+// QueueAdapter is an instance of the QueueAdapter.
+func QueueAdapter() *queueAdapter {
+	return queueAdapterInstance
+}
@@ -0,0 +1,150 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package repositoryadapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	commonrepo "github.com/openmerlin/merlin-server/common/domain/repository"
+	"github.com/openmerlin/merlin-server/spaceapp/domain"
+)
+
+type embedTokenAdapter struct {
+	dao      dao
+	usageDao dao
+}
+
+// Add adds an embed token to the repository.
+func (adapter *embedTokenAdapter) Add(t *domain.SpaceEmbedToken) error {
+	do := toEmbedTokenDO(t)
+
+	if err := adapter.dao.DB().Create(&do).Error; err != nil {
+		return err
+	}
+
+	t.Id = primitive.CreateIdentity(do.Id)
+
+	return nil
+}
+
+// Find finds an embed token by its id.
+func (adapter *embedTokenAdapter) Find(ctx context.Context, id primitive.Identity) (
+	domain.SpaceEmbedToken, error,
+) {
+	do := embedTokenDO{Id: id.Integer()}
+
+	if err := adapter.dao.GetByPrimaryKey(ctx, &do); err != nil {
+		return domain.SpaceEmbedToken{}, err
+	}
+
+	return do.toEmbedToken(), nil
+}
+
+// FindByLastEight finds all the embed tokens that match the given last eight characters.
+func (adapter *embedTokenAdapter) FindByLastEight(ctx context.Context, lastEight string) (
+	[]domain.SpaceEmbedToken, error,
+) {
+	var dos []embedTokenDO
+
+	err := adapter.dao.DB().WithContext(ctx).Where(
+		adapter.dao.EqualQuery(fieldLastEight), lastEight,
+	).Find(&dos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toEmbedTokens(dos), nil
+}
+
+// ListBySpaceId lists the embed tokens of the space.
+func (adapter *embedTokenAdapter) ListBySpaceId(ctx context.Context, spaceId primitive.Identity) (
+	[]domain.SpaceEmbedToken, error,
+) {
+	var dos []embedTokenDO
+
+	err := adapter.dao.DB().WithContext(ctx).Where(
+		adapter.dao.EqualQuery(fieldSpaceId), spaceId.Integer(),
+	).Order(fieldCreatedAt + " desc").Find(&dos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toEmbedTokens(dos), nil
+}
+
+// Save saves an embed token in the repository.
+func (adapter *embedTokenAdapter) Save(t *domain.SpaceEmbedToken) error {
+	do := toEmbedTokenDO(t)
+	do.Version += 1
+
+	v := adapter.dao.DB().Model(
+		&embedTokenDO{Id: t.Id.Integer()},
+	).Where(
+		adapter.dao.EqualQuery(fieldVersion), t.Version,
+	).Select(`*`).Updates(&do)
+
+	if v.Error != nil {
+		return v.Error
+	}
+
+	if v.RowsAffected == 0 {
+		return commonrepo.NewErrorConcurrentUpdating(
+			errors.New("concurrent updating"),
+		)
+	}
+
+	return nil
+}
+
+// AddUsage adds the uses to the daily usage of the embed token.
+func (adapter *embedTokenAdapter) AddUsage(u *domain.SpaceEmbedTokenUsage) error {
+	do := toEmbedTokenUsageDO(u)
+
+	return adapter.usageDao.DB().Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: fieldTokenId}, {Name: fieldDay}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			fieldCount:  gorm.Expr(fmt.Sprintf("%s.%s + excluded.%s", embedTokenUsageTableName, fieldCount, fieldCount)),
+			fieldOrigin: gorm.Expr("excluded." + fieldOrigin),
+			fieldIP:     gorm.Expr("excluded." + fieldIP),
+			fieldUsedAt: gorm.Expr("excluded." + fieldUsedAt),
+		}),
+	}).Create(&do).Error
+}
+
+// ListUsage lists the daily usage of the embed token in the latest days.
+func (adapter *embedTokenAdapter) ListUsage(ctx context.Context, tokenId primitive.Identity, limit int) (
+	[]domain.SpaceEmbedTokenUsage, error,
+) {
+	var dos []embedTokenUsageDO
+
+	err := adapter.usageDao.DB().WithContext(ctx).Where(
+		adapter.usageDao.EqualQuery(fieldTokenId), tokenId.Integer(),
+	).Order(fieldDay + " desc").Limit(limit).Find(&dos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]domain.SpaceEmbedTokenUsage, len(dos))
+	for i := range dos {
+		r[i] = dos[i].toEmbedTokenUsage()
+	}
+
+	return r, nil
+}
+
+func toEmbedTokens(dos []embedTokenDO) []domain.SpaceEmbedToken {
+	r := make([]domain.SpaceEmbedToken, len(dos))
+	for i := range dos {
+		r[i] = dos[i].toEmbedToken()
+	}
+
+	return r
+}
@@ -0,0 +1,146 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package repositoryadapter
+
+import (
+	"github.com/lib/pq"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/spaceapp/domain"
+	appprimitive "github.com/openmerlin/merlin-server/spaceapp/domain/primitive"
+)
+
+const (
+	fieldLastEight = "last_eight"
+	fieldTokenId   = "token_id"
+	fieldCreatedAt = "created_at"
+	fieldUsedAt    = "used_at"
+	fieldDay       = "day"
+	fieldCount     = "count"
+	fieldOrigin    = "origin"
+	fieldIP        = "ip"
+
+	secondsOfDay = 24 * 60 * 60
+)
+
+var (
+	embedTokenTableName      = ""
+	embedTokenUsageTableName = ""
+)
+
+func toEmbedTokenDO(t *domain.SpaceEmbedToken) embedTokenDO {
+	do := embedTokenDO{
+		SpaceId:   t.SpaceId.Integer(),
+		Name:      t.Name,
+		Scope:     t.Scope.EmbedScope(),
+		Origins:   make(pq.StringArray, len(t.Origins)),
+		Token:     t.Token,
+		Salt:      t.Salt,
+		LastEight: t.LastEight,
+		CreatedBy: t.CreatedBy.Account(),
+		CreatedAt: t.CreatedAt,
+		ExpiredAt: t.ExpiredAt,
+		RevokedAt: t.RevokedAt,
+		Version:   t.Version,
+	}
+
+	for i := range t.Origins {
+		do.Origins[i] = t.Origins[i].EmbedOrigin()
+	}
+
+	if t.Id != nil {
+		do.Id = t.Id.Integer()
+	}
+
+	return do
+}
+
+// embedTokenDO
+type embedTokenDO struct {
+	Id        int64          `gorm:"primarykey"`
+	SpaceId   int64          `gorm:"column:space_id;index"`
+	Name      string         `gorm:"column:name"`
+	Scope     string         `gorm:"column:scope"`
+	Origins   pq.StringArray `gorm:"column:origins;type:text[];default:'{}'"`
+	Token     string         `gorm:"column:token"`
+	Salt      string         `gorm:"column:salt"`
+	LastEight string         `gorm:"column:last_eight;index"`
+	CreatedBy string         `gorm:"column:created_by"`
+	CreatedAt int64          `gorm:"column:created_at"`
+	ExpiredAt int64          `gorm:"column:expired_at"`
+	RevokedAt int64          `gorm:"column:revoked_at"`
+	Version   int            `gorm:"column:version"`
+}
+
+// TableName returns the name of the table for the embedTokenDO struct.
+func (do *embedTokenDO) TableName() string {
+	return embedTokenTableName
+}
+
+func (do *embedTokenDO) toEmbedToken() domain.SpaceEmbedToken {
+	t := domain.SpaceEmbedToken{
+		Id:        primitive.CreateIdentity(do.Id),
+		SpaceId:   primitive.CreateIdentity(do.SpaceId),
+		Name:      do.Name,
+		Scope:     appprimitive.CreateEmbedScope(do.Scope),
+		Origins:   make([]appprimitive.EmbedOrigin, len(do.Origins)),
+		Token:     do.Token,
+		Salt:      do.Salt,
+		LastEight: do.LastEight,
+		CreatedBy: primitive.CreateAccount(do.CreatedBy),
+		CreatedAt: do.CreatedAt,
+		ExpiredAt: do.ExpiredAt,
+		RevokedAt: do.RevokedAt,
+		Version:   do.Version,
+	}
+
+	for i := range do.Origins {
+		t.Origins[i] = appprimitive.CreateEmbedOrigin(do.Origins[i])
+	}
+
+	return t
+}
+
+func toEmbedTokenUsageDO(u *domain.SpaceEmbedTokenUsage) embedTokenUsageDO {
+	return embedTokenUsageDO{
+		TokenId: u.TokenId.Integer(),
+		SpaceId: u.SpaceId.Integer(),
+		Day:     u.Day,
+		Count:   u.Count,
+		Origin:  u.Origin,
+		IP:      u.IP,
+		UsedAt:  u.UsedAt,
+	}
+}
+
+// embedTokenUsageDO is the uses of an embed token in a UTC day,
+// the rows recorded per use before are the ones whose day is null.
+type embedTokenUsageDO struct {
+	Id      int64  `gorm:"primarykey"`
+	TokenId int64  `gorm:"column:token_id;index;uniqueIndex:embed_token_usage_day,priority:1"`
+	SpaceId int64  `gorm:"column:space_id"`
+	Day     int64  `gorm:"column:day;uniqueIndex:embed_token_usage_day,priority:2"`
+	Count   int64  `gorm:"column:count"`
+	Origin  string `gorm:"column:origin"`
+	IP      string `gorm:"column:ip"`
+	UsedAt  int64  `gorm:"column:used_at"`
+}
+
+// TableName returns the name of the table for the embedTokenUsageDO struct.
+func (do *embedTokenUsageDO) TableName() string {
+	return embedTokenUsageTableName
+}
+
+func (do *embedTokenUsageDO) toEmbedTokenUsage() domain.SpaceEmbedTokenUsage {
+	return domain.SpaceEmbedTokenUsage{
+		TokenId: primitive.CreateIdentity(do.TokenId),
+		SpaceId: primitive.CreateIdentity(do.SpaceId),
+		Day:     do.Day,
+		Count:   do.Count,
+		Origin:  do.Origin,
+		IP:      do.IP,
+		UsedAt:  do.UsedAt,
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package repositoryadapter
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openmerlin/merlin-server/spaceapp/domain"
+	"github.com/openmerlin/merlin-server/spaceapp/domain/repository"
+)
+
+type metricAdapter struct {
+	dao dao
+}
+
+// Add saves the raw metric and merges it into the buckets of each granularity.
+func (adapter *metricAdapter) Add(m *domain.SpaceAppMetric) error {
+	return adapter.dao.DB().Transaction(func(tx *gorm.DB) error {
+		for i := range domain.MetricGranularities {
+			do := toMetricDO(m, &domain.MetricGranularities[i])
+
+			if err := tx.Clauses(adapter.mergeBucket()).Create(&do).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (adapter *metricAdapter) mergeBucket() clause.OnConflict {
+	sum := func(field string) clause.Expr {
+		return gorm.Expr(fmt.Sprintf("%s.%s + excluded.%s", metricTableName, field, field))
+	}
+
+	return clause.OnConflict{
+		Columns: []clause.Column{{Name: fieldSpaceId}, {Name: fieldGranularity}, {Name: fieldTimestamp}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			fieldCommitId:     gorm.Expr("excluded." + fieldCommitId),
+			fieldCPUUsage:     sum(fieldCPUUsage),
+			fieldMemoryUsage:  sum(fieldMemoryUsage),
+			fieldNPUUsage:     sum(fieldNPUUsage),
+			fieldRequestCount: sum(fieldRequestCount),
+			fieldSamples:      sum(fieldSamples),
+		}),
+	}
+}
+
+// RemoveExpired removes the metrics which are beyond the retention of their granularity.
+func (adapter *metricAdapter) RemoveExpired(now int64) error {
+	db := adapter.dao.DB()
+
+	for _, g := range domain.MetricGranularities {
+		err := db.Where(
+			adapter.dao.EqualQuery(fieldGranularity), g.Name,
+		).Where(
+			fieldTimestamp+" < ?", now-g.Retention,
+		).Delete(&metricDO{}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// List lists the metrics of the granularity since the time in ascending order.
+func (adapter *metricAdapter) List(ctx context.Context, opt *repository.MetricListOption) (
+	[]domain.SpaceAppMetric, error,
+) {
+	var dos []metricDO
+
+	err := adapter.dao.DB().WithContext(ctx).Where(
+		adapter.dao.EqualQuery(fieldSpaceId), opt.SpaceId.Integer(),
+	).Where(
+		adapter.dao.EqualQuery(fieldGranularity), opt.Granularity,
+	).Where(
+		fieldTimestamp+" >= ?", opt.Since,
+	).Order(fieldTimestamp + " asc").Find(&dos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]domain.SpaceAppMetric, len(dos))
+	for i := range dos {
+		r[i] = dos[i].toMetric()
+	}
+
+	return r, nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package repositoryadapter
+
+import (
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/spaceapp/domain"
+)
+
+const (
+	fieldGranularity  = "granularity"
+	fieldTimestamp    = "timestamp"
+	fieldCPUUsage     = "cpu_usage"
+	fieldMemoryUsage  = "memory_usage"
+	fieldNPUUsage     = "npu_usage"
+	fieldRequestCount = "request_count"
+	fieldSamples      = "samples"
+)
+
+var metricTableName = ""
+
+func toMetricDO(m *domain.SpaceAppMetric, g *domain.MetricGranularity) metricDO {
+	// the usages are kept as the sum of samples, so that the buckets can be merged
+	return metricDO{
+		SpaceId:      m.SpaceId.Integer(),
+		CommitId:     m.CommitId,
+		Granularity:  g.Name,
+		Timestamp:    g.Bucket(m.Timestamp),
+		CPUUsage:     m.CPUUsage * float64(m.Samples),
+		MemoryUsage:  m.MemoryUsage * float64(m.Samples),
+		NPUUsage:     m.NPUUsage * float64(m.Samples),
+		RequestCount: m.RequestCount,
+		Samples:      m.Samples,
+	}
+}
+
+// metricDO
+type metricDO struct {
+	Id           int64   `gorm:"primarykey"`
+	SpaceId      int64   `gorm:"column:space_id;uniqueIndex:metric_bucket,priority:1"`
+	CommitId     string  `gorm:"column:commit_id"`
+	Granularity  string  `gorm:"column:granularity;uniqueIndex:metric_bucket,priority:2;index:metric_expiry,priority:1"`
+	Timestamp    int64   `gorm:"column:timestamp;uniqueIndex:metric_bucket,priority:3;index:metric_expiry,priority:2"`
+	CPUUsage     float64 `gorm:"column:cpu_usage"`
+	MemoryUsage  float64 `gorm:"column:memory_usage"`
+	NPUUsage     float64 `gorm:"column:npu_usage"`
+	RequestCount int64   `gorm:"column:request_count"`
+	Samples      int64   `gorm:"column:samples"`
+}
+
+// TableName returns the name of the table for the metricDO struct.
+func (do *metricDO) TableName() string {
+	return metricTableName
+}
+
+func (do *metricDO) toMetric() domain.SpaceAppMetric {
+	m := domain.SpaceAppMetric{
+		SpaceAppIndex: domain.SpaceAppIndex{
+			SpaceId:  primitive.CreateIdentity(do.SpaceId),
+			CommitId: do.CommitId,
+		},
+		Timestamp:    do.Timestamp,
+		RequestCount: do.RequestCount,
+		Samples:      do.Samples,
+	}
+
+	if do.Samples > 0 {
+		n := float64(do.Samples)
+
+		m.CPUUsage = do.CPUUsage / n
+		m.MemoryUsage = do.MemoryUsage / n
+		m.NPUUsage = do.NPUUsage / n
+	}
+
+	return m
+}
@@ -0,0 +1,102 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package repositoryadapter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/common/domain/repository"
+	"github.com/openmerlin/merlin-server/spaceapp/domain"
+)
+
+type queueAdapter struct {
+	dao dao
+}
+
+// Add adds the entry of space app waiting for the quota.
+func (adapter *queueAdapter) Add(e *domain.SpaceAppQueueEntry) error {
+	do := toQueueEntryDO(e)
+
+	err := adapter.dao.DB().Create(&do).Error
+	if err != nil {
+		if adapter.dao.IsRecordExists(err) {
+			return repository.NewErrorDuplicateCreating(
+				errors.New("space app is queued"),
+			)
+		}
+
+		return err
+	}
+
+	e.Id = primitive.CreateIdentity(do.Id)
+
+	return nil
+}
+
+// FindBySpaceId finds the entry of the space.
+func (adapter *queueAdapter) FindBySpaceId(ctx context.Context, spaceId primitive.Identity) (
+	domain.SpaceAppQueueEntry, error,
+) {
+	do := queueEntryDO{SpaceId: spaceId.Integer()}
+
+	// It must new a new DO, otherwise the sql statement will include duplicate conditions.
+	result := queueEntryDO{}
+
+	if err := adapter.dao.GetRecord(ctx, &do, &result); err != nil {
+		return domain.SpaceAppQueueEntry{}, err
+	}
+
+	return result.toQueueEntry(), nil
+}
+
+// Delete deletes the entry by its id.
+func (adapter *queueAdapter) Delete(id primitive.Identity) error {
+	return adapter.dao.DB().Where(
+		adapter.dao.EqualQuery(fieldId), id.Integer(),
+	).Delete(&queueEntryDO{}).Error
+}
+
+// DeleteBySpaceId deletes the entry of the space.
+func (adapter *queueAdapter) DeleteBySpaceId(spaceId primitive.Identity) error {
+	return adapter.dao.DB().Where(
+		adapter.dao.EqualQuery(fieldSpaceId), spaceId.Integer(),
+	).Delete(&queueEntryDO{}).Error
+}
+
+// CountAhead counts the entries of the same owner and compute type which are queued before the entry,
+// it must be consistent with domain.SpaceAppQueueEntry.QueueKey.
+func (adapter *queueAdapter) CountAhead(e *domain.SpaceAppQueueEntry) (int, error) {
+	var total int64
+
+	err := adapter.dao.DB().Where(
+		adapter.dao.EqualQuery(fieldOwner), e.Owner.Account(),
+	).Where(
+		adapter.dao.EqualQuery(fieldComputeType), e.ComputeType.ComputilityType(),
+	).Where(
+		"("+fieldCreatedAt+" < ? OR ("+fieldCreatedAt+" = ? AND "+fieldId+" < ?))",
+		e.CreatedAt, e.CreatedAt, e.Id.Integer(),
+	).Count(&total).Error
+
+	return int(total), err
+}
+
+// ListAll lists all the entries in the order of queuing.
+func (adapter *queueAdapter) ListAll() ([]domain.SpaceAppQueueEntry, error) {
+	var dos []queueEntryDO
+
+	err := adapter.dao.DB().Order(fieldCreatedAt + " asc").Order(fieldId + " asc").Find(&dos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]domain.SpaceAppQueueEntry, len(dos))
+	for i := range dos {
+		r[i] = dos[i].toQueueEntry()
+	}
+
+	return r, nil
+}
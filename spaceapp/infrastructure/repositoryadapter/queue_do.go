@@ -0,0 +1,75 @@
+/*
+Copyright (c) Huawei Technologies Co., Ltd. 2024. All rights reserved
+*/
+
+package repositoryadapter
+
+import (
+	"github.com/openmerlin/merlin-server/common/domain/primitive"
+	"github.com/openmerlin/merlin-server/spaceapp/domain"
+)
+
+const (
+	fieldId          = "id"
+	fieldOwner       = "owner"
+	fieldComputeType = "compute_type"
+)
+
+var queueTableName = ""
+
+func toQueueEntryDO(e *domain.SpaceAppQueueEntry) queueEntryDO {
+	do := queueEntryDO{
+		SpaceId:   e.SpaceId.Integer(),
+		Owner:     e.Owner.Account(),
+		User:      e.User.Account(),
+		Action:    e.Action,
+		CommitId:  e.CommitId,
+		Hardware:  e.Hardware,
+		SDK:       e.SDK,
+		CreatedAt: e.CreatedAt,
+	}
+
+	if e.ComputeType != nil {
+		do.ComputeType = e.ComputeType.ComputilityType()
+	}
+
+	if e.Id != nil {
+		do.Id = e.Id.Integer()
+	}
+
+	return do
+}
+
+// queueEntryDO
+type queueEntryDO struct {
+	Id          int64  `gorm:"primarykey"`
+	SpaceId     int64  `gorm:"column:space_id;uniqueIndex"`
+	Owner       string `gorm:"column:owner;index:idx_space_app_queue_owner_type"`
+	ComputeType string `gorm:"column:compute_type;index:idx_space_app_queue_owner_type"`
+	User        string `gorm:"column:user_name"`
+	Action      string `gorm:"column:action"`
+	CommitId    string `gorm:"column:commit_id"`
+	Hardware    string `gorm:"column:hardware"`
+	SDK         string `gorm:"column:sdk"`
+	CreatedAt   int64  `gorm:"column:created_at"`
+}
+
+// TableName returns the name of the table for the queueEntryDO struct.
+func (do *queueEntryDO) TableName() string {
+	return queueTableName
+}
+
+func (do *queueEntryDO) toQueueEntry() domain.SpaceAppQueueEntry {
+	return domain.SpaceAppQueueEntry{
+		Id:          primitive.CreateIdentity(do.Id),
+		SpaceId:     primitive.CreateIdentity(do.SpaceId),
+		Owner:       primitive.CreateAccount(do.Owner),
+		User:        primitive.CreateAccount(do.User),
+		ComputeType: primitive.CreateComputilityType(do.ComputeType),
+		Action:      do.Action,
+		CommitId:    do.CommitId,
+		Hardware:    do.Hardware,
+		SDK:         do.SDK,
+		CreatedAt:   do.CreatedAt,
+	}
+}